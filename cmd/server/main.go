@@ -25,6 +25,9 @@ import (
 	"devops-console-backend/internal/controllers/monitor"
 	"devops-console-backend/internal/middlewares"
 	"devops-console-backend/internal/routes"
+	"devops-console-backend/internal/services/alert"
+	"devops-console-backend/internal/services/scanner"
+	"devops-console-backend/internal/watcher"
 	"devops-console-backend/internal/websocket"
 	"devops-console-backend/pkg/configs"
 	"devops-console-backend/pkg/database"
@@ -53,6 +56,7 @@ func main() {
 	defer database.CloseRedis()
 	configs.NewDB()
 	defer configs.CloseDB()
+	configs.AutoMigrate()
 	// 跨域配置 todo 待迁移
 	r.Use(cors.New(cors.Config{
 		//AllowOrigins:     []string{"http://127.0.0.1:5174", "http://localhost:5174"}, // 前端地址
@@ -85,6 +89,11 @@ func main() {
 	// 注册WebSocket路由
 	websocket.RegisterWebSocketRoutes(r)
 
+	// 启动后台任务
+	stopCh := make(chan struct{})
+	defer close(stopCh)
+	startBackgroundTasks(stopCh)
+
 	go func() {
 		http.Handle("/metrics", promhttp.Handler())
 		_ = http.ListenAndServe(":9090", nil)
@@ -95,6 +104,34 @@ func main() {
 	}
 }
 
+// startBackgroundTasks 启动后台任务
+func startBackgroundTasks(stopCh <-chan struct{}) {
+	// 服务重启后，之前进行中的终端和端口转发会话已断开
+	if configs.GORMDB != nil {
+		if err := configs.NewK8sExecSessionRepository().MarkInterrupted(); err != nil {
+			logs.Warning(map[string]interface{}{"error": err.Error()}, "修正终端会话状态失败")
+		}
+		if err := configs.NewK8sPortForwardSessionRepository().MarkInterrupted(); err != nil {
+			logs.Warning(map[string]interface{}{"error": err.Error()}, "修正端口转发会话状态失败")
+		}
+		if err := configs.NewK8sScanRepository().MarkInterrupted(); err != nil {
+			logs.Warning(map[string]interface{}{"error": err.Error()}, "修正扫描任务状态失败")
+		}
+	}
+	// 告警规则引擎
+	if configs.GetAlertConfig().Enabled && configs.GORMDB != nil {
+		go alert.SharedEngine(configs.GORMDB).Run(stopCh)
+	}
+	// K8s事件归档
+	if archive := configs.GetKubernetesConfig().EventArchive; archive.Enabled && configs.GORMDB != nil {
+		go watcher.NewEventWatcher(archive.RetentionDays).Run(stopCh)
+	}
+	// 最佳实践与安全扫描
+	if configs.GetKubernetesConfig().Scan.Enabled && configs.GORMDB != nil {
+		go scanner.NewRunnerFromConfig().Run(stopCh)
+	}
+}
+
 // 设置中间件
 func setMiddleware(router *gin.Engine, globalConfig *common.GlobalConfig) {
 	// 认证
@@ -1,8 +1,9 @@
 package common
 
 var (
-	TokenKey    = "Authorization"
-	UserInfoKey = "claims"
+	TokenKey      = "Authorization"
+	UserInfoKey   = "claims"
+	InstanceIDKey = "instance_id" // InstanceAuth中间件写入的实例ID
 )
 
 // redis key
@@ -0,0 +1,184 @@
+package alert
+
+import (
+	"devops-console-backend/internal/dal"
+	"devops-console-backend/internal/dal/request/alert"
+	alertService "devops-console-backend/internal/services/alert"
+	"devops-console-backend/pkg/utils"
+	"time"
+
+	"github.com/gin-gonic/gin"
+	"gorm.io/gorm"
+)
+
+// ChannelController 告警通知渠道控制器
+type ChannelController struct {
+	db     *gorm.DB
+	engine *alertService.Engine
+}
+
+// NewChannelController 创建通知渠道控制器实例
+func NewChannelController(db *gorm.DB, engine *alertService.Engine) *ChannelController {
+	return &ChannelController{db: db, engine: engine}
+}
+
+// GetChannelList 获取通知渠道列表
+func (c *ChannelController) GetChannelList(ctx *gin.Context) {
+	helper := utils.NewResponseHelper(ctx)
+
+	var channels []dal.AlertChannel
+	if err := c.db.Order("created_at DESC").Find(&channels).Error; err != nil {
+		helper.DatabaseError("获取通知渠道列表失败")
+		return
+	}
+
+	channelList := make([]alert.ChannelListItem, 0, len(channels))
+	for _, channel := range channels {
+		channelList = append(channelList, alert.ChannelListItem{
+			ID:        channel.ID,
+			Name:      channel.Name,
+			Type:      channel.Type,
+			Config:    alertService.MaskChannelConfig(channel.Config),
+			Enabled:   channel.Enabled,
+			CreatedAt: channel.CreatedAt.Unix(),
+			UpdatedAt: channel.UpdatedAt.Unix(),
+		})
+	}
+
+	helper.SuccessWithData("获取成功", "channelList", channelList)
+}
+
+// CreateChannel 创建通知渠道
+func (c *ChannelController) CreateChannel(ctx *gin.Context) {
+	helper := utils.NewResponseHelper(ctx)
+
+	var req alert.ChannelCreateRequest
+	if err := ctx.ShouldBindJSON(&req); err != nil {
+		helper.ValidationError(err.Error())
+		return
+	}
+
+	config, err := alertService.EncryptChannelConfig(req.Config, "")
+	if err != nil {
+		helper.BadRequest(err.Error())
+		return
+	}
+	channel := dal.AlertChannel{
+		Name:    req.Name,
+		Type:    req.Type,
+		Config:  config,
+		Enabled: true,
+	}
+	if req.Enabled != nil {
+		channel.Enabled = *req.Enabled
+	}
+	// 校验渠道配置是否完整
+	if _, err := alertService.NewNotifier(&channel, time.Second); err != nil {
+		helper.BadRequest(err.Error())
+		return
+	}
+
+	if err := c.db.Create(&channel).Error; err != nil {
+		helper.DatabaseError("创建通知渠道失败")
+		return
+	}
+
+	channel.Config = alertService.MaskChannelConfig(channel.Config)
+	helper.SuccessWithData("创建成功", "channel", channel)
+}
+
+// UpdateChannel 更新通知渠道
+func (c *ChannelController) UpdateChannel(ctx *gin.Context) {
+	helper := utils.NewResponseHelper(ctx)
+
+	var id uint
+	utils.GetParam(ctx, "id", &id, nil)
+
+	var req alert.ChannelUpdateRequest
+	if err := ctx.ShouldBindJSON(&req); err != nil {
+		helper.ValidationError(err.Error())
+		return
+	}
+
+	var channel dal.AlertChannel
+	if err := c.db.First(&channel, id).Error; err != nil {
+		helper.NotFound("通知渠道不存在")
+		return
+	}
+
+	updates := make(map[string]interface{})
+	if req.Name != "" {
+		updates["name"] = req.Name
+	}
+	if req.Type != "" {
+		channel.Type = req.Type
+		updates["type"] = req.Type
+	}
+	if req.Config != nil {
+		config, err := alertService.EncryptChannelConfig(*req.Config, channel.Config)
+		if err != nil {
+			helper.BadRequest(err.Error())
+			return
+		}
+		channel.Config = config
+		updates["config"] = config
+	}
+	if req.Enabled != nil {
+		updates["enabled"] = *req.Enabled
+	}
+	if _, err := alertService.NewNotifier(&channel, time.Second); err != nil {
+		helper.BadRequest(err.Error())
+		return
+	}
+
+	if err := c.db.Model(&dal.AlertChannel{}).Where("id = ?", id).Updates(updates).Error; err != nil {
+		helper.DatabaseError("更新通知渠道失败")
+		return
+	}
+
+	helper.Success("更新成功")
+}
+
+// DeleteChannel 删除通知渠道
+func (c *ChannelController) DeleteChannel(ctx *gin.Context) {
+	helper := utils.NewResponseHelper(ctx)
+
+	var id uint
+	utils.GetParam(ctx, "id", &id, nil)
+
+	if err := c.db.Delete(&dal.AlertChannel{}, id).Error; err != nil {
+		helper.DatabaseError("删除通知渠道失败")
+		return
+	}
+
+	helper.Success("删除成功")
+}
+
+// TestChannel 发送测试通知
+func (c *ChannelController) TestChannel(ctx *gin.Context) {
+	helper := utils.NewResponseHelper(ctx)
+
+	var id uint
+	utils.GetParam(ctx, "id", &id, nil)
+
+	var channel dal.AlertChannel
+	if err := c.db.First(&channel, id).Error; err != nil {
+		helper.NotFound("通知渠道不存在")
+		return
+	}
+
+	err := c.engine.Send(&channel, &alertService.Notification{
+		RuleName: "测试通知",
+		Severity: dal.AlertSeverityInfo,
+		Status:   dal.AlertStatusFiring,
+		Target:   "channel/" + channel.Name,
+		Summary:  "这是一条来自 DevOps Console 的测试通知",
+		StartsAt: time.Now(),
+	})
+	if err != nil {
+		helper.InternalError("发送测试通知失败: " + err.Error())
+		return
+	}
+
+	helper.Success("发送成功")
+}
@@ -0,0 +1,65 @@
+package alert
+
+import (
+	"devops-console-backend/internal/dal"
+	"devops-console-backend/internal/dal/request/alert"
+	"devops-console-backend/pkg/utils"
+
+	"github.com/gin-gonic/gin"
+	"gorm.io/gorm"
+)
+
+// EventController 告警记录控制器
+type EventController struct {
+	db *gorm.DB
+}
+
+// NewEventController 创建告警记录控制器实例
+func NewEventController(db *gorm.DB) *EventController {
+	return &EventController{db: db}
+}
+
+// GetEventList 分页获取告警记录
+func (c *EventController) GetEventList(ctx *gin.Context) {
+	helper := utils.NewResponseHelper(ctx)
+
+	var req alert.EventListRequest
+	if err := ctx.ShouldBindQuery(&req); err != nil {
+		helper.ValidationError(err.Error())
+		return
+	}
+
+	query := c.db.Model(&dal.AlertEvent{})
+	if req.RuleID > 0 {
+		query = query.Where("rule_id = ?", req.RuleID)
+	}
+	if req.InstanceID > 0 {
+		query = query.Where("instance_id = ?", req.InstanceID)
+	}
+	if req.Status != "" {
+		query = query.Where("status = ?", req.Status)
+	}
+	if req.Severity != "" {
+		query = query.Where("severity = ?", req.Severity)
+	}
+
+	var total int64
+	if err := query.Count(&total).Error; err != nil {
+		helper.DatabaseError("获取总数失败")
+		return
+	}
+
+	var events []dal.AlertEvent
+	offset := (req.Page - 1) * req.PageSize
+	if err := query.Order("last_seen_at DESC").Limit(req.PageSize).Offset(offset).Find(&events).Error; err != nil {
+		helper.DatabaseError("获取告警记录失败")
+		return
+	}
+
+	helper.SuccessWithData("获取成功", "data", gin.H{
+		"eventList": events,
+		"total":     total,
+		"page":      req.Page,
+		"pageSize":  req.PageSize,
+	})
+}
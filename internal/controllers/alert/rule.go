@@ -0,0 +1,257 @@
+package alert
+
+import (
+	"devops-console-backend/internal/dal"
+	"devops-console-backend/internal/dal/request/alert"
+	alertService "devops-console-backend/internal/services/alert"
+	"devops-console-backend/pkg/utils"
+	"errors"
+
+	"github.com/gin-gonic/gin"
+	"gorm.io/gorm"
+)
+
+// defaultRepeatInterval 未指定时的重复通知间隔（分钟）
+const defaultRepeatInterval = 60
+
+// RuleController 告警规则控制器
+type RuleController struct {
+	db     *gorm.DB
+	engine *alertService.Engine
+}
+
+// NewRuleController 创建告警规则控制器实例
+func NewRuleController(db *gorm.DB, engine *alertService.Engine) *RuleController {
+	return &RuleController{db: db, engine: engine}
+}
+
+// GetRuleTypes 获取支持的条件类型
+func (c *RuleController) GetRuleTypes(ctx *gin.Context) {
+	helper := utils.NewResponseHelper(ctx)
+	helper.SuccessWithData("获取成功", "ruleTypes", alertService.SupportedRuleTypes())
+}
+
+// GetRuleList 获取告警规则列表
+func (c *RuleController) GetRuleList(ctx *gin.Context) {
+	helper := utils.NewResponseHelper(ctx)
+
+	var rules []dal.AlertRule
+	if err := c.db.Order("created_at DESC").Find(&rules).Error; err != nil {
+		helper.DatabaseError("获取告警规则列表失败")
+		return
+	}
+
+	ruleList := make([]alert.RuleListItem, 0, len(rules))
+	for _, rule := range rules {
+		ruleList = append(ruleList, toRuleListItem(&rule))
+	}
+
+	helper.SuccessWithData("获取成功", "ruleList", ruleList)
+}
+
+// CreateRule 创建告警规则
+func (c *RuleController) CreateRule(ctx *gin.Context) {
+	helper := utils.NewResponseHelper(ctx)
+
+	var req alert.RuleCreateRequest
+	if err := ctx.ShouldBindJSON(&req); err != nil {
+		helper.ValidationError(err.Error())
+		return
+	}
+	if !alertService.IsSupportedRuleType(req.Type) {
+		helper.BadRequest("不支持的条件类型: " + req.Type)
+		return
+	}
+	if _, err := alertService.ParseRuleParams(req.Params); err != nil {
+		helper.BadRequest(err.Error())
+		return
+	}
+	if !c.channelsExist(req.ChannelIDs) {
+		helper.BadRequest("通知渠道不存在")
+		return
+	}
+
+	rule := dal.AlertRule{
+		Name:           req.Name,
+		Type:           req.Type,
+		InstanceID:     req.InstanceID,
+		Params:         req.Params,
+		Severity:       req.Severity,
+		ChannelIDs:     alertService.FormatChannelIDs(req.ChannelIDs),
+		RepeatInterval: defaultRepeatInterval,
+		SendResolved:   true,
+		Enabled:        true,
+		Description:    req.Description,
+	}
+	if rule.Severity == "" {
+		rule.Severity = dal.AlertSeverityWarning
+	}
+	if req.RepeatInterval != nil {
+		rule.RepeatInterval = *req.RepeatInterval
+	}
+	if req.SendResolved != nil {
+		rule.SendResolved = *req.SendResolved
+	}
+	if req.Enabled != nil {
+		rule.Enabled = *req.Enabled
+	}
+
+	if err := c.db.Create(&rule).Error; err != nil {
+		helper.DatabaseError("创建告警规则失败")
+		return
+	}
+
+	helper.SuccessWithData("创建成功", "rule", toRuleListItem(&rule))
+}
+
+// UpdateRule 更新告警规则
+func (c *RuleController) UpdateRule(ctx *gin.Context) {
+	helper := utils.NewResponseHelper(ctx)
+
+	var id uint
+	utils.GetParam(ctx, "id", &id, nil)
+
+	var req alert.RuleUpdateRequest
+	if err := ctx.ShouldBindJSON(&req); err != nil {
+		helper.ValidationError(err.Error())
+		return
+	}
+
+	updates := make(map[string]interface{})
+	if req.Name != "" {
+		updates["name"] = req.Name
+	}
+	if req.Type != "" {
+		if !alertService.IsSupportedRuleType(req.Type) {
+			helper.BadRequest("不支持的条件类型: " + req.Type)
+			return
+		}
+		updates["type"] = req.Type
+	}
+	if req.InstanceID != nil {
+		updates["instance_id"] = *req.InstanceID
+	}
+	if req.Params != nil {
+		if _, err := alertService.ParseRuleParams(*req.Params); err != nil {
+			helper.BadRequest(err.Error())
+			return
+		}
+		updates["params"] = *req.Params
+	}
+	if req.Severity != "" {
+		updates["severity"] = req.Severity
+	}
+	if req.ChannelIDs != nil {
+		if !c.channelsExist(req.ChannelIDs) {
+			helper.BadRequest("通知渠道不存在")
+			return
+		}
+		updates["channel_ids"] = alertService.FormatChannelIDs(req.ChannelIDs)
+	}
+	if req.RepeatInterval != nil {
+		updates["repeat_interval"] = *req.RepeatInterval
+	}
+	if req.SendResolved != nil {
+		updates["send_resolved"] = *req.SendResolved
+	}
+	if req.Enabled != nil {
+		updates["enabled"] = *req.Enabled
+	}
+	if req.Description != nil {
+		updates["description"] = *req.Description
+	}
+
+	result := c.db.Model(&dal.AlertRule{}).Where("id = ?", id).Updates(updates)
+	if result.Error != nil {
+		helper.DatabaseError("更新告警规则失败")
+		return
+	}
+
+	helper.Success("更新成功")
+}
+
+// DeleteRule 删除告警规则及其告警记录
+func (c *RuleController) DeleteRule(ctx *gin.Context) {
+	helper := utils.NewResponseHelper(ctx)
+
+	var id uint
+	utils.GetParam(ctx, "id", &id, nil)
+
+	err := c.db.Transaction(func(tx *gorm.DB) error {
+		if err := tx.Where("rule_id = ?", id).Delete(&dal.AlertEvent{}).Error; err != nil {
+			return err
+		}
+		return tx.Delete(&dal.AlertRule{}, id).Error
+	})
+	if err != nil {
+		helper.DatabaseError("删除告警规则失败")
+		return
+	}
+
+	helper.Success("删除成功")
+}
+
+// EvaluateRule 立即评估一次规则
+func (c *RuleController) EvaluateRule(ctx *gin.Context) {
+	helper := utils.NewResponseHelper(ctx)
+
+	var id uint
+	utils.GetParam(ctx, "id", &id, nil)
+
+	var rule dal.AlertRule
+	if err := c.db.First(&rule, id).Error; err != nil {
+		helper.NotFound("告警规则不存在")
+		return
+	}
+	// 部分实例评估失败时仍返回其余实例的结果
+	var failed alertService.InstanceErrors
+	if err := c.engine.EvaluateRule(&rule); err != nil && !errors.As(err, &failed) {
+		helper.InternalError("规则评估失败: " + err.Error())
+		return
+	}
+
+	var firing []dal.AlertEvent
+	if err := c.db.Where("rule_id = ? AND status = ?", rule.ID, dal.AlertStatusFiring).Find(&firing).Error; err != nil {
+		helper.DatabaseError("查询告警记录失败")
+		return
+	}
+	if len(failed) > 0 {
+		helper.Success("部分实例评估失败", map[string]interface{}{
+			"firing": firing,
+			"errors": failed.Error(),
+		})
+		return
+	}
+	helper.SuccessWithData("评估完成", "firing", firing)
+}
+
+// channelsExist 校验通知渠道是否都存在
+func (c *RuleController) channelsExist(ids []uint) bool {
+	if len(ids) == 0 {
+		return true
+	}
+	var count int64
+	if err := c.db.Model(&dal.AlertChannel{}).Where("id IN ?", ids).Count(&count).Error; err != nil {
+		return false
+	}
+	return int(count) == len(ids)
+}
+
+// toRuleListItem 转换为响应格式
+func toRuleListItem(rule *dal.AlertRule) alert.RuleListItem {
+	return alert.RuleListItem{
+		ID:             rule.ID,
+		Name:           rule.Name,
+		Type:           rule.Type,
+		InstanceID:     rule.InstanceID,
+		Params:         rule.Params,
+		Severity:       rule.Severity,
+		ChannelIDs:     alertService.ParseChannelIDs(rule.ChannelIDs),
+		RepeatInterval: rule.RepeatInterval,
+		SendResolved:   rule.SendResolved,
+		Enabled:        rule.Enabled,
+		Description:    rule.Description,
+		CreatedAt:      rule.CreatedAt.Unix(),
+		UpdatedAt:      rule.UpdatedAt.Unix(),
+	}
+}
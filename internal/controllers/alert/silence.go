@@ -0,0 +1,95 @@
+package alert
+
+import (
+	"devops-console-backend/internal/dal"
+	"devops-console-backend/internal/dal/request/alert"
+	"devops-console-backend/pkg/utils"
+	"time"
+
+	"github.com/gin-gonic/gin"
+	"gorm.io/gorm"
+)
+
+// SilenceController 告警静默控制器
+type SilenceController struct {
+	db *gorm.DB
+}
+
+// NewSilenceController 创建告警静默控制器实例
+func NewSilenceController(db *gorm.DB) *SilenceController {
+	return &SilenceController{db: db}
+}
+
+// GetSilenceList 获取静默列表，默认只返回未过期的静默
+func (c *SilenceController) GetSilenceList(ctx *gin.Context) {
+	helper := utils.NewResponseHelper(ctx)
+
+	var all bool
+	utils.GetParam(ctx, "all", &all, nil)
+
+	query := c.db.Order("created_at DESC")
+	if !all {
+		query = query.Where("ends_at > ?", time.Now())
+	}
+	var silences []dal.AlertSilence
+	if err := query.Find(&silences).Error; err != nil {
+		helper.DatabaseError("获取静默列表失败")
+		return
+	}
+
+	helper.SuccessWithData("获取成功", "silenceList", silences)
+}
+
+// CreateSilence 创建静默
+func (c *SilenceController) CreateSilence(ctx *gin.Context) {
+	helper := utils.NewResponseHelper(ctx)
+
+	var req alert.SilenceCreateRequest
+	if err := ctx.ShouldBindJSON(&req); err != nil {
+		helper.ValidationError(err.Error())
+		return
+	}
+
+	startsAt := time.Now()
+	if req.StartsAt > 0 {
+		startsAt = time.Unix(req.StartsAt, 0)
+	}
+	endsAt := time.Unix(req.EndsAt, 0)
+	if !endsAt.After(startsAt) {
+		helper.BadRequest("结束时间必须晚于开始时间")
+		return
+	}
+
+	silence := dal.AlertSilence{
+		RuleID:     req.RuleID,
+		InstanceID: req.InstanceID,
+		Target:     req.Target,
+		Comment:    req.Comment,
+		CreatedBy:  utils.GetUserNameFromContext(ctx),
+		StartsAt:   startsAt,
+		EndsAt:     endsAt,
+	}
+	if err := c.db.Create(&silence).Error; err != nil {
+		helper.DatabaseError("创建静默失败")
+		return
+	}
+
+	helper.SuccessWithData("创建成功", "silence", silence)
+}
+
+// ExpireSilence 立即结束静默
+func (c *SilenceController) ExpireSilence(ctx *gin.Context) {
+	helper := utils.NewResponseHelper(ctx)
+
+	var id uint
+	utils.GetParam(ctx, "id", &id, nil)
+
+	err := c.db.Model(&dal.AlertSilence{}).Where("id = ? AND ends_at > ?", id, time.Now()).
+		Update("ends_at", time.Now()).Error
+	if err != nil {
+		helper.DatabaseError("结束静默失败")
+		return
+	}
+
+	helper.Success("操作成功")
+}
@@ -0,0 +1,50 @@
+package common
+
+import (
+	"devops-console-backend/internal/services/workload"
+	"devops-console-backend/pkg/utils"
+	"devops-console-backend/pkg/utils/logs"
+	"fmt"
+	"net/http"
+	"strconv"
+
+	"github.com/gin-gonic/gin"
+	"k8s.io/client-go/kubernetes"
+)
+
+// CheckReferences 删除前检查工作负载引用，存在引用且未指定force=true时拒绝删除。
+// 返回false表示已写入响应；强制删除时返回的引用列表用于在响应中提示影响范围
+func CheckReferences(ctx *gin.Context, helper *utils.ResponseHelper, client kubernetes.Interface, kind, namespace, name string) ([]workload.WorkloadRef, bool) {
+	force, _ := strconv.ParseBool(ctx.Query("force"))
+	refs, err := workload.FindReferences(ctx, client, namespace, kind, name)
+	if err != nil {
+		if !force {
+			helper.InternalError("检查引用失败: " + err.Error() + "，如需删除请指定force=true")
+			return nil, false
+		}
+		logs.Warning(map[string]interface{}{
+			"kind":      kind,
+			"namespace": namespace,
+			"name":      name,
+			"error":     err.Error(),
+		}, "检查引用失败，按强制删除处理")
+		return nil, true
+	}
+	if len(refs) == 0 {
+		return refs, true
+	}
+	if !force {
+		helper.Error(http.StatusConflict, fmt.Sprintf("%s %s 正在被 %d 个工作负载引用: %s，如需删除请指定force=true",
+			kind, name, len(refs), workload.DescribeRefs(refs)))
+		return refs, false
+	}
+
+	logs.Warning(map[string]interface{}{
+		"kind":       kind,
+		"namespace":  namespace,
+		"name":       name,
+		"references": workload.DescribeRefs(refs),
+		"operator":   utils.GetUserNameFromContext(ctx),
+	}, "强制删除仍被工作负载引用的对象")
+	return refs, true
+}
@@ -1,7 +1,11 @@
 package config
 
 import (
+	"devops-console-backend/internal/controllers/common"
+	"devops-console-backend/internal/dal"
 	"devops-console-backend/internal/dal/request/k8s"
+	"devops-console-backend/internal/services/confighistory"
+	"devops-console-backend/internal/services/workload"
 	"devops-console-backend/pkg/configs"
 	"devops-console-backend/pkg/utils"
 	"strconv"
@@ -180,9 +184,14 @@ func (c *ConfigMapController) UpdateConfigMap(ctx *gin.Context) {
 		return
 	}
 
-	var configMap *corev1.ConfigMap
-	var err error
+	existingConfigMap, err := client.CoreV1().ConfigMaps(namespace).Get(ctx, name, metav1.GetOptions{})
+	if err != nil {
+		helper := utils.NewResponseHelper(ctx)
+		helper.NotFound("ConfigMap 不存在")
+		return
+	}
 
+	var configMap *corev1.ConfigMap
 	if req.YAML != "" {
 		configMap, err = c.parseYAMLToConfigMap(req.YAML)
 		if err != nil {
@@ -191,27 +200,35 @@ func (c *ConfigMapController) UpdateConfigMap(ctx *gin.Context) {
 			return
 		}
 	} else {
-		// Get existing configmap first
-		existingConfigMap, err := client.CoreV1().ConfigMaps(namespace).Get(ctx, name, metav1.GetOptions{})
-		if err != nil {
-			helper := utils.NewResponseHelper(ctx)
-			helper.NotFound("ConfigMap 不存在")
-			return
-		}
+		configMap = existingConfigMap.DeepCopy()
+		configMap.Data = req.Data
+	}
 
-		existingConfigMap.Data = req.Data
-		configMap = existingConfigMap
+	// 保存更新前的内容，用于对比和回滚
+	helper := utils.NewResponseHelper(ctx)
+	revision, ok := recordRevision(ctx, helper, instanceID, workload.RefKindConfigMap, namespace, name,
+		confighistory.ConfigMapContent(existingConfigMap), dal.ConfigRevisionActionUpdate)
+	if !ok {
+		return
 	}
 
 	_, err = client.CoreV1().ConfigMaps(namespace).Update(ctx, configMap, metav1.UpdateOptions{})
 	if err != nil {
-		helper := utils.NewResponseHelper(ctx)
+		discardRevision(revision)
 		helper.InternalError("更新ConfigMap失败: " + err.Error())
 		return
 	}
 
-	helper := utils.NewResponseHelper(ctx)
-	helper.Success("ConfigMap 更新成功")
+	result := map[string]interface{}{}
+	if req.RestartWorkloads {
+		restarted, err := restartConsumers(ctx, client, workload.RefKindConfigMap, namespace, name)
+		if err != nil {
+			helper.InternalError("ConfigMap已更新，但重启工作负载失败: " + err.Error())
+			return
+		}
+		result["restarted"] = restarted
+	}
+	helper.Success("ConfigMap 更新成功", result)
 }
 
 // DeleteConfigMap 删除ConfigMap
@@ -233,15 +250,19 @@ func (c *ConfigMapController) DeleteConfigMap(ctx *gin.Context) {
 		return
 	}
 
+	helper := utils.NewResponseHelper(ctx)
+	refs, ok := common.CheckReferences(ctx, helper, client, workload.RefKindConfigMap, namespace, name)
+	if !ok {
+		return
+	}
+
 	err := client.CoreV1().ConfigMaps(namespace).Delete(ctx, name, metav1.DeleteOptions{})
 	if err != nil {
-		helper := utils.NewResponseHelper(ctx)
 		helper.InternalError("删除ConfigMap失败: " + err.Error())
 		return
 	}
 
-	helper := utils.NewResponseHelper(ctx)
-	helper.Success("ConfigMap 删除成功")
+	helper.Success("ConfigMap 删除成功", map[string]interface{}{"references": refs})
 }
 
 func (c *ConfigMapController) parseYAMLToConfigMap(yamlContent string) (*corev1.ConfigMap, error) {
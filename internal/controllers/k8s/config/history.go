@@ -0,0 +1,318 @@
+package config
+
+import (
+	"context"
+	"devops-console-backend/internal/dal"
+	"devops-console-backend/internal/dal/request/k8s"
+	"devops-console-backend/internal/services/confighistory"
+	"devops-console-backend/internal/services/workload"
+	"devops-console-backend/pkg/configs"
+	"devops-console-backend/pkg/utils"
+	"devops-console-backend/pkg/utils/logs"
+	"errors"
+	"strconv"
+
+	"github.com/gin-gonic/gin"
+	"gorm.io/gorm"
+	apierrors "k8s.io/apimachinery/pkg/api/errors"
+	metav1 "k8s.io/apimachinery/pkg/apis/meta/v1"
+	"k8s.io/client-go/kubernetes"
+)
+
+// ConfigHistoryController ConfigMap/Secret历史版本控制器
+type ConfigHistoryController struct{}
+
+// NewConfigHistoryController 创建历史版本控制器实例
+func NewConfigHistoryController() *ConfigHistoryController {
+	return &ConfigHistoryController{}
+}
+
+// configKinds 路径中的kind参数到资源类型的映射
+var configKinds = map[string]string{
+	"configmap": workload.RefKindConfigMap,
+	"secret":    workload.RefKindSecret,
+}
+
+// recordRevision 保存变更前的内容，未配置加密密钥时跳过Secret历史版本，失败时已写入响应
+func recordRevision(ctx *gin.Context, helper *utils.ResponseHelper, instanceID uint, kind, namespace, name string,
+	content *confighistory.Content, action string) (*dal.K8sConfigRevision, bool) {
+	revision, err := confighistory.Record(instanceID, kind, namespace, name, content, action, utils.GetUserNameFromContext(ctx))
+	if err == nil {
+		return revision, true
+	}
+	if errors.Is(err, confighistory.ErrNoEncryptionKey) {
+		logs.Warning(map[string]interface{}{
+			"kind":      kind,
+			"namespace": namespace,
+			"name":      name,
+		}, err.Error())
+		return nil, true
+	}
+	logs.Error(map[string]interface{}{
+		"kind":      kind,
+		"namespace": namespace,
+		"name":      name,
+	}, "保存历史版本失败: "+err.Error())
+	helper.DatabaseError("保存历史版本失败")
+	return nil, false
+}
+
+// discardRevision 对象更新失败时删除本次保存的历史版本
+func discardRevision(revision *dal.K8sConfigRevision) {
+	if revision == nil {
+		return
+	}
+	if err := configs.NewK8sConfigRevisionRepository().Delete(revision.ID); err != nil {
+		logs.Error(map[string]interface{}{
+			"kind":      revision.Kind,
+			"namespace": revision.Namespace,
+			"name":      revision.Name,
+			"version":   revision.Version,
+		}, "删除历史版本失败: "+err.Error())
+	}
+}
+
+// restartConsumers 滚动重启引用该对象的工作负载，单个失败不影响其他工作负载
+func restartConsumers(ctx context.Context, client kubernetes.Interface, kind, namespace, name string) ([]k8s.ConfigRestartResult, error) {
+	refs, err := workload.FindConfigConsumers(ctx, client, namespace, kind, name)
+	if err != nil {
+		return nil, err
+	}
+	results := make([]k8s.ConfigRestartResult, 0, len(refs))
+	for _, ref := range refs {
+		result := k8s.ConfigRestartResult{Kind: ref.Kind, Namespace: ref.Namespace, Name: ref.Name, Via: ref.Via}
+		if err := workload.RolloutRestart(ctx, client, ref); err != nil {
+			result.Error = err.Error()
+		}
+		results = append(results, result)
+	}
+	return results, nil
+}
+
+// resolveConfigKind 解析kind参数并获取客户端，失败时已写入响应
+func resolveConfigKind(ctx *gin.Context, helper *utils.ResponseHelper) (string, uint, kubernetes.Interface, bool) {
+	kind, ok := configKinds[ctx.Param("kind")]
+	if !ok {
+		helper.BadRequest("不支持的类型: " + ctx.Param("kind"))
+		return "", 0, nil, false
+	}
+	instanceID := utils.GetInstanceIDFromContext(ctx)
+	client, exists := configs.GetK8sClient(instanceID)
+	if !exists {
+		helper.InternalError("K8s客户端未初始化")
+		return "", 0, nil, false
+	}
+	return kind, instanceID, client, true
+}
+
+// loadCurrent 读取对象当前内容
+func loadCurrent(ctx context.Context, client kubernetes.Interface, kind, namespace, name string) (*confighistory.Content, error) {
+	if kind == workload.RefKindSecret {
+		secret, err := client.CoreV1().Secrets(namespace).Get(ctx, name, metav1.GetOptions{})
+		if err != nil {
+			return nil, err
+		}
+		return confighistory.SecretContent(secret), nil
+	}
+	configMap, err := client.CoreV1().ConfigMaps(namespace).Get(ctx, name, metav1.GetOptions{})
+	if err != nil {
+		return nil, err
+	}
+	return confighistory.ConfigMapContent(configMap), nil
+}
+
+// loadVersion 读取历史版本内容，version为current时读取集群中的当前内容
+func loadVersion(ctx *gin.Context, client kubernetes.Interface, instanceID uint, kind, namespace, name, version string) (*confighistory.Content, error) {
+	if version == "" || version == "current" {
+		return loadCurrent(ctx, client, kind, namespace, name)
+	}
+	number, err := strconv.Atoi(version)
+	if err != nil {
+		return nil, errors.New("无效的版本号: " + version)
+	}
+	revision, err := configs.NewK8sConfigRevisionRepository().GetByVersion(instanceID, kind, namespace, name, number)
+	if err != nil {
+		if errors.Is(err, gorm.ErrRecordNotFound) {
+			return nil, errors.New("版本不存在: " + version)
+		}
+		return nil, err
+	}
+	return confighistory.Load(revision)
+}
+
+// GetRevisionList 获取历史版本列表
+func (c *ConfigHistoryController) GetRevisionList(ctx *gin.Context) {
+	helper := utils.NewResponseHelper(ctx)
+	kind, instanceID, _, ok := resolveConfigKind(ctx, helper)
+	if !ok {
+		return
+	}
+	namespace := ctx.Param("namespace")
+	name := ctx.Param("name")
+
+	revisions, err := configs.NewK8sConfigRevisionRepository().List(instanceID, kind, namespace, name)
+	if err != nil {
+		helper.DatabaseError("获取历史版本失败")
+		return
+	}
+	items := make([]k8s.ConfigRevisionItem, 0, len(revisions))
+	for i := range revisions {
+		item := k8s.ConfigRevisionItem{
+			Version:   revisions[i].Version,
+			Action:    revisions[i].Action,
+			Operator:  revisions[i].Operator,
+			Encrypted: revisions[i].Encrypted,
+			CreatedAt: revisions[i].CreatedAt,
+		}
+		if content, err := confighistory.Load(&revisions[i]); err == nil {
+			item.Keys = content.Keys()
+		}
+		items = append(items, item)
+	}
+	helper.SuccessWithData("success", "revisionList", items)
+}
+
+// DiffRevision 比较两个版本，from和to为版本号或current，to默认为current
+func (c *ConfigHistoryController) DiffRevision(ctx *gin.Context) {
+	helper := utils.NewResponseHelper(ctx)
+	kind, instanceID, client, ok := resolveConfigKind(ctx, helper)
+	if !ok {
+		return
+	}
+	namespace := ctx.Param("namespace")
+	name := ctx.Param("name")
+	fromVersion := ctx.Query("from")
+	if fromVersion == "" {
+		helper.BadRequest("缺少from参数")
+		return
+	}
+	toVersion := ctx.DefaultQuery("to", "current")
+
+	from, err := loadVersion(ctx, client, instanceID, kind, namespace, name, fromVersion)
+	if err != nil {
+		helper.BadRequest("读取版本失败: " + err.Error())
+		return
+	}
+	to, err := loadVersion(ctx, client, instanceID, kind, namespace, name, toVersion)
+	if err != nil {
+		helper.BadRequest("读取版本失败: " + err.Error())
+		return
+	}
+	helper.Success("success", map[string]interface{}{
+		"from": fromVersion,
+		"to":   toVersion,
+		"diff": confighistory.Diff(from, to),
+	})
+}
+
+// RollbackRevision 回滚到指定历史版本，回滚前的内容同样保存为新版本
+func (c *ConfigHistoryController) RollbackRevision(ctx *gin.Context) {
+	helper := utils.NewResponseHelper(ctx)
+	kind, instanceID, client, ok := resolveConfigKind(ctx, helper)
+	if !ok {
+		return
+	}
+	namespace := ctx.Param("namespace")
+	name := ctx.Param("name")
+	var req k8s.ConfigRollbackRequest
+	if err := ctx.ShouldBindJSON(&req); err != nil {
+		helper.BadRequest("请求参数错误: " + err.Error())
+		return
+	}
+
+	target, err := loadVersion(ctx, client, instanceID, kind, namespace, name, strconv.Itoa(req.Version))
+	if err != nil {
+		helper.BadRequest("读取版本失败: " + err.Error())
+		return
+	}
+
+	var revision *dal.K8sConfigRevision
+	var updateErr error
+	if kind == workload.RefKindSecret {
+		secret, err := client.CoreV1().Secrets(namespace).Get(ctx, name, metav1.GetOptions{})
+		if err != nil {
+			helper.NotFound("Secret 不存在")
+			return
+		}
+		if revision, ok = recordRevision(ctx, helper, instanceID, kind, namespace, name, confighistory.SecretContent(secret), dal.ConfigRevisionActionRollback); !ok {
+			return
+		}
+		confighistory.ApplyToSecret(secret, target)
+		_, updateErr = client.CoreV1().Secrets(namespace).Update(ctx, secret, metav1.UpdateOptions{})
+	} else {
+		configMap, err := client.CoreV1().ConfigMaps(namespace).Get(ctx, name, metav1.GetOptions{})
+		if err != nil {
+			helper.NotFound("ConfigMap 不存在")
+			return
+		}
+		if revision, ok = recordRevision(ctx, helper, instanceID, kind, namespace, name, confighistory.ConfigMapContent(configMap), dal.ConfigRevisionActionRollback); !ok {
+			return
+		}
+		confighistory.ApplyToConfigMap(configMap, target)
+		_, updateErr = client.CoreV1().ConfigMaps(namespace).Update(ctx, configMap, metav1.UpdateOptions{})
+	}
+	if updateErr != nil {
+		discardRevision(revision)
+		helper.InternalError("回滚失败: " + updateErr.Error())
+		return
+	}
+	logs.Info(map[string]interface{}{
+		"kind":      kind,
+		"namespace": namespace,
+		"name":      name,
+		"version":   req.Version,
+		"operator":  utils.GetUserNameFromContext(ctx),
+	}, "回滚配置到历史版本")
+
+	result := map[string]interface{}{}
+	if req.RestartWorkloads {
+		restarted, err := restartConsumers(ctx, client, kind, namespace, name)
+		if err != nil {
+			helper.InternalError("回滚成功，但重启工作负载失败: " + err.Error())
+			return
+		}
+		result["restarted"] = restarted
+	}
+	helper.Success("回滚成功", result)
+}
+
+// GetConsumers 获取引用该对象的工作负载
+func (c *ConfigHistoryController) GetConsumers(ctx *gin.Context) {
+	helper := utils.NewResponseHelper(ctx)
+	kind, _, client, ok := resolveConfigKind(ctx, helper)
+	if !ok {
+		return
+	}
+	refs, err := workload.FindConfigConsumers(ctx, client, ctx.Param("namespace"), kind, ctx.Param("name"))
+	if err != nil {
+		helper.InternalError(err.Error())
+		return
+	}
+	helper.SuccessWithData("success", "consumers", refs)
+}
+
+// RestartConsumers 滚动重启引用该对象的工作负载
+func (c *ConfigHistoryController) RestartConsumers(ctx *gin.Context) {
+	helper := utils.NewResponseHelper(ctx)
+	kind, _, client, ok := resolveConfigKind(ctx, helper)
+	if !ok {
+		return
+	}
+	namespace := ctx.Param("namespace")
+	name := ctx.Param("name")
+	if _, err := loadCurrent(ctx, client, kind, namespace, name); err != nil {
+		if apierrors.IsNotFound(err) {
+			helper.NotFound(kind + " 不存在")
+			return
+		}
+		helper.InternalError("获取" + kind + "失败: " + err.Error())
+		return
+	}
+
+	restarted, err := restartConsumers(ctx, client, kind, namespace, name)
+	if err != nil {
+		helper.InternalError(err.Error())
+		return
+	}
+	helper.SuccessWithData("重启已触发", "restarted", restarted)
+}
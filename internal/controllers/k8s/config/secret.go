@@ -1,7 +1,12 @@
 package config
 
 import (
+	"devops-console-backend/internal/controllers/common"
+	"devops-console-backend/internal/dal"
 	"devops-console-backend/internal/dal/request/k8s"
+	"devops-console-backend/internal/services/confighistory"
+	secretsvc "devops-console-backend/internal/services/secret"
+	"devops-console-backend/internal/services/workload"
 	"devops-console-backend/pkg/configs"
 	"devops-console-backend/pkg/utils"
 	"encoding/base64"
@@ -58,11 +63,12 @@ func (c *SecretController) GetSecretList(ctx *gin.Context) {
 	secretList := make([]k8s.SecretListItem, 0)
 	for _, item := range list.Items {
 		secretList = append(secretList, k8s.SecretListItem{
-			Name:      item.Name,
-			Namespace: item.Namespace,
-			Type:      string(item.Type),
-			DataCount: len(item.Data),
-			Age:       item.CreationTimestamp.Unix(),
+			Name:        item.Name,
+			Namespace:   item.Namespace,
+			Type:        string(item.Type),
+			DataCount:   len(item.Data),
+			Age:         item.CreationTimestamp.Unix(),
+			Certificate: secretsvc.CertificateOf(&item),
 		})
 	}
 
@@ -70,7 +76,7 @@ func (c *SecretController) GetSecretList(ctx *gin.Context) {
 	helper.SuccessWithData("success", "secretList", secretList)
 }
 
-// GetSecretDetail 获取Secret详情
+// GetSecretDetail 获取Secret详情，data默认脱敏，只返回键名和长度，明文需通过reveal接口查看
 func (c *SecretController) GetSecretDetail(ctx *gin.Context) {
 	namespace := ctx.Param("namespace")
 	name := ctx.Param("name")
@@ -96,8 +102,13 @@ func (c *SecretController) GetSecretDetail(ctx *gin.Context) {
 		return
 	}
 
+	masked, keys := secretsvc.Mask(secret)
 	helper := utils.NewResponseHelper(ctx)
-	helper.SuccessWithData("success", "secretDetail", secret)
+	helper.Success("success", map[string]interface{}{
+		"secretDetail": masked,
+		"keys":         keys,
+		"certificate":  secretsvc.CertificateOf(secret),
+	})
 }
 
 // CreateSecret 创建Secret
@@ -174,6 +185,35 @@ func (c *SecretController) CreateSecret(ctx *gin.Context) {
 	helper.Success("Secret创建成功")
 }
 
+// CreateTypedSecret 按类型创建Secret，支持opaque、docker-registry、tls、basic-auth
+func (c *SecretController) CreateTypedSecret(ctx *gin.Context) {
+	helper := utils.NewResponseHelper(ctx)
+	var req k8s.SecretTypedCreateRequest
+	if err := ctx.ShouldBindJSON(&req); err != nil {
+		helper.BadRequest("请求参数错误: " + err.Error())
+		return
+	}
+	secret, certificate, err := secretsvc.BuildTyped(&req)
+	if err != nil {
+		helper.BadRequest(err.Error())
+		return
+	}
+
+	client, exists := configs.GetK8sClient(utils.GetInstanceIDFromContext(ctx))
+	if !exists {
+		helper.InternalError("K8s客户端未初始化")
+		return
+	}
+
+	if _, err := client.CoreV1().Secrets(req.Namespace).Create(ctx, secret, metav1.CreateOptions{}); err != nil {
+		helper.InternalError("创建Secret失败: " + err.Error())
+		return
+	}
+	helper.Success("Secret创建成功", map[string]interface{}{
+		"certificate": certificate,
+	})
+}
+
 // UpdateSecret 更新Secret
 func (c *SecretController) UpdateSecret(ctx *gin.Context) {
 	namespace := ctx.Param("namespace")
@@ -201,9 +241,14 @@ func (c *SecretController) UpdateSecret(ctx *gin.Context) {
 		return
 	}
 
-	var secret *corev1.Secret
-	var err error
+	existingSecret, err := client.CoreV1().Secrets(namespace).Get(ctx, name, metav1.GetOptions{})
+	if err != nil {
+		helper := utils.NewResponseHelper(ctx)
+		helper.NotFound("Secret 不存在")
+		return
+	}
 
+	var secret *corev1.Secret
 	if req.YAML != "" {
 		secret, err = c.parseYAMLToSecret(req.YAML)
 		if err != nil {
@@ -212,14 +257,6 @@ func (c *SecretController) UpdateSecret(ctx *gin.Context) {
 			return
 		}
 	} else {
-		// Get existing secret first
-		existingSecret, err := client.CoreV1().Secrets(namespace).Get(ctx, name, metav1.GetOptions{})
-		if err != nil {
-			helper := utils.NewResponseHelper(ctx)
-			helper.NotFound("Secret 不存在")
-			return
-		}
-
 		// Convert string data to byte data
 		data := make(map[string][]byte)
 		for k, v := range req.Data {
@@ -231,19 +268,35 @@ func (c *SecretController) UpdateSecret(ctx *gin.Context) {
 			}
 		}
 
-		existingSecret.Data = data
-		secret = existingSecret
+		secret = existingSecret.DeepCopy()
+		secret.Data = data
+	}
+
+	// 保存更新前的内容（加密），用于对比和回滚
+	helper := utils.NewResponseHelper(ctx)
+	revision, ok := recordRevision(ctx, helper, instanceID, workload.RefKindSecret, namespace, name,
+		confighistory.SecretContent(existingSecret), dal.ConfigRevisionActionUpdate)
+	if !ok {
+		return
 	}
 
 	_, err = client.CoreV1().Secrets(namespace).Update(ctx, secret, metav1.UpdateOptions{})
 	if err != nil {
-		helper := utils.NewResponseHelper(ctx)
+		discardRevision(revision)
 		helper.InternalError("更新Secret失败: " + err.Error())
 		return
 	}
 
-	helper := utils.NewResponseHelper(ctx)
-	helper.Success("Secret 更新成功")
+	result := map[string]interface{}{}
+	if req.RestartWorkloads {
+		restarted, err := restartConsumers(ctx, client, workload.RefKindSecret, namespace, name)
+		if err != nil {
+			helper.InternalError("Secret已更新，但重启工作负载失败: " + err.Error())
+			return
+		}
+		result["restarted"] = restarted
+	}
+	helper.Success("Secret 更新成功", result)
 }
 
 // DeleteSecret 删除Secret
@@ -265,15 +318,19 @@ func (c *SecretController) DeleteSecret(ctx *gin.Context) {
 		return
 	}
 
+	helper := utils.NewResponseHelper(ctx)
+	refs, ok := common.CheckReferences(ctx, helper, client, workload.RefKindSecret, namespace, name)
+	if !ok {
+		return
+	}
+
 	err := client.CoreV1().Secrets(namespace).Delete(ctx, name, metav1.DeleteOptions{})
 	if err != nil {
-		helper := utils.NewResponseHelper(ctx)
 		helper.InternalError("删除Secret失败: " + err.Error())
 		return
 	}
 
-	helper := utils.NewResponseHelper(ctx)
-	helper.Success("Secret 删除成功")
+	helper.Success("Secret 删除成功", map[string]interface{}{"references": refs})
 }
 
 func (c *SecretController) parseYAMLToSecret(yamlContent string) (*corev1.Secret, error) {
@@ -0,0 +1,125 @@
+package config
+
+import (
+	"devops-console-backend/internal/dal"
+	"devops-console-backend/internal/dal/request/k8s"
+	secretsvc "devops-console-backend/internal/services/secret"
+	"devops-console-backend/pkg/configs"
+	"devops-console-backend/pkg/utils"
+	"devops-console-backend/pkg/utils/logs"
+	"strings"
+	"time"
+
+	"github.com/gin-gonic/gin"
+	apierrors "k8s.io/apimachinery/pkg/api/errors"
+	metav1 "k8s.io/apimachinery/pkg/apis/meta/v1"
+)
+
+// RevealSecret 查看Secret明文，需要命名空间的secret-view授权，每次查看都会记录审计
+func (c *SecretController) RevealSecret(ctx *gin.Context) {
+	helper := utils.NewResponseHelper(ctx)
+	namespace := ctx.Param("namespace")
+	name := ctx.Param("name")
+	var req k8s.SecretRevealRequest
+	if err := ctx.ShouldBindJSON(&req); err != nil {
+		helper.BadRequest("请求参数错误: " + err.Error())
+		return
+	}
+
+	claims := utils.GetUserInfoFromContext(ctx)
+	if claims == nil {
+		return
+	}
+	instanceID := utils.GetInstanceIDFromContext(ctx)
+	allowed, err := configs.HasNamespacePermission(claims.GetUserId(), claims.GetUserName(), claims.GetRoles(),
+		instanceID, namespace, dal.K8sActionSecretView)
+	if err != nil {
+		helper.DatabaseError("校验权限失败")
+		return
+	}
+	if !allowed {
+		helper.Forbidden("没有查看该命名空间Secret明文的权限")
+		return
+	}
+
+	client, exists := configs.GetK8sClient(instanceID)
+	if !exists {
+		helper.InternalError("K8s客户端未初始化")
+		return
+	}
+	secret, err := client.CoreV1().Secrets(namespace).Get(ctx, name, metav1.GetOptions{})
+	if err != nil {
+		if apierrors.IsNotFound(err) {
+			helper.NotFound("Secret 不存在")
+			return
+		}
+		helper.InternalError("获取Secret失败: " + err.Error())
+		return
+	}
+	values, err := secretsvc.Reveal(secret, req.Keys)
+	if err != nil {
+		helper.BadRequest(err.Error())
+		return
+	}
+
+	keys := make([]string, 0, len(values))
+	for _, value := range values {
+		keys = append(keys, value.Key)
+	}
+	logData := map[string]interface{}{
+		"user":       claims.GetUserName(),
+		"instanceId": instanceID,
+		"namespace":  namespace,
+		"secret":     name,
+		"keys":       keys,
+	}
+	// 审计记录写入失败时不返回明文
+	record := &dal.K8sSecretAccessLog{
+		UserID:     claims.GetUserId(),
+		Username:   claims.GetUserName(),
+		InstanceID: instanceID,
+		Namespace:  namespace,
+		Name:       name,
+		Keys:       strings.Join(keys, ","),
+		ClientIP:   utils.GetClientIP(ctx.Request),
+		CreatedAt:  time.Now(),
+	}
+	if err := configs.NewK8sSecretAccessLogRepository().Create(record); err != nil {
+		logs.Error(logData, "保存Secret查看记录失败: "+err.Error())
+		helper.DatabaseError("保存审计记录失败")
+		return
+	}
+	logs.Info(logData, "查看Secret明文")
+	helper.SuccessWithData("success", "values", values)
+}
+
+// GetSecretAccessLogs 分页获取Secret明文查看记录，非管理员只能看到自己的记录
+func (c *SecretController) GetSecretAccessLogs(ctx *gin.Context) {
+	helper := utils.NewResponseHelper(ctx)
+	var req k8s.SessionListRequest
+	if err := ctx.ShouldBindQuery(&req); err != nil {
+		helper.ValidationError(err.Error())
+		return
+	}
+
+	claims := utils.GetUserInfoFromContext(ctx)
+	if claims == nil {
+		return
+	}
+	var userID int64
+	if !configs.IsAdminUser(claims.GetUserName(), claims.GetRoles()) {
+		userID = claims.GetUserId()
+	}
+	records, total, err := configs.NewK8sSecretAccessLogRepository().GetWithPagination(userID, (req.Page-1)*req.PageSize, req.PageSize)
+	if err != nil {
+		helper.DatabaseError("获取Secret查看记录失败")
+		return
+	}
+
+	helper.SuccessWithData("success", "data", gin.H{
+		"logList":  records,
+		"total":    total,
+		"page":     req.Page,
+		"pageSize": req.PageSize,
+	})
+}
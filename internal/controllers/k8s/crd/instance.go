@@ -0,0 +1,338 @@
+package crd
+
+import (
+	"bytes"
+	"devops-console-backend/internal/dal/request/k8s"
+	"devops-console-backend/pkg/configs"
+	"devops-console-backend/pkg/utils"
+	"devops-console-backend/pkg/utils/logs"
+	"encoding/json"
+	"fmt"
+	"strings"
+
+	"github.com/gin-gonic/gin"
+	"k8s.io/apiextensions-apiserver/pkg/apis/apiextensions"
+	apiextensionsv1 "k8s.io/apiextensions-apiserver/pkg/apis/apiextensions/v1"
+	"k8s.io/apiextensions-apiserver/pkg/apiserver/validation"
+	metav1 "k8s.io/apimachinery/pkg/apis/meta/v1"
+	"k8s.io/apimachinery/pkg/apis/meta/v1/unstructured"
+	"k8s.io/apimachinery/pkg/runtime/schema"
+	"k8s.io/apimachinery/pkg/util/validation/field"
+	"k8s.io/client-go/dynamic"
+	"k8s.io/client-go/util/jsonpath"
+	"sigs.k8s.io/yaml"
+)
+
+// customResourceContext 自定义资源操作所需的CRD信息和客户端
+type customResourceContext struct {
+	crd     *apiextensionsv1.CustomResourceDefinition
+	version *apiextensionsv1.CustomResourceDefinitionVersion
+	gvr     schema.GroupVersionResource
+	client  dynamic.Interface
+}
+
+// namespaced 判断自定义资源是否为命名空间级别
+func (r *customResourceContext) namespaced() bool {
+	return r.crd.Spec.Scope == apiextensionsv1.NamespaceScoped
+}
+
+// resource 返回指定命名空间的资源接口，集群级资源忽略命名空间
+func (r *customResourceContext) resource(namespace string) dynamic.ResourceInterface {
+	if !r.namespaced() || namespace == "" {
+		return r.client.Resource(r.gvr)
+	}
+	return r.client.Resource(r.gvr).Namespace(namespace)
+}
+
+// loadCustomResourceContext 根据CRD名称解析GVR，默认使用storage版本，可通过version参数指定其他served版本，失败时已写入响应
+func loadCustomResourceContext(ctx *gin.Context) (*customResourceContext, bool) {
+	helper := utils.NewResponseHelper(ctx)
+	instanceID := utils.GetInstanceIDFromContext(ctx)
+
+	apiClient, exists := configs.GetApiExtensionsClient(instanceID)
+	if !exists {
+		helper.InternalError("K8s ApiExtensions客户端未初始化")
+		return nil, false
+	}
+	dynamicClient, exists := configs.GetDynamicClient(instanceID)
+	if !exists {
+		helper.InternalError("K8s动态客户端未初始化")
+		return nil, false
+	}
+
+	crd, err := apiClient.ApiextensionsV1().CustomResourceDefinitions().Get(ctx, ctx.Param("crd"), metav1.GetOptions{})
+	if err != nil {
+		helper.NotFound("CRD不存在: " + err.Error())
+		return nil, false
+	}
+
+	version := selectVersion(crd, ctx.Query("version"))
+	if version == nil {
+		helper.BadRequest("CRD没有可用的served版本")
+		return nil, false
+	}
+
+	return &customResourceContext{
+		crd:     crd,
+		version: version,
+		gvr: schema.GroupVersionResource{
+			Group:    crd.Spec.Group,
+			Version:  version.Name,
+			Resource: crd.Spec.Names.Plural,
+		},
+		client: dynamicClient,
+	}, true
+}
+
+// selectVersion 选择访问版本：指定的served版本，否则为served的storage版本，再否则为第一个served版本
+func selectVersion(crd *apiextensionsv1.CustomResourceDefinition, requested string) *apiextensionsv1.CustomResourceDefinitionVersion {
+	var fallback *apiextensionsv1.CustomResourceDefinitionVersion
+	for i := range crd.Spec.Versions {
+		version := &crd.Spec.Versions[i]
+		if !version.Served {
+			continue
+		}
+		if requested != "" {
+			if version.Name == requested {
+				return version
+			}
+			continue
+		}
+		if version.Storage {
+			return version
+		}
+		if fallback == nil {
+			fallback = version
+		}
+	}
+	return fallback
+}
+
+// GetCustomResourceList 列出CRD的实例，按additionalPrinterColumns渲染表格列
+func (c *CRDController) GetCustomResourceList(ctx *gin.Context) {
+	helper := utils.NewResponseHelper(ctx)
+	rc, ok := loadCustomResourceContext(ctx)
+	if !ok {
+		return
+	}
+
+	namespace := ctx.Query("namespace")
+	if namespace == "all" {
+		namespace = ""
+	}
+	list, err := rc.resource(namespace).List(ctx, metav1.ListOptions{LabelSelector: ctx.Query("label_selector")})
+	if err != nil {
+		helper.InternalError("获取自定义资源列表失败: " + err.Error())
+		return
+	}
+
+	columns, parsers := printerColumns(rc.version.AdditionalPrinterColumns)
+	rows := make([]gin.H, 0, len(list.Items))
+	for i := range list.Items {
+		item := &list.Items[i]
+		cells := make([]interface{}, 0, len(parsers))
+		for _, parser := range parsers {
+			cells = append(cells, evaluateColumn(parser, item.Object))
+		}
+		rows = append(rows, gin.H{
+			"name":      item.GetName(),
+			"namespace": item.GetNamespace(),
+			"age":       item.GetCreationTimestamp().Unix(),
+			"cells":     cells,
+		})
+	}
+
+	helper.SuccessWithData("success", "data", gin.H{
+		"group":      rc.crd.Spec.Group,
+		"version":    rc.version.Name,
+		"kind":       rc.crd.Spec.Names.Kind,
+		"namespaced": rc.namespaced(),
+		"columns":    columns,
+		"rows":       rows,
+	})
+}
+
+// GetCustomResourceDetail 获取单个自定义资源
+func (c *CRDController) GetCustomResourceDetail(ctx *gin.Context) {
+	helper := utils.NewResponseHelper(ctx)
+	rc, ok := loadCustomResourceContext(ctx)
+	if !ok {
+		return
+	}
+
+	obj, err := rc.resource(ctx.Query("namespace")).Get(ctx, ctx.Param("name"), metav1.GetOptions{})
+	if err != nil {
+		helper.NotFound("自定义资源不存在: " + err.Error())
+		return
+	}
+	content, err := yaml.Marshal(obj.Object)
+	if err != nil {
+		helper.InternalError("转换YAML失败: " + err.Error())
+		return
+	}
+
+	helper.Success("success", map[string]interface{}{
+		"resourceDetail": obj.Object,
+		"yaml":           string(content),
+	})
+}
+
+// UpdateCustomResource 以YAML更新自定义资源，提交前按CRD的OpenAPI schema校验
+func (c *CRDController) UpdateCustomResource(ctx *gin.Context) {
+	helper := utils.NewResponseHelper(ctx)
+	var req k8s.CustomResourceUpdateRequest
+	if err := ctx.ShouldBindJSON(&req); err != nil {
+		helper.ValidationError(err.Error())
+		return
+	}
+
+	rc, ok := loadCustomResourceContext(ctx)
+	if !ok {
+		return
+	}
+
+	obj := &unstructured.Unstructured{}
+	if err := yaml.Unmarshal([]byte(req.YAML), &obj.Object); err != nil {
+		helper.BadRequest("YAML格式错误: " + err.Error())
+		return
+	}
+	name := ctx.Param("name")
+	namespace := ctx.Query("namespace")
+	if obj.GetName() != name {
+		helper.BadRequest("不能修改资源名称")
+		return
+	}
+	if rc.namespaced() && obj.GetNamespace() != namespace {
+		helper.BadRequest("不能修改资源所属命名空间")
+		return
+	}
+	if obj.GetAPIVersion() != rc.gvr.GroupVersion().String() || obj.GetKind() != rc.crd.Spec.Names.Kind {
+		helper.BadRequest(fmt.Sprintf("apiVersion和kind必须为%s %s", rc.gvr.GroupVersion().String(), rc.crd.Spec.Names.Kind))
+		return
+	}
+
+	if errs := validateAgainstSchema(rc.version, obj); len(errs) > 0 {
+		messages := make([]string, 0, len(errs))
+		for _, e := range errs {
+			messages = append(messages, e.Error())
+		}
+		helper.BadRequest("校验失败: " + strings.Join(messages, "; "))
+		return
+	}
+
+	// 未携带resourceVersion时使用当前版本
+	if obj.GetResourceVersion() == "" {
+		current, err := rc.resource(namespace).Get(ctx, name, metav1.GetOptions{})
+		if err != nil {
+			helper.NotFound("自定义资源不存在: " + err.Error())
+			return
+		}
+		obj.SetResourceVersion(current.GetResourceVersion())
+	}
+
+	if _, err := rc.resource(namespace).Update(ctx, obj, metav1.UpdateOptions{}); err != nil {
+		helper.InternalError("更新自定义资源失败: " + err.Error())
+		return
+	}
+
+	logs.Info(map[string]interface{}{
+		"crd":       rc.crd.Name,
+		"namespace": namespace,
+		"name":      name,
+		"user":      utils.GetUserNameFromContext(ctx),
+	}, "更新自定义资源")
+	helper.Success("更新成功")
+}
+
+// DeleteCustomResource 删除自定义资源
+func (c *CRDController) DeleteCustomResource(ctx *gin.Context) {
+	helper := utils.NewResponseHelper(ctx)
+	rc, ok := loadCustomResourceContext(ctx)
+	if !ok {
+		return
+	}
+
+	namespace := ctx.Query("namespace")
+	name := ctx.Param("name")
+	if err := rc.resource(namespace).Delete(ctx, name, metav1.DeleteOptions{}); err != nil {
+		helper.InternalError("删除自定义资源失败: " + err.Error())
+		return
+	}
+
+	logs.Info(map[string]interface{}{
+		"crd":       rc.crd.Name,
+		"namespace": namespace,
+		"name":      name,
+		"user":      utils.GetUserNameFromContext(ctx),
+	}, "删除自定义资源")
+	helper.Success("删除成功")
+}
+
+// printerColumns 解析additionalPrinterColumns，JSONPath无效的列会被跳过
+func printerColumns(definitions []apiextensionsv1.CustomResourceColumnDefinition) ([]k8s.CustomResourceColumn, []*jsonpath.JSONPath) {
+	columns := make([]k8s.CustomResourceColumn, 0, len(definitions))
+	parsers := make([]*jsonpath.JSONPath, 0, len(definitions))
+	for _, definition := range definitions {
+		parser := jsonpath.New(definition.Name).AllowMissingKeys(true)
+		if err := parser.Parse(fmt.Sprintf("{%s}", definition.JSONPath)); err != nil {
+			continue
+		}
+		columns = append(columns, k8s.CustomResourceColumn{
+			Name:        definition.Name,
+			Type:        definition.Type,
+			Description: definition.Description,
+			Priority:    definition.Priority,
+		})
+		parsers = append(parsers, parser)
+	}
+	return columns, parsers
+}
+
+// evaluateColumn 计算单元格的值，单个简单值原样返回，多个值以逗号拼接
+func evaluateColumn(parser *jsonpath.JSONPath, obj map[string]interface{}) interface{} {
+	results, err := parser.FindResults(obj)
+	if err != nil || len(results) == 0 {
+		return nil
+	}
+
+	values := make([]string, 0)
+	for _, result := range results {
+		for _, value := range result {
+			if !value.IsValid() || !value.CanInterface() {
+				continue
+			}
+			raw := value.Interface()
+			switch v := raw.(type) {
+			case string, bool, int64, float64:
+				if len(results) == 1 && len(result) == 1 {
+					return v
+				}
+				values = append(values, fmt.Sprint(v))
+			default:
+				data, _ := json.Marshal(v)
+				values = append(values, string(bytes.TrimSpace(data)))
+			}
+		}
+	}
+	if len(values) == 0 {
+		return nil
+	}
+	return strings.Join(values, ",")
+}
+
+// validateAgainstSchema 按CRD版本的OpenAPI v3 schema校验对象，未定义schema时跳过
+func validateAgainstSchema(version *apiextensionsv1.CustomResourceDefinitionVersion, obj *unstructured.Unstructured) field.ErrorList {
+	if version.Schema == nil || version.Schema.OpenAPIV3Schema == nil {
+		return nil
+	}
+
+	internal := &apiextensions.JSONSchemaProps{}
+	if err := apiextensionsv1.Convert_v1_JSONSchemaProps_To_apiextensions_JSONSchemaProps(version.Schema.OpenAPIV3Schema, internal, nil); err != nil {
+		return field.ErrorList{field.InternalError(nil, err)}
+	}
+	validator, _, err := validation.NewSchemaValidator(internal)
+	if err != nil {
+		return field.ErrorList{field.InternalError(nil, err)}
+	}
+	return validation.ValidateCustomResource(nil, obj.UnstructuredContent(), validator)
+}
@@ -0,0 +1,166 @@
+package cronjob
+
+import (
+	"context"
+
+	batchv1 "k8s.io/api/batch/v1"
+	batchv1beta1 "k8s.io/api/batch/v1beta1"
+	apierrors "k8s.io/apimachinery/pkg/api/errors"
+	metav1 "k8s.io/apimachinery/pkg/apis/meta/v1"
+	"k8s.io/apimachinery/pkg/types"
+	"k8s.io/client-go/kubernetes"
+)
+
+// cronJobAPI 根据集群能力选择batch/v1或batch/v1beta1访问CronJob，对外统一使用batch/v1类型
+type cronJobAPI struct {
+	client kubernetes.Interface
+	v1     bool
+}
+
+// newCronJobAPI 探测集群是否提供batch/v1 CronJob（Kubernetes 1.21+），否则回退到batch/v1beta1
+func newCronJobAPI(client kubernetes.Interface) *cronJobAPI {
+	api := &cronJobAPI{client: client, v1: true}
+	resources, err := client.Discovery().ServerResourcesForGroupVersion("batch/v1")
+	if err != nil {
+		// 探测失败时默认使用batch/v1，只有明确不存在时才回退
+		api.v1 = !apierrors.IsNotFound(err)
+		return api
+	}
+	api.v1 = false
+	for _, resource := range resources.APIResources {
+		if resource.Name == "cronjobs" {
+			api.v1 = true
+			break
+		}
+	}
+	return api
+}
+
+// Version 返回实际使用的API版本
+func (a *cronJobAPI) Version() string {
+	if a.v1 {
+		return "batch/v1"
+	}
+	return "batch/v1beta1"
+}
+
+// List 获取CronJob列表
+func (a *cronJobAPI) List(ctx context.Context, namespace string) ([]batchv1.CronJob, error) {
+	if a.v1 {
+		list, err := a.client.BatchV1().CronJobs(namespace).List(ctx, metav1.ListOptions{})
+		if err != nil {
+			return nil, err
+		}
+		return list.Items, nil
+	}
+
+	list, err := a.client.BatchV1beta1().CronJobs(namespace).List(ctx, metav1.ListOptions{})
+	if err != nil {
+		return nil, err
+	}
+	items := make([]batchv1.CronJob, 0, len(list.Items))
+	for i := range list.Items {
+		items = append(items, *convertV1Beta1ToV1CronJob(&list.Items[i]))
+	}
+	return items, nil
+}
+
+// Get 获取CronJob
+func (a *cronJobAPI) Get(ctx context.Context, namespace, name string) (*batchv1.CronJob, error) {
+	if a.v1 {
+		return a.client.BatchV1().CronJobs(namespace).Get(ctx, name, metav1.GetOptions{})
+	}
+	cronJob, err := a.client.BatchV1beta1().CronJobs(namespace).Get(ctx, name, metav1.GetOptions{})
+	if err != nil {
+		return nil, err
+	}
+	return convertV1Beta1ToV1CronJob(cronJob), nil
+}
+
+// Create 创建CronJob
+func (a *cronJobAPI) Create(ctx context.Context, cronJob *batchv1.CronJob) error {
+	if a.v1 {
+		_, err := a.client.BatchV1().CronJobs(cronJob.Namespace).Create(ctx, cronJob, metav1.CreateOptions{})
+		return err
+	}
+	_, err := a.client.BatchV1beta1().CronJobs(cronJob.Namespace).Create(ctx, convertV1ToV1Beta1CronJob(cronJob), metav1.CreateOptions{})
+	return err
+}
+
+// Patch 更新CronJob，两个版本的字段名一致，补丁可以通用
+func (a *cronJobAPI) Patch(ctx context.Context, namespace, name string, patchType types.PatchType, data []byte) error {
+	if a.v1 {
+		_, err := a.client.BatchV1().CronJobs(namespace).Patch(ctx, name, patchType, data, metav1.PatchOptions{})
+		return err
+	}
+	_, err := a.client.BatchV1beta1().CronJobs(namespace).Patch(ctx, name, patchType, data, metav1.PatchOptions{})
+	return err
+}
+
+// Delete 删除CronJob，同时删除其创建的Job
+func (a *cronJobAPI) Delete(ctx context.Context, namespace, name string) error {
+	propagation := metav1.DeletePropagationBackground
+	opts := metav1.DeleteOptions{PropagationPolicy: &propagation}
+	if a.v1 {
+		return a.client.BatchV1().CronJobs(namespace).Delete(ctx, name, opts)
+	}
+	return a.client.BatchV1beta1().CronJobs(namespace).Delete(ctx, name, opts)
+}
+
+// convertV1ToV1Beta1CronJob 将 batch/v1 CronJob 转换为 batch/v1beta1 CronJob
+func convertV1ToV1Beta1CronJob(v1CronJob *batchv1.CronJob) *batchv1beta1.CronJob {
+	if v1CronJob == nil {
+		return nil
+	}
+
+	return &batchv1beta1.CronJob{
+		ObjectMeta: v1CronJob.ObjectMeta,
+		Spec: batchv1beta1.CronJobSpec{
+			Schedule:                v1CronJob.Spec.Schedule,
+			TimeZone:                v1CronJob.Spec.TimeZone,
+			StartingDeadlineSeconds: v1CronJob.Spec.StartingDeadlineSeconds,
+			ConcurrencyPolicy:       batchv1beta1.ConcurrencyPolicy(v1CronJob.Spec.ConcurrencyPolicy),
+			Suspend:                 v1CronJob.Spec.Suspend,
+			JobTemplate: batchv1beta1.JobTemplateSpec{
+				ObjectMeta: v1CronJob.Spec.JobTemplate.ObjectMeta,
+				Spec:       v1CronJob.Spec.JobTemplate.Spec,
+			},
+			SuccessfulJobsHistoryLimit: v1CronJob.Spec.SuccessfulJobsHistoryLimit,
+			FailedJobsHistoryLimit:     v1CronJob.Spec.FailedJobsHistoryLimit,
+		},
+		Status: batchv1beta1.CronJobStatus{
+			Active:             v1CronJob.Status.Active,
+			LastScheduleTime:   v1CronJob.Status.LastScheduleTime,
+			LastSuccessfulTime: v1CronJob.Status.LastSuccessfulTime,
+		},
+	}
+}
+
+// convertV1Beta1ToV1CronJob 将 batch/v1beta1 CronJob 转换为 batch/v1 CronJob
+func convertV1Beta1ToV1CronJob(v1beta1CronJob *batchv1beta1.CronJob) *batchv1.CronJob {
+	if v1beta1CronJob == nil {
+		return nil
+	}
+
+	return &batchv1.CronJob{
+		ObjectMeta: v1beta1CronJob.ObjectMeta,
+		Spec: batchv1.CronJobSpec{
+			Schedule:                v1beta1CronJob.Spec.Schedule,
+			TimeZone:                v1beta1CronJob.Spec.TimeZone,
+			StartingDeadlineSeconds: v1beta1CronJob.Spec.StartingDeadlineSeconds,
+			ConcurrencyPolicy:       batchv1.ConcurrencyPolicy(v1beta1CronJob.Spec.ConcurrencyPolicy),
+			Suspend:                 v1beta1CronJob.Spec.Suspend,
+			JobTemplate: batchv1.JobTemplateSpec{
+				ObjectMeta: v1beta1CronJob.Spec.JobTemplate.ObjectMeta,
+				Spec:       v1beta1CronJob.Spec.JobTemplate.Spec,
+			},
+			SuccessfulJobsHistoryLimit: v1beta1CronJob.Spec.SuccessfulJobsHistoryLimit,
+			FailedJobsHistoryLimit:     v1beta1CronJob.Spec.FailedJobsHistoryLimit,
+		},
+		Status: batchv1.CronJobStatus{
+			Active:             v1beta1CronJob.Status.Active,
+			LastScheduleTime:   v1beta1CronJob.Status.LastScheduleTime,
+			LastSuccessfulTime: v1beta1CronJob.Status.LastSuccessfulTime,
+		},
+	}
+}
@@ -1,21 +1,20 @@
 package cronjob
 
 import (
-	"context"
 	"devops-console-backend/internal/dal/request/k8s"
+	"devops-console-backend/internal/services/workload"
 	"devops-console-backend/pkg/configs"
 	"devops-console-backend/pkg/utils"
 	"devops-console-backend/pkg/utils/logs"
 	"encoding/json"
-	"strconv"
-	"strings"
+	"time"
 
 	"github.com/gin-gonic/gin"
 	batchv1 "k8s.io/api/batch/v1"
-	batchv1beta1 "k8s.io/api/batch/v1beta1"
 	corev1 "k8s.io/api/core/v1"
 	metav1 "k8s.io/apimachinery/pkg/apis/meta/v1"
 	"k8s.io/apimachinery/pkg/types"
+	"k8s.io/client-go/kubernetes"
 )
 
 // CronJobController CronJob控制器
@@ -26,86 +25,18 @@ func NewCronJobController() *CronJobController {
 	return &CronJobController{}
 }
 
-// convertV1ToV1Beta1CronJob 将 batch/v1 CronJob 转换为 batch/v1beta1 CronJob
-func (c *CronJobController) convertV1ToV1Beta1CronJob(v1CronJob *batchv1.CronJob) *batchv1beta1.CronJob {
-	if v1CronJob == nil {
-		return nil
-	}
-
-	return &batchv1beta1.CronJob{
-		ObjectMeta: v1CronJob.ObjectMeta,
-		Spec: batchv1beta1.CronJobSpec{
-			Schedule:                v1CronJob.Spec.Schedule,
-			StartingDeadlineSeconds: v1CronJob.Spec.StartingDeadlineSeconds,
-			ConcurrencyPolicy:       batchv1beta1.ConcurrencyPolicy(v1CronJob.Spec.ConcurrencyPolicy),
-			Suspend:                 v1CronJob.Spec.Suspend,
-			JobTemplate: batchv1beta1.JobTemplateSpec{
-				ObjectMeta: v1CronJob.Spec.JobTemplate.ObjectMeta,
-				Spec: batchv1.JobSpec{
-					Parallelism:             v1CronJob.Spec.JobTemplate.Spec.Parallelism,
-					Completions:             v1CronJob.Spec.JobTemplate.Spec.Completions,
-					ActiveDeadlineSeconds:   v1CronJob.Spec.JobTemplate.Spec.ActiveDeadlineSeconds,
-					BackoffLimit:            v1CronJob.Spec.JobTemplate.Spec.BackoffLimit,
-					TTLSecondsAfterFinished: v1CronJob.Spec.JobTemplate.Spec.TTLSecondsAfterFinished,
-					Template:                v1CronJob.Spec.JobTemplate.Spec.Template,
-				},
-			},
-			SuccessfulJobsHistoryLimit: v1CronJob.Spec.SuccessfulJobsHistoryLimit,
-			FailedJobsHistoryLimit:     v1CronJob.Spec.FailedJobsHistoryLimit,
-		},
-		Status: batchv1beta1.CronJobStatus{
-			Active:           v1CronJob.Status.Active,
-			LastScheduleTime: v1CronJob.Status.LastScheduleTime,
-			LastSuccessfulTime: func() *metav1.Time {
-				if v1CronJob.Status.LastSuccessfulTime != nil {
-					t := *v1CronJob.Status.LastSuccessfulTime
-					return &t
-				}
-				return nil
-			}(),
-		},
-	}
-}
-
-// convertV1Beta1ToV1CronJob 将 batch/v1beta1 CronJob 转换为 batch/v1 CronJob
-func (c *CronJobController) convertV1Beta1ToV1CronJob(v1beta1CronJob *batchv1beta1.CronJob) *batchv1.CronJob {
-	if v1beta1CronJob == nil {
-		return nil
-	}
+// getClient 获取instance_id对应的K8s客户端，失败时已写入响应
+func (c *CronJobController) getClient(ctx *gin.Context, logData map[string]interface{}) (kubernetes.Interface, bool) {
+	instanceID := utils.GetInstanceIDFromContext(ctx)
 
-	return &batchv1.CronJob{
-		ObjectMeta: v1beta1CronJob.ObjectMeta,
-		Spec: batchv1.CronJobSpec{
-			Schedule:                v1beta1CronJob.Spec.Schedule,
-			StartingDeadlineSeconds: v1beta1CronJob.Spec.StartingDeadlineSeconds,
-			ConcurrencyPolicy:       batchv1.ConcurrencyPolicy(v1beta1CronJob.Spec.ConcurrencyPolicy),
-			Suspend:                 v1beta1CronJob.Spec.Suspend,
-			JobTemplate: batchv1.JobTemplateSpec{
-				ObjectMeta: v1beta1CronJob.Spec.JobTemplate.ObjectMeta,
-				Spec: batchv1.JobSpec{
-					Parallelism:             v1beta1CronJob.Spec.JobTemplate.Spec.Parallelism,
-					Completions:             v1beta1CronJob.Spec.JobTemplate.Spec.Completions,
-					ActiveDeadlineSeconds:   v1beta1CronJob.Spec.JobTemplate.Spec.ActiveDeadlineSeconds,
-					BackoffLimit:            v1beta1CronJob.Spec.JobTemplate.Spec.BackoffLimit,
-					TTLSecondsAfterFinished: v1beta1CronJob.Spec.JobTemplate.Spec.TTLSecondsAfterFinished,
-					Template:                v1beta1CronJob.Spec.JobTemplate.Spec.Template,
-				},
-			},
-			SuccessfulJobsHistoryLimit: v1beta1CronJob.Spec.SuccessfulJobsHistoryLimit,
-			FailedJobsHistoryLimit:     v1beta1CronJob.Spec.FailedJobsHistoryLimit,
-		},
-		Status: batchv1.CronJobStatus{
-			Active:           v1beta1CronJob.Status.Active,
-			LastScheduleTime: v1beta1CronJob.Status.LastScheduleTime,
-			LastSuccessfulTime: func() *metav1.Time {
-				if v1beta1CronJob.Status.LastSuccessfulTime != nil {
-					t := *v1beta1CronJob.Status.LastSuccessfulTime
-					return &t
-				}
-				return nil
-			}(),
-		},
+	client, exists := configs.GetK8sClient(instanceID)
+	if !exists {
+		logs.Error(logData, "K8s客户端未初始化")
+		helper := utils.NewResponseHelper(ctx)
+		helper.InternalError("K8s客户端未初始化")
+		return nil, false
 	}
+	return client, true
 }
 
 // CreateCronJob 创建CronJob
@@ -127,48 +58,18 @@ func (c *CronJobController) CreateCronJob(ctx *gin.Context) {
 		"schedule":  req.Schedule,
 	}
 
-	instanceIDStr := ctx.Query("instance_id")
-	instanceID := uint(1) // 默认值
-	if instanceIDStr != "" {
-		if id, err := strconv.ParseInt(instanceIDStr, 10, 32); err == nil {
-			instanceID = uint(id)
-		}
-	}
-
-	client, exists := configs.GetK8sClient(instanceID)
-	if !exists {
-		logs.Error(logData, "K8s客户端未初始化")
+	if _, err := workload.NextSchedules(req.Schedule, req.TimeZone, time.Now(), 1); err != nil {
 		helper := utils.NewResponseHelper(ctx)
-		helper.InternalError("K8s客户端未初始化")
+		helper.BadRequest(err.Error())
 		return
 	}
 
-	cronJob := c.convertCreateRequestToK8sCronJob(&req)
-	var err error
-
-	// 首先尝试使用 batch/v1 API (Kubernetes 1.21+)
-	if groups, err := client.Discovery().ServerGroups(); err == nil {
-		for _, group := range groups.Groups {
-			if group.Name == "batch" {
-				for _, version := range group.Versions {
-					if version.Version == "v1" {
-						// 转换为 batch/v1 格式
-						v1CronJob := c.convertV1Beta1ToV1CronJob(cronJob)
-						_, err = client.BatchV1().CronJobs(req.Namespace).Create(context.TODO(), v1CronJob, metav1.CreateOptions{})
-						break
-					}
-				}
-				break
-			}
-		}
-	}
-
-	// 如果 batch/v1 不可用或失败，尝试使用 batch/v1beta1
-	if err != nil {
-		_, err = client.BatchV1beta1().CronJobs(req.Namespace).Create(context.TODO(), cronJob, metav1.CreateOptions{})
+	client, ok := c.getClient(ctx, logData)
+	if !ok {
+		return
 	}
 
-	if err != nil {
+	if err := newCronJobAPI(client).Create(ctx, c.convertCreateRequestToK8sCronJob(&req)); err != nil {
 		logs.Error(logData, "创建CronJob失败: "+err.Error())
 		helper := utils.NewResponseHelper(ctx)
 		helper.InternalError("创建CronJob失败: " + err.Error())
@@ -198,44 +99,12 @@ func (c *CronJobController) DeleteCronJob(ctx *gin.Context) {
 		"namespace": req.Namespace,
 	}
 
-	instanceIDStr := ctx.Query("instance_id")
-	instanceID := uint(1) // 默认值
-	if instanceIDStr != "" {
-		if id, err := strconv.ParseInt(instanceIDStr, 10, 32); err == nil {
-			instanceID = uint(id)
-		}
-	}
-
-	client, exists := configs.GetK8sClient(instanceID)
-	if !exists {
-		logs.Error(logData, "K8s客户端未初始化")
-		helper := utils.NewResponseHelper(ctx)
-		helper.InternalError("K8s客户端未初始化")
+	client, ok := c.getClient(ctx, logData)
+	if !ok {
 		return
 	}
 
-	var err error
-
-	// 首先尝试使用 batch/v1 API (Kubernetes 1.21+)
-	if groups, err := client.Discovery().ServerGroups(); err == nil {
-		for _, group := range groups.Groups {
-			if group.Name == "batch" {
-				for _, version := range group.Versions {
-					if version.Version == "v1" {
-						err = client.BatchV1().CronJobs(req.Namespace).Delete(context.TODO(), req.Name, metav1.DeleteOptions{})
-						break
-					}
-				}
-				break
-			}
-		}
-	}
-
-	// 如果 batch/v1 不可用或失败，尝试使用 batch/v1beta1
-	if err != nil {
-		err = client.BatchV1beta1().CronJobs(req.Namespace).Delete(context.TODO(), req.Name, metav1.DeleteOptions{})
-	}
-	if err != nil {
+	if err := newCronJobAPI(client).Delete(ctx, req.Namespace, req.Name); err != nil {
 		logs.Error(logData, "删除CronJob失败: "+err.Error())
 		helper := utils.NewResponseHelper(ctx)
 		helper.InternalError("删除CronJob失败: " + err.Error())
@@ -261,100 +130,109 @@ func (c *CronJobController) GetCronJobList(ctx *gin.Context) {
 	logData := map[string]interface{}{"namespace": namespace}
 	logs.Debug(logData, "获取CronJob列表")
 
-	instanceIDStr := ctx.Query("instance_id")
-	instanceID := uint(1) // 默认值
-	if instanceIDStr != "" {
-		if id, err := strconv.ParseInt(instanceIDStr, 10, 32); err == nil {
-			instanceID = uint(id)
-		}
+	client, ok := c.getClient(ctx, logData)
+	if !ok {
+		return
 	}
 
-	client, exists := configs.GetK8sClient(instanceID)
-	if !exists {
-		logs.Error(logData, "K8s客户端未初始化")
+	cronJobs, err := newCronJobAPI(client).List(ctx, namespace)
+	if err != nil {
+		logs.Error(logData, "获取CronJob列表失败: "+err.Error())
 		helper := utils.NewResponseHelper(ctx)
-		helper.InternalError("K8s客户端未初始化")
+		helper.InternalError("获取CronJob列表失败: " + err.Error())
 		return
 	}
 
-	var cronJobList *batchv1beta1.CronJobList
-	var err error
-
-	// 首先尝试使用 batch/v1 API (Kubernetes 1.21+)
-	if groups, err := client.Discovery().ServerGroups(); err == nil {
-		// 检查是否支持 batch/v1
-		for _, group := range groups.Groups {
-			if group.Name == "batch" {
-				for _, version := range group.Versions {
-					if version.Version == "v1" {
-						// 使用 batch/v1 API
-						v1CronJobs, err1 := client.BatchV1().CronJobs(namespace).List(context.TODO(), metav1.ListOptions{})
-						if err1 == nil {
-							// 转换为 v1beta1 格式以保持兼容性
-							cronJobList = &batchv1beta1.CronJobList{
-								ListMeta: v1CronJobs.ListMeta,
-								Items:    make([]batchv1beta1.CronJob, len(v1CronJobs.Items)),
-							}
-							for i, item := range v1CronJobs.Items {
-								converted := c.convertV1ToV1Beta1CronJob(&item)
-								if converted != nil {
-									cronJobList.Items[i] = *converted
-								}
-							}
-							err = nil
-							break
-						}
-					}
-				}
-				break
+	now := time.Now()
+	resp := make([]k8s.CronJobListItem, 0, len(cronJobs))
+	for i := range cronJobs {
+		cj := &cronJobs[i]
+		containerName, image, command := c.firstContainer(cj)
+
+		item := k8s.CronJobListItem{
+			Name:             cj.Name,
+			Namespace:        cj.Namespace,
+			ContainerName:    containerName,
+			Image:            image,
+			Command:          command,
+			Schedule:         cj.Spec.Schedule,
+			TimeZone:         stringValue(cj.Spec.TimeZone),
+			Suspend:          isSuspended(cj),
+			Status:           cronJobStatus(cj),
+			ActiveJobs:       len(cj.Status.Active),
+			LastScheduleTime: formatTime(cj.Status.LastScheduleTime),
+			Age:              cj.CreationTimestamp.Unix(),
+		}
+		if !item.Suspend {
+			if next, err := workload.NextSchedules(cj.Spec.Schedule, item.TimeZone, now, 1); err == nil && len(next) > 0 {
+				item.NextScheduleTime = next[0].Format(time.RFC3339)
 			}
 		}
+		resp = append(resp, item)
 	}
 
-	// 如果 batch/v1 不可用或失败，尝试使用 batch/v1beta1
-	if cronJobList == nil {
-		cronJobList, err = client.BatchV1beta1().CronJobs(namespace).List(context.TODO(), metav1.ListOptions{})
+	logs.Info(map[string]interface{}{"count": len(resp), "data": logData}, "获取CronJob列表成功")
+	helper := utils.NewResponseHelper(ctx)
+	helper.SuccessWithData("查询成功", "cronJobList", resp)
+}
+
+// GetCronJobDetail 获取CronJob详情，包含接下来的调度时间
+func (c *CronJobController) GetCronJobDetail(ctx *gin.Context) {
+	namespace := ctx.Param("namespace")
+	name := ctx.Param("name")
+	logData := map[string]interface{}{"namespace": namespace, "name": name}
+
+	client, ok := c.getClient(ctx, logData)
+	if !ok {
+		return
 	}
 
+	api := newCronJobAPI(client)
+	cj, err := api.Get(ctx, namespace, name)
 	if err != nil {
-		logs.Error(logData, "获取CronJob列表失败: "+err.Error())
 		helper := utils.NewResponseHelper(ctx)
-		helper.InternalError("获取CronJob列表失败: " + err.Error())
+		helper.NotFound("CronJob不存在")
 		return
 	}
 
-	resp := make([]k8s.CronJobListItem, 0, len(cronJobList.Items))
-	for _, cj := range cronJobList.Items {
-		status := ""
-		if strings.Contains(cj.Status.String(), "Active") {
-			status = "active"
-		}
-
-		containerName := ""
-		image := ""
-		command := []string{}
-		if len(cj.Spec.JobTemplate.Spec.Template.Spec.Containers) > 0 {
-			container := cj.Spec.JobTemplate.Spec.Template.Spec.Containers[0]
-			containerName = container.Name
-			image = container.Image
-			command = container.Command
+	containerName, image, command := c.firstContainer(cj)
+	detail := k8s.CronJobDetail{
+		Name:                       cj.Name,
+		Namespace:                  cj.Namespace,
+		ContainerName:              containerName,
+		Image:                      image,
+		Command:                    command,
+		Schedule:                   cj.Spec.Schedule,
+		TimeZone:                   stringValue(cj.Spec.TimeZone),
+		Suspend:                    isSuspended(cj),
+		ConcurrencyPolicy:          string(cj.Spec.ConcurrencyPolicy),
+		StartingDeadlineSeconds:    cj.Spec.StartingDeadlineSeconds,
+		SuccessfulJobsHistoryLimit: cj.Spec.SuccessfulJobsHistoryLimit,
+		FailedJobsHistoryLimit:     cj.Spec.FailedJobsHistoryLimit,
+		Status:                     cronJobStatus(cj),
+		ActiveJobs:                 make([]string, 0, len(cj.Status.Active)),
+		LastScheduleTime:           formatTime(cj.Status.LastScheduleTime),
+		LastSuccessfulTime:         formatTime(cj.Status.LastSuccessfulTime),
+		NextScheduleTimes:          []string{},
+		APIVersion:                 api.Version(),
+		Age:                        cj.CreationTimestamp.Unix(),
+		Labels:                     cj.Labels,
+	}
+	for _, ref := range cj.Status.Active {
+		detail.ActiveJobs = append(detail.ActiveJobs, ref.Name)
+	}
+
+	next, err := workload.NextSchedules(cj.Spec.Schedule, detail.TimeZone, time.Now(), 5)
+	if err != nil {
+		detail.ScheduleError = err.Error()
+	} else if !detail.Suspend {
+		for _, t := range next {
+			detail.NextScheduleTimes = append(detail.NextScheduleTimes, t.Format(time.RFC3339))
 		}
-
-		resp = append(resp, k8s.CronJobListItem{
-			Name:          cj.Name,
-			Namespace:     cj.Namespace,
-			ContainerName: containerName,
-			Image:         image,
-			Command:       command,
-			Schedule:      cj.Spec.Schedule,
-			Status:        status,
-			Age:           cj.CreationTimestamp.Unix(),
-		})
 	}
 
-	logs.Info(map[string]interface{}{"count": len(resp), "data": logData}, "获取CronJob列表成功")
 	helper := utils.NewResponseHelper(ctx)
-	helper.SuccessWithData("查询成功", "cronJobList", resp)
+	helper.SuccessWithData("success", "cronJobDetail", detail)
 }
 
 // UpdateCronJob 更新CronJob
@@ -375,50 +253,14 @@ func (c *CronJobController) UpdateCronJob(ctx *gin.Context) {
 		"namespace": req.Namespace,
 	}
 
-	instanceIDStr := ctx.Query("instance_id")
-	instanceID := uint(1) // 默认值
-	if instanceIDStr != "" {
-		if id, err := strconv.ParseInt(instanceIDStr, 10, 32); err == nil {
-			instanceID = uint(id)
-		}
-	}
-
-	client, exists := configs.GetK8sClient(instanceID)
-	if !exists {
-		logs.Error(logData, "K8s客户端未初始化")
-		helper := utils.NewResponseHelper(ctx)
-		helper.InternalError("K8s客户端未初始化")
+	client, ok := c.getClient(ctx, logData)
+	if !ok {
 		return
 	}
 
 	// 获取现有CronJob
-	var oldCJ *batchv1beta1.CronJob
-	var err error
-
-	// 首先尝试使用 batch/v1 API (Kubernetes 1.21+)
-	if groups, err := client.Discovery().ServerGroups(); err == nil {
-		for _, group := range groups.Groups {
-			if group.Name == "batch" {
-				for _, version := range group.Versions {
-					if version.Version == "v1" {
-						v1CronJob, err1 := client.BatchV1().CronJobs(req.Namespace).Get(context.TODO(), req.Name, metav1.GetOptions{})
-						if err1 == nil {
-							// 转换为 v1beta1 格式以保持兼容性
-							oldCJ = c.convertV1ToV1Beta1CronJob(v1CronJob)
-							err = nil
-							break
-						}
-					}
-				}
-				break
-			}
-		}
-	}
-
-	// 如果 batch/v1 不可用或失败，尝试使用 batch/v1beta1
-	if oldCJ == nil {
-		oldCJ, err = client.BatchV1beta1().CronJobs(req.Namespace).Get(context.TODO(), req.Name, metav1.GetOptions{})
-	}
+	api := newCronJobAPI(client)
+	oldCJ, err := api.Get(ctx, req.Namespace, req.Name)
 	if err != nil {
 		logs.Error(logData, "获取CronJob失败: "+err.Error())
 		helper := utils.NewResponseHelper(ctx)
@@ -427,26 +269,44 @@ func (c *CronJobController) UpdateCronJob(ctx *gin.Context) {
 	}
 
 	// 构造patch数据
-	patch := make(map[string]interface{})
+	spec := make(map[string]interface{})
 	updateFields := make(map[string]interface{})
 
+	schedule := oldCJ.Spec.Schedule
+	timeZone := stringValue(oldCJ.Spec.TimeZone)
 	if req.Schedule != nil {
-		patch["spec"] = make(map[string]interface{})
-		patch["spec"].(map[string]interface{})["schedule"] = *req.Schedule
+		schedule = *req.Schedule
+		spec["schedule"] = *req.Schedule
 		updateFields["schedule"] = *req.Schedule
 	}
-
-	if req.Image != nil {
-		containerName := ""
-		if len(oldCJ.Spec.JobTemplate.Spec.Template.Spec.Containers) > 0 {
-			containerName = oldCJ.Spec.JobTemplate.Spec.Template.Spec.Containers[0].Name
+	if req.TimeZone != nil {
+		timeZone = *req.TimeZone
+		if *req.TimeZone == "" {
+			spec["timeZone"] = nil
+		} else {
+			spec["timeZone"] = *req.TimeZone
+		}
+		updateFields["timeZone"] = *req.TimeZone
+	}
+	if req.Schedule != nil || req.TimeZone != nil {
+		if _, err := workload.NextSchedules(schedule, timeZone, time.Now(), 1); err != nil {
+			helper := utils.NewResponseHelper(ctx)
+			helper.BadRequest(err.Error())
+			return
 		}
+	}
+	if req.ConcurrencyPolicy != nil {
+		spec["concurrencyPolicy"] = *req.ConcurrencyPolicy
+		updateFields["concurrencyPolicy"] = *req.ConcurrencyPolicy
+	}
 
-		jobTpl := map[string]interface{}{
+	if req.Image != nil {
+		containerName, _, _ := c.firstContainer(oldCJ)
+		// 策略合并补丁按容器名合并，不会丢失容器的其他配置
+		spec["jobTemplate"] = map[string]interface{}{
 			"spec": map[string]interface{}{
 				"template": map[string]interface{}{
 					"spec": map[string]interface{}{
-						"restartPolicy": "OnFailure",
 						"containers": []map[string]interface{}{{
 							"name":  containerName,
 							"image": *req.Image,
@@ -455,37 +315,12 @@ func (c *CronJobController) UpdateCronJob(ctx *gin.Context) {
 				},
 			},
 		}
-
-		if _, ok := patch["spec"]; !ok {
-			patch["spec"] = make(map[string]interface{})
-		}
-		patch["spec"].(map[string]interface{})["jobTemplate"] = jobTpl
 		updateFields["image"] = *req.Image
 	}
 
 	// 发送patch请求
-	payload, _ := json.Marshal(patch)
-
-	// 首先尝试使用 batch/v1 API (Kubernetes 1.21+)
-	if groups, err := client.Discovery().ServerGroups(); err == nil {
-		for _, group := range groups.Groups {
-			if group.Name == "batch" {
-				for _, version := range group.Versions {
-					if version.Version == "v1" {
-						_, err = client.BatchV1().CronJobs(req.Namespace).Patch(context.TODO(), req.Name, types.MergePatchType, payload, metav1.PatchOptions{})
-						break
-					}
-				}
-				break
-			}
-		}
-	}
-
-	// 如果 batch/v1 不可用或失败，尝试使用 batch/v1beta1
-	if err != nil {
-		_, err = client.BatchV1beta1().CronJobs(req.Namespace).Patch(context.TODO(), req.Name, types.MergePatchType, payload, metav1.PatchOptions{})
-	}
-	if err != nil {
+	payload, _ := json.Marshal(map[string]interface{}{"spec": spec})
+	if err := api.Patch(ctx, req.Namespace, req.Name, types.StrategicMergePatchType, payload); err != nil {
 		logs.Error(map[string]interface{}{"updateFields": updateFields, "error": err.Error(), "data": logData}, "更新CronJob失败")
 		helper := utils.NewResponseHelper(ctx)
 		helper.InternalError("更新CronJob失败: " + err.Error())
@@ -497,16 +332,27 @@ func (c *CronJobController) UpdateCronJob(ctx *gin.Context) {
 	helper.Success("CronJob更新成功")
 }
 
+// firstContainer 返回jobTemplate中第一个容器的名称、镜像和命令
+func (c *CronJobController) firstContainer(cj *batchv1.CronJob) (string, string, []string) {
+	containers := cj.Spec.JobTemplate.Spec.Template.Spec.Containers
+	if len(containers) == 0 {
+		return "", "", []string{}
+	}
+	return containers[0].Name, containers[0].Image, containers[0].Command
+}
+
 // convertCreateRequestToK8sCronJob 转换创建请求为K8s CronJob
-func (c *CronJobController) convertCreateRequestToK8sCronJob(req *k8s.CronJobCreateRequest) *batchv1beta1.CronJob {
-	return &batchv1beta1.CronJob{
+func (c *CronJobController) convertCreateRequestToK8sCronJob(req *k8s.CronJobCreateRequest) *batchv1.CronJob {
+	cronJob := &batchv1.CronJob{
 		ObjectMeta: metav1.ObjectMeta{
 			Name:      req.Name,
 			Namespace: req.Namespace,
 		},
-		Spec: batchv1beta1.CronJobSpec{
-			Schedule: req.Schedule,
-			JobTemplate: batchv1beta1.JobTemplateSpec{
+		Spec: batchv1.CronJobSpec{
+			Schedule:          req.Schedule,
+			ConcurrencyPolicy: batchv1.ConcurrencyPolicy(req.ConcurrencyPolicy),
+			Suspend:           &req.Suspend,
+			JobTemplate: batchv1.JobTemplateSpec{
 				Spec: batchv1.JobSpec{
 					Template: corev1.PodTemplateSpec{
 						Spec: corev1.PodSpec{
@@ -522,4 +368,40 @@ func (c *CronJobController) convertCreateRequestToK8sCronJob(req *k8s.CronJobCre
 			},
 		},
 	}
+	if req.TimeZone != "" {
+		cronJob.Spec.TimeZone = &req.TimeZone
+	}
+	return cronJob
+}
+
+// isSuspended 判断CronJob是否已暂停
+func isSuspended(cj *batchv1.CronJob) bool {
+	return cj.Spec.Suspend != nil && *cj.Spec.Suspend
+}
+
+// cronJobStatus 返回CronJob状态：suspended、active（有运行中的Job）或空
+func cronJobStatus(cj *batchv1.CronJob) string {
+	if isSuspended(cj) {
+		return "suspended"
+	}
+	if len(cj.Status.Active) > 0 {
+		return "active"
+	}
+	return ""
+}
+
+// stringValue 返回字符串指针的值
+func stringValue(s *string) string {
+	if s == nil {
+		return ""
+	}
+	return *s
+}
+
+// formatTime 格式化时间，空值返回空字符串
+func formatTime(t *metav1.Time) string {
+	if t == nil {
+		return ""
+	}
+	return t.Format(time.RFC3339)
 }
@@ -0,0 +1,211 @@
+package cronjob
+
+import (
+	"devops-console-backend/internal/dal/request/k8s"
+	"devops-console-backend/internal/services/workload"
+	"devops-console-backend/pkg/utils"
+	"devops-console-backend/pkg/utils/logs"
+	"fmt"
+	"sort"
+	"strconv"
+	"time"
+
+	"github.com/gin-gonic/gin"
+	batchv1 "k8s.io/api/batch/v1"
+	metav1 "k8s.io/apimachinery/pkg/apis/meta/v1"
+	"k8s.io/apimachinery/pkg/types"
+)
+
+// SuspendCronJob 暂停CronJob调度
+func (c *CronJobController) SuspendCronJob(ctx *gin.Context) {
+	c.setSuspend(ctx, true)
+}
+
+// ResumeCronJob 恢复CronJob调度
+func (c *CronJobController) ResumeCronJob(ctx *gin.Context) {
+	c.setSuspend(ctx, false)
+}
+
+// setSuspend 设置CronJob的suspend字段
+func (c *CronJobController) setSuspend(ctx *gin.Context, suspend bool) {
+	namespace := ctx.Param("namespace")
+	name := ctx.Param("name")
+	logData := map[string]interface{}{"namespace": namespace, "name": name, "suspend": suspend}
+
+	client, ok := c.getClient(ctx, logData)
+	if !ok {
+		return
+	}
+
+	payload := []byte(fmt.Sprintf(`{"spec":{"suspend":%t}}`, suspend))
+	if err := newCronJobAPI(client).Patch(ctx, namespace, name, types.MergePatchType, payload); err != nil {
+		logs.Error(logData, "设置CronJob暂停状态失败: "+err.Error())
+		helper := utils.NewResponseHelper(ctx)
+		helper.InternalError("操作失败: " + err.Error())
+		return
+	}
+
+	logs.Info(logData, "设置CronJob暂停状态成功")
+	helper := utils.NewResponseHelper(ctx)
+	if suspend {
+		helper.Success("CronJob已暂停")
+	} else {
+		helper.Success("CronJob已恢复")
+	}
+}
+
+// TriggerCronJob 立即按jobTemplate创建一次Job，不受暂停状态影响
+func (c *CronJobController) TriggerCronJob(ctx *gin.Context) {
+	namespace := ctx.Param("namespace")
+	name := ctx.Param("name")
+	logData := map[string]interface{}{"namespace": namespace, "name": name}
+
+	client, ok := c.getClient(ctx, logData)
+	if !ok {
+		return
+	}
+
+	cj, err := newCronJobAPI(client).Get(ctx, namespace, name)
+	if err != nil {
+		helper := utils.NewResponseHelper(ctx)
+		helper.NotFound("CronJob不存在")
+		return
+	}
+
+	job, err := client.BatchV1().Jobs(namespace).Create(ctx, workload.NewJobFromCronJob(cj), metav1.CreateOptions{})
+	if err != nil {
+		logs.Error(logData, "手动触发CronJob失败: "+err.Error())
+		helper := utils.NewResponseHelper(ctx)
+		helper.InternalError("手动触发失败: " + err.Error())
+		return
+	}
+
+	logData["job"] = job.Name
+	logData["user"] = utils.GetUserNameFromContext(ctx)
+	logs.Info(logData, "手动触发CronJob成功")
+	helper := utils.NewResponseHelper(ctx)
+	helper.SuccessWithData("已触发", "jobName", job.Name)
+}
+
+// GetCronJobHistory 获取CronJob创建的Job执行记录及Pod状态，按创建时间倒序
+func (c *CronJobController) GetCronJobHistory(ctx *gin.Context) {
+	namespace := ctx.Param("namespace")
+	name := ctx.Param("name")
+	logData := map[string]interface{}{"namespace": namespace, "name": name}
+
+	client, ok := c.getClient(ctx, logData)
+	if !ok {
+		return
+	}
+
+	cj, err := newCronJobAPI(client).Get(ctx, namespace, name)
+	if err != nil {
+		helper := utils.NewResponseHelper(ctx)
+		helper.NotFound("CronJob不存在")
+		return
+	}
+
+	jobList, err := client.BatchV1().Jobs(namespace).List(ctx, metav1.ListOptions{})
+	if err != nil {
+		logs.Error(logData, "获取Job列表失败: "+err.Error())
+		helper := utils.NewResponseHelper(ctx)
+		helper.InternalError("获取Job列表失败: " + err.Error())
+		return
+	}
+
+	jobs := make([]*batchv1.Job, 0)
+	for i := range jobList.Items {
+		if ref := metav1.GetControllerOf(&jobList.Items[i]); ref != nil && ref.UID == cj.UID {
+			jobs = append(jobs, &jobList.Items[i])
+		}
+	}
+	sort.Slice(jobs, func(i, j int) bool {
+		return jobs[j].CreationTimestamp.Before(&jobs[i].CreationTimestamp)
+	})
+
+	history := make([]k8s.JobHistoryItem, 0, len(jobs))
+	for _, job := range jobs {
+		pods, err := workload.ListJobPods(ctx, client, job)
+		if err != nil {
+			logs.Warning(map[string]interface{}{"job": job.Name, "error": err.Error()}, "获取Job的Pod失败")
+		}
+		history = append(history, workload.ToJobHistoryItem(job, pods))
+	}
+
+	helper := utils.NewResponseHelper(ctx)
+	helper.SuccessWithData("success", "jobHistory", history)
+}
+
+// GetCronJobJobLogs 获取CronJob某次执行的Pod日志
+func (c *CronJobController) GetCronJobJobLogs(ctx *gin.Context) {
+	namespace := ctx.Param("namespace")
+	name := ctx.Param("name")
+	jobName := ctx.Param("jobName")
+	logData := map[string]interface{}{"namespace": namespace, "name": name, "job": jobName}
+
+	tailLines := int64(500)
+	if value := ctx.Query("tail_lines"); value != "" {
+		if n, err := strconv.ParseInt(value, 10, 64); err == nil {
+			tailLines = n
+		}
+	}
+
+	client, ok := c.getClient(ctx, logData)
+	if !ok {
+		return
+	}
+
+	cj, err := newCronJobAPI(client).Get(ctx, namespace, name)
+	if err != nil {
+		helper := utils.NewResponseHelper(ctx)
+		helper.NotFound("CronJob不存在")
+		return
+	}
+	job, err := client.BatchV1().Jobs(namespace).Get(ctx, jobName, metav1.GetOptions{})
+	if err != nil {
+		helper := utils.NewResponseHelper(ctx)
+		helper.NotFound("Job不存在")
+		return
+	}
+	if ref := metav1.GetControllerOf(job); ref == nil || ref.UID != cj.UID {
+		helper := utils.NewResponseHelper(ctx)
+		helper.BadRequest("该Job不属于此CronJob")
+		return
+	}
+
+	pods, err := workload.ListJobPods(ctx, client, job)
+	if err != nil {
+		helper := utils.NewResponseHelper(ctx)
+		helper.InternalError("获取Job的Pod失败: " + err.Error())
+		return
+	}
+
+	helper := utils.NewResponseHelper(ctx)
+	helper.SuccessWithData("success", "podLogs", workload.CollectPodLogs(ctx, client, pods, ctx.Query("container"), tailLines))
+}
+
+// PreviewSchedule 预览cron表达式接下来的调度时间，用于创建或修改前校验
+func (c *CronJobController) PreviewSchedule(ctx *gin.Context) {
+	helper := utils.NewResponseHelper(ctx)
+
+	var req k8s.CronJobNextScheduleRequest
+	if err := ctx.ShouldBindQuery(&req); err != nil {
+		helper.ValidationError(err.Error())
+		return
+	}
+	if req.Count == 0 {
+		req.Count = 5
+	}
+
+	next, err := workload.NextSchedules(req.Schedule, req.TimeZone, time.Now(), req.Count)
+	if err != nil {
+		helper.BadRequest(err.Error())
+		return
+	}
+
+	times := make([]string, 0, len(next))
+	for _, t := range next {
+		times = append(times, t.Format(time.RFC3339))
+	}
+	helper.SuccessWithData("success", "nextScheduleTimes", times)
+}
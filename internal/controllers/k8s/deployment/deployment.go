@@ -1,12 +1,15 @@
 package deployment
 
 import (
+	"devops-console-backend/internal/dal"
 	"devops-console-backend/internal/dal/request/k8s"
+	"devops-console-backend/internal/services/workload"
 	"devops-console-backend/pkg/configs"
 	"devops-console-backend/pkg/utils"
 	"devops-console-backend/pkg/utils/logs"
 	"fmt"
 	"strconv"
+	"time"
 
 	"github.com/gin-gonic/gin"
 	appsv1 "k8s.io/api/apps/v1"
@@ -60,17 +63,32 @@ func (c *DeploymentController) GetDeploymentDetail(ctx *gin.Context) {
 		conditions[i] = condition
 	}
 
+	// 最近24小时Deployment及其ReplicaSet/Pod的Warning事件
+	warningEvents := make([]dal.K8sEventWarningSummary, 0)
+	ownedUIDs, err := workload.DescendantUIDs(ctx, client, "Deployment", deploymentDetail)
+	if err != nil {
+		logs.Warning(logData, "获取Deployment下属对象失败: "+err.Error())
+	}
+	summaries, err := configs.NewK8sEventRepository().GetWarningSummary(instanceID, deploymentDetail.Namespace,
+		"Deployment", deploymentDetail.Name, ownedUIDs, time.Now().Add(-24*time.Hour))
+	if err != nil {
+		logs.Warning(logData, "获取Warning事件失败: "+err.Error())
+	} else if summaries != nil {
+		warningEvents = summaries
+	}
+
 	logs.Info(logData, "获取Deployment详情成功")
 	helper := utils.NewResponseHelper(ctx)
 	helper.SuccessWithData("success", "deploymentDetail", k8s.DeploymentDetail{
-		Name:       deploymentDetail.Name,
-		Namespace:  deploymentDetail.Namespace,
-		Replicas:   deploymentDetail.Status.Replicas,
-		Ready:      deploymentDetail.Status.ReadyReplicas,
-		Available:  deploymentDetail.Status.AvailableReplicas,
-		Conditions: conditions,
-		Labels:     deploymentDetail.Labels,
-		Age:        deploymentDetail.CreationTimestamp.Unix(),
+		Name:          deploymentDetail.Name,
+		Namespace:     deploymentDetail.Namespace,
+		Replicas:      deploymentDetail.Status.Replicas,
+		Ready:         deploymentDetail.Status.ReadyReplicas,
+		Available:     deploymentDetail.Status.AvailableReplicas,
+		Conditions:    conditions,
+		Labels:        deploymentDetail.Labels,
+		Age:           deploymentDetail.CreationTimestamp.Unix(),
+		WarningEvents: warningEvents,
 	})
 }
 
@@ -0,0 +1,46 @@
+package diagnosis
+
+import (
+	"devops-console-backend/internal/services/diagnosis"
+	"devops-console-backend/pkg/configs"
+	"devops-console-backend/pkg/utils"
+	"errors"
+
+	"github.com/gin-gonic/gin"
+)
+
+// DiagnosisController 工作负载诊断控制器
+type DiagnosisController struct{}
+
+// NewDiagnosisController 创建工作负载诊断控制器实例
+func NewDiagnosisController() *DiagnosisController {
+	return &DiagnosisController{}
+}
+
+// DiagnoseWorkload 诊断工作负载及其Pod，返回发现的问题及处理建议
+func (c *DiagnosisController) DiagnoseWorkload(ctx *gin.Context) {
+	helper := utils.NewResponseHelper(ctx)
+	kind, ok := diagnosis.WorkloadKinds[ctx.Param("kind")]
+	if !ok {
+		helper.BadRequest("不支持的类型: " + ctx.Param("kind"))
+		return
+	}
+
+	client, exists := configs.GetK8sClient(utils.GetInstanceIDFromContext(ctx))
+	if !exists {
+		helper.InternalError("K8s客户端未初始化")
+		return
+	}
+
+	result, err := diagnosis.DiagnoseWorkload(ctx, client, kind, ctx.Param("namespace"), ctx.Param("name"))
+	if err != nil {
+		if errors.Is(err, diagnosis.ErrNotFound) {
+			helper.NotFound(kind + " 不存在")
+			return
+		}
+		helper.InternalError("诊断失败: " + err.Error())
+		return
+	}
+
+	helper.SuccessWithData("success", "diagnosis", result)
+}
@@ -1,13 +1,17 @@
 package event
 
 import (
+	"devops-console-backend/internal/dal"
 	"devops-console-backend/internal/dal/request/k8s"
+	"devops-console-backend/internal/services/workload"
 	"devops-console-backend/pkg/configs"
 	"devops-console-backend/pkg/utils"
-	"strconv"
+	"devops-console-backend/pkg/utils/logs"
+	"time"
 
 	"github.com/gin-gonic/gin"
 	corev1 "k8s.io/api/core/v1"
+	apierrors "k8s.io/apimachinery/pkg/api/errors"
 	metav1 "k8s.io/apimachinery/pkg/apis/meta/v1"
 )
 
@@ -22,13 +26,7 @@ func NewEventController() *EventController {
 // GetEventList 获取Event列表
 func (c *EventController) GetEventList(ctx *gin.Context) {
 	namespace := ctx.Param("namespace")
-	instanceIDStr := ctx.Query("instance_id")
-	instanceID := uint(1)
-	if instanceIDStr != "" {
-		if id, err := strconv.ParseInt(instanceIDStr, 10, 32); err == nil {
-			instanceID = uint(id)
-		}
-	}
+	instanceID := utils.GetInstanceIDFromContext(ctx)
 
 	client, exists := configs.GetK8sClient(instanceID)
 	if !exists {
@@ -73,3 +71,112 @@ func (c *EventController) GetEventList(ctx *gin.Context) {
 	helper := utils.NewResponseHelper(ctx)
 	helper.SuccessWithData("success", "eventList", eventList)
 }
+
+// GetEventHistory 分页查询归档的历史Event
+func (c *EventController) GetEventHistory(ctx *gin.Context) {
+	helper := utils.NewResponseHelper(ctx)
+
+	var req k8s.EventHistoryRequest
+	if err := ctx.ShouldBindQuery(&req); err != nil {
+		helper.ValidationError(err.Error())
+		return
+	}
+	if req.InstanceID == 0 {
+		req.InstanceID = 1
+	}
+
+	filter := &dal.K8sEventFilter{
+		InstanceID:   req.InstanceID,
+		Namespace:    req.Namespace,
+		InvolvedKind: req.Kind,
+		InvolvedName: req.Name,
+		Reason:       req.Reason,
+		Type:         req.Type,
+	}
+	if filter.Namespace == "all" {
+		filter.Namespace = ""
+	}
+	if req.StartTime > 0 {
+		startTime := time.Unix(req.StartTime, 0)
+		filter.StartTime = &startTime
+	}
+	if req.EndTime > 0 {
+		endTime := time.Unix(req.EndTime, 0)
+		filter.EndTime = &endTime
+	}
+
+	offset := (req.Page - 1) * req.PageSize
+	events, total, err := configs.NewK8sEventRepository().GetWithPagination(filter, offset, req.PageSize)
+	if err != nil {
+		helper.DatabaseError("获取历史Event失败")
+		return
+	}
+
+	eventList := make([]k8s.EventListItem, 0, len(events))
+	for _, item := range events {
+		eventList = append(eventList, k8s.EventListItem{
+			Name:           item.Name,
+			Namespace:      item.Namespace,
+			Type:           item.Type,
+			Reason:         item.Reason,
+			Message:        item.Message,
+			InvolvedObject: item.InvolvedName,
+			InvolvedKind:   item.InvolvedKind,
+			Source:         item.Source,
+			Count:          item.Count,
+			FirstTimestamp: item.FirstTimestamp.Unix(),
+			LastTimestamp:  item.LastTimestamp.Unix(),
+		})
+	}
+
+	helper.SuccessWithData("success", "data", gin.H{
+		"eventList": eventList,
+		"total":     total,
+		"page":      req.Page,
+		"pageSize":  req.PageSize,
+	})
+}
+
+// GetWarningSummary 按原因聚合指定资源最近的Warning事件
+func (c *EventController) GetWarningSummary(ctx *gin.Context) {
+	helper := utils.NewResponseHelper(ctx)
+
+	var req k8s.EventWarningRequest
+	if err := ctx.ShouldBindQuery(&req); err != nil {
+		helper.ValidationError(err.Error())
+		return
+	}
+	if req.InstanceID == 0 {
+		req.InstanceID = 1
+	}
+	if req.Hours == 0 {
+		req.Hours = 24
+	}
+
+	// 工作负载同时统计其下属ReplicaSet、Job和Pod的事件，按ownerReferences解析下属对象
+	var ownedUIDs []string
+	if client, exists := configs.GetK8sClient(req.InstanceID); exists {
+		uids, err := workload.OwnedUIDs(ctx, client, req.Kind, req.Namespace, req.Name)
+		if err != nil && !apierrors.IsNotFound(err) {
+			logs.Warning(map[string]interface{}{
+				"kind":      req.Kind,
+				"namespace": req.Namespace,
+				"name":      req.Name,
+				"error":     err.Error(),
+			}, "获取工作负载下属对象失败，只统计工作负载本身的事件")
+		}
+		ownedUIDs = uids
+	}
+
+	since := time.Now().Add(-time.Duration(req.Hours) * time.Hour)
+	summaries, err := configs.NewK8sEventRepository().GetWarningSummary(req.InstanceID, req.Namespace, req.Kind, req.Name, ownedUIDs, since)
+	if err != nil {
+		helper.DatabaseError("获取Warning事件失败")
+		return
+	}
+	if summaries == nil {
+		summaries = make([]dal.K8sEventWarningSummary, 0)
+	}
+
+	helper.SuccessWithData("success", "warningEvents", summaries)
+}
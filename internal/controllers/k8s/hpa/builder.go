@@ -0,0 +1,173 @@
+package hpa
+
+import (
+	"devops-console-backend/internal/dal/request/k8s"
+	"devops-console-backend/internal/services/workload"
+	"fmt"
+
+	autoscalingv2 "k8s.io/api/autoscaling/v2"
+	corev1 "k8s.io/api/core/v1"
+	"k8s.io/apimachinery/pkg/api/resource"
+	metav1 "k8s.io/apimachinery/pkg/apis/meta/v1"
+)
+
+// buildHPASpec 将请求转换为autoscaling/v2的HPA Spec
+func buildHPASpec(req *k8s.HPARequest) (autoscalingv2.HorizontalPodAutoscalerSpec, error) {
+	spec := autoscalingv2.HorizontalPodAutoscalerSpec{
+		ScaleTargetRef: autoscalingv2.CrossVersionObjectReference{
+			Kind:       req.ScaleTargetRef.Kind,
+			Name:       req.ScaleTargetRef.Name,
+			APIVersion: workload.DefaultScaleTargetAPIVersion(req.ScaleTargetRef.Kind, req.ScaleTargetRef.APIVersion),
+		},
+		MinReplicas: req.MinReplicas,
+		MaxReplicas: req.MaxReplicas,
+	}
+	if req.MinReplicas != nil && *req.MinReplicas > req.MaxReplicas {
+		return spec, fmt.Errorf("minReplicas不能大于maxReplicas")
+	}
+
+	for i := range req.Metrics {
+		metric, err := buildMetricSpec(&req.Metrics[i])
+		if err != nil {
+			return spec, fmt.Errorf("第%d个指标: %w", i+1, err)
+		}
+		spec.Metrics = append(spec.Metrics, metric)
+	}
+
+	if req.Behavior != nil {
+		spec.Behavior = &autoscalingv2.HorizontalPodAutoscalerBehavior{
+			ScaleUp:   buildScalingRules(req.Behavior.ScaleUp),
+			ScaleDown: buildScalingRules(req.Behavior.ScaleDown),
+		}
+	}
+	return spec, nil
+}
+
+// buildMetricSpec 按指标类型构造MetricSpec
+func buildMetricSpec(metric *k8s.HPAMetric) (autoscalingv2.MetricSpec, error) {
+	target, err := buildMetricTarget(&metric.Target)
+	if err != nil {
+		return autoscalingv2.MetricSpec{}, err
+	}
+	spec := autoscalingv2.MetricSpec{Type: autoscalingv2.MetricSourceType(metric.Type)}
+
+	switch spec.Type {
+	case autoscalingv2.ResourceMetricSourceType, autoscalingv2.ContainerResourceMetricSourceType:
+		if metric.ResourceName == "" {
+			return spec, fmt.Errorf("缺少resourceName")
+		}
+		if target.Type == autoscalingv2.ValueMetricType {
+			return spec, fmt.Errorf("资源指标只支持Utilization或AverageValue")
+		}
+		if spec.Type == autoscalingv2.ResourceMetricSourceType {
+			spec.Resource = &autoscalingv2.ResourceMetricSource{
+				Name:   corev1.ResourceName(metric.ResourceName),
+				Target: target,
+			}
+			break
+		}
+		if metric.Container == "" {
+			return spec, fmt.Errorf("ContainerResource指标缺少container")
+		}
+		spec.ContainerResource = &autoscalingv2.ContainerResourceMetricSource{
+			Name:      corev1.ResourceName(metric.ResourceName),
+			Container: metric.Container,
+			Target:    target,
+		}
+	case autoscalingv2.PodsMetricSourceType:
+		if target.Type != autoscalingv2.AverageValueMetricType {
+			return spec, fmt.Errorf("Pods指标只支持AverageValue")
+		}
+		spec.Pods = &autoscalingv2.PodsMetricSource{
+			Metric: buildMetricIdentifier(metric),
+			Target: target,
+		}
+	case autoscalingv2.ObjectMetricSourceType:
+		if metric.Object == nil {
+			return spec, fmt.Errorf("Object指标缺少object")
+		}
+		if target.Type == autoscalingv2.UtilizationMetricType {
+			return spec, fmt.Errorf("Object指标只支持Value或AverageValue")
+		}
+		spec.Object = &autoscalingv2.ObjectMetricSource{
+			DescribedObject: autoscalingv2.CrossVersionObjectReference{
+				Kind:       metric.Object.Kind,
+				Name:       metric.Object.Name,
+				APIVersion: metric.Object.APIVersion,
+			},
+			Metric: buildMetricIdentifier(metric),
+			Target: target,
+		}
+	case autoscalingv2.ExternalMetricSourceType:
+		if target.Type == autoscalingv2.UtilizationMetricType {
+			return spec, fmt.Errorf("External指标只支持Value或AverageValue")
+		}
+		spec.External = &autoscalingv2.ExternalMetricSource{
+			Metric: buildMetricIdentifier(metric),
+			Target: target,
+		}
+	}
+
+	if spec.Pods != nil || spec.Object != nil || spec.External != nil {
+		if metric.MetricName == "" {
+			return spec, fmt.Errorf("缺少metricName")
+		}
+	}
+	return spec, nil
+}
+
+// buildMetricIdentifier 构造自定义或外部指标标识
+func buildMetricIdentifier(metric *k8s.HPAMetric) autoscalingv2.MetricIdentifier {
+	identifier := autoscalingv2.MetricIdentifier{Name: metric.MetricName}
+	if len(metric.MetricSelector) > 0 {
+		identifier.Selector = &metav1.LabelSelector{MatchLabels: metric.MetricSelector}
+	}
+	return identifier
+}
+
+// buildMetricTarget 构造指标目标，数值使用Kubernetes quantity格式
+func buildMetricTarget(target *k8s.HPAMetricTarget) (autoscalingv2.MetricTarget, error) {
+	result := autoscalingv2.MetricTarget{Type: autoscalingv2.MetricTargetType(target.Type)}
+	switch result.Type {
+	case autoscalingv2.UtilizationMetricType:
+		if target.AverageUtilization == nil || *target.AverageUtilization <= 0 {
+			return result, fmt.Errorf("averageUtilization必须大于0")
+		}
+		result.AverageUtilization = target.AverageUtilization
+	case autoscalingv2.AverageValueMetricType:
+		quantity, err := resource.ParseQuantity(target.AverageValue)
+		if err != nil {
+			return result, fmt.Errorf("无效的averageValue %q", target.AverageValue)
+		}
+		result.AverageValue = &quantity
+	case autoscalingv2.ValueMetricType:
+		quantity, err := resource.ParseQuantity(target.Value)
+		if err != nil {
+			return result, fmt.Errorf("无效的value %q", target.Value)
+		}
+		result.Value = &quantity
+	}
+	return result, nil
+}
+
+// buildScalingRules 构造扩容或缩容规则
+func buildScalingRules(rules *k8s.HPAScalingRules) *autoscalingv2.HPAScalingRules {
+	if rules == nil {
+		return nil
+	}
+	result := &autoscalingv2.HPAScalingRules{
+		StabilizationWindowSeconds: rules.StabilizationWindowSeconds,
+	}
+	if rules.SelectPolicy != "" {
+		policy := autoscalingv2.ScalingPolicySelect(rules.SelectPolicy)
+		result.SelectPolicy = &policy
+	}
+	for _, policy := range rules.Policies {
+		result.Policies = append(result.Policies, autoscalingv2.HPAScalingPolicy{
+			Type:          autoscalingv2.HPAScalingPolicyType(policy.Type),
+			Value:         policy.Value,
+			PeriodSeconds: policy.PeriodSeconds,
+		})
+	}
+	return result
+}
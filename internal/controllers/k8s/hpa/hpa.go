@@ -1,15 +1,26 @@
 package hpa
 
 import (
+	"devops-console-backend/internal/dal/request/k8s"
+	"devops-console-backend/internal/services/workload"
 	"devops-console-backend/pkg/configs"
 	"devops-console-backend/pkg/utils"
+	"devops-console-backend/pkg/utils/logs"
+	"sort"
 	"strconv"
+	"time"
 
 	"github.com/gin-gonic/gin"
 	autoscalingv2 "k8s.io/api/autoscaling/v2"
+	corev1 "k8s.io/api/core/v1"
 	metav1 "k8s.io/apimachinery/pkg/apis/meta/v1"
+	"k8s.io/apimachinery/pkg/fields"
+	"k8s.io/client-go/kubernetes"
 )
 
+// maxScalingEvents 详情中返回的扩缩容事件数量
+const maxScalingEvents = 20
+
 type HPAController struct{}
 
 func NewHPAController() *HPAController {
@@ -82,8 +93,150 @@ func (c *HPAController) GetHPADetail(ctx *gin.Context) {
 		return
 	}
 
+	// 最近的扩缩容事件
+	scalingEvents := make([]gin.H, 0)
+	events, err := client.CoreV1().Events(namespace).List(ctx, metav1.ListOptions{
+		FieldSelector: fields.Set{
+			"involvedObject.kind": "HorizontalPodAutoscaler",
+			"involvedObject.name": name,
+		}.String(),
+	})
+	if err == nil {
+		sort.Slice(events.Items, func(i, j int) bool {
+			return eventTime(&events.Items[j]).Before(eventTime(&events.Items[i]))
+		})
+		for i, event := range events.Items {
+			if i >= maxScalingEvents {
+				break
+			}
+			scalingEvents = append(scalingEvents, gin.H{
+				"type":     event.Type,
+				"reason":   event.Reason,
+				"message":  event.Message,
+				"count":    event.Count,
+				"lastTime": eventTime(&event).Unix(),
+			})
+		}
+	}
+
+	var lastScaleTime int64
+	if hpa.Status.LastScaleTime != nil {
+		lastScaleTime = hpa.Status.LastScaleTime.Unix()
+	}
+
+	helper := utils.NewResponseHelper(ctx)
+	helper.Success("success", map[string]interface{}{
+		"hpaDetail": hpa,
+		"replicas": gin.H{
+			"current":       hpa.Status.CurrentReplicas,
+			"desired":       hpa.Status.DesiredReplicas,
+			"min":           hpa.Spec.MinReplicas,
+			"max":           hpa.Spec.MaxReplicas,
+			"lastScaleTime": lastScaleTime,
+		},
+		"currentMetrics": hpa.Status.CurrentMetrics,
+		"conditions":     hpa.Status.Conditions,
+		"scalingEvents":  scalingEvents,
+	})
+}
+
+// CreateHPA 创建autoscaling/v2 HPA
+func (c *HPAController) CreateHPA(ctx *gin.Context) {
+	helper := utils.NewResponseHelper(ctx)
+
+	var req k8s.HPARequest
+	if err := ctx.ShouldBindJSON(&req); err != nil {
+		helper.ValidationError(err.Error())
+		return
+	}
+	spec, client, ok := c.validateRequest(ctx, &req)
+	if !ok {
+		return
+	}
+
+	hpa := &autoscalingv2.HorizontalPodAutoscaler{
+		ObjectMeta: metav1.ObjectMeta{
+			Name:      req.Name,
+			Namespace: req.Namespace,
+		},
+		Spec: spec,
+	}
+	if _, err := client.AutoscalingV2().HorizontalPodAutoscalers(req.Namespace).Create(ctx, hpa, metav1.CreateOptions{}); err != nil {
+		helper.InternalError("创建HPA失败: " + err.Error())
+		return
+	}
+
+	logs.Info(map[string]interface{}{"namespace": req.Namespace, "name": req.Name, "target": req.ScaleTargetRef.Name}, "创建HPA成功")
+	helper.Success("HPA创建成功")
+}
+
+// UpdateHPA 更新HPA，整体替换扩缩容目标、副本范围、指标和行为
+func (c *HPAController) UpdateHPA(ctx *gin.Context) {
+	helper := utils.NewResponseHelper(ctx)
+
+	var req k8s.HPARequest
+	if err := ctx.ShouldBindJSON(&req); err != nil {
+		helper.ValidationError(err.Error())
+		return
+	}
+	spec, client, ok := c.validateRequest(ctx, &req)
+	if !ok {
+		return
+	}
+
+	hpa, err := client.AutoscalingV2().HorizontalPodAutoscalers(req.Namespace).Get(ctx, req.Name, metav1.GetOptions{})
+	if err != nil {
+		helper.NotFound("HPA不存在")
+		return
+	}
+	hpa.Spec = spec
+	if _, err := client.AutoscalingV2().HorizontalPodAutoscalers(req.Namespace).Update(ctx, hpa, metav1.UpdateOptions{}); err != nil {
+		helper.InternalError("更新HPA失败: " + err.Error())
+		return
+	}
+
+	logs.Info(map[string]interface{}{"namespace": req.Namespace, "name": req.Name, "target": req.ScaleTargetRef.Name}, "更新HPA成功")
+	helper.Success("HPA更新成功")
+}
+
+// validateRequest 构造HPA Spec并校验扩缩容目标存在，失败时已写入响应
+func (c *HPAController) validateRequest(ctx *gin.Context, req *k8s.HPARequest) (autoscalingv2.HorizontalPodAutoscalerSpec, kubernetes.Interface, bool) {
 	helper := utils.NewResponseHelper(ctx)
-	helper.SuccessWithData("success", "hpaDetail", hpa)
+	spec, err := buildHPASpec(req)
+	if err != nil {
+		helper.BadRequest(err.Error())
+		return spec, nil, false
+	}
+
+	instanceID := utils.GetInstanceIDFromContext(ctx)
+	client, exists := configs.GetK8sClient(instanceID)
+	if !exists {
+		helper.InternalError("K8s客户端未初始化")
+		return spec, nil, false
+	}
+	dynamicClient, exists := configs.GetDynamicClient(instanceID)
+	if !exists {
+		helper.InternalError("K8s动态客户端未初始化")
+		return spec, nil, false
+	}
+
+	target := spec.ScaleTargetRef
+	if err := workload.CheckScaleTarget(ctx, client, dynamicClient, req.Namespace, target.APIVersion, target.Kind, target.Name, true); err != nil {
+		helper.BadRequest(err.Error())
+		return spec, nil, false
+	}
+	return spec, client, true
+}
+
+// eventTime 返回事件最后发生时间
+func eventTime(event *corev1.Event) time.Time {
+	if !event.LastTimestamp.IsZero() {
+		return event.LastTimestamp.Time
+	}
+	if !event.EventTime.IsZero() {
+		return event.EventTime.Time
+	}
+	return event.CreationTimestamp.Time
 }
 
 func (c *HPAController) DeleteHPA(ctx *gin.Context) {
@@ -265,7 +265,9 @@ func (c *JobController) DeleteJob(ctx *gin.Context) {
 		return
 	}
 
-	err := client.BatchV1().Jobs(namespace).Delete(ctx, jobName, metav1.DeleteOptions{})
+	// 级联删除Job创建的Pod
+	propagation := metav1.DeletePropagationBackground
+	err := client.BatchV1().Jobs(namespace).Delete(ctx, jobName, metav1.DeleteOptions{PropagationPolicy: &propagation})
 	if err != nil {
 		helper := utils.NewResponseHelper(ctx)
 		helper.InternalError("删除Job失败: " + err.Error())
@@ -0,0 +1,223 @@
+package job
+
+import (
+	"devops-console-backend/internal/dal/request/k8s"
+	"devops-console-backend/internal/services/workload"
+	"devops-console-backend/pkg/configs"
+	"devops-console-backend/pkg/utils"
+	"devops-console-backend/pkg/utils/logs"
+	"fmt"
+	"strconv"
+	"time"
+
+	"github.com/gin-gonic/gin"
+	batchv1 "k8s.io/api/batch/v1"
+	metav1 "k8s.io/apimachinery/pkg/apis/meta/v1"
+	"k8s.io/apimachinery/pkg/types"
+	"k8s.io/client-go/kubernetes"
+)
+
+// getClient 获取instance_id对应的K8s客户端，失败时已写入响应
+func (c *JobController) getClient(ctx *gin.Context) (kubernetes.Interface, bool) {
+	instanceID := utils.GetInstanceIDFromContext(ctx)
+
+	client, exists := configs.GetK8sClient(instanceID)
+	if !exists {
+		helper := utils.NewResponseHelper(ctx)
+		helper.InternalError("K8s客户端未初始化")
+		return nil, false
+	}
+	return client, true
+}
+
+// RerunJob 以新名称重新运行已结束的Job
+func (c *JobController) RerunJob(ctx *gin.Context) {
+	helper := utils.NewResponseHelper(ctx)
+	namespace := ctx.Param("namespace")
+	jobName := ctx.Param("jobName")
+
+	client, ok := c.getClient(ctx)
+	if !ok {
+		return
+	}
+
+	job, err := client.BatchV1().Jobs(namespace).Get(ctx, jobName, metav1.GetOptions{})
+	if err != nil {
+		helper.NotFound("Job不存在")
+		return
+	}
+	if !workload.IsJobFinished(job) {
+		helper.BadRequest("只能重跑已结束的Job")
+		return
+	}
+
+	newJob, err := client.BatchV1().Jobs(namespace).Create(ctx, workload.CloneJobForRerun(job), metav1.CreateOptions{})
+	if err != nil {
+		helper.InternalError("重跑Job失败: " + err.Error())
+		return
+	}
+
+	logs.Info(map[string]interface{}{
+		"namespace": namespace,
+		"job":       jobName,
+		"newJob":    newJob.Name,
+		"user":      utils.GetUserNameFromContext(ctx),
+	}, "重跑Job")
+	helper.SuccessWithData("已重新运行", "jobName", newJob.Name)
+}
+
+// GetJobLogs 聚合获取Job所有Pod的日志
+func (c *JobController) GetJobLogs(ctx *gin.Context) {
+	helper := utils.NewResponseHelper(ctx)
+	namespace := ctx.Param("namespace")
+	jobName := ctx.Param("jobName")
+
+	tailLines := int64(500)
+	if value := ctx.Query("tail_lines"); value != "" {
+		if n, err := strconv.ParseInt(value, 10, 64); err == nil {
+			tailLines = n
+		}
+	}
+
+	client, ok := c.getClient(ctx)
+	if !ok {
+		return
+	}
+
+	job, err := client.BatchV1().Jobs(namespace).Get(ctx, jobName, metav1.GetOptions{})
+	if err != nil {
+		helper.NotFound("Job不存在")
+		return
+	}
+	pods, err := workload.ListJobPods(ctx, client, job)
+	if err != nil {
+		helper.InternalError("获取Job的Pod失败: " + err.Error())
+		return
+	}
+
+	helper.SuccessWithData("success", "podLogs", workload.CollectPodLogs(ctx, client, pods, ctx.Query("container"), tailLines))
+}
+
+// SuspendJob 暂停Indexed Job，运行中的Pod会被终止，恢复后未完成的索引继续执行
+func (c *JobController) SuspendJob(ctx *gin.Context) {
+	c.setSuspend(ctx, true)
+}
+
+// ResumeJob 恢复已暂停的Indexed Job
+func (c *JobController) ResumeJob(ctx *gin.Context) {
+	c.setSuspend(ctx, false)
+}
+
+// setSuspend 设置Job的suspend字段，只支持Indexed Job
+func (c *JobController) setSuspend(ctx *gin.Context, suspend bool) {
+	helper := utils.NewResponseHelper(ctx)
+	namespace := ctx.Param("namespace")
+	jobName := ctx.Param("jobName")
+
+	client, ok := c.getClient(ctx)
+	if !ok {
+		return
+	}
+
+	job, err := client.BatchV1().Jobs(namespace).Get(ctx, jobName, metav1.GetOptions{})
+	if err != nil {
+		helper.NotFound("Job不存在")
+		return
+	}
+	if job.Spec.CompletionMode == nil || *job.Spec.CompletionMode != batchv1.IndexedCompletion {
+		helper.BadRequest("只支持暂停和恢复Indexed Job（completionMode为Indexed）")
+		return
+	}
+	if workload.IsJobFinished(job) {
+		helper.BadRequest("Job已结束")
+		return
+	}
+
+	payload := []byte(fmt.Sprintf(`{"spec":{"suspend":%t}}`, suspend))
+	if _, err := client.BatchV1().Jobs(namespace).Patch(ctx, jobName, types.MergePatchType, payload, metav1.PatchOptions{}); err != nil {
+		helper.InternalError("操作失败: " + err.Error())
+		return
+	}
+
+	logs.Info(map[string]interface{}{"namespace": namespace, "job": jobName, "suspend": suspend}, "设置Job暂停状态")
+	if suspend {
+		helper.Success("Job已暂停")
+	} else {
+		helper.Success("Job已恢复")
+	}
+}
+
+// CleanupJobs 批量删除命名空间中结束超过指定时间的Job，并级联删除其Pod
+func (c *JobController) CleanupJobs(ctx *gin.Context) {
+	helper := utils.NewResponseHelper(ctx)
+
+	var req k8s.JobCleanupRequest
+	if err := ctx.ShouldBindJSON(&req); err != nil {
+		helper.ValidationError(err.Error())
+		return
+	}
+	statuses := map[string]bool{}
+	for _, status := range req.Statuses {
+		statuses[status] = true
+	}
+	if len(statuses) == 0 {
+		statuses[workload.JobStatusComplete] = true
+		statuses[workload.JobStatusFailed] = true
+	}
+
+	client, ok := c.getClient(ctx)
+	if !ok {
+		return
+	}
+
+	jobList, err := client.BatchV1().Jobs(req.Namespace).List(ctx, metav1.ListOptions{})
+	if err != nil {
+		helper.InternalError("获取Job列表失败: " + err.Error())
+		return
+	}
+
+	deadline := time.Now().Add(-time.Duration(req.OlderThanHours) * time.Hour)
+	candidates := make([]*batchv1.Job, 0)
+	for i := range jobList.Items {
+		job := &jobList.Items[i]
+		if !statuses[workload.JobStatus(job)] {
+			continue
+		}
+		if !req.IncludeOwned && metav1.GetControllerOf(job) != nil {
+			continue
+		}
+		if finished := workload.JobFinishedTime(job); finished == nil || finished.After(deadline) {
+			continue
+		}
+		candidates = append(candidates, job)
+	}
+
+	deleted := make([]string, 0, len(candidates))
+	failed := make(map[string]string)
+	propagation := metav1.DeletePropagationBackground
+	for _, job := range candidates {
+		if req.DryRun {
+			deleted = append(deleted, job.Name)
+			continue
+		}
+		err := client.BatchV1().Jobs(req.Namespace).Delete(ctx, job.Name, metav1.DeleteOptions{PropagationPolicy: &propagation})
+		if err != nil {
+			failed[job.Name] = err.Error()
+			continue
+		}
+		deleted = append(deleted, job.Name)
+	}
+
+	logs.Info(map[string]interface{}{
+		"namespace": req.Namespace,
+		"deleted":   len(deleted),
+		"failed":    len(failed),
+		"dryRun":    req.DryRun,
+		"user":      utils.GetUserNameFromContext(ctx),
+	}, "批量清理Job")
+	helper.SuccessWithData("清理完成", "data", gin.H{
+		"deleted": deleted,
+		"failed":  failed,
+		"dryRun":  req.DryRun,
+	})
+}
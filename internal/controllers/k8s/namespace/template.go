@@ -0,0 +1,256 @@
+package namespace
+
+import (
+	"devops-console-backend/internal/dal"
+	"devops-console-backend/internal/dal/request/k8s"
+	"devops-console-backend/internal/services/nstemplate"
+	"devops-console-backend/pkg/configs"
+	"devops-console-backend/pkg/utils"
+	"devops-console-backend/pkg/utils/logs"
+	"encoding/json"
+	"errors"
+	"strconv"
+
+	"github.com/gin-gonic/gin"
+	"gorm.io/gorm"
+	metav1 "k8s.io/apimachinery/pkg/apis/meta/v1"
+)
+
+// toTemplateItem 转换模板记录，模板内容解析失败时返回空内容
+func toTemplateItem(template *dal.K8sNamespaceTemplate) k8s.NamespaceTemplateItem {
+	item := k8s.NamespaceTemplateItem{
+		ID:          template.ID,
+		Name:        template.Name,
+		Description: template.Description,
+		CreatedBy:   template.CreatedBy,
+		CreatedAt:   template.CreatedAt,
+		UpdatedAt:   template.UpdatedAt,
+	}
+	_ = json.Unmarshal([]byte(template.Spec), &item.Spec)
+	return item
+}
+
+// bindTemplateRequest 绑定并校验模板请求，返回序列化后的模板内容
+func bindTemplateRequest(ctx *gin.Context, helper *utils.ResponseHelper) (*k8s.NamespaceTemplateRequest, string, bool) {
+	var req k8s.NamespaceTemplateRequest
+	if err := ctx.ShouldBindJSON(&req); err != nil {
+		helper.ValidationError(err.Error())
+		return nil, "", false
+	}
+	if _, err := nstemplate.Build("validate", &req.Spec); err != nil {
+		helper.ValidationError("模板内容无效: " + err.Error())
+		return nil, "", false
+	}
+	spec, err := json.Marshal(req.Spec)
+	if err != nil {
+		helper.InternalError("序列化模板内容失败")
+		return nil, "", false
+	}
+	return &req, string(spec), true
+}
+
+// GetTemplateList 获取命名空间模板列表
+func (c *NamespaceController) GetTemplateList(ctx *gin.Context) {
+	helper := utils.NewResponseHelper(ctx)
+
+	templates, err := configs.NewK8sNamespaceTemplateRepository().List()
+	if err != nil {
+		helper.DatabaseError("获取命名空间模板失败")
+		return
+	}
+	items := make([]k8s.NamespaceTemplateItem, 0, len(templates))
+	for i := range templates {
+		items = append(items, toTemplateItem(&templates[i]))
+	}
+	helper.SuccessWithData("success", "templateList", items)
+}
+
+// CreateTemplate 创建命名空间模板，仅管理员可操作
+func (c *NamespaceController) CreateTemplate(ctx *gin.Context) {
+	helper := utils.NewResponseHelper(ctx)
+	if !utils.IsAdminFromContext(ctx) {
+		helper.Forbidden("只有管理员可以管理命名空间模板")
+		return
+	}
+	req, spec, ok := bindTemplateRequest(ctx, helper)
+	if !ok {
+		return
+	}
+
+	repo := configs.NewK8sNamespaceTemplateRepository()
+	if _, err := repo.GetByName(req.Name); err == nil {
+		helper.BadRequest("模板名称已存在")
+		return
+	}
+	template := &dal.K8sNamespaceTemplate{
+		Name:        req.Name,
+		Description: req.Description,
+		Spec:        spec,
+		CreatedBy:   utils.GetUserNameFromContext(ctx),
+	}
+	if err := repo.Create(template); err != nil {
+		helper.DatabaseError("创建命名空间模板失败")
+		return
+	}
+	helper.SuccessWithData("创建命名空间模板成功", "template", toTemplateItem(template))
+}
+
+// UpdateTemplate 更新命名空间模板，仅管理员可操作
+func (c *NamespaceController) UpdateTemplate(ctx *gin.Context) {
+	helper := utils.NewResponseHelper(ctx)
+	if !utils.IsAdminFromContext(ctx) {
+		helper.Forbidden("只有管理员可以管理命名空间模板")
+		return
+	}
+	id, err := strconv.ParseUint(ctx.Param("id"), 10, 32)
+	if err != nil {
+		helper.BadRequest("无效的模板ID")
+		return
+	}
+	req, spec, ok := bindTemplateRequest(ctx, helper)
+	if !ok {
+		return
+	}
+
+	repo := configs.NewK8sNamespaceTemplateRepository()
+	template, err := repo.GetByID(uint(id))
+	if err != nil {
+		if errors.Is(err, gorm.ErrRecordNotFound) {
+			helper.NotFound("命名空间模板不存在")
+			return
+		}
+		helper.DatabaseError("获取命名空间模板失败")
+		return
+	}
+	if existing, err := repo.GetByName(req.Name); err == nil && existing.ID != template.ID {
+		helper.BadRequest("模板名称已存在")
+		return
+	}
+	template.Name = req.Name
+	template.Description = req.Description
+	template.Spec = spec
+	if err := repo.Update(template); err != nil {
+		helper.DatabaseError("更新命名空间模板失败")
+		return
+	}
+	helper.SuccessWithData("更新命名空间模板成功", "template", toTemplateItem(template))
+}
+
+// DeleteTemplate 删除命名空间模板，已开通的命名空间不受影响
+func (c *NamespaceController) DeleteTemplate(ctx *gin.Context) {
+	helper := utils.NewResponseHelper(ctx)
+	if !utils.IsAdminFromContext(ctx) {
+		helper.Forbidden("只有管理员可以管理命名空间模板")
+		return
+	}
+	id, err := strconv.ParseUint(ctx.Param("id"), 10, 32)
+	if err != nil {
+		helper.BadRequest("无效的模板ID")
+		return
+	}
+	if err := configs.NewK8sNamespaceTemplateRepository().Delete(uint(id)); err != nil {
+		helper.DatabaseError("删除命名空间模板失败")
+		return
+	}
+	helper.Success("删除命名空间模板成功")
+}
+
+// ProvisionNamespace 按模板开通命名空间，失败时整体回滚
+func (c *NamespaceController) ProvisionNamespace(ctx *gin.Context) {
+	helper := utils.NewResponseHelper(ctx)
+	if !utils.IsAdminFromContext(ctx) {
+		helper.Forbidden("只有管理员可以开通命名空间")
+		return
+	}
+	var req k8s.NamespaceProvisionRequest
+	if err := ctx.ShouldBindJSON(&req); err != nil {
+		helper.ValidationError(err.Error())
+		return
+	}
+
+	template, err := configs.NewK8sNamespaceTemplateRepository().GetByID(req.TemplateID)
+	if err != nil {
+		if errors.Is(err, gorm.ErrRecordNotFound) {
+			helper.NotFound("命名空间模板不存在")
+			return
+		}
+		helper.DatabaseError("获取命名空间模板失败")
+		return
+	}
+	item := toTemplateItem(template)
+
+	instanceID := utils.GetInstanceIDFromContext(ctx)
+	client, exists := configs.GetK8sClient(instanceID)
+	if !exists {
+		helper.InternalError("K8s客户端未初始化")
+		return
+	}
+
+	logData := map[string]interface{}{
+		"instanceId": instanceID,
+		"namespace":  req.Namespace,
+		"template":   template.Name,
+		"operator":   utils.GetUserNameFromContext(ctx),
+	}
+	created, err := nstemplate.Provision(ctx, client, req.Namespace, template.ID, &item.Spec)
+	if err != nil {
+		logs.Error(logData, "按模板开通命名空间失败: "+err.Error())
+		helper.InternalError("开通命名空间失败: " + err.Error())
+		return
+	}
+	logs.Info(logData, "按模板开通命名空间成功")
+	helper.SuccessWithData("开通命名空间成功", "created", created)
+}
+
+// GetNamespaceDrift 比较命名空间与模板的差异，未指定template_id时使用开通时记录的模板
+func (c *NamespaceController) GetNamespaceDrift(ctx *gin.Context) {
+	helper := utils.NewResponseHelper(ctx)
+	namespaceName := ctx.Param("namespace")
+
+	instanceID := utils.GetInstanceIDFromContext(ctx)
+	client, exists := configs.GetK8sClient(instanceID)
+	if !exists {
+		helper.InternalError("K8s客户端未初始化")
+		return
+	}
+
+	templateIDStr := ctx.Query("template_id")
+	if templateIDStr == "" {
+		ns, err := client.CoreV1().Namespaces().Get(ctx, namespaceName, metav1.GetOptions{})
+		if err != nil {
+			helper.InternalError("获取Namespace失败: " + err.Error())
+			return
+		}
+		templateIDStr = ns.Labels[dal.NamespaceTemplateLabel]
+		if templateIDStr == "" {
+			helper.BadRequest("该命名空间不是由模板开通的，请指定template_id")
+			return
+		}
+	}
+	templateID, err := strconv.ParseUint(templateIDStr, 10, 32)
+	if err != nil {
+		helper.BadRequest("无效的模板ID")
+		return
+	}
+	template, err := configs.NewK8sNamespaceTemplateRepository().GetByID(uint(templateID))
+	if err != nil {
+		if errors.Is(err, gorm.ErrRecordNotFound) {
+			helper.NotFound("命名空间模板不存在")
+			return
+		}
+		helper.DatabaseError("获取命名空间模板失败")
+		return
+	}
+	item := toTemplateItem(template)
+
+	drift, err := nstemplate.Drift(ctx, client, namespaceName, &item.Spec)
+	if err != nil {
+		helper.InternalError("检查命名空间漂移失败: " + err.Error())
+		return
+	}
+	helper.Success("success", map[string]interface{}{
+		"template": item,
+		"drift":    drift,
+		"inSync":   len(drift) == 0,
+	})
+}
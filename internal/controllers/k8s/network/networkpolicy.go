@@ -0,0 +1,282 @@
+package network
+
+import (
+	"devops-console-backend/internal/dal/request/k8s"
+	"devops-console-backend/internal/services/netpol"
+	"devops-console-backend/pkg/configs"
+	"devops-console-backend/pkg/utils"
+	"errors"
+
+	"github.com/gin-gonic/gin"
+	corev1 "k8s.io/api/core/v1"
+	networkingv1 "k8s.io/api/networking/v1"
+	apierrors "k8s.io/apimachinery/pkg/api/errors"
+	metav1 "k8s.io/apimachinery/pkg/apis/meta/v1"
+	"sigs.k8s.io/yaml"
+)
+
+// NetworkPolicyController NetworkPolicy控制器
+type NetworkPolicyController struct{}
+
+// NewNetworkPolicyController 创建NetworkPolicy控制器实例
+func NewNetworkPolicyController() *NetworkPolicyController {
+	return &NetworkPolicyController{}
+}
+
+// GetNetworkPolicyList 获取NetworkPolicy列表
+func (c *NetworkPolicyController) GetNetworkPolicyList(ctx *gin.Context) {
+	helper := utils.NewResponseHelper(ctx)
+	namespace := ctx.Param("namespace")
+	if namespace == "all" {
+		namespace = ""
+	}
+
+	client, exists := configs.GetK8sClient(utils.GetInstanceIDFromContext(ctx))
+	if !exists {
+		helper.InternalError("K8s客户端未初始化")
+		return
+	}
+
+	list, err := client.NetworkingV1().NetworkPolicies(namespace).List(ctx, metav1.ListOptions{})
+	if err != nil {
+		helper.InternalError("获取NetworkPolicy列表失败: " + err.Error())
+		return
+	}
+
+	policyList := make([]k8s.NetworkPolicyListItem, 0, len(list.Items))
+	for _, item := range list.Items {
+		policyTypes := make([]string, 0, len(item.Spec.PolicyTypes))
+		for _, policyType := range item.Spec.PolicyTypes {
+			policyTypes = append(policyTypes, string(policyType))
+		}
+		policyList = append(policyList, k8s.NetworkPolicyListItem{
+			Name:        item.Name,
+			Namespace:   item.Namespace,
+			PodSelector: item.Spec.PodSelector.MatchLabels,
+			PolicyTypes: policyTypes,
+			Ingress:     len(item.Spec.Ingress),
+			Egress:      len(item.Spec.Egress),
+			Age:         item.CreationTimestamp.Unix(),
+		})
+	}
+	helper.SuccessWithData("success", "networkPolicyList", policyList)
+}
+
+// GetNetworkPolicyDetail 获取NetworkPolicy详情
+func (c *NetworkPolicyController) GetNetworkPolicyDetail(ctx *gin.Context) {
+	helper := utils.NewResponseHelper(ctx)
+	namespace := ctx.Param("namespace")
+	name := ctx.Param("name")
+
+	client, exists := configs.GetK8sClient(utils.GetInstanceIDFromContext(ctx))
+	if !exists {
+		helper.InternalError("K8s客户端未初始化")
+		return
+	}
+
+	policy, err := client.NetworkingV1().NetworkPolicies(namespace).Get(ctx, name, metav1.GetOptions{})
+	if err != nil {
+		if apierrors.IsNotFound(err) {
+			helper.NotFound("NetworkPolicy 不存在")
+			return
+		}
+		helper.InternalError("获取NetworkPolicy失败: " + err.Error())
+		return
+	}
+	policy.ManagedFields = nil
+	policyYAML, err := yaml.Marshal(policy)
+	if err != nil {
+		helper.InternalError("序列化NetworkPolicy失败: " + err.Error())
+		return
+	}
+	helper.Success("success", map[string]interface{}{
+		"networkPolicyDetail": policy,
+		"yaml":                string(policyYAML),
+	})
+}
+
+// CreateNetworkPolicy 创建NetworkPolicy
+func (c *NetworkPolicyController) CreateNetworkPolicy(ctx *gin.Context) {
+	helper := utils.NewResponseHelper(ctx)
+	var req k8s.NetworkPolicyCreateRequest
+	if err := ctx.ShouldBindJSON(&req); err != nil {
+		helper.BadRequest("请求参数错误: " + err.Error())
+		return
+	}
+
+	var policy *networkingv1.NetworkPolicy
+	if req.YAML != "" {
+		parsed, err := parseNetworkPolicyYAML(req.YAML)
+		if err != nil {
+			helper.BadRequest("YAML解析失败: " + err.Error())
+			return
+		}
+		policy = parsed
+		policy.Namespace = req.Namespace
+	} else {
+		if req.Name == "" || req.Spec == nil {
+			helper.BadRequest("请提供name和spec，或提供yaml")
+			return
+		}
+		policy = &networkingv1.NetworkPolicy{
+			ObjectMeta: metav1.ObjectMeta{Name: req.Name, Namespace: req.Namespace, Labels: req.Labels},
+			Spec:       *req.Spec,
+		}
+	}
+
+	client, exists := configs.GetK8sClient(utils.GetInstanceIDFromContext(ctx))
+	if !exists {
+		helper.InternalError("K8s客户端未初始化")
+		return
+	}
+
+	if _, err := client.NetworkingV1().NetworkPolicies(req.Namespace).Create(ctx, policy, metav1.CreateOptions{}); err != nil {
+		helper.InternalError("创建NetworkPolicy失败: " + err.Error())
+		return
+	}
+	helper.Success("NetworkPolicy创建成功")
+}
+
+// UpdateNetworkPolicy 更新NetworkPolicy，只替换标签和spec
+func (c *NetworkPolicyController) UpdateNetworkPolicy(ctx *gin.Context) {
+	helper := utils.NewResponseHelper(ctx)
+	namespace := ctx.Param("namespace")
+	name := ctx.Param("name")
+	var req k8s.NetworkPolicyUpdateRequest
+	if err := ctx.ShouldBindJSON(&req); err != nil {
+		helper.BadRequest("请求参数错误: " + err.Error())
+		return
+	}
+
+	labels, spec := req.Labels, req.Spec
+	if req.YAML != "" {
+		parsed, err := parseNetworkPolicyYAML(req.YAML)
+		if err != nil {
+			helper.BadRequest("YAML解析失败: " + err.Error())
+			return
+		}
+		labels, spec = parsed.Labels, &parsed.Spec
+	}
+	if spec == nil {
+		helper.BadRequest("请提供spec或yaml")
+		return
+	}
+
+	client, exists := configs.GetK8sClient(utils.GetInstanceIDFromContext(ctx))
+	if !exists {
+		helper.InternalError("K8s客户端未初始化")
+		return
+	}
+
+	policy, err := client.NetworkingV1().NetworkPolicies(namespace).Get(ctx, name, metav1.GetOptions{})
+	if err != nil {
+		if apierrors.IsNotFound(err) {
+			helper.NotFound("NetworkPolicy 不存在")
+			return
+		}
+		helper.InternalError("获取NetworkPolicy失败: " + err.Error())
+		return
+	}
+	if labels != nil {
+		policy.Labels = labels
+	}
+	policy.Spec = *spec
+	if _, err := client.NetworkingV1().NetworkPolicies(namespace).Update(ctx, policy, metav1.UpdateOptions{}); err != nil {
+		helper.InternalError("更新NetworkPolicy失败: " + err.Error())
+		return
+	}
+	helper.Success("NetworkPolicy更新成功")
+}
+
+// DeleteNetworkPolicy 删除NetworkPolicy
+func (c *NetworkPolicyController) DeleteNetworkPolicy(ctx *gin.Context) {
+	helper := utils.NewResponseHelper(ctx)
+	namespace := ctx.Param("namespace")
+	name := ctx.Param("name")
+
+	client, exists := configs.GetK8sClient(utils.GetInstanceIDFromContext(ctx))
+	if !exists {
+		helper.InternalError("K8s客户端未初始化")
+		return
+	}
+
+	if err := client.NetworkingV1().NetworkPolicies(namespace).Delete(ctx, name, metav1.DeleteOptions{}); err != nil {
+		helper.InternalError("删除NetworkPolicy失败: " + err.Error())
+		return
+	}
+	helper.Success("NetworkPolicy删除成功")
+}
+
+// AnalyzeNetworkPolicy 分析源到目标Pod指定端口的流量是否被NetworkPolicy放行
+func (c *NetworkPolicyController) AnalyzeNetworkPolicy(ctx *gin.Context) {
+	helper := utils.NewResponseHelper(ctx)
+	var req k8s.NetworkPolicyAnalyzeRequest
+	if err := ctx.ShouldBindJSON(&req); err != nil {
+		helper.BadRequest("请求参数错误: " + err.Error())
+		return
+	}
+
+	client, exists := configs.GetK8sClient(utils.GetInstanceIDFromContext(ctx))
+	if !exists {
+		helper.InternalError("K8s客户端未初始化")
+		return
+	}
+
+	srcNamespace, err := client.CoreV1().Namespaces().Get(ctx, req.SourceNamespace, metav1.GetOptions{})
+	if err != nil {
+		helper.BadRequest("获取源命名空间失败: " + err.Error())
+		return
+	}
+	dstNamespace, err := client.CoreV1().Namespaces().Get(ctx, req.DestinationNamespace, metav1.GetOptions{})
+	if err != nil {
+		helper.BadRequest("获取目标命名空间失败: " + err.Error())
+		return
+	}
+	dstPod, err := client.CoreV1().Pods(req.DestinationNamespace).Get(ctx, req.DestinationPod, metav1.GetOptions{})
+	if err != nil {
+		helper.BadRequest("获取目标Pod失败: " + err.Error())
+		return
+	}
+	// 未指定源Pod时，用sourceLabels模拟源命名空间中的一个Pod
+	srcPod := &corev1.Pod{ObjectMeta: metav1.ObjectMeta{Namespace: req.SourceNamespace, Labels: req.SourceLabels}}
+	if req.SourcePod != "" {
+		srcPod, err = client.CoreV1().Pods(req.SourceNamespace).Get(ctx, req.SourcePod, metav1.GetOptions{})
+		if err != nil {
+			helper.BadRequest("获取源Pod失败: " + err.Error())
+			return
+		}
+	}
+
+	policies, err := client.NetworkingV1().NetworkPolicies(req.SourceNamespace).List(ctx, metav1.ListOptions{})
+	if err != nil {
+		helper.InternalError("获取NetworkPolicy列表失败: " + err.Error())
+		return
+	}
+	items := policies.Items
+	if req.DestinationNamespace != req.SourceNamespace {
+		dstPolicies, err := client.NetworkingV1().NetworkPolicies(req.DestinationNamespace).List(ctx, metav1.ListOptions{})
+		if err != nil {
+			helper.InternalError("获取NetworkPolicy列表失败: " + err.Error())
+			return
+		}
+		items = append(items, dstPolicies.Items...)
+	}
+
+	analysis := netpol.Analyze(items,
+		netpol.Endpoint{Pod: srcPod, Namespace: srcNamespace},
+		netpol.Endpoint{Pod: dstPod, Namespace: dstNamespace},
+		req.Port, corev1.Protocol(req.Protocol))
+	helper.SuccessWithData("success", "analysis", analysis)
+}
+
+// parseNetworkPolicyYAML 解析NetworkPolicy YAML
+func parseNetworkPolicyYAML(content string) (*networkingv1.NetworkPolicy, error) {
+	var policy networkingv1.NetworkPolicy
+	if err := yaml.UnmarshalStrict([]byte(content), &policy); err != nil {
+		return nil, err
+	}
+	if policy.Kind != "" && policy.Kind != "NetworkPolicy" {
+		return nil, errors.New("资源类型不是NetworkPolicy")
+	}
+	return &policy, nil
+}
@@ -0,0 +1,200 @@
+package operator
+
+import (
+	"devops-console-backend/pkg/configs"
+	"devops-console-backend/pkg/utils"
+	"strings"
+
+	"github.com/gin-gonic/gin"
+	metav1 "k8s.io/apimachinery/pkg/apis/meta/v1"
+	"k8s.io/apimachinery/pkg/apis/meta/v1/unstructured"
+	"k8s.io/apimachinery/pkg/runtime/schema"
+	"k8s.io/client-go/dynamic"
+)
+
+var (
+	catalogSourceGVR = schema.GroupVersionResource{
+		Group:    "operators.coreos.com",
+		Version:  "v1alpha1",
+		Resource: "catalogsources",
+	}
+	packageManifestGVR = schema.GroupVersionResource{
+		Group:    "packages.operators.coreos.com",
+		Version:  "v1",
+		Resource: "packagemanifests",
+	}
+)
+
+// getDynamicClient 获取instance_id对应的动态客户端，失败时已写入响应
+func getDynamicClient(ctx *gin.Context) (dynamic.Interface, bool) {
+	instanceID := utils.GetInstanceIDFromContext(ctx)
+
+	client, exists := configs.GetDynamicClient(instanceID)
+	if !exists {
+		helper := utils.NewResponseHelper(ctx)
+		helper.InternalError("K8s动态客户端未初始化")
+		return nil, false
+	}
+	return client, true
+}
+
+// listResource 列出命名空间下的资源，namespace为all时列出全部命名空间
+func listResource(ctx *gin.Context, client dynamic.Interface, gvr schema.GroupVersionResource, namespace string, opts metav1.ListOptions) (*unstructured.UnstructuredList, error) {
+	if namespace == "all" {
+		return client.Resource(gvr).List(ctx, opts)
+	}
+	return client.Resource(gvr).Namespace(namespace).List(ctx, opts)
+}
+
+// GetCatalogSourceList 获取CatalogSource列表及连接状态
+func (c *OperatorController) GetCatalogSourceList(ctx *gin.Context) {
+	helper := utils.NewResponseHelper(ctx)
+	client, ok := getDynamicClient(ctx)
+	if !ok {
+		return
+	}
+
+	list, err := listResource(ctx, client, catalogSourceGVR, ctx.Param("namespace"), metav1.ListOptions{})
+	if err != nil {
+		helper.InternalError("获取CatalogSource列表失败(请确认OLM是否已安装): " + err.Error())
+		return
+	}
+
+	catalogs := make([]gin.H, 0, len(list.Items))
+	for _, item := range list.Items {
+		displayName, _, _ := unstructured.NestedString(item.Object, "spec", "displayName")
+		publisher, _, _ := unstructured.NestedString(item.Object, "spec", "publisher")
+		sourceType, _, _ := unstructured.NestedString(item.Object, "spec", "sourceType")
+		image, _, _ := unstructured.NestedString(item.Object, "spec", "image")
+		state, _, _ := unstructured.NestedString(item.Object, "status", "connectionState", "lastObservedState")
+		catalogs = append(catalogs, gin.H{
+			"name":        item.GetName(),
+			"namespace":   item.GetNamespace(),
+			"displayName": displayName,
+			"publisher":   publisher,
+			"sourceType":  sourceType,
+			"image":       image,
+			"state":       state,
+			"age":         item.GetCreationTimestamp().Unix(),
+		})
+	}
+
+	helper.SuccessWithData("success", "catalogSourceList", catalogs)
+}
+
+// GetPackageManifestList 获取可安装的Operator包，支持按CatalogSource和关键字过滤
+func (c *OperatorController) GetPackageManifestList(ctx *gin.Context) {
+	helper := utils.NewResponseHelper(ctx)
+	client, ok := getDynamicClient(ctx)
+	if !ok {
+		return
+	}
+
+	opts := metav1.ListOptions{}
+	if catalog := ctx.Query("catalog"); catalog != "" {
+		opts.LabelSelector = "catalog=" + catalog
+	}
+	list, err := listResource(ctx, client, packageManifestGVR, ctx.Param("namespace"), opts)
+	if err != nil {
+		helper.InternalError("获取PackageManifest列表失败(请确认OLM是否已安装): " + err.Error())
+		return
+	}
+
+	keyword := strings.ToLower(ctx.Query("keyword"))
+	packages := make([]gin.H, 0, len(list.Items))
+	for _, item := range list.Items {
+		summary := summarizePackage(&item)
+		if keyword != "" && !strings.Contains(strings.ToLower(item.GetName()), keyword) &&
+			!strings.Contains(strings.ToLower(summary["displayName"].(string)), keyword) {
+			continue
+		}
+		packages = append(packages, summary)
+	}
+
+	helper.SuccessWithData("success", "packageList", packages)
+}
+
+// GetPackageManifestDetail 获取Operator包详情，包含各频道的CSV描述和安装模式
+func (c *OperatorController) GetPackageManifestDetail(ctx *gin.Context) {
+	helper := utils.NewResponseHelper(ctx)
+	client, ok := getDynamicClient(ctx)
+	if !ok {
+		return
+	}
+
+	pkg, err := client.Resource(packageManifestGVR).Namespace(ctx.Param("namespace")).Get(ctx, ctx.Param("name"), metav1.GetOptions{})
+	if err != nil {
+		helper.NotFound("PackageManifest不存在: " + err.Error())
+		return
+	}
+
+	helper.SuccessWithData("success", "packageDetail", pkg)
+}
+
+// summarizePackage 提取PackageManifest的摘要信息
+func summarizePackage(pkg *unstructured.Unstructured) gin.H {
+	catalog, _, _ := unstructured.NestedString(pkg.Object, "status", "catalogSource")
+	catalogNamespace, _, _ := unstructured.NestedString(pkg.Object, "status", "catalogSourceNamespace")
+	provider, _, _ := unstructured.NestedString(pkg.Object, "status", "provider", "name")
+	defaultChannel, _, _ := unstructured.NestedString(pkg.Object, "status", "defaultChannel")
+
+	displayName := ""
+	description := ""
+	channels := make([]gin.H, 0)
+	rawChannels, _, _ := unstructured.NestedSlice(pkg.Object, "status", "channels")
+	for _, raw := range rawChannels {
+		channel, ok := raw.(map[string]interface{})
+		if !ok {
+			continue
+		}
+		name, _, _ := unstructured.NestedString(channel, "name")
+		currentCSV, _, _ := unstructured.NestedString(channel, "currentCSV")
+		version, _, _ := unstructured.NestedString(channel, "currentCSVDesc", "version")
+		channels = append(channels, gin.H{"name": name, "currentCSV": currentCSV, "version": version})
+		if name == defaultChannel || displayName == "" {
+			displayName, _, _ = unstructured.NestedString(channel, "currentCSVDesc", "displayName")
+			description, _, _ = unstructured.NestedString(channel, "currentCSVDesc", "annotations", "description")
+		}
+	}
+	if displayName == "" {
+		displayName = pkg.GetName()
+	}
+
+	return gin.H{
+		"name":             pkg.GetName(),
+		"namespace":        pkg.GetNamespace(),
+		"displayName":      displayName,
+		"description":      description,
+		"provider":         provider,
+		"catalog":          catalog,
+		"catalogNamespace": catalogNamespace,
+		"defaultChannel":   defaultChannel,
+		"channels":         channels,
+	}
+}
+
+// channelInstallModes 返回频道当前CSV支持的安装模式
+func channelInstallModes(pkg *unstructured.Unstructured, channelName string) map[string]bool {
+	modes := make(map[string]bool)
+	rawChannels, _, _ := unstructured.NestedSlice(pkg.Object, "status", "channels")
+	for _, raw := range rawChannels {
+		channel, ok := raw.(map[string]interface{})
+		if !ok {
+			continue
+		}
+		if name, _, _ := unstructured.NestedString(channel, "name"); name != channelName {
+			continue
+		}
+		installModes, _, _ := unstructured.NestedSlice(channel, "currentCSVDesc", "installModes")
+		for _, rawMode := range installModes {
+			mode, ok := rawMode.(map[string]interface{})
+			if !ok {
+				continue
+			}
+			modeType, _, _ := unstructured.NestedString(mode, "type")
+			supported, _, _ := unstructured.NestedBool(mode, "supported")
+			modes[modeType] = supported
+		}
+	}
+	return modes
+}
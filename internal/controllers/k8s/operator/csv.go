@@ -0,0 +1,131 @@
+package operator
+
+import (
+	"devops-console-backend/pkg/utils"
+
+	"github.com/gin-gonic/gin"
+	metav1 "k8s.io/apimachinery/pkg/apis/meta/v1"
+	"k8s.io/apimachinery/pkg/apis/meta/v1/unstructured"
+	"k8s.io/apimachinery/pkg/runtime/schema"
+)
+
+var (
+	csvGVR = schema.GroupVersionResource{
+		Group:    "operators.coreos.com",
+		Version:  "v1alpha1",
+		Resource: "clusterserviceversions",
+	}
+	crdGVR = schema.GroupVersionResource{
+		Group:    "apiextensions.k8s.io",
+		Version:  "v1",
+		Resource: "customresourcedefinitions",
+	}
+)
+
+// GetCSVList 获取ClusterServiceVersion列表及安装状态
+func (c *OperatorController) GetCSVList(ctx *gin.Context) {
+	helper := utils.NewResponseHelper(ctx)
+	client, ok := getDynamicClient(ctx)
+	if !ok {
+		return
+	}
+
+	list, err := listResource(ctx, client, csvGVR, ctx.Param("namespace"), metav1.ListOptions{})
+	if err != nil {
+		helper.InternalError("获取CSV列表失败(请确认OLM是否已安装): " + err.Error())
+		return
+	}
+
+	csvs := make([]gin.H, 0, len(list.Items))
+	for _, item := range list.Items {
+		// OLM会把AllNamespaces模式的CSV复制到各命名空间，只展示原始CSV
+		if item.GetLabels()["olm.copiedFrom"] != "" {
+			continue
+		}
+		csvs = append(csvs, summarizeCSV(&item))
+	}
+
+	helper.SuccessWithData("success", "csvList", csvs)
+}
+
+// GetCSVDetail 获取ClusterServiceVersion状态和其拥有的CRD
+func (c *OperatorController) GetCSVDetail(ctx *gin.Context) {
+	helper := utils.NewResponseHelper(ctx)
+	client, ok := getDynamicClient(ctx)
+	if !ok {
+		return
+	}
+
+	csv, err := client.Resource(csvGVR).Namespace(ctx.Param("namespace")).Get(ctx, ctx.Param("name"), metav1.GetOptions{})
+	if err != nil {
+		helper.NotFound("CSV不存在: " + err.Error())
+		return
+	}
+
+	ownedCRDs := make([]gin.H, 0)
+	owned, _, _ := unstructured.NestedSlice(csv.Object, "spec", "customresourcedefinitions", "owned")
+	for _, raw := range owned {
+		item, ok := raw.(map[string]interface{})
+		if !ok {
+			continue
+		}
+		name, _, _ := unstructured.NestedString(item, "name")
+		kind, _, _ := unstructured.NestedString(item, "kind")
+		version, _, _ := unstructured.NestedString(item, "version")
+		displayName, _, _ := unstructured.NestedString(item, "displayName")
+		description, _, _ := unstructured.NestedString(item, "description")
+
+		// CRD是否已创建并就绪
+		established := false
+		if crd, err := client.Resource(crdGVR).Get(ctx, name, metav1.GetOptions{}); err == nil {
+			conditions, _, _ := unstructured.NestedSlice(crd.Object, "status", "conditions")
+			for _, rawCondition := range conditions {
+				condition, ok := rawCondition.(map[string]interface{})
+				if ok && condition["type"] == "Established" && condition["status"] == "True" {
+					established = true
+				}
+			}
+		}
+		ownedCRDs = append(ownedCRDs, gin.H{
+			"name":        name,
+			"kind":        kind,
+			"version":     version,
+			"displayName": displayName,
+			"description": description,
+			"established": established,
+		})
+	}
+
+	conditions, _, _ := unstructured.NestedSlice(csv.Object, "status", "conditions")
+	requirements, _, _ := unstructured.NestedSlice(csv.Object, "status", "requirementStatus")
+	helper.SuccessWithData("success", "csvDetail", gin.H{
+		"summary":           summarizeCSV(csv),
+		"ownedCRDs":         ownedCRDs,
+		"conditions":        conditions,
+		"requirementStatus": requirements,
+	})
+}
+
+// summarizeCSV 提取CSV的摘要信息
+func summarizeCSV(csv *unstructured.Unstructured) gin.H {
+	displayName, _, _ := unstructured.NestedString(csv.Object, "spec", "displayName")
+	version, _, _ := unstructured.NestedString(csv.Object, "spec", "version")
+	provider, _, _ := unstructured.NestedString(csv.Object, "spec", "provider", "name")
+	phase, _, _ := unstructured.NestedString(csv.Object, "status", "phase")
+	reason, _, _ := unstructured.NestedString(csv.Object, "status", "reason")
+	message, _, _ := unstructured.NestedString(csv.Object, "status", "message")
+	owned, _, _ := unstructured.NestedSlice(csv.Object, "spec", "customresourcedefinitions", "owned")
+
+	return gin.H{
+		"name":          csv.GetName(),
+		"namespace":     csv.GetNamespace(),
+		"displayName":   displayName,
+		"version":       version,
+		"provider":      provider,
+		"phase":         phase,
+		"reason":        reason,
+		"message":       message,
+		"ownedCRDCount": len(owned),
+		"age":           csv.GetCreationTimestamp().Unix(),
+	}
+}
@@ -0,0 +1,276 @@
+package operator
+
+import (
+	"context"
+	"devops-console-backend/internal/dal/request/k8s"
+	"devops-console-backend/pkg/utils"
+	"devops-console-backend/pkg/utils/logs"
+	"fmt"
+	"sort"
+	"strings"
+
+	"github.com/gin-gonic/gin"
+	metav1 "k8s.io/apimachinery/pkg/apis/meta/v1"
+	"k8s.io/apimachinery/pkg/apis/meta/v1/unstructured"
+	"k8s.io/apimachinery/pkg/runtime/schema"
+	"k8s.io/apimachinery/pkg/types"
+	"k8s.io/client-go/dynamic"
+)
+
+var (
+	operatorGroupGVR = schema.GroupVersionResource{
+		Group:    "operators.coreos.com",
+		Version:  "v1",
+		Resource: "operatorgroups",
+	}
+	installPlanGVR = schema.GroupVersionResource{
+		Group:    "operators.coreos.com",
+		Version:  "v1alpha1",
+		Resource: "installplans",
+	}
+)
+
+// InstallOperator 安装Operator：命名空间没有OperatorGroup时先创建，再创建Subscription
+func (c *OperatorController) InstallOperator(ctx *gin.Context) {
+	helper := utils.NewResponseHelper(ctx)
+
+	var req k8s.OperatorInstallRequest
+	if err := ctx.ShouldBindJSON(&req); err != nil {
+		helper.ValidationError(err.Error())
+		return
+	}
+	if req.Approval == "" {
+		req.Approval = "Automatic"
+	}
+
+	client, ok := getDynamicClient(ctx)
+	if !ok {
+		return
+	}
+
+	// 校验包、频道和安装模式，必须使用请求指定目录源中的包
+	pkg, err := findPackageManifest(ctx, client, req.Namespace, req.PackageName, req.Source, req.SourceNamespace)
+	if err != nil {
+		helper.BadRequest("PackageManifest不存在: " + err.Error())
+		return
+	}
+	if req.Channel == "" {
+		req.Channel, _, _ = unstructured.NestedString(pkg.Object, "status", "defaultChannel")
+	}
+	modes := channelInstallModes(pkg, req.Channel)
+	if len(modes) == 0 {
+		helper.BadRequest(fmt.Sprintf("频道 %s 不存在", req.Channel))
+		return
+	}
+	mode := installModeOf(req.Namespace, req.TargetNamespaces)
+	if !modes[mode] {
+		helper.BadRequest(fmt.Sprintf("该Operator不支持%s安装模式", mode))
+		return
+	}
+
+	// 每个命名空间只能有一个OperatorGroup，已存在时要求监听范围一致
+	groups, err := client.Resource(operatorGroupGVR).Namespace(req.Namespace).List(ctx, metav1.ListOptions{})
+	if err != nil {
+		helper.InternalError("获取OperatorGroup失败(请确认OLM是否已安装): " + err.Error())
+		return
+	}
+	operatorGroup := ""
+	operatorGroupCreated := false
+	switch len(groups.Items) {
+	case 0:
+		group := &unstructured.Unstructured{Object: map[string]interface{}{
+			"apiVersion": operatorGroupGVR.GroupVersion().String(),
+			"kind":       "OperatorGroup",
+			"metadata": map[string]interface{}{
+				"name":      req.Namespace,
+				"namespace": req.Namespace,
+			},
+			"spec": map[string]interface{}{},
+		}}
+		if len(req.TargetNamespaces) > 0 {
+			targets := make([]interface{}, 0, len(req.TargetNamespaces))
+			for _, ns := range req.TargetNamespaces {
+				targets = append(targets, ns)
+			}
+			group.Object["spec"] = map[string]interface{}{"targetNamespaces": targets}
+		}
+		if _, err := client.Resource(operatorGroupGVR).Namespace(req.Namespace).Create(ctx, group, metav1.CreateOptions{}); err != nil {
+			helper.InternalError("创建OperatorGroup失败: " + err.Error())
+			return
+		}
+		operatorGroup = req.Namespace
+		operatorGroupCreated = true
+	case 1:
+		existing, _, _ := unstructured.NestedStringSlice(groups.Items[0].Object, "spec", "targetNamespaces")
+		if !sameNamespaces(existing, req.TargetNamespaces) {
+			helper.BadRequest(fmt.Sprintf("命名空间已有OperatorGroup %s，其监听范围与请求不一致", groups.Items[0].GetName()))
+			return
+		}
+		operatorGroup = groups.Items[0].GetName()
+	default:
+		helper.BadRequest("命名空间存在多个OperatorGroup，OLM无法安装Operator")
+		return
+	}
+
+	spec := map[string]interface{}{
+		"name":                req.PackageName,
+		"channel":             req.Channel,
+		"source":              req.Source,
+		"sourceNamespace":     req.SourceNamespace,
+		"installPlanApproval": req.Approval,
+	}
+	if req.StartingCSV != "" {
+		spec["startingCSV"] = req.StartingCSV
+	}
+	subscription := &unstructured.Unstructured{Object: map[string]interface{}{
+		"apiVersion": subGVR.GroupVersion().String(),
+		"kind":       "Subscription",
+		"metadata": map[string]interface{}{
+			"name":      req.PackageName,
+			"namespace": req.Namespace,
+		},
+		"spec": spec,
+	}}
+	if _, err := client.Resource(subGVR).Namespace(req.Namespace).Create(ctx, subscription, metav1.CreateOptions{}); err != nil {
+		// 回滚本次创建的OperatorGroup，避免影响之后的安装
+		if operatorGroupCreated {
+			if delErr := client.Resource(operatorGroupGVR).Namespace(req.Namespace).Delete(ctx, operatorGroup, metav1.DeleteOptions{}); delErr != nil {
+				logs.Error(map[string]interface{}{
+					"namespace":     req.Namespace,
+					"operatorGroup": operatorGroup,
+					"error":         delErr.Error(),
+				}, "回滚OperatorGroup失败")
+			}
+		}
+		helper.InternalError("创建Subscription失败: " + err.Error())
+		return
+	}
+
+	logs.Info(map[string]interface{}{
+		"namespace": req.Namespace,
+		"package":   req.PackageName,
+		"channel":   req.Channel,
+		"approval":  req.Approval,
+		"user":      utils.GetUserNameFromContext(ctx),
+	}, "安装Operator")
+	helper.Success("Operator安装已提交", map[string]interface{}{
+		"subscription":         req.PackageName,
+		"operatorGroup":        operatorGroup,
+		"operatorGroupCreated": operatorGroupCreated,
+		"installMode":          mode,
+	})
+}
+
+// GetInstallPlanList 获取InstallPlan列表，Manual审批的Subscription需要在此批准
+func (c *OperatorController) GetInstallPlanList(ctx *gin.Context) {
+	helper := utils.NewResponseHelper(ctx)
+	client, ok := getDynamicClient(ctx)
+	if !ok {
+		return
+	}
+
+	list, err := listResource(ctx, client, installPlanGVR, ctx.Param("namespace"), metav1.ListOptions{})
+	if err != nil {
+		helper.InternalError("获取InstallPlan列表失败(请确认OLM是否已安装): " + err.Error())
+		return
+	}
+
+	sort.Slice(list.Items, func(i, j int) bool {
+		ti := list.Items[i].GetCreationTimestamp()
+		tj := list.Items[j].GetCreationTimestamp()
+		return tj.Before(&ti)
+	})
+	plans := make([]gin.H, 0, len(list.Items))
+	for _, item := range list.Items {
+		approval, _, _ := unstructured.NestedString(item.Object, "spec", "approval")
+		approved, _, _ := unstructured.NestedBool(item.Object, "spec", "approved")
+		csvNames, _, _ := unstructured.NestedStringSlice(item.Object, "spec", "clusterServiceVersionNames")
+		phase, _, _ := unstructured.NestedString(item.Object, "status", "phase")
+		plans = append(plans, gin.H{
+			"name":      item.GetName(),
+			"namespace": item.GetNamespace(),
+			"approval":  approval,
+			"approved":  approved,
+			"csvNames":  csvNames,
+			"phase":     phase,
+			"pending":   phase == "RequiresApproval",
+			"age":       item.GetCreationTimestamp().Unix(),
+		})
+	}
+
+	helper.SuccessWithData("success", "installPlanList", plans)
+}
+
+// ApproveInstallPlan 批准待审批的InstallPlan
+func (c *OperatorController) ApproveInstallPlan(ctx *gin.Context) {
+	helper := utils.NewResponseHelper(ctx)
+	namespace := ctx.Param("namespace")
+	name := ctx.Param("name")
+
+	client, ok := getDynamicClient(ctx)
+	if !ok {
+		return
+	}
+
+	plan, err := client.Resource(installPlanGVR).Namespace(namespace).Get(ctx, name, metav1.GetOptions{})
+	if err != nil {
+		helper.NotFound("InstallPlan不存在")
+		return
+	}
+	if approved, _, _ := unstructured.NestedBool(plan.Object, "spec", "approved"); approved {
+		helper.BadRequest("InstallPlan已批准")
+		return
+	}
+
+	patch := []byte(`{"spec":{"approved":true}}`)
+	if _, err := client.Resource(installPlanGVR).Namespace(namespace).Patch(ctx, name, types.MergePatchType, patch, metav1.PatchOptions{}); err != nil {
+		helper.InternalError("批准InstallPlan失败: " + err.Error())
+		return
+	}
+
+	logs.Info(map[string]interface{}{"namespace": namespace, "installPlan": name, "user": utils.GetUserNameFromContext(ctx)}, "批准InstallPlan")
+	helper.Success("InstallPlan已批准")
+}
+
+// findPackageManifest 查找指定目录源提供的PackageManifest，不同目录源可能提供同名的包
+func findPackageManifest(ctx context.Context, client dynamic.Interface, namespace, name, source, sourceNamespace string) (*unstructured.Unstructured, error) {
+	list, err := client.Resource(packageManifestGVR).Namespace(namespace).List(ctx, metav1.ListOptions{LabelSelector: "catalog=" + source})
+	if err != nil {
+		return nil, err
+	}
+	for i := range list.Items {
+		item := &list.Items[i]
+		catalog, _, _ := unstructured.NestedString(item.Object, "status", "catalogSource")
+		catalogNamespace, _, _ := unstructured.NestedString(item.Object, "status", "catalogSourceNamespace")
+		if item.GetName() == name && catalog == source && catalogNamespace == sourceNamespace {
+			return item, nil
+		}
+	}
+	return nil, fmt.Errorf("目录源 %s/%s 中没有包 %s", sourceNamespace, source, name)
+}
+
+// installModeOf 根据OperatorGroup监听的命名空间得出安装模式
+func installModeOf(namespace string, targets []string) string {
+	switch {
+	case len(targets) == 0:
+		return "AllNamespaces"
+	case len(targets) == 1 && targets[0] == namespace:
+		return "OwnNamespace"
+	case len(targets) == 1:
+		return "SingleNamespace"
+	default:
+		return "MultiNamespace"
+	}
+}
+
+// sameNamespaces 判断两个命名空间列表是否一致（忽略顺序）
+func sameNamespaces(a, b []string) bool {
+	if len(a) != len(b) {
+		return false
+	}
+	x := append([]string{}, a...)
+	y := append([]string{}, b...)
+	sort.Strings(x)
+	sort.Strings(y)
+	return strings.Join(x, ",") == strings.Join(y, ",")
+}
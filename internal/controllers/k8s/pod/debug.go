@@ -0,0 +1,319 @@
+package pod
+
+import (
+	"context"
+	"devops-console-backend/internal/dal"
+	"devops-console-backend/internal/dal/request/k8s"
+	"devops-console-backend/pkg/configs"
+	"devops-console-backend/pkg/utils"
+	"devops-console-backend/pkg/utils/logs"
+	"fmt"
+	"net/url"
+	"strconv"
+	"time"
+
+	"github.com/gin-gonic/gin"
+	corev1 "k8s.io/api/core/v1"
+	metav1 "k8s.io/apimachinery/pkg/apis/meta/v1"
+	utilrand "k8s.io/apimachinery/pkg/util/rand"
+	"k8s.io/apimachinery/pkg/util/wait"
+	"k8s.io/client-go/kubernetes"
+)
+
+// debugWaitTimeout 等待调试容器启动的最长时间
+const debugWaitTimeout = 30 * time.Second
+
+// authorizeExec 校验当前用户在命名空间上的exec授权，失败时已写入响应
+func authorizeExec(ctx *gin.Context, instanceID uint, namespace string) bool {
+	helper := utils.NewResponseHelper(ctx)
+	claims := utils.GetUserInfoFromContext(ctx)
+	allowed, err := configs.HasNamespacePermission(claims.GetUserId(), claims.GetUserName(), claims.GetRoles(),
+		instanceID, namespace, dal.K8sActionExec)
+	if err != nil {
+		helper.DatabaseError("校验权限失败")
+		return false
+	}
+	if !allowed {
+		helper.Forbidden("没有该命名空间的容器操作权限")
+		return false
+	}
+	return true
+}
+
+// resolveDebugImage 返回实际使用的调试镜像，并校验是否在允许列表中
+func resolveDebugImage(image string) (string, error) {
+	debugConfig := configs.GetKubernetesConfig().Debug
+	if image == "" {
+		image = debugConfig.DefaultImage
+	}
+	if image == "" {
+		return "", fmt.Errorf("未指定调试镜像")
+	}
+	if len(debugConfig.AllowedImages) == 0 {
+		return image, nil
+	}
+	for _, allowed := range debugConfig.AllowedImages {
+		if allowed == image {
+			return image, nil
+		}
+	}
+	return "", fmt.Errorf("调试镜像 %s 不在允许列表中", image)
+}
+
+// execPath 生成连接容器终端的WebSocket路径
+func execPath(instanceID uint, namespace, podName, container string) string {
+	query := url.Values{}
+	query.Set("namespace", namespace)
+	query.Set("container", container)
+	query.Set("instance_id", strconv.FormatUint(uint64(instanceID), 10))
+	return "/ws/pod/" + url.PathEscape(podName) + "/exec?" + query.Encode()
+}
+
+// waitContainerRunning 等待容器进入运行状态，超时返回false，镜像拉取等失败时返回错误
+func waitContainerRunning(ctx context.Context, client kubernetes.Interface, namespace, podName, container string) (bool, error) {
+	running := false
+	err := wait.PollUntilContextTimeout(ctx, time.Second, debugWaitTimeout, true, func(ctx context.Context) (bool, error) {
+		pod, err := client.CoreV1().Pods(namespace).Get(ctx, podName, metav1.GetOptions{})
+		if err != nil {
+			return false, err
+		}
+		statuses := append(append([]corev1.ContainerStatus{}, pod.Status.ContainerStatuses...), pod.Status.EphemeralContainerStatuses...)
+		for _, status := range statuses {
+			if status.Name != container {
+				continue
+			}
+			if status.State.Running != nil {
+				running = true
+				return true, nil
+			}
+			if status.State.Terminated != nil {
+				return false, fmt.Errorf("容器已退出: %s", status.State.Terminated.Reason)
+			}
+			if waiting := status.State.Waiting; waiting != nil {
+				switch waiting.Reason {
+				case "ErrImagePull", "ImagePullBackOff", "InvalidImageName", "CreateContainerError":
+					return false, fmt.Errorf("%s: %s", waiting.Reason, waiting.Message)
+				}
+			}
+		}
+		return false, nil
+	})
+	if err != nil && !wait.Interrupted(err) {
+		return false, err
+	}
+	return running, nil
+}
+
+// CreateDebugContainer 向运行中的Pod注入临时调试容器，适用于无shell的distroless镜像
+func (c *PodController) CreateDebugContainer(ctx *gin.Context) {
+	helper := utils.NewResponseHelper(ctx)
+	namespace := ctx.Param("namespace")
+	podName := ctx.Param("podname")
+	instanceID := utils.GetInstanceIDFromContext(ctx)
+
+	var req k8s.PodDebugRequest
+	if err := ctx.ShouldBindJSON(&req); err != nil {
+		helper.ValidationError(err.Error())
+		return
+	}
+	image, err := resolveDebugImage(req.Image)
+	if err != nil {
+		helper.BadRequest(err.Error())
+		return
+	}
+
+	if !authorizeExec(ctx, instanceID, namespace) {
+		return
+	}
+	client, exists := configs.GetK8sClient(instanceID)
+	if !exists {
+		helper.InternalError("K8s客户端未初始化")
+		return
+	}
+
+	pod, err := client.CoreV1().Pods(namespace).Get(ctx, podName, metav1.GetOptions{})
+	if err != nil {
+		helper.NotFound(fmt.Sprintf("Pod '%s' 在命名空间 '%s' 中不存在", podName, namespace))
+		return
+	}
+	if pod.Status.Phase != corev1.PodRunning {
+		helper.BadRequest("只能向运行中的Pod注入调试容器")
+		return
+	}
+	if req.TargetContainer != "" {
+		found := false
+		for _, container := range pod.Spec.Containers {
+			if container.Name == req.TargetContainer {
+				found = true
+				break
+			}
+		}
+		if !found {
+			helper.BadRequest(fmt.Sprintf("目标容器 '%s' 不存在", req.TargetContainer))
+			return
+		}
+	}
+
+	debugName := "debugger-" + utilrand.String(5)
+	pod.Spec.EphemeralContainers = append(pod.Spec.EphemeralContainers, corev1.EphemeralContainer{
+		EphemeralContainerCommon: corev1.EphemeralContainerCommon{
+			Name:                     debugName,
+			Image:                    image,
+			Command:                  req.Command,
+			ImagePullPolicy:          corev1.PullIfNotPresent,
+			Stdin:                    true,
+			TTY:                      true,
+			TerminationMessagePolicy: corev1.TerminationMessageReadFile,
+		},
+		TargetContainerName: req.TargetContainer,
+	})
+	if _, err := client.CoreV1().Pods(namespace).UpdateEphemeralContainers(ctx, podName, pod, metav1.UpdateOptions{}); err != nil {
+		helper.InternalError("注入调试容器失败: " + err.Error())
+		return
+	}
+
+	logs.Info(map[string]interface{}{
+		"user":       utils.GetUserNameFromContext(ctx),
+		"instanceId": instanceID,
+		"namespace":  namespace,
+		"pod":        podName,
+		"container":  debugName,
+		"image":      image,
+		"target":     req.TargetContainer,
+	}, "注入临时调试容器")
+
+	running, err := waitContainerRunning(ctx, client, namespace, podName, debugName)
+	if err != nil {
+		helper.InternalError("调试容器启动失败: " + err.Error())
+		return
+	}
+
+	helper.SuccessWithData("调试容器已创建", "debug", gin.H{
+		"namespace": namespace,
+		"pod":       podName,
+		"container": debugName,
+		"image":     image,
+		"running":   running,
+		"execPath":  execPath(instanceID, namespace, podName, debugName),
+	})
+}
+
+// CopyPodForDebug 复制Pod并加入调试容器或替换镜像，对应kubectl debug --copy-to
+func (c *PodController) CopyPodForDebug(ctx *gin.Context) {
+	helper := utils.NewResponseHelper(ctx)
+	namespace := ctx.Param("namespace")
+	podName := ctx.Param("podname")
+	instanceID := utils.GetInstanceIDFromContext(ctx)
+
+	var req k8s.PodDebugCopyRequest
+	if err := ctx.ShouldBindJSON(&req); err != nil {
+		helper.ValidationError(err.Error())
+		return
+	}
+	// 只替换镜像时不加入调试容器
+	addDebugContainer := req.Image != "" || len(req.SetImage) == 0
+	image := ""
+	if addDebugContainer {
+		var err error
+		if image, err = resolveDebugImage(req.Image); err != nil {
+			helper.BadRequest(err.Error())
+			return
+		}
+	}
+
+	if !authorizeExec(ctx, instanceID, namespace) {
+		return
+	}
+	client, exists := configs.GetK8sClient(instanceID)
+	if !exists {
+		helper.InternalError("K8s客户端未初始化")
+		return
+	}
+
+	source, err := client.CoreV1().Pods(namespace).Get(ctx, podName, metav1.GetOptions{})
+	if err != nil {
+		helper.NotFound(fmt.Sprintf("Pod '%s' 在命名空间 '%s' 中不存在", podName, namespace))
+		return
+	}
+
+	copyName := req.CopyTo
+	if copyName == "" {
+		copyName = podName + "-debug"
+	}
+	copied := &corev1.Pod{
+		ObjectMeta: metav1.ObjectMeta{
+			Name:        copyName,
+			Namespace:   namespace,
+			Annotations: source.Annotations,
+		},
+		Spec: *source.Spec.DeepCopy(),
+	}
+	// 默认去除标签，避免副本被Service或控制器选中
+	if req.KeepLabels {
+		copied.Labels = source.Labels
+	}
+	if !req.SameNode {
+		copied.Spec.NodeName = ""
+	}
+	copied.Spec.EphemeralContainers = nil
+	shareProcesses := req.ShareProcesses == nil || *req.ShareProcesses
+	copied.Spec.ShareProcessNamespace = &shareProcesses
+
+	for name, newImage := range req.SetImage {
+		found := false
+		for i := range copied.Spec.Containers {
+			if copied.Spec.Containers[i].Name == name {
+				copied.Spec.Containers[i].Image = newImage
+				found = true
+				break
+			}
+		}
+		if !found {
+			helper.BadRequest(fmt.Sprintf("容器 '%s' 不存在", name))
+			return
+		}
+	}
+
+	container := copied.Spec.Containers[0].Name
+	if addDebugContainer {
+		container = "debugger-" + utilrand.String(5)
+		copied.Spec.Containers = append(copied.Spec.Containers, corev1.Container{
+			Name:                     container,
+			Image:                    image,
+			Command:                  req.Command,
+			ImagePullPolicy:          corev1.PullIfNotPresent,
+			Stdin:                    true,
+			TTY:                      true,
+			TerminationMessagePolicy: corev1.TerminationMessageReadFile,
+		})
+	}
+
+	if _, err := client.CoreV1().Pods(namespace).Create(ctx, copied, metav1.CreateOptions{}); err != nil {
+		helper.InternalError("创建调试副本失败: " + err.Error())
+		return
+	}
+
+	logs.Info(map[string]interface{}{
+		"user":       utils.GetUserNameFromContext(ctx),
+		"instanceId": instanceID,
+		"namespace":  namespace,
+		"pod":        podName,
+		"copyTo":     copyName,
+		"image":      image,
+	}, "复制Pod进行调试")
+
+	running, err := waitContainerRunning(ctx, client, namespace, copyName, container)
+	if err != nil {
+		helper.InternalError("调试副本启动失败: " + err.Error())
+		return
+	}
+
+	helper.SuccessWithData("调试副本已创建", "debug", gin.H{
+		"namespace": namespace,
+		"pod":       copyName,
+		"container": container,
+		"image":     image,
+		"running":   running,
+		"execPath":  execPath(instanceID, namespace, copyName, container),
+	})
+}
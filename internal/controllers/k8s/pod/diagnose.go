@@ -0,0 +1,40 @@
+package pod
+
+import (
+	"devops-console-backend/internal/services/diagnosis"
+	"devops-console-backend/pkg/configs"
+	"devops-console-backend/pkg/utils"
+
+	"github.com/gin-gonic/gin"
+)
+
+// DiagnosePod 诊断Pod无法正常运行的原因，返回发现的问题及处理建议
+func (c *PodController) DiagnosePod(ctx *gin.Context) {
+	helper := utils.NewResponseHelper(ctx)
+	namespace := ctx.Param("namespace")
+	podName := ctx.Param("podname")
+
+	client, exists := configs.GetK8sClient(utils.GetInstanceIDFromContext(ctx))
+	if !exists {
+		helper.InternalError("K8s客户端未初始化")
+		return
+	}
+
+	pod, ok := c.getPod(ctx, client, namespace, podName)
+	if !ok {
+		return
+	}
+	events, err := diagnosis.ListEvents(ctx, client, namespace, "Pod", podName)
+	if err != nil {
+		helper.InternalError("获取Pod事件失败: " + err.Error())
+		return
+	}
+
+	result, err := diagnosis.DiagnosePod(ctx, client, pod, events)
+	if err != nil {
+		helper.InternalError("诊断失败: " + err.Error())
+		return
+	}
+
+	helper.SuccessWithData("success", "diagnosis", result)
+}
@@ -0,0 +1,189 @@
+package pod
+
+import (
+	"compress/gzip"
+	"devops-console-backend/internal/services/podfile"
+	"devops-console-backend/pkg/configs"
+	"devops-console-backend/pkg/utils"
+	"devops-console-backend/pkg/utils/logs"
+	"errors"
+	"fmt"
+	"path"
+	"strconv"
+
+	"github.com/gin-gonic/gin"
+)
+
+// newPodFileClient 校验exec授权并创建容器文件操作客户端，失败时已写入响应
+func newPodFileClient(ctx *gin.Context) (*podfile.Client, map[string]interface{}, bool) {
+	helper := utils.NewResponseHelper(ctx)
+	namespace := ctx.Param("namespace")
+	podName := ctx.Param("podname")
+	container := ctx.Query("container")
+
+	instanceID := utils.GetInstanceIDFromContext(ctx)
+
+	// 文件传输基于exec子资源，需要与终端相同的授权
+	if !authorizeExec(ctx, instanceID, namespace) {
+		return nil, nil, false
+	}
+
+	client, exists := configs.GetK8sClient(instanceID)
+	if !exists {
+		helper.InternalError("K8s客户端未初始化")
+		return nil, nil, false
+	}
+	config, exists := configs.GetK8sConfig(instanceID)
+	if !exists {
+		helper.InternalError("获取K8s配置失败")
+		return nil, nil, false
+	}
+
+	logData := map[string]interface{}{
+		"user":       utils.GetUserNameFromContext(ctx),
+		"instanceId": instanceID,
+		"namespace":  namespace,
+		"pod":        podName,
+		"container":  container,
+	}
+	return podfile.NewClient(client, config, namespace, podName, container), logData, true
+}
+
+// ListPodFiles 列出容器内目录
+func (c *PodController) ListPodFiles(ctx *gin.Context) {
+	helper := utils.NewResponseHelper(ctx)
+	dir, err := podfile.CleanPath(ctx.DefaultQuery("path", "/"))
+	if err != nil {
+		helper.BadRequest(err.Error())
+		return
+	}
+
+	fileClient, _, ok := newPodFileClient(ctx)
+	if !ok {
+		return
+	}
+
+	entries, err := fileClient.List(ctx, dir)
+	if err != nil {
+		helper.InternalError("列出目录失败: " + err.Error())
+		return
+	}
+
+	helper.SuccessWithData("success", "fileList", entries)
+}
+
+// DownloadPodFile 下载容器内文件，目录打包为tar.gz
+func (c *PodController) DownloadPodFile(ctx *gin.Context) {
+	helper := utils.NewResponseHelper(ctx)
+	filePath, err := podfile.CleanPath(ctx.Query("path"))
+	if err != nil {
+		helper.BadRequest(err.Error())
+		return
+	}
+
+	fileClient, logData, ok := newPodFileClient(ctx)
+	if !ok {
+		return
+	}
+	logData["path"] = filePath
+
+	entry, err := fileClient.Stat(ctx, filePath)
+	if err != nil {
+		helper.NotFound("文件不存在: " + err.Error())
+		return
+	}
+
+	limit := configs.GetKubernetesConfig().FileTransfer.MaxDownloadSize * 1024 * 1024
+	if entry.Type == "file" && limit > 0 && entry.Size > limit {
+		helper.BadRequest(fmt.Sprintf("文件大小超过下载上限%dMB", limit/1024/1024))
+		return
+	}
+	if entry.Type != "file" && entry.Type != "directory" {
+		helper.BadRequest("只支持下载普通文件或目录")
+		return
+	}
+
+	logs.Info(logData, "下载容器文件")
+	if entry.Type == "directory" {
+		ctx.Header("Content-Type", "application/gzip")
+		ctx.Header("Content-Disposition", "attachment; filename="+path.Base(filePath)+".tar.gz")
+		gz := gzip.NewWriter(ctx.Writer)
+		err = fileClient.ReadDir(ctx, filePath, gz, limit)
+		if err == nil {
+			err = gz.Close()
+		}
+	} else {
+		ctx.Header("Content-Type", "application/octet-stream")
+		ctx.Header("Content-Disposition", "attachment; filename="+path.Base(filePath))
+		ctx.Header("Content-Length", strconv.FormatInt(entry.Size, 10))
+		err = fileClient.ReadFile(ctx, filePath, ctx.Writer, limit)
+	}
+	if err == nil {
+		return
+	}
+
+	logData["error"] = err.Error()
+	logs.Warning(logData, "下载容器文件失败")
+	if ctx.Writer.Written() {
+		// 已开始传输，无法再返回错误信息
+		return
+	}
+	ctx.Writer.Header().Del("Content-Disposition")
+	ctx.Writer.Header().Del("Content-Length")
+	ctx.Writer.Header().Set("Content-Type", "application/json; charset=utf-8")
+	if errors.Is(err, podfile.ErrSizeLimit) {
+		helper.BadRequest(fmt.Sprintf("下载内容超过上限%dMB", limit/1024/1024))
+		return
+	}
+	helper.InternalError("下载文件失败: " + err.Error())
+}
+
+// UploadPodFile 上传文件到容器目录
+func (c *PodController) UploadPodFile(ctx *gin.Context) {
+	helper := utils.NewResponseHelper(ctx)
+	dir, err := podfile.CleanPath(ctx.PostForm("path"))
+	if err != nil {
+		helper.BadRequest(err.Error())
+		return
+	}
+
+	fileHeader, err := ctx.FormFile("file")
+	if err != nil {
+		helper.BadRequest("缺少上传文件")
+		return
+	}
+	limit := configs.GetKubernetesConfig().FileTransfer.MaxUploadSize * 1024 * 1024
+	if limit > 0 && fileHeader.Size > limit {
+		helper.BadRequest(fmt.Sprintf("文件大小超过上传上限%dMB", limit/1024/1024))
+		return
+	}
+	name := path.Base(path.Clean("/" + fileHeader.Filename))
+	if name == "/" || name == "." {
+		helper.BadRequest("文件名无效")
+		return
+	}
+
+	fileClient, logData, ok := newPodFileClient(ctx)
+	if !ok {
+		return
+	}
+	logData["path"] = path.Join(dir, name)
+	logData["size"] = fileHeader.Size
+
+	file, err := fileHeader.Open()
+	if err != nil {
+		helper.InternalError("读取上传文件失败")
+		return
+	}
+	defer file.Close()
+
+	if err := fileClient.WriteFile(ctx, dir, name, fileHeader.Size, file); err != nil {
+		logData["error"] = err.Error()
+		logs.Warning(logData, "上传容器文件失败")
+		helper.InternalError("上传文件失败: " + err.Error())
+		return
+	}
+
+	logs.Info(logData, "上传容器文件")
+	helper.Success("上传成功")
+}
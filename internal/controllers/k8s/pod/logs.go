@@ -0,0 +1,294 @@
+package pod
+
+import (
+	"bufio"
+	"bytes"
+	"compress/gzip"
+	"context"
+	"devops-console-backend/internal/dal/request/k8s"
+	"devops-console-backend/pkg/configs"
+	"devops-console-backend/pkg/utils"
+	"devops-console-backend/pkg/utils/logs"
+	"encoding/json"
+	"fmt"
+	"io"
+	"strconv"
+	"strings"
+	"time"
+
+	"github.com/gin-gonic/gin"
+	corev1 "k8s.io/api/core/v1"
+	metav1 "k8s.io/apimachinery/pkg/apis/meta/v1"
+)
+
+// DownloadPodLogs 下载容器日志，支持按时间范围截取和gzip压缩
+// 参数: container, previous, since_time/until_time（Unix秒）, timestamps, format=text|gzip
+func (c *PodController) DownloadPodLogs(ctx *gin.Context) {
+	namespace := ctx.Param("namespace")
+	podName := ctx.Param("podname")
+	container := ctx.Query("container")
+	previous := ctx.Query("previous") == "true"
+	timestamps := ctx.Query("timestamps") == "true"
+	format := ctx.DefaultQuery("format", "text")
+
+	instanceID := utils.GetInstanceIDFromContext(ctx)
+
+	helper := utils.NewResponseHelper(ctx)
+	if format != "text" && format != "gzip" {
+		helper.BadRequest("format只支持text或gzip")
+		return
+	}
+
+	client, exists := configs.GetK8sClient(instanceID)
+	if !exists {
+		helper.InternalError("K8s客户端未初始化")
+		return
+	}
+
+	var until time.Time
+	logOptions := &corev1.PodLogOptions{
+		Container: container,
+		Previous:  previous,
+		// 按结束时间截取需要逐行比较时间戳
+		Timestamps: timestamps || ctx.Query("until_time") != "",
+	}
+	if s := ctx.Query("since_time"); s != "" {
+		sec, err := strconv.ParseInt(s, 10, 64)
+		if err != nil {
+			helper.BadRequest("since_time格式错误")
+			return
+		}
+		sinceTime := metav1.NewTime(time.Unix(sec, 0))
+		logOptions.SinceTime = &sinceTime
+	}
+	if s := ctx.Query("until_time"); s != "" {
+		sec, err := strconv.ParseInt(s, 10, 64)
+		if err != nil {
+			helper.BadRequest("until_time格式错误")
+			return
+		}
+		until = time.Unix(sec, 0)
+	}
+
+	stream, err := client.CoreV1().Pods(namespace).GetLogs(podName, logOptions).Stream(ctx)
+	if err != nil {
+		helper.InternalError("获取Pod日志失败: " + err.Error())
+		return
+	}
+	defer stream.Close()
+
+	filename := fmt.Sprintf("%s-%s-%s.log", podName, container, time.Now().Format("20060102150405"))
+	if container == "" {
+		filename = fmt.Sprintf("%s-%s.log", podName, time.Now().Format("20060102150405"))
+	}
+	var writer io.Writer = ctx.Writer
+	if format == "gzip" {
+		filename += ".gz"
+		ctx.Header("Content-Type", "application/gzip")
+		gz := gzip.NewWriter(ctx.Writer)
+		defer gz.Close()
+		writer = gz
+	} else {
+		ctx.Header("Content-Type", "text/plain; charset=utf-8")
+	}
+	ctx.Header("Content-Disposition", "attachment; filename="+filename)
+	ctx.Status(200)
+
+	if until.IsZero() {
+		_, err = io.Copy(writer, stream)
+	} else {
+		err = copyLogsUntil(writer, stream, until, timestamps)
+	}
+	if err != nil {
+		// 响应头已发送，只能记录日志
+		logs.Warning(map[string]interface{}{
+			"namespace": namespace,
+			"pod":       podName,
+			"container": container,
+			"error":     err.Error(),
+		}, "下载Pod日志中断")
+	}
+}
+
+// copyLogsUntil 逐行复制日志，遇到晚于until的行时停止，keepTimestamps为false时去掉行首时间戳
+func copyLogsUntil(w io.Writer, r io.Reader, until time.Time, keepTimestamps bool) error {
+	scanner := bufio.NewScanner(r)
+	scanner.Buffer(make([]byte, 64*1024), 1024*1024)
+	for scanner.Scan() {
+		line := scanner.Text()
+		prefix, rest, found := strings.Cut(line, " ")
+		if found {
+			if ts, err := time.Parse(time.RFC3339Nano, prefix); err == nil {
+				if ts.After(until) {
+					return nil
+				}
+				if !keepTimestamps {
+					line = rest
+				}
+			}
+		}
+		if _, err := io.WriteString(w, line+"\n"); err != nil {
+			return err
+		}
+	}
+	return scanner.Err()
+}
+
+// SearchPodLogs 在存放集群日志的ES实例中检索容器日志
+func (c *PodController) SearchPodLogs(ctx *gin.Context) {
+	helper := utils.NewResponseHelper(ctx)
+
+	var req k8s.PodLogSearchRequest
+	if err := ctx.ShouldBindQuery(&req); err != nil {
+		helper.ValidationError(err.Error())
+		return
+	}
+
+	esClient, exists := configs.GetEsClient(req.EsInstanceID)
+	if !exists {
+		helper.BadRequest("ES实例不存在或未初始化")
+		return
+	}
+
+	fields := configs.GetKubernetesConfig().LogSearch
+	index := req.Index
+	if index == "" {
+		index = fields.Index
+	}
+
+	body, err := json.Marshal(buildLogSearchQuery(&req, fields))
+	if err != nil {
+		helper.InternalError("构建查询失败")
+		return
+	}
+
+	searchCtx, cancel := context.WithTimeout(ctx, 30*time.Second)
+	defer cancel()
+	res, err := esClient.Search(
+		esClient.Search.WithContext(searchCtx),
+		esClient.Search.WithIndex(index),
+		esClient.Search.WithBody(bytes.NewReader(body)),
+		esClient.Search.WithIgnoreUnavailable(true),
+	)
+	if err != nil {
+		helper.InternalError("检索日志失败: " + err.Error())
+		return
+	}
+	defer res.Body.Close()
+	if res.IsError() {
+		raw, _ := io.ReadAll(res.Body)
+		helper.InternalError("检索日志失败: " + string(raw))
+		return
+	}
+
+	var result struct {
+		Hits struct {
+			Total struct {
+				Value int64 `json:"value"`
+			} `json:"total"`
+			Hits []struct {
+				Index     string                 `json:"_index"`
+				Source    map[string]interface{} `json:"_source"`
+				Highlight map[string][]string    `json:"highlight"`
+			} `json:"hits"`
+		} `json:"hits"`
+	}
+	if err := json.NewDecoder(res.Body).Decode(&result); err != nil {
+		helper.InternalError("解析检索结果失败: " + err.Error())
+		return
+	}
+
+	logList := make([]k8s.PodLogSearchItem, 0, len(result.Hits.Hits))
+	for _, hit := range result.Hits.Hits {
+		logList = append(logList, k8s.PodLogSearchItem{
+			Timestamp: sourceField(hit.Source, fields.TimestampField),
+			Namespace: sourceField(hit.Source, fields.NamespaceField),
+			Pod:       sourceField(hit.Source, fields.PodField),
+			Container: sourceField(hit.Source, fields.ContainerField),
+			Message:   sourceField(hit.Source, fields.MessageField),
+			Highlight: hit.Highlight[fields.MessageField],
+			Index:     hit.Index,
+		})
+	}
+
+	helper.SuccessWithData("success", "data", gin.H{
+		"logList":  logList,
+		"total":    result.Hits.Total.Value,
+		"page":     req.Page,
+		"pageSize": req.PageSize,
+	})
+}
+
+// buildLogSearchQuery 构建日志检索的ES查询
+func buildLogSearchQuery(req *k8s.PodLogSearchRequest, fields configs.LogSearchConfig) map[string]interface{} {
+	filters := make([]interface{}, 0)
+	if req.Namespace != "" {
+		filters = append(filters, gin.H{"match_phrase": gin.H{fields.NamespaceField: req.Namespace}})
+	}
+	if req.Pod != "" {
+		if strings.Contains(req.Pod, "*") {
+			filters = append(filters, gin.H{"wildcard": gin.H{fields.PodField: req.Pod}})
+		} else {
+			filters = append(filters, gin.H{"match_phrase": gin.H{fields.PodField: req.Pod}})
+		}
+	}
+	if req.Container != "" {
+		filters = append(filters, gin.H{"match_phrase": gin.H{fields.ContainerField: req.Container}})
+	}
+	if req.StartTime > 0 || req.EndTime > 0 {
+		timeRange := gin.H{"format": "epoch_second"}
+		if req.StartTime > 0 {
+			timeRange["gte"] = req.StartTime
+		}
+		if req.EndTime > 0 {
+			timeRange["lte"] = req.EndTime
+		}
+		filters = append(filters, gin.H{"range": gin.H{fields.TimestampField: timeRange}})
+	}
+
+	boolQuery := gin.H{"filter": filters}
+	if req.Query != "" {
+		boolQuery["must"] = []interface{}{
+			gin.H{"simple_query_string": gin.H{
+				"query":            req.Query,
+				"fields":           []string{fields.MessageField},
+				"default_operator": "and",
+			}},
+		}
+	}
+
+	return map[string]interface{}{
+		"from":             (req.Page - 1) * req.PageSize,
+		"size":             req.PageSize,
+		"track_total_hits": true,
+		"query":            gin.H{"bool": boolQuery},
+		"sort":             []interface{}{gin.H{fields.TimestampField: gin.H{"order": "desc"}}},
+		"highlight": gin.H{
+			"fields": gin.H{fields.MessageField: gin.H{"number_of_fragments": 0}},
+		},
+	}
+}
+
+// sourceField 按点分路径读取文档字段，兼容嵌套对象和扁平的带点字段名
+func sourceField(source map[string]interface{}, path string) string {
+	if v, ok := source[path]; ok {
+		return fmt.Sprint(v)
+	}
+	head, rest, found := strings.Cut(path, ".")
+	if !found {
+		return ""
+	}
+	// 依次尝试更长的前缀，例如 kubernetes.pod.name 可能存为 {"kubernetes": {"pod.name": ...}}
+	for {
+		if nested, ok := source[head].(map[string]interface{}); ok {
+			if v := sourceField(nested, rest); v != "" {
+				return v
+			}
+		}
+		next, remain, ok := strings.Cut(rest, ".")
+		if !ok {
+			return ""
+		}
+		head, rest = head+"."+next, remain
+	}
+}
@@ -1,19 +1,23 @@
 package pod
 
 import (
+	"devops-console-backend/internal/dal"
 	"devops-console-backend/internal/dal/request/k8s"
+	"devops-console-backend/internal/services/diagnosis"
 	"devops-console-backend/pkg/configs"
 	"devops-console-backend/pkg/utils"
 	"fmt"
 	"io"
 	"strconv"
 	"strings"
+	"time"
 
 	"github.com/gin-gonic/gin"
 	"gopkg.in/yaml.v2"
 	corev1 "k8s.io/api/core/v1"
 	"k8s.io/apimachinery/pkg/api/resource"
 	metav1 "k8s.io/apimachinery/pkg/apis/meta/v1"
+	"k8s.io/client-go/kubernetes"
 )
 
 // PodController Pod控制器
@@ -44,23 +48,8 @@ func (c *PodController) GetPodDetail(ctx *gin.Context) {
 		return
 	}
 
-	podDetail, err := client.CoreV1().Pods(namespace).Get(ctx, podName, metav1.GetOptions{})
-	if err != nil {
-		// 尝试在所有命名空间中查找该Pod
-		allPods, err2 := client.CoreV1().Pods("").List(ctx, metav1.ListOptions{
-			FieldSelector: fmt.Sprintf("metadata.name=%s", podName),
-		})
-
-		if err2 == nil && len(allPods.Items) > 0 {
-			// 找到了Pod，返回实际命名空间的错误信息
-			actualNamespace := allPods.Items[0].Namespace
-			helper := utils.NewResponseHelper(ctx)
-			helper.BadRequest(fmt.Sprintf("Pod '%s' 存在于命名空间 '%s' 中，而不是 '%s'", podName, actualNamespace, namespace))
-			return
-		}
-
-		helper := utils.NewResponseHelper(ctx)
-		helper.NotFound(fmt.Sprintf("Pod '%s' 在命名空间 '%s' 中不存在", podName, namespace))
+	podDetail, ok := c.getPod(ctx, client, namespace, podName)
+	if !ok {
 		return
 	}
 
@@ -84,6 +73,12 @@ func (c *PodController) GetPodDetail(ctx *gin.Context) {
 		restartCount += int(containerStatus.RestartCount)
 	}
 
+	// 最近24小时的Warning事件
+	warningEvents := make([]dal.K8sEventWarningSummary, 0)
+	if summaries, err := configs.NewK8sEventRepository().GetWarningSummary(instanceID, namespace, "Pod", podName, nil, time.Now().Add(-24*time.Hour)); err == nil && summaries != nil {
+		warningEvents = summaries
+	}
+
 	helper := utils.NewResponseHelper(ctx)
 	helper.SuccessWithData("success", "podDetail", gin.H{
 		"name":          podDetail.Name,
@@ -99,9 +94,35 @@ func (c *PodController) GetPodDetail(ctx *gin.Context) {
 		"annotations":   podDetail.Annotations,
 		"containers":    containers,
 		"conditions":    podDetail.Status.Conditions,
+		"warningEvents": warningEvents,
 	})
 }
 
+// getPod 获取Pod，不存在时会尝试在其他命名空间中查找以给出更明确的提示；返回false表示已写入响应
+func (c *PodController) getPod(ctx *gin.Context, client kubernetes.Interface, namespace, podName string) (*corev1.Pod, bool) {
+	pod, err := client.CoreV1().Pods(namespace).Get(ctx, podName, metav1.GetOptions{})
+	if err == nil {
+		return pod, true
+	}
+
+	// 尝试在所有命名空间中查找该Pod
+	allPods, err2 := client.CoreV1().Pods("").List(ctx, metav1.ListOptions{
+		FieldSelector: fmt.Sprintf("metadata.name=%s", podName),
+	})
+
+	if err2 == nil && len(allPods.Items) > 0 {
+		// 找到了Pod，返回实际命名空间的错误信息
+		actualNamespace := allPods.Items[0].Namespace
+		helper := utils.NewResponseHelper(ctx)
+		helper.BadRequest(fmt.Sprintf("Pod '%s' 存在于命名空间 '%s' 中，而不是 '%s'", podName, actualNamespace, namespace))
+		return nil, false
+	}
+
+	helper := utils.NewResponseHelper(ctx)
+	helper.NotFound(fmt.Sprintf("Pod '%s' 在命名空间 '%s' 中不存在", podName, namespace))
+	return nil, false
+}
+
 // GetPodList 获取Pod列表
 func (c *PodController) GetPodList(ctx *gin.Context) {
 	namespace := ctx.Param("namespace")
@@ -285,11 +306,7 @@ func (c *PodController) GetPodEvents(ctx *gin.Context) {
 	}
 
 	// 获取Pod事件
-	fieldSelector := fmt.Sprintf("involvedObject.name=%s", podName)
-	events, err := client.CoreV1().Events(namespace).List(ctx, metav1.ListOptions{
-		FieldSelector: fieldSelector,
-		Limit:         50,
-	})
+	events, err := diagnosis.ListEvents(ctx, client, namespace, "", podName)
 	if err != nil {
 		helper := utils.NewResponseHelper(ctx)
 		helper.InternalError("获取Pod事件失败: " + err.Error())
@@ -298,7 +315,7 @@ func (c *PodController) GetPodEvents(ctx *gin.Context) {
 
 	// 转换事件数据
 	eventList := make([]gin.H, 0)
-	for _, event := range events.Items {
+	for _, event := range events {
 		eventData := gin.H{
 			"type":           event.Type,
 			"reason":         event.Reason,
@@ -0,0 +1,88 @@
+package portforward
+
+import (
+	"devops-console-backend/internal/dal/request/k8s"
+	"devops-console-backend/internal/services/terminal"
+	"devops-console-backend/pkg/configs"
+	"devops-console-backend/pkg/utils"
+
+	"github.com/gin-gonic/gin"
+)
+
+// PortForwardController 端口转发会话控制器
+type PortForwardController struct{}
+
+// NewPortForwardController 创建端口转发会话控制器实例
+func NewPortForwardController() *PortForwardController {
+	return &PortForwardController{}
+}
+
+// GetSessionList 分页获取端口转发记录，非管理员只能看到自己的记录
+func (c *PortForwardController) GetSessionList(ctx *gin.Context) {
+	helper := utils.NewResponseHelper(ctx)
+
+	var req k8s.SessionListRequest
+	if err := ctx.ShouldBindQuery(&req); err != nil {
+		helper.ValidationError(err.Error())
+		return
+	}
+
+	var userID int64
+	if !utils.IsAdminFromContext(ctx) {
+		userID = utils.GetUserIdFromContext(ctx)
+	}
+	sessions, total, err := configs.NewK8sPortForwardSessionRepository().GetWithPagination(userID, (req.Page-1)*req.PageSize, req.PageSize)
+	if err != nil {
+		helper.DatabaseError("获取端口转发记录失败")
+		return
+	}
+
+	helper.SuccessWithData("success", "data", gin.H{
+		"sessionList": sessions,
+		"total":       total,
+		"page":        req.Page,
+		"pageSize":    req.PageSize,
+	})
+}
+
+// GetLiveSessionList 获取进行中的端口转发，非管理员只能看到自己的会话
+func (c *PortForwardController) GetLiveSessionList(ctx *gin.Context) {
+	helper := utils.NewResponseHelper(ctx)
+
+	sessions := terminal.PortForwardRegistry.List()
+	if !utils.IsAdminFromContext(ctx) {
+		userID := utils.GetUserIdFromContext(ctx)
+		own := make([]terminal.LiveSession, 0, len(sessions))
+		for _, session := range sessions {
+			if session.UserID == userID {
+				own = append(own, session)
+			}
+		}
+		sessions = own
+	}
+
+	helper.SuccessWithData("success", "sessionList", sessions)
+}
+
+// CloseSession 关闭端口转发，用户可以关闭自己的会话，管理员可以关闭任意会话
+func (c *PortForwardController) CloseSession(ctx *gin.Context) {
+	helper := utils.NewResponseHelper(ctx)
+	sessionID := ctx.Param("sessionId")
+
+	session, ok := terminal.PortForwardRegistry.Get(sessionID)
+	if !ok {
+		helper.NotFound("会话不存在或已结束")
+		return
+	}
+	if !utils.IsAdminFromContext(ctx) && session.UserID != utils.GetUserIdFromContext(ctx) {
+		helper.Forbidden("无权关闭该会话")
+		return
+	}
+
+	if !terminal.PortForwardRegistry.Kill(sessionID, utils.GetUserNameFromContext(ctx)) {
+		helper.NotFound("会话不存在或已结束")
+		return
+	}
+
+	helper.Success("端口转发已关闭")
+}
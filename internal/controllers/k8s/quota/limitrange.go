@@ -0,0 +1,211 @@
+package quota
+
+import (
+	"devops-console-backend/internal/dal/request/k8s"
+	"devops-console-backend/internal/services/capacity"
+	"devops-console-backend/pkg/configs"
+	"devops-console-backend/pkg/utils"
+	"fmt"
+
+	"github.com/gin-gonic/gin"
+	corev1 "k8s.io/api/core/v1"
+	apierrors "k8s.io/apimachinery/pkg/api/errors"
+	metav1 "k8s.io/apimachinery/pkg/apis/meta/v1"
+)
+
+// buildLimitRangeItems 转换并校验LimitRange条目
+func buildLimitRangeItems(items []k8s.LimitRangeItem) ([]corev1.LimitRangeItem, error) {
+	limits := make([]corev1.LimitRangeItem, 0, len(items))
+	for i, item := range items {
+		limit := corev1.LimitRangeItem{Type: corev1.LimitType(item.Type)}
+		fields := []struct {
+			name   string
+			values map[string]string
+			target *corev1.ResourceList
+		}{
+			{"default", item.Default, &limit.Default},
+			{"defaultRequest", item.DefaultRequest, &limit.DefaultRequest},
+			{"max", item.Max, &limit.Max},
+			{"min", item.Min, &limit.Min},
+			{"maxLimitRequestRatio", item.MaxLimitRequestRatio, &limit.MaxLimitRequestRatio},
+		}
+		for _, field := range fields {
+			list, err := capacity.ParseResourceList(field.values)
+			if err != nil {
+				return nil, fmt.Errorf("limits[%d].%s: %w", i, field.name, err)
+			}
+			*field.target = list
+		}
+		limits = append(limits, limit)
+	}
+	return limits, nil
+}
+
+// toLimitRangeItem 转换LimitRange列表项
+func toLimitRangeItem(limitRange *corev1.LimitRange) k8s.LimitRangeListItem {
+	item := k8s.LimitRangeListItem{
+		Name:      limitRange.Name,
+		Namespace: limitRange.Namespace,
+		Limits:    make([]k8s.LimitRangeItem, 0, len(limitRange.Spec.Limits)),
+		Age:       limitRange.CreationTimestamp.Unix(),
+	}
+	for _, limit := range limitRange.Spec.Limits {
+		item.Limits = append(item.Limits, k8s.LimitRangeItem{
+			Type:                 string(limit.Type),
+			Default:              capacity.FormatResourceList(limit.Default),
+			DefaultRequest:       capacity.FormatResourceList(limit.DefaultRequest),
+			Max:                  capacity.FormatResourceList(limit.Max),
+			Min:                  capacity.FormatResourceList(limit.Min),
+			MaxLimitRequestRatio: capacity.FormatResourceList(limit.MaxLimitRequestRatio),
+		})
+	}
+	return item
+}
+
+// GetLimitRangeList 获取LimitRange列表
+func (c *QuotaController) GetLimitRangeList(ctx *gin.Context) {
+	helper := utils.NewResponseHelper(ctx)
+	namespace := ctx.Param("namespace")
+	if namespace == "all" {
+		namespace = ""
+	}
+
+	client, exists := configs.GetK8sClient(utils.GetInstanceIDFromContext(ctx))
+	if !exists {
+		helper.InternalError("K8s客户端未初始化")
+		return
+	}
+
+	list, err := client.CoreV1().LimitRanges(namespace).List(ctx, metav1.ListOptions{})
+	if err != nil {
+		helper.InternalError("获取LimitRange列表失败: " + err.Error())
+		return
+	}
+	limitRangeList := make([]k8s.LimitRangeListItem, 0, len(list.Items))
+	for i := range list.Items {
+		limitRangeList = append(limitRangeList, toLimitRangeItem(&list.Items[i]))
+	}
+	helper.SuccessWithData("success", "limitRangeList", limitRangeList)
+}
+
+// GetLimitRangeDetail 获取LimitRange详情
+func (c *QuotaController) GetLimitRangeDetail(ctx *gin.Context) {
+	helper := utils.NewResponseHelper(ctx)
+	namespace := ctx.Param("namespace")
+	name := ctx.Param("name")
+
+	client, exists := configs.GetK8sClient(utils.GetInstanceIDFromContext(ctx))
+	if !exists {
+		helper.InternalError("K8s客户端未初始化")
+		return
+	}
+
+	limitRange, err := client.CoreV1().LimitRanges(namespace).Get(ctx, name, metav1.GetOptions{})
+	if err != nil {
+		if apierrors.IsNotFound(err) {
+			helper.NotFound("LimitRange 不存在")
+			return
+		}
+		helper.InternalError("获取LimitRange失败: " + err.Error())
+		return
+	}
+	helper.SuccessWithData("success", "limitRangeDetail", limitRange)
+}
+
+// CreateLimitRange 创建LimitRange
+func (c *QuotaController) CreateLimitRange(ctx *gin.Context) {
+	helper := utils.NewResponseHelper(ctx)
+	if !requireAdmin(ctx, helper) {
+		return
+	}
+	var req k8s.LimitRangeCreateRequest
+	if err := ctx.ShouldBindJSON(&req); err != nil {
+		helper.BadRequest("请求参数错误: " + err.Error())
+		return
+	}
+	limits, err := buildLimitRangeItems(req.Limits)
+	if err != nil {
+		helper.BadRequest("限制无效: " + err.Error())
+		return
+	}
+
+	client, exists := configs.GetK8sClient(utils.GetInstanceIDFromContext(ctx))
+	if !exists {
+		helper.InternalError("K8s客户端未初始化")
+		return
+	}
+
+	limitRange := &corev1.LimitRange{
+		ObjectMeta: metav1.ObjectMeta{Name: req.Name, Namespace: req.Namespace},
+		Spec:       corev1.LimitRangeSpec{Limits: limits},
+	}
+	if _, err := client.CoreV1().LimitRanges(req.Namespace).Create(ctx, limitRange, metav1.CreateOptions{}); err != nil {
+		helper.InternalError("创建LimitRange失败: " + err.Error())
+		return
+	}
+	helper.Success("LimitRange创建成功")
+}
+
+// UpdateLimitRange 更新LimitRange的限制条目
+func (c *QuotaController) UpdateLimitRange(ctx *gin.Context) {
+	helper := utils.NewResponseHelper(ctx)
+	if !requireAdmin(ctx, helper) {
+		return
+	}
+	namespace := ctx.Param("namespace")
+	name := ctx.Param("name")
+	var req k8s.LimitRangeUpdateRequest
+	if err := ctx.ShouldBindJSON(&req); err != nil {
+		helper.BadRequest("请求参数错误: " + err.Error())
+		return
+	}
+	limits, err := buildLimitRangeItems(req.Limits)
+	if err != nil {
+		helper.BadRequest("限制无效: " + err.Error())
+		return
+	}
+
+	client, exists := configs.GetK8sClient(utils.GetInstanceIDFromContext(ctx))
+	if !exists {
+		helper.InternalError("K8s客户端未初始化")
+		return
+	}
+
+	limitRange, err := client.CoreV1().LimitRanges(namespace).Get(ctx, name, metav1.GetOptions{})
+	if err != nil {
+		if apierrors.IsNotFound(err) {
+			helper.NotFound("LimitRange 不存在")
+			return
+		}
+		helper.InternalError("获取LimitRange失败: " + err.Error())
+		return
+	}
+	limitRange.Spec.Limits = limits
+	if _, err := client.CoreV1().LimitRanges(namespace).Update(ctx, limitRange, metav1.UpdateOptions{}); err != nil {
+		helper.InternalError("更新LimitRange失败: " + err.Error())
+		return
+	}
+	helper.Success("LimitRange更新成功")
+}
+
+// DeleteLimitRange 删除LimitRange
+func (c *QuotaController) DeleteLimitRange(ctx *gin.Context) {
+	helper := utils.NewResponseHelper(ctx)
+	if !requireAdmin(ctx, helper) {
+		return
+	}
+	namespace := ctx.Param("namespace")
+	name := ctx.Param("name")
+
+	client, exists := configs.GetK8sClient(utils.GetInstanceIDFromContext(ctx))
+	if !exists {
+		helper.InternalError("K8s客户端未初始化")
+		return
+	}
+
+	if err := client.CoreV1().LimitRanges(namespace).Delete(ctx, name, metav1.DeleteOptions{}); err != nil {
+		helper.InternalError("删除LimitRange失败: " + err.Error())
+		return
+	}
+	helper.Success("LimitRange删除成功")
+}
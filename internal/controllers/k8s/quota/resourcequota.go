@@ -0,0 +1,245 @@
+package quota
+
+import (
+	"devops-console-backend/internal/dal/request/k8s"
+	"devops-console-backend/internal/services/capacity"
+	"devops-console-backend/pkg/configs"
+	"devops-console-backend/pkg/utils"
+	"strconv"
+
+	"github.com/gin-gonic/gin"
+	corev1 "k8s.io/api/core/v1"
+	apierrors "k8s.io/apimachinery/pkg/api/errors"
+	metav1 "k8s.io/apimachinery/pkg/apis/meta/v1"
+)
+
+// QuotaController ResourceQuota与LimitRange控制器
+type QuotaController struct{}
+
+// NewQuotaController 创建配额控制器实例
+func NewQuotaController() *QuotaController {
+	return &QuotaController{}
+}
+
+// requireAdmin 配额和限制属于命名空间的治理策略，只允许管理员修改
+func requireAdmin(ctx *gin.Context, helper *utils.ResponseHelper) bool {
+	if !utils.IsAdminFromContext(ctx) {
+		helper.Forbidden("只有管理员可以修改资源配额和限制")
+		return false
+	}
+	return true
+}
+
+// toScopes 转换配额作用域
+func toScopes(scopes []string) []corev1.ResourceQuotaScope {
+	if len(scopes) == 0 {
+		return nil
+	}
+	result := make([]corev1.ResourceQuotaScope, 0, len(scopes))
+	for _, scope := range scopes {
+		result = append(result, corev1.ResourceQuotaScope(scope))
+	}
+	return result
+}
+
+// toResourceQuotaItem 转换ResourceQuota列表项
+func toResourceQuotaItem(quota *corev1.ResourceQuota) k8s.ResourceQuotaListItem {
+	item := k8s.ResourceQuotaListItem{
+		Name:      quota.Name,
+		Namespace: quota.Namespace,
+		Hard:      capacity.FormatResourceList(quota.Spec.Hard),
+		Used:      capacity.FormatResourceList(quota.Status.Used),
+		Age:       quota.CreationTimestamp.Unix(),
+	}
+	for _, scope := range quota.Spec.Scopes {
+		item.Scopes = append(item.Scopes, string(scope))
+	}
+	return item
+}
+
+// GetResourceQuotaList 获取ResourceQuota列表
+func (c *QuotaController) GetResourceQuotaList(ctx *gin.Context) {
+	helper := utils.NewResponseHelper(ctx)
+	namespace := ctx.Param("namespace")
+	if namespace == "all" {
+		namespace = ""
+	}
+
+	client, exists := configs.GetK8sClient(utils.GetInstanceIDFromContext(ctx))
+	if !exists {
+		helper.InternalError("K8s客户端未初始化")
+		return
+	}
+
+	list, err := client.CoreV1().ResourceQuotas(namespace).List(ctx, metav1.ListOptions{})
+	if err != nil {
+		helper.InternalError("获取ResourceQuota列表失败: " + err.Error())
+		return
+	}
+	quotaList := make([]k8s.ResourceQuotaListItem, 0, len(list.Items))
+	for i := range list.Items {
+		quotaList = append(quotaList, toResourceQuotaItem(&list.Items[i]))
+	}
+	helper.SuccessWithData("success", "resourceQuotaList", quotaList)
+}
+
+// GetResourceQuotaDetail 获取ResourceQuota详情
+func (c *QuotaController) GetResourceQuotaDetail(ctx *gin.Context) {
+	helper := utils.NewResponseHelper(ctx)
+	namespace := ctx.Param("namespace")
+	name := ctx.Param("name")
+
+	client, exists := configs.GetK8sClient(utils.GetInstanceIDFromContext(ctx))
+	if !exists {
+		helper.InternalError("K8s客户端未初始化")
+		return
+	}
+
+	quota, err := client.CoreV1().ResourceQuotas(namespace).Get(ctx, name, metav1.GetOptions{})
+	if err != nil {
+		if apierrors.IsNotFound(err) {
+			helper.NotFound("ResourceQuota 不存在")
+			return
+		}
+		helper.InternalError("获取ResourceQuota失败: " + err.Error())
+		return
+	}
+	helper.SuccessWithData("success", "resourceQuotaDetail", quota)
+}
+
+// CreateResourceQuota 创建ResourceQuota
+func (c *QuotaController) CreateResourceQuota(ctx *gin.Context) {
+	helper := utils.NewResponseHelper(ctx)
+	if !requireAdmin(ctx, helper) {
+		return
+	}
+	var req k8s.ResourceQuotaCreateRequest
+	if err := ctx.ShouldBindJSON(&req); err != nil {
+		helper.BadRequest("请求参数错误: " + err.Error())
+		return
+	}
+	hard, err := capacity.ParseResourceList(req.Hard)
+	if err != nil {
+		helper.BadRequest("配额无效: " + err.Error())
+		return
+	}
+
+	client, exists := configs.GetK8sClient(utils.GetInstanceIDFromContext(ctx))
+	if !exists {
+		helper.InternalError("K8s客户端未初始化")
+		return
+	}
+
+	quota := &corev1.ResourceQuota{
+		ObjectMeta: metav1.ObjectMeta{Name: req.Name, Namespace: req.Namespace},
+		Spec: corev1.ResourceQuotaSpec{
+			Hard:   hard,
+			Scopes: toScopes(req.Scopes),
+		},
+	}
+	if _, err := client.CoreV1().ResourceQuotas(req.Namespace).Create(ctx, quota, metav1.CreateOptions{}); err != nil {
+		helper.InternalError("创建ResourceQuota失败: " + err.Error())
+		return
+	}
+	helper.Success("ResourceQuota创建成功")
+}
+
+// UpdateResourceQuota 更新ResourceQuota的配额和作用域
+func (c *QuotaController) UpdateResourceQuota(ctx *gin.Context) {
+	helper := utils.NewResponseHelper(ctx)
+	if !requireAdmin(ctx, helper) {
+		return
+	}
+	namespace := ctx.Param("namespace")
+	name := ctx.Param("name")
+	var req k8s.ResourceQuotaUpdateRequest
+	if err := ctx.ShouldBindJSON(&req); err != nil {
+		helper.BadRequest("请求参数错误: " + err.Error())
+		return
+	}
+	hard, err := capacity.ParseResourceList(req.Hard)
+	if err != nil {
+		helper.BadRequest("配额无效: " + err.Error())
+		return
+	}
+
+	client, exists := configs.GetK8sClient(utils.GetInstanceIDFromContext(ctx))
+	if !exists {
+		helper.InternalError("K8s客户端未初始化")
+		return
+	}
+
+	quota, err := client.CoreV1().ResourceQuotas(namespace).Get(ctx, name, metav1.GetOptions{})
+	if err != nil {
+		if apierrors.IsNotFound(err) {
+			helper.NotFound("ResourceQuota 不存在")
+			return
+		}
+		helper.InternalError("获取ResourceQuota失败: " + err.Error())
+		return
+	}
+	quota.Spec.Hard = hard
+	quota.Spec.Scopes = toScopes(req.Scopes)
+	if _, err := client.CoreV1().ResourceQuotas(namespace).Update(ctx, quota, metav1.UpdateOptions{}); err != nil {
+		helper.InternalError("更新ResourceQuota失败: " + err.Error())
+		return
+	}
+	helper.Success("ResourceQuota更新成功")
+}
+
+// DeleteResourceQuota 删除ResourceQuota
+func (c *QuotaController) DeleteResourceQuota(ctx *gin.Context) {
+	helper := utils.NewResponseHelper(ctx)
+	if !requireAdmin(ctx, helper) {
+		return
+	}
+	namespace := ctx.Param("namespace")
+	name := ctx.Param("name")
+
+	client, exists := configs.GetK8sClient(utils.GetInstanceIDFromContext(ctx))
+	if !exists {
+		helper.InternalError("K8s客户端未初始化")
+		return
+	}
+
+	if err := client.CoreV1().ResourceQuotas(namespace).Delete(ctx, name, metav1.DeleteOptions{}); err != nil {
+		helper.InternalError("删除ResourceQuota失败: " + err.Error())
+		return
+	}
+	helper.Success("ResourceQuota删除成功")
+}
+
+// GetNamespaceCapacity 命名空间容量视图，展示各配额的已用与上限，threshold参数可覆盖配置的告警阈值
+func (c *QuotaController) GetNamespaceCapacity(ctx *gin.Context) {
+	helper := utils.NewResponseHelper(ctx)
+	namespace := ctx.Param("namespace")
+
+	threshold := configs.GetKubernetesConfig().Quota.WarningThreshold
+	if value := ctx.Query("threshold"); value != "" {
+		parsed, err := strconv.ParseFloat(value, 64)
+		if err != nil || parsed <= 0 {
+			helper.BadRequest("无效的threshold参数")
+			return
+		}
+		threshold = parsed
+	}
+
+	client, exists := configs.GetK8sClient(utils.GetInstanceIDFromContext(ctx))
+	if !exists {
+		helper.InternalError("K8s客户端未初始化")
+		return
+	}
+
+	list, err := client.CoreV1().ResourceQuotas(namespace).List(ctx, metav1.ListOptions{})
+	if err != nil {
+		helper.InternalError("获取ResourceQuota列表失败: " + err.Error())
+		return
+	}
+	items, warnings := capacity.Usage(list.Items, threshold)
+	helper.Success("success", map[string]interface{}{
+		"namespace": namespace,
+		"threshold": threshold,
+		"usage":     items,
+		"warnings":  warnings,
+	})
+}
@@ -0,0 +1,109 @@
+package rbac
+
+import (
+	"devops-console-backend/internal/dal/request/k8s"
+	rbacsvc "devops-console-backend/internal/services/rbac"
+	"devops-console-backend/pkg/configs"
+	"devops-console-backend/pkg/utils"
+
+	"github.com/gin-gonic/gin"
+	rbacv1 "k8s.io/api/rbac/v1"
+)
+
+// maxCrossCheckSubjects who-can复核的主体数量上限，避免大量SubjectAccessReview请求
+const maxCrossCheckSubjects = 50
+
+// WhoCan 查询可以执行指定操作的主体，如 verb=delete&resource=pods&namespace=x
+func (c *RBACController) WhoCan(ctx *gin.Context) {
+	helper := utils.NewResponseHelper(ctx)
+	var req k8s.RBACWhoCanRequest
+	if err := ctx.ShouldBindQuery(&req); err != nil {
+		helper.BadRequest("请求参数错误: " + err.Error())
+		return
+	}
+
+	client, exists := configs.GetK8sClient(utils.GetInstanceIDFromContext(ctx))
+	if !exists {
+		helper.InternalError("K8s客户端未初始化")
+		return
+	}
+	snapshot, err := rbacsvc.LoadSnapshot(ctx, client)
+	if err != nil {
+		helper.InternalError(err.Error())
+		return
+	}
+
+	grants := rbacsvc.WhoCan(snapshot, &req.RBACAccessQuery)
+	result := map[string]interface{}{
+		"query":  req.RBACAccessQuery,
+		"grants": grants,
+	}
+	if req.CrossCheck {
+		// 同一主体只复核一次，ServiceAccount和Group的结果包含其继承的权限
+		verified := map[k8s.RBACSubject]*bool{}
+		for i := range grants {
+			subject := grants[i].Subject
+			value, checked := verified[subject]
+			if !checked {
+				if len(verified) >= maxCrossCheckSubjects {
+					result["crossCheckTruncated"] = true
+					break
+				}
+				allowed, err := rbacsvc.Verify(ctx, client, &subject, nil, &req.RBACAccessQuery)
+				if err != nil {
+					result["crossCheckError"] = "SubjectAccessReview不可用: " + err.Error()
+					break
+				}
+				value = &allowed
+				verified[subject] = value
+			}
+			grants[i].Verified = value
+		}
+	}
+	helper.Success("success", result)
+}
+
+// GetSubjectPermissions 查询主体获得的全部规则，请求中带check时同时给出本地判定和SubjectAccessReview复核结果
+func (c *RBACController) GetSubjectPermissions(ctx *gin.Context) {
+	helper := utils.NewResponseHelper(ctx)
+	var req k8s.RBACSubjectRequest
+	if err := ctx.ShouldBindJSON(&req); err != nil {
+		helper.BadRequest("请求参数错误: " + err.Error())
+		return
+	}
+	if req.Subject.Kind == rbacv1.ServiceAccountKind && req.Subject.Namespace == "" {
+		helper.BadRequest("ServiceAccount需要指定命名空间")
+		return
+	}
+	if req.Check != nil && (req.Check.Verb == "" || req.Check.Resource == "") {
+		helper.BadRequest("check需要指定verb和resource")
+		return
+	}
+
+	client, exists := configs.GetK8sClient(utils.GetInstanceIDFromContext(ctx))
+	if !exists {
+		helper.InternalError("K8s客户端未初始化")
+		return
+	}
+	snapshot, err := rbacsvc.LoadSnapshot(ctx, client)
+	if err != nil {
+		helper.InternalError(err.Error())
+		return
+	}
+
+	result := map[string]interface{}{
+		"subject": req.Subject,
+		"rules":   rbacsvc.SubjectRules(snapshot, &req.Subject, req.Groups),
+	}
+	if req.Check != nil {
+		grants := rbacsvc.Check(snapshot, &req.Subject, req.Groups, req.Check)
+		decision := k8s.RBACAccessDecision{Allowed: len(grants) > 0, GrantedBy: grants}
+		if allowed, err := rbacsvc.Verify(ctx, client, &req.Subject, req.Groups, req.Check); err != nil {
+			decision.VerifyError = "SubjectAccessReview不可用: " + err.Error()
+		} else {
+			decision.Verified = &allowed
+		}
+		result["decision"] = decision
+	}
+	helper.Success("success", result)
+}
@@ -0,0 +1,323 @@
+package rbac
+
+import (
+	"devops-console-backend/internal/dal/request/k8s"
+	"devops-console-backend/pkg/configs"
+	"devops-console-backend/pkg/utils"
+	"devops-console-backend/pkg/utils/logs"
+
+	"github.com/gin-gonic/gin"
+	corev1 "k8s.io/api/core/v1"
+	rbacv1 "k8s.io/api/rbac/v1"
+	apierrors "k8s.io/apimachinery/pkg/api/errors"
+	metav1 "k8s.io/apimachinery/pkg/apis/meta/v1"
+	"k8s.io/apimachinery/pkg/apis/meta/v1/unstructured"
+	"k8s.io/apimachinery/pkg/runtime"
+	"k8s.io/apimachinery/pkg/runtime/schema"
+	"k8s.io/client-go/dynamic"
+	"sigs.k8s.io/yaml"
+)
+
+// RBACController RBAC浏览与权限查询控制器
+type RBACController struct{}
+
+// NewRBACController 创建RBAC控制器实例
+func NewRBACController() *RBACController {
+	return &RBACController{}
+}
+
+// rbacKind 支持管理的RBAC对象类型
+type rbacKind struct {
+	kind       string
+	gvr        schema.GroupVersionResource
+	namespaced bool
+}
+
+// rbacKinds 路径中的kind参数到资源类型的映射
+var rbacKinds = map[string]rbacKind{
+	"role":               {"Role", rbacv1.SchemeGroupVersion.WithResource("roles"), true},
+	"clusterrole":        {"ClusterRole", rbacv1.SchemeGroupVersion.WithResource("clusterroles"), false},
+	"rolebinding":        {"RoleBinding", rbacv1.SchemeGroupVersion.WithResource("rolebindings"), true},
+	"clusterrolebinding": {"ClusterRoleBinding", rbacv1.SchemeGroupVersion.WithResource("clusterrolebindings"), false},
+	"serviceaccount":     {"ServiceAccount", corev1.SchemeGroupVersion.WithResource("serviceaccounts"), true},
+}
+
+// requireAdmin RBAC对象决定集群的访问权限，只允许管理员修改
+func requireAdmin(ctx *gin.Context, helper *utils.ResponseHelper) bool {
+	if !utils.IsAdminFromContext(ctx) {
+		helper.Forbidden("只有管理员可以修改RBAC对象")
+		return false
+	}
+	return true
+}
+
+// resolveKind 解析kind参数并获取动态客户端，失败时已写入响应
+func resolveKind(ctx *gin.Context, helper *utils.ResponseHelper) (rbacKind, dynamic.Interface, bool) {
+	kind, ok := rbacKinds[ctx.Param("kind")]
+	if !ok {
+		helper.BadRequest("不支持的RBAC类型: " + ctx.Param("kind"))
+		return kind, nil, false
+	}
+	client, exists := configs.GetDynamicClient(utils.GetInstanceIDFromContext(ctx))
+	if !exists {
+		helper.InternalError("K8s动态客户端未初始化")
+		return kind, nil, false
+	}
+	return kind, client, true
+}
+
+// resource 返回资源接口，集群级对象忽略命名空间
+func (k rbacKind) resource(client dynamic.Interface, namespace string) dynamic.ResourceInterface {
+	if !k.namespaced || namespace == "" {
+		return client.Resource(k.gvr)
+	}
+	return client.Resource(k.gvr).Namespace(namespace)
+}
+
+// toListItem 转换列表项，按类型提取摘要字段
+func (k rbacKind) toListItem(obj *unstructured.Unstructured) k8s.RBACListItem {
+	item := k8s.RBACListItem{
+		Kind:      k.kind,
+		Name:      obj.GetName(),
+		Namespace: obj.GetNamespace(),
+		Age:       obj.GetCreationTimestamp().Unix(),
+	}
+	converter := runtime.DefaultUnstructuredConverter
+	switch k.kind {
+	case "Role":
+		var role rbacv1.Role
+		if converter.FromUnstructured(obj.Object, &role) == nil {
+			item.RuleCount = len(role.Rules)
+		}
+	case "ClusterRole":
+		var role rbacv1.ClusterRole
+		if converter.FromUnstructured(obj.Object, &role) == nil {
+			item.RuleCount = len(role.Rules)
+			item.Aggregated = role.AggregationRule != nil
+		}
+	case "RoleBinding":
+		var binding rbacv1.RoleBinding
+		if converter.FromUnstructured(obj.Object, &binding) == nil {
+			item.RoleRef = binding.RoleRef.Kind + "/" + binding.RoleRef.Name
+			item.Subjects = formatSubjects(binding.Subjects)
+		}
+	case "ClusterRoleBinding":
+		var binding rbacv1.ClusterRoleBinding
+		if converter.FromUnstructured(obj.Object, &binding) == nil {
+			item.RoleRef = binding.RoleRef.Kind + "/" + binding.RoleRef.Name
+			item.Subjects = formatSubjects(binding.Subjects)
+		}
+	case "ServiceAccount":
+		var sa corev1.ServiceAccount
+		if converter.FromUnstructured(obj.Object, &sa) == nil {
+			item.Secrets = len(sa.Secrets)
+		}
+	}
+	return item
+}
+
+// formatSubjects 格式化绑定主体
+func formatSubjects(subjects []rbacv1.Subject) []string {
+	result := make([]string, 0, len(subjects))
+	for _, subject := range subjects {
+		if subject.Namespace != "" {
+			result = append(result, subject.Kind+":"+subject.Namespace+"/"+subject.Name)
+		} else {
+			result = append(result, subject.Kind+":"+subject.Name)
+		}
+	}
+	return result
+}
+
+// parseObject 解析YAML并校验类型，命名空间级对象使用请求中的命名空间
+func (k rbacKind) parseObject(req *k8s.RBACWriteRequest) (*unstructured.Unstructured, string) {
+	obj := &unstructured.Unstructured{}
+	if err := yaml.Unmarshal([]byte(req.YAML), &obj.Object); err != nil {
+		return nil, "YAML解析失败: " + err.Error()
+	}
+	if obj.Object == nil {
+		return nil, "YAML内容为空"
+	}
+	if obj.GetKind() != k.kind {
+		return nil, "YAML中的kind应为" + k.kind
+	}
+	if obj.GetAPIVersion() != k.gvr.GroupVersion().String() {
+		return nil, "YAML中的apiVersion应为" + k.gvr.GroupVersion().String()
+	}
+	if obj.GetName() == "" {
+		return nil, "YAML中缺少metadata.name"
+	}
+	if k.namespaced {
+		if req.Namespace == "" {
+			return nil, k.kind + "需要指定命名空间"
+		}
+		obj.SetNamespace(req.Namespace)
+	} else {
+		obj.SetNamespace("")
+	}
+	return obj, ""
+}
+
+// GetRBACList 获取RBAC对象列表，namespace参数为空时返回全部命名空间
+func (c *RBACController) GetRBACList(ctx *gin.Context) {
+	helper := utils.NewResponseHelper(ctx)
+	kind, client, ok := resolveKind(ctx, helper)
+	if !ok {
+		return
+	}
+
+	list, err := kind.resource(client, ctx.Query("namespace")).List(ctx, metav1.ListOptions{})
+	if err != nil {
+		helper.InternalError("获取" + kind.kind + "列表失败: " + err.Error())
+		return
+	}
+	items := make([]k8s.RBACListItem, 0, len(list.Items))
+	for i := range list.Items {
+		items = append(items, kind.toListItem(&list.Items[i]))
+	}
+	helper.SuccessWithData("success", "list", items)
+}
+
+// GetRBACDetail 获取RBAC对象详情
+func (c *RBACController) GetRBACDetail(ctx *gin.Context) {
+	helper := utils.NewResponseHelper(ctx)
+	kind, client, ok := resolveKind(ctx, helper)
+	if !ok {
+		return
+	}
+
+	obj, err := kind.resource(client, ctx.Query("namespace")).Get(ctx, ctx.Param("name"), metav1.GetOptions{})
+	if err != nil {
+		if apierrors.IsNotFound(err) {
+			helper.NotFound(kind.kind + " 不存在")
+			return
+		}
+		helper.InternalError("获取" + kind.kind + "失败: " + err.Error())
+		return
+	}
+	unstructured.RemoveNestedField(obj.Object, "metadata", "managedFields")
+	content, err := yaml.Marshal(obj.Object)
+	if err != nil {
+		helper.InternalError("序列化" + kind.kind + "失败: " + err.Error())
+		return
+	}
+	helper.Success("success", map[string]interface{}{
+		"detail": obj.Object,
+		"yaml":   string(content),
+	})
+}
+
+// CreateRBAC 通过YAML创建RBAC对象
+func (c *RBACController) CreateRBAC(ctx *gin.Context) {
+	helper := utils.NewResponseHelper(ctx)
+	if !requireAdmin(ctx, helper) {
+		return
+	}
+	kind, client, ok := resolveKind(ctx, helper)
+	if !ok {
+		return
+	}
+	var req k8s.RBACWriteRequest
+	if err := ctx.ShouldBindJSON(&req); err != nil {
+		helper.BadRequest("请求参数错误: " + err.Error())
+		return
+	}
+	obj, msg := kind.parseObject(&req)
+	if obj == nil {
+		helper.BadRequest(msg)
+		return
+	}
+
+	if _, err := kind.resource(client, obj.GetNamespace()).Create(ctx, obj, metav1.CreateOptions{}); err != nil {
+		helper.InternalError("创建" + kind.kind + "失败: " + err.Error())
+		return
+	}
+	logs.Info(map[string]interface{}{
+		"kind":      kind.kind,
+		"namespace": obj.GetNamespace(),
+		"name":      obj.GetName(),
+		"operator":  utils.GetUserNameFromContext(ctx),
+	}, "创建RBAC对象")
+	helper.Success(kind.kind + "创建成功")
+}
+
+// UpdateRBAC 通过YAML更新RBAC对象，YAML未带resourceVersion时使用当前版本
+func (c *RBACController) UpdateRBAC(ctx *gin.Context) {
+	helper := utils.NewResponseHelper(ctx)
+	if !requireAdmin(ctx, helper) {
+		return
+	}
+	kind, client, ok := resolveKind(ctx, helper)
+	if !ok {
+		return
+	}
+	var req k8s.RBACWriteRequest
+	if err := ctx.ShouldBindJSON(&req); err != nil {
+		helper.BadRequest("请求参数错误: " + err.Error())
+		return
+	}
+	obj, msg := kind.parseObject(&req)
+	if obj == nil {
+		helper.BadRequest(msg)
+		return
+	}
+	if obj.GetName() != ctx.Param("name") {
+		helper.BadRequest("YAML中的名称与路径不一致")
+		return
+	}
+
+	resource := kind.resource(client, obj.GetNamespace())
+	if obj.GetResourceVersion() == "" {
+		existing, err := resource.Get(ctx, obj.GetName(), metav1.GetOptions{})
+		if err != nil {
+			if apierrors.IsNotFound(err) {
+				helper.NotFound(kind.kind + " 不存在")
+				return
+			}
+			helper.InternalError("获取" + kind.kind + "失败: " + err.Error())
+			return
+		}
+		obj.SetResourceVersion(existing.GetResourceVersion())
+	}
+	if _, err := resource.Update(ctx, obj, metav1.UpdateOptions{}); err != nil {
+		helper.InternalError("更新" + kind.kind + "失败: " + err.Error())
+		return
+	}
+	logs.Info(map[string]interface{}{
+		"kind":      kind.kind,
+		"namespace": obj.GetNamespace(),
+		"name":      obj.GetName(),
+		"operator":  utils.GetUserNameFromContext(ctx),
+	}, "更新RBAC对象")
+	helper.Success(kind.kind + "更新成功")
+}
+
+// DeleteRBAC 删除RBAC对象
+func (c *RBACController) DeleteRBAC(ctx *gin.Context) {
+	helper := utils.NewResponseHelper(ctx)
+	if !requireAdmin(ctx, helper) {
+		return
+	}
+	kind, client, ok := resolveKind(ctx, helper)
+	if !ok {
+		return
+	}
+	namespace := ctx.Query("namespace")
+	if kind.namespaced && namespace == "" {
+		helper.BadRequest(kind.kind + "需要指定命名空间")
+		return
+	}
+
+	name := ctx.Param("name")
+	if err := kind.resource(client, namespace).Delete(ctx, name, metav1.DeleteOptions{}); err != nil {
+		helper.InternalError("删除" + kind.kind + "失败: " + err.Error())
+		return
+	}
+	logs.Info(map[string]interface{}{
+		"kind":      kind.kind,
+		"namespace": namespace,
+		"name":      name,
+		"operator":  utils.GetUserNameFromContext(ctx),
+	}, "删除RBAC对象")
+	helper.Success(kind.kind + "删除成功")
+}
@@ -0,0 +1,90 @@
+package relation
+
+import (
+	"devops-console-backend/internal/services/relation"
+	"devops-console-backend/internal/services/workload"
+	"devops-console-backend/pkg/configs"
+	"devops-console-backend/pkg/utils"
+	"errors"
+
+	"github.com/gin-gonic/gin"
+	"k8s.io/client-go/dynamic"
+)
+
+// RelationController 资源关联关系控制器
+type RelationController struct{}
+
+// NewRelationController 创建资源关联关系控制器实例
+func NewRelationController() *RelationController {
+	return &RelationController{}
+}
+
+// referenceKinds 路径参数与被引用对象类型的对应关系
+var referenceKinds = map[string]string{
+	"configmap":      workload.RefKindConfigMap,
+	"secret":         workload.RefKindSecret,
+	"pvc":            workload.RefKindPersistentVolumeClaim,
+	"serviceaccount": workload.RefKindServiceAccount,
+}
+
+// GetReferences 查找引用指定ConfigMap、Secret、PVC或ServiceAccount的工作负载，用于评估变更和删除的影响范围
+func (c *RelationController) GetReferences(ctx *gin.Context) {
+	helper := utils.NewResponseHelper(ctx)
+	kind, ok := referenceKinds[ctx.Param("kind")]
+	if !ok {
+		helper.BadRequest("不支持的类型: " + ctx.Param("kind"))
+		return
+	}
+
+	client, exists := configs.GetK8sClient(utils.GetInstanceIDFromContext(ctx))
+	if !exists {
+		helper.InternalError("K8s客户端未初始化")
+		return
+	}
+
+	refs, err := workload.FindReferences(ctx, client, ctx.Param("namespace"), kind, ctx.Param("name"))
+	if err != nil {
+		helper.InternalError(err.Error())
+		return
+	}
+
+	helper.Success("success", map[string]interface{}{
+		"kind":       kind,
+		"references": refs,
+		"total":      len(refs),
+	})
+}
+
+// GetGraph 获取以工作负载、Service或Ingress为起点的资源关系图，节点带健康状态
+func (c *RelationController) GetGraph(ctx *gin.Context) {
+	helper := utils.NewResponseHelper(ctx)
+	kind, ok := relation.RootKinds[ctx.Param("kind")]
+	if !ok {
+		helper.BadRequest("不支持的类型: " + ctx.Param("kind"))
+		return
+	}
+
+	instanceID := utils.GetInstanceIDFromContext(ctx)
+	client, exists := configs.GetK8sClient(instanceID)
+	if !exists {
+		helper.InternalError("K8s客户端未初始化")
+		return
+	}
+	// 动态客户端仅用于读取VPA，未初始化时忽略
+	var dyn dynamic.Interface
+	if dynamicClient, exists := configs.GetDynamicClient(instanceID); exists {
+		dyn = dynamicClient
+	}
+
+	graph, err := relation.Build(ctx, client, dyn, kind, ctx.Param("namespace"), ctx.Param("name"))
+	if err != nil {
+		if errors.Is(err, relation.ErrRootNotFound) {
+			helper.NotFound(kind + " 不存在")
+			return
+		}
+		helper.InternalError("构建关系图失败: " + err.Error())
+		return
+	}
+
+	helper.SuccessWithData("success", "graph", graph)
+}
@@ -0,0 +1,180 @@
+package scan
+
+import (
+	"devops-console-backend/internal/dal"
+	"devops-console-backend/internal/dal/request/k8s"
+	"devops-console-backend/internal/services/scanner"
+	"devops-console-backend/pkg/configs"
+	"devops-console-backend/pkg/utils"
+	"errors"
+	"net/http"
+	"strconv"
+	"time"
+
+	"github.com/gin-gonic/gin"
+	"gorm.io/gorm"
+)
+
+// ScanController 最佳实践与安全扫描控制器
+type ScanController struct{}
+
+// NewScanController 创建扫描控制器实例
+func NewScanController() *ScanController {
+	return &ScanController{}
+}
+
+// getRunID 解析路径中的扫描记录ID，失败时已写入响应
+func getRunID(ctx *gin.Context, helper *utils.ResponseHelper) (uint, bool) {
+	id, err := strconv.ParseUint(ctx.Param("id"), 10, 32)
+	if err != nil {
+		helper.BadRequest("无效的扫描记录ID")
+		return 0, false
+	}
+	return uint(id), true
+}
+
+// TriggerScan 手动触发集群扫描（管理员），扫描在后台执行
+func (c *ScanController) TriggerScan(ctx *gin.Context) {
+	helper := utils.NewResponseHelper(ctx)
+	claims := utils.GetUserInfoFromContext(ctx)
+	if claims == nil || !configs.IsAdminUser(claims.GetUserName(), claims.GetRoles()) {
+		helper.Forbidden("只有管理员可以触发集群扫描")
+		return
+	}
+
+	run, err := scanner.NewRunnerFromConfig().Trigger(utils.GetInstanceIDFromContext(ctx), claims.GetUserName())
+	if err != nil {
+		if errors.Is(err, scanner.ErrScanRunning) {
+			helper.Error(http.StatusConflict, err.Error())
+			return
+		}
+		helper.InternalError("触发扫描失败: " + err.Error())
+		return
+	}
+
+	helper.SuccessWithData("扫描已开始", "run", run)
+}
+
+// GetRunList 分页获取扫描记录
+func (c *ScanController) GetRunList(ctx *gin.Context) {
+	helper := utils.NewResponseHelper(ctx)
+
+	var req k8s.ScanRunListRequest
+	if err := ctx.ShouldBindQuery(&req); err != nil {
+		helper.ValidationError(err.Error())
+		return
+	}
+
+	runs, total, err := configs.NewK8sScanRepository().GetRunsWithPagination(req.InstanceID, (req.Page-1)*req.PageSize, req.PageSize)
+	if err != nil {
+		helper.DatabaseError("获取扫描记录失败")
+		return
+	}
+
+	helper.SuccessWithData("success", "data", gin.H{
+		"runList":  runs,
+		"total":    total,
+		"page":     req.Page,
+		"pageSize": req.PageSize,
+	})
+}
+
+// GetRunDetail 获取扫描记录及各命名空间得分
+func (c *ScanController) GetRunDetail(ctx *gin.Context) {
+	helper := utils.NewResponseHelper(ctx)
+	id, ok := getRunID(ctx, helper)
+	if !ok {
+		return
+	}
+
+	repo := configs.NewK8sScanRepository()
+	run, err := repo.GetRun(id)
+	if err != nil {
+		if errors.Is(err, gorm.ErrRecordNotFound) {
+			helper.NotFound("扫描记录不存在")
+			return
+		}
+		helper.DatabaseError("获取扫描记录失败")
+		return
+	}
+	scores, err := repo.GetScores(id)
+	if err != nil {
+		helper.DatabaseError("获取命名空间得分失败")
+		return
+	}
+
+	helper.Success("success", map[string]interface{}{
+		"run":    run,
+		"scores": scores,
+	})
+}
+
+// GetFindingList 分页获取扫描发现的问题
+func (c *ScanController) GetFindingList(ctx *gin.Context) {
+	helper := utils.NewResponseHelper(ctx)
+	id, ok := getRunID(ctx, helper)
+	if !ok {
+		return
+	}
+
+	var req k8s.ScanFindingListRequest
+	if err := ctx.ShouldBindQuery(&req); err != nil {
+		helper.ValidationError(err.Error())
+		return
+	}
+
+	filter := &dal.K8sScanFindingFilter{
+		RunID:     id,
+		Namespace: req.Namespace,
+		Rule:      req.Rule,
+		Severity:  req.Severity,
+	}
+	findings, total, err := configs.NewK8sScanRepository().GetFindingsWithPagination(filter, (req.Page-1)*req.PageSize, req.PageSize)
+	if err != nil {
+		helper.DatabaseError("获取扫描问题失败")
+		return
+	}
+
+	helper.SuccessWithData("success", "data", gin.H{
+		"findingList": findings,
+		"total":       total,
+		"page":        req.Page,
+		"pageSize":    req.PageSize,
+	})
+}
+
+// GetTrend 获取集群或命名空间的得分趋势
+func (c *ScanController) GetTrend(ctx *gin.Context) {
+	helper := utils.NewResponseHelper(ctx)
+
+	var req k8s.ScanTrendRequest
+	if err := ctx.ShouldBindQuery(&req); err != nil {
+		helper.ValidationError(err.Error())
+		return
+	}
+	if req.Days == 0 {
+		req.Days = 30
+	}
+
+	since := time.Now().AddDate(0, 0, -req.Days)
+	points, err := configs.NewK8sScanRepository().GetTrend(utils.GetInstanceIDFromContext(ctx), req.Namespace, since)
+	if err != nil {
+		helper.DatabaseError("获取得分趋势失败")
+		return
+	}
+
+	helper.SuccessWithData("success", "trend", points)
+}
+
+// GetRuleList 获取已注册的扫描规则
+func (c *ScanController) GetRuleList(ctx *gin.Context) {
+	helper := utils.NewResponseHelper(ctx)
+
+	rules := scanner.Rules()
+	items := make([]k8s.ScanRuleItem, 0, len(rules))
+	for _, rule := range rules {
+		items = append(items, k8s.ScanRuleItem{ID: rule.ID(), Description: rule.Description()})
+	}
+
+	helper.SuccessWithData("success", "ruleList", items)
+}
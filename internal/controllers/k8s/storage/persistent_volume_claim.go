@@ -1,7 +1,9 @@
 package storage
 
 import (
+	"devops-console-backend/internal/controllers/common"
 	"devops-console-backend/internal/dal/request/k8s"
+	"devops-console-backend/internal/services/workload"
 	"devops-console-backend/pkg/configs"
 	"devops-console-backend/pkg/utils"
 	"strconv"
@@ -170,15 +172,19 @@ func (c *PersistentVolumeClaimController) DeletePersistentVolumeClaim(ctx *gin.C
 		return
 	}
 
+	helper := utils.NewResponseHelper(ctx)
+	refs, ok := common.CheckReferences(ctx, helper, client, workload.RefKindPersistentVolumeClaim, namespace, pvcName)
+	if !ok {
+		return
+	}
+
 	err := client.CoreV1().PersistentVolumeClaims(namespace).Delete(ctx, pvcName, metav1.DeleteOptions{})
 	if err != nil {
-		helper := utils.NewResponseHelper(ctx)
 		helper.InternalError("删除PVC失败: " + err.Error())
 		return
 	}
 
-	helper := utils.NewResponseHelper(ctx)
-	helper.Success("PVC删除成功")
+	helper.Success("PVC删除成功", map[string]interface{}{"references": refs})
 }
 
 func (c *PersistentVolumeClaimController) parseYAMLToPVC(yamlContent string) (*corev1.PersistentVolumeClaim, error) {
@@ -0,0 +1,169 @@
+package terminal
+
+import (
+	"devops-console-backend/internal/dal"
+	"devops-console-backend/internal/dal/request/k8s"
+	"devops-console-backend/internal/services/terminal"
+	"devops-console-backend/pkg/configs"
+	"devops-console-backend/pkg/utils"
+	"os"
+
+	"github.com/gin-gonic/gin"
+)
+
+// TerminalController 容器终端会话控制器
+type TerminalController struct{}
+
+// NewTerminalController 创建终端会话控制器实例
+func NewTerminalController() *TerminalController {
+	return &TerminalController{}
+}
+
+// GetSessionList 分页获取终端会话记录，非管理员只能看到自己的会话
+func (c *TerminalController) GetSessionList(ctx *gin.Context) {
+	helper := utils.NewResponseHelper(ctx)
+
+	var req k8s.SessionListRequest
+	if err := ctx.ShouldBindQuery(&req); err != nil {
+		helper.ValidationError(err.Error())
+		return
+	}
+
+	var userID int64
+	if !utils.IsAdminFromContext(ctx) {
+		userID = utils.GetUserIdFromContext(ctx)
+	}
+	sessions, total, err := configs.NewK8sExecSessionRepository().GetWithPagination(userID, (req.Page-1)*req.PageSize, req.PageSize)
+	if err != nil {
+		helper.DatabaseError("获取终端会话记录失败")
+		return
+	}
+
+	helper.SuccessWithData("success", "data", gin.H{
+		"sessionList": sessions,
+		"total":       total,
+		"page":        req.Page,
+		"pageSize":    req.PageSize,
+	})
+}
+
+// GetSessionRecording 获取会话录像（asciicast v2格式），可直接用于asciinema-player回放
+func (c *TerminalController) GetSessionRecording(ctx *gin.Context) {
+	helper := utils.NewResponseHelper(ctx)
+	sessionID := ctx.Param("sessionId")
+
+	session, err := configs.NewK8sExecSessionRepository().GetBySessionID(sessionID)
+	if err != nil {
+		helper.NotFound("会话不存在")
+		return
+	}
+	if !utils.IsAdminFromContext(ctx) && session.UserID != utils.GetUserIdFromContext(ctx) {
+		helper.Forbidden("无权查看该会话录像")
+		return
+	}
+	if session.RecordPath == "" {
+		helper.NotFound("该会话没有录像")
+		return
+	}
+	if _, err := os.Stat(session.RecordPath); err != nil {
+		helper.NotFound("录像文件不存在")
+		return
+	}
+
+	ctx.Header("Content-Type", "application/x-asciicast")
+	ctx.Header("Content-Disposition", "inline; filename="+sessionID+".cast")
+	ctx.File(session.RecordPath)
+}
+
+// GetLiveSessionList 获取进行中的终端会话（管理员）
+func (c *TerminalController) GetLiveSessionList(ctx *gin.Context) {
+	helper := utils.NewResponseHelper(ctx)
+	if !utils.IsAdminFromContext(ctx) {
+		helper.Forbidden("仅管理员可查看进行中的会话")
+		return
+	}
+
+	helper.SuccessWithData("success", "sessionList", terminal.DefaultRegistry.List())
+}
+
+// KillSession 强制结束终端会话（管理员）
+func (c *TerminalController) KillSession(ctx *gin.Context) {
+	helper := utils.NewResponseHelper(ctx)
+	if !utils.IsAdminFromContext(ctx) {
+		helper.Forbidden("仅管理员可终止会话")
+		return
+	}
+
+	if !terminal.DefaultRegistry.Kill(ctx.Param("sessionId"), utils.GetUserNameFromContext(ctx)) {
+		helper.NotFound("会话不存在或已结束")
+		return
+	}
+
+	helper.Success("会话已终止")
+}
+
+// GetPermissionList 获取命名空间操作授权列表（管理员）
+func (c *TerminalController) GetPermissionList(ctx *gin.Context) {
+	helper := utils.NewResponseHelper(ctx)
+	if !utils.IsAdminFromContext(ctx) {
+		helper.Forbidden("仅管理员可管理授权")
+		return
+	}
+
+	var userID int64
+	utils.GetParam(ctx, "user_id", &userID, nil)
+	permissions, err := configs.NewK8sNamespacePermissionRepository().List(userID)
+	if err != nil {
+		helper.DatabaseError("获取授权列表失败")
+		return
+	}
+
+	helper.SuccessWithData("success", "permissionList", permissions)
+}
+
+// CreatePermission 创建命名空间操作授权（管理员）
+func (c *TerminalController) CreatePermission(ctx *gin.Context) {
+	helper := utils.NewResponseHelper(ctx)
+	if !utils.IsAdminFromContext(ctx) {
+		helper.Forbidden("仅管理员可管理授权")
+		return
+	}
+
+	var req k8s.NamespacePermissionCreateRequest
+	if err := ctx.ShouldBindJSON(&req); err != nil {
+		helper.ValidationError(err.Error())
+		return
+	}
+
+	permission := dal.K8sNamespacePermission{
+		UserID:     req.UserID,
+		InstanceID: req.InstanceID,
+		Namespace:  req.Namespace,
+		Action:     req.Action,
+		CreatedBy:  utils.GetUserNameFromContext(ctx),
+	}
+	if err := configs.NewK8sNamespacePermissionRepository().Create(&permission); err != nil {
+		helper.DatabaseError("创建授权失败")
+		return
+	}
+
+	helper.SuccessWithData("创建成功", "permission", permission)
+}
+
+// DeletePermission 删除命名空间操作授权（管理员）
+func (c *TerminalController) DeletePermission(ctx *gin.Context) {
+	helper := utils.NewResponseHelper(ctx)
+	if !utils.IsAdminFromContext(ctx) {
+		helper.Forbidden("仅管理员可管理授权")
+		return
+	}
+
+	var id uint
+	utils.GetParam(ctx, "id", &id, nil)
+	if err := configs.NewK8sNamespacePermissionRepository().Delete(id); err != nil {
+		helper.DatabaseError("删除授权失败")
+		return
+	}
+
+	helper.Success("删除成功")
+}
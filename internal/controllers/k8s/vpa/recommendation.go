@@ -0,0 +1,346 @@
+package vpa
+
+import (
+	"context"
+	"devops-console-backend/internal/dal/request/k8s"
+	"devops-console-backend/pkg/configs"
+	"devops-console-backend/pkg/utils"
+	"devops-console-backend/pkg/utils/logs"
+	"encoding/json"
+	"fmt"
+	"sort"
+
+	"github.com/gin-gonic/gin"
+	corev1 "k8s.io/api/core/v1"
+	"k8s.io/apimachinery/pkg/api/resource"
+	metav1 "k8s.io/apimachinery/pkg/apis/meta/v1"
+	"k8s.io/apimachinery/pkg/apis/meta/v1/unstructured"
+	"k8s.io/apimachinery/pkg/types"
+	"k8s.io/client-go/kubernetes"
+)
+
+// rightsizingResources 参与调整的资源类型
+var rightsizingResources = []corev1.ResourceName{corev1.ResourceCPU, corev1.ResourceMemory}
+
+// GetVPARecommendation 获取结构化的VPA建议，并与目标工作负载当前的资源配置对比
+func (c *VPAController) GetVPARecommendation(ctx *gin.Context) {
+	helper := utils.NewResponseHelper(ctx)
+	namespace := ctx.Param("namespace")
+	name := ctx.Param("name")
+
+	vpa, client, ok := c.loadVPA(ctx, namespace, name)
+	if !ok {
+		return
+	}
+	kind, targetName := targetRef(vpa)
+	containers, err := getTargetContainers(ctx, client, namespace, kind, targetName)
+	if err != nil {
+		helper.InternalError("获取目标工作负载失败: " + err.Error())
+		return
+	}
+
+	recommendations := parseRecommendations(vpa)
+	result := make([]k8s.VPAContainerRecommendation, 0, len(recommendations))
+	for _, container := range containers {
+		rec, found := recommendations[container.Name]
+		if !found {
+			continue
+		}
+		rec.CurrentRequests = resourceListToMap(container.Resources.Requests)
+		rec.CurrentLimits = resourceListToMap(container.Resources.Limits)
+		result = append(result, rec)
+	}
+
+	changes, _ := computeChanges(containers, recommendations, ctx.DefaultQuery("bound", "target"), nil, false)
+	helper.Success("success", map[string]interface{}{
+		"target":          gin.H{"kind": kind, "name": targetName},
+		"recommendations": result,
+		"changes":         changes,
+	})
+}
+
+// ApplyVPARecommendation 将VPA建议写入目标工作负载的requests，DryRun时只返回差异
+func (c *VPAController) ApplyVPARecommendation(ctx *gin.Context) {
+	helper := utils.NewResponseHelper(ctx)
+	namespace := ctx.Param("namespace")
+	name := ctx.Param("name")
+
+	var req k8s.VPAApplyRequest
+	if err := ctx.ShouldBindJSON(&req); err != nil {
+		helper.ValidationError(err.Error())
+		return
+	}
+	if req.Bound == "" {
+		req.Bound = "target"
+	}
+
+	vpa, client, ok := c.loadVPA(ctx, namespace, name)
+	if !ok {
+		return
+	}
+	kind, targetName := targetRef(vpa)
+	containers, err := getTargetContainers(ctx, client, namespace, kind, targetName)
+	if err != nil {
+		helper.InternalError("获取目标工作负载失败: " + err.Error())
+		return
+	}
+
+	var selected map[string]bool
+	if len(req.Containers) > 0 {
+		selected = make(map[string]bool, len(req.Containers))
+		for _, container := range req.Containers {
+			selected[container] = true
+		}
+	}
+	changes, resources := computeChanges(containers, parseRecommendations(vpa), req.Bound, selected, req.ScaleLimits)
+	if len(changes) == 0 {
+		helper.BadRequest("没有可应用的建议，VPA可能尚未产生建议或当前配置已一致")
+		return
+	}
+	if req.DryRun {
+		helper.SuccessWithData("预览", "changes", changes)
+		return
+	}
+
+	if err := patchContainerResources(ctx, client, namespace, kind, targetName, resources); err != nil {
+		helper.InternalError("应用建议失败: " + err.Error())
+		return
+	}
+
+	logs.Info(map[string]interface{}{
+		"namespace": namespace,
+		"vpa":       name,
+		"target":    kind + "/" + targetName,
+		"bound":     req.Bound,
+		"changes":   len(changes),
+		"user":      utils.GetUserNameFromContext(ctx),
+	}, "应用VPA建议")
+	helper.SuccessWithData("已应用", "changes", changes)
+}
+
+// loadVPA 获取VPA对象和K8s客户端，失败时已写入响应
+func (c *VPAController) loadVPA(ctx *gin.Context, namespace, name string) (*unstructured.Unstructured, kubernetes.Interface, bool) {
+	helper := utils.NewResponseHelper(ctx)
+	instanceID := utils.GetInstanceIDFromContext(ctx)
+	dynamicClient, exists := configs.GetDynamicClient(instanceID)
+	if !exists {
+		helper.InternalError("K8s动态客户端未初始化")
+		return nil, nil, false
+	}
+	client, exists := configs.GetK8sClient(instanceID)
+	if !exists {
+		helper.InternalError("K8s客户端未初始化")
+		return nil, nil, false
+	}
+
+	vpa, err := dynamicClient.Resource(vpaGVR).Namespace(namespace).Get(ctx, name, metav1.GetOptions{})
+	if err != nil {
+		helper.NotFound("VPA不存在: " + err.Error())
+		return nil, nil, false
+	}
+	return vpa, client, true
+}
+
+// targetRef 读取VPA的目标工作负载
+func targetRef(vpa *unstructured.Unstructured) (string, string) {
+	kind, _, _ := unstructured.NestedString(vpa.Object, "spec", "targetRef", "kind")
+	name, _, _ := unstructured.NestedString(vpa.Object, "spec", "targetRef", "name")
+	return kind, name
+}
+
+// parseRecommendations 解析status.recommendation.containerRecommendations
+func parseRecommendations(vpa *unstructured.Unstructured) map[string]k8s.VPAContainerRecommendation {
+	result := make(map[string]k8s.VPAContainerRecommendation)
+	items, _, _ := unstructured.NestedSlice(vpa.Object, "status", "recommendation", "containerRecommendations")
+	for _, item := range items {
+		m, ok := item.(map[string]interface{})
+		if !ok {
+			continue
+		}
+		name, _, _ := unstructured.NestedString(m, "containerName")
+		target, _, _ := unstructured.NestedStringMap(m, "target")
+		lower, _, _ := unstructured.NestedStringMap(m, "lowerBound")
+		upper, _, _ := unstructured.NestedStringMap(m, "upperBound")
+		uncapped, _, _ := unstructured.NestedStringMap(m, "uncappedTarget")
+		result[name] = k8s.VPAContainerRecommendation{
+			ContainerName:  name,
+			Target:         target,
+			LowerBound:     lower,
+			UpperBound:     upper,
+			UncappedTarget: uncapped,
+		}
+	}
+	return result
+}
+
+// recommendationBound 按名称取建议值
+func recommendationBound(rec k8s.VPAContainerRecommendation, bound string) map[string]string {
+	switch bound {
+	case "lowerBound":
+		return rec.LowerBound
+	case "upperBound":
+		return rec.UpperBound
+	case "uncappedTarget":
+		return rec.UncappedTarget
+	default:
+		return rec.Target
+	}
+}
+
+// computeChanges 计算应用建议后各容器的资源变化和新的资源配置，selected为空时处理全部容器
+func computeChanges(containers []corev1.Container, recommendations map[string]k8s.VPAContainerRecommendation,
+	bound string, selected map[string]bool, scaleLimits bool) ([]k8s.VPAResourceChange, map[string]corev1.ResourceRequirements) {
+	changes := make([]k8s.VPAResourceChange, 0)
+	resources := make(map[string]corev1.ResourceRequirements)
+
+	for _, container := range containers {
+		if selected != nil && !selected[container.Name] {
+			continue
+		}
+		rec, found := recommendations[container.Name]
+		if !found {
+			continue
+		}
+		values := recommendationBound(rec, bound)
+
+		requirements := *container.Resources.DeepCopy()
+		changed := false
+		for _, name := range rightsizingResources {
+			value, found := values[string(name)]
+			if !found {
+				continue
+			}
+			newRequest, err := resource.ParseQuantity(value)
+			if err != nil {
+				continue
+			}
+			oldRequest, hasRequest := requirements.Requests[name]
+			if hasRequest && oldRequest.Cmp(newRequest) == 0 {
+				continue
+			}
+
+			if requirements.Requests == nil {
+				requirements.Requests = corev1.ResourceList{}
+			}
+			requirements.Requests[name] = newRequest
+			changes = append(changes, k8s.VPAResourceChange{
+				Container: container.Name,
+				Field:     "requests",
+				Resource:  string(name),
+				Current:   quantityString(oldRequest, hasRequest),
+				New:       newRequest.String(),
+			})
+			changed = true
+
+			oldLimit, hasLimit := requirements.Limits[name]
+			if !hasLimit {
+				continue
+			}
+			newLimit := oldLimit
+			if scaleLimits && hasRequest && !oldRequest.IsZero() {
+				ratio := float64(oldLimit.MilliValue()) / float64(oldRequest.MilliValue())
+				newLimit = *resource.NewMilliQuantity(int64(ratio*float64(newRequest.MilliValue())), oldLimit.Format)
+			}
+			// limits不能小于requests
+			if newLimit.Cmp(newRequest) < 0 {
+				newLimit = newRequest
+			}
+			if newLimit.Cmp(oldLimit) != 0 {
+				requirements.Limits[name] = newLimit
+				changes = append(changes, k8s.VPAResourceChange{
+					Container: container.Name,
+					Field:     "limits",
+					Resource:  string(name),
+					Current:   oldLimit.String(),
+					New:       newLimit.String(),
+				})
+			}
+		}
+		if changed {
+			resources[container.Name] = requirements
+		}
+	}
+	return changes, resources
+}
+
+// getTargetContainers 获取目标工作负载Pod模板中的容器
+func getTargetContainers(ctx context.Context, client kubernetes.Interface, namespace, kind, name string) ([]corev1.Container, error) {
+	switch kind {
+	case "Deployment":
+		obj, err := client.AppsV1().Deployments(namespace).Get(ctx, name, metav1.GetOptions{})
+		if err != nil {
+			return nil, err
+		}
+		return obj.Spec.Template.Spec.Containers, nil
+	case "StatefulSet":
+		obj, err := client.AppsV1().StatefulSets(namespace).Get(ctx, name, metav1.GetOptions{})
+		if err != nil {
+			return nil, err
+		}
+		return obj.Spec.Template.Spec.Containers, nil
+	case "DaemonSet":
+		obj, err := client.AppsV1().DaemonSets(namespace).Get(ctx, name, metav1.GetOptions{})
+		if err != nil {
+			return nil, err
+		}
+		return obj.Spec.Template.Spec.Containers, nil
+	}
+	return nil, fmt.Errorf("不支持的目标类型 %s", kind)
+}
+
+// patchContainerResources 以策略合并补丁按容器名更新资源配置
+func patchContainerResources(ctx context.Context, client kubernetes.Interface, namespace, kind, name string,
+	resources map[string]corev1.ResourceRequirements) error {
+	names := make([]string, 0, len(resources))
+	for container := range resources {
+		names = append(names, container)
+	}
+	sort.Strings(names)
+
+	containers := make([]map[string]interface{}, 0, len(names))
+	for _, container := range names {
+		containers = append(containers, map[string]interface{}{
+			"name":      container,
+			"resources": resources[container],
+		})
+	}
+	payload, err := json.Marshal(map[string]interface{}{
+		"spec": map[string]interface{}{
+			"template": map[string]interface{}{
+				"spec": map[string]interface{}{"containers": containers},
+			},
+		},
+	})
+	if err != nil {
+		return err
+	}
+
+	switch kind {
+	case "Deployment":
+		_, err = client.AppsV1().Deployments(namespace).Patch(ctx, name, types.StrategicMergePatchType, payload, metav1.PatchOptions{})
+	case "StatefulSet":
+		_, err = client.AppsV1().StatefulSets(namespace).Patch(ctx, name, types.StrategicMergePatchType, payload, metav1.PatchOptions{})
+	case "DaemonSet":
+		_, err = client.AppsV1().DaemonSets(namespace).Patch(ctx, name, types.StrategicMergePatchType, payload, metav1.PatchOptions{})
+	default:
+		err = fmt.Errorf("不支持的目标类型 %s", kind)
+	}
+	return err
+}
+
+// resourceListToMap 将ResourceList转换为字符串map
+func resourceListToMap(list corev1.ResourceList) map[string]string {
+	result := make(map[string]string, len(list))
+	for name, quantity := range list {
+		result[string(name)] = quantity.String()
+	}
+	return result
+}
+
+// quantityString 格式化可能不存在的资源值
+func quantityString(quantity resource.Quantity, exists bool) string {
+	if !exists {
+		return ""
+	}
+	return quantity.String()
+}
@@ -1,13 +1,19 @@
 package vpa
 
 import (
+	"devops-console-backend/internal/dal/request/k8s"
 	"devops-console-backend/pkg/configs"
 	"devops-console-backend/pkg/utils"
+	"devops-console-backend/pkg/utils/logs"
+	"fmt"
 	"strconv"
 
 	"github.com/gin-gonic/gin"
+	"k8s.io/apimachinery/pkg/api/resource"
 	metav1 "k8s.io/apimachinery/pkg/apis/meta/v1"
+	"k8s.io/apimachinery/pkg/apis/meta/v1/unstructured"
 	"k8s.io/apimachinery/pkg/runtime/schema"
+	"k8s.io/client-go/dynamic"
 )
 
 type VPAController struct{}
@@ -119,3 +125,131 @@ func (c *VPAController) DeleteVPA(ctx *gin.Context) {
 	helper := utils.NewResponseHelper(ctx)
 	helper.Success("VPA删除成功")
 }
+
+// CreateVPA 创建VPA，默认Off模式只产生建议
+func (c *VPAController) CreateVPA(ctx *gin.Context) {
+	helper := utils.NewResponseHelper(ctx)
+
+	var req k8s.VPARequest
+	if err := ctx.ShouldBindJSON(&req); err != nil {
+		helper.ValidationError(err.Error())
+		return
+	}
+	dynamicClient, spec, ok := c.validateRequest(ctx, &req)
+	if !ok {
+		return
+	}
+
+	vpa := &unstructured.Unstructured{Object: map[string]interface{}{
+		"apiVersion": vpaGVR.GroupVersion().String(),
+		"kind":       "VerticalPodAutoscaler",
+		"metadata": map[string]interface{}{
+			"name":      req.Name,
+			"namespace": req.Namespace,
+		},
+		"spec": spec,
+	}}
+	if _, err := dynamicClient.Resource(vpaGVR).Namespace(req.Namespace).Create(ctx, vpa, metav1.CreateOptions{}); err != nil {
+		helper.InternalError("创建VPA失败(请确认VPA是否已安装): " + err.Error())
+		return
+	}
+
+	logs.Info(map[string]interface{}{"namespace": req.Namespace, "name": req.Name, "target": req.TargetRef.Name}, "创建VPA成功")
+	helper.Success("VPA创建成功")
+}
+
+// UpdateVPA 更新VPA，整体替换spec
+func (c *VPAController) UpdateVPA(ctx *gin.Context) {
+	helper := utils.NewResponseHelper(ctx)
+
+	var req k8s.VPARequest
+	if err := ctx.ShouldBindJSON(&req); err != nil {
+		helper.ValidationError(err.Error())
+		return
+	}
+	dynamicClient, spec, ok := c.validateRequest(ctx, &req)
+	if !ok {
+		return
+	}
+
+	vpa, err := dynamicClient.Resource(vpaGVR).Namespace(req.Namespace).Get(ctx, req.Name, metav1.GetOptions{})
+	if err != nil {
+		helper.NotFound("VPA不存在")
+		return
+	}
+	vpa.Object["spec"] = spec
+	if _, err := dynamicClient.Resource(vpaGVR).Namespace(req.Namespace).Update(ctx, vpa, metav1.UpdateOptions{}); err != nil {
+		helper.InternalError("更新VPA失败: " + err.Error())
+		return
+	}
+
+	logs.Info(map[string]interface{}{"namespace": req.Namespace, "name": req.Name, "target": req.TargetRef.Name}, "更新VPA成功")
+	helper.Success("VPA更新成功")
+}
+
+// validateRequest 校验目标工作负载和资源值并构造VPA spec，失败时已写入响应
+func (c *VPAController) validateRequest(ctx *gin.Context, req *k8s.VPARequest) (dynamic.Interface, map[string]interface{}, bool) {
+	helper := utils.NewResponseHelper(ctx)
+	instanceID := utils.GetInstanceIDFromContext(ctx)
+	dynamicClient, exists := configs.GetDynamicClient(instanceID)
+	if !exists {
+		helper.InternalError("K8s动态客户端未初始化")
+		return nil, nil, false
+	}
+	client, exists := configs.GetK8sClient(instanceID)
+	if !exists {
+		helper.InternalError("K8s客户端未初始化")
+		return nil, nil, false
+	}
+	if _, err := getTargetContainers(ctx, client, req.Namespace, req.TargetRef.Kind, req.TargetRef.Name); err != nil {
+		helper.BadRequest(fmt.Sprintf("目标 %s/%s 不存在: %s", req.TargetRef.Kind, req.TargetRef.Name, err.Error()))
+		return nil, nil, false
+	}
+
+	updateMode := req.UpdateMode
+	if updateMode == "" {
+		updateMode = "Off"
+	}
+	spec := map[string]interface{}{
+		"targetRef": map[string]interface{}{
+			"apiVersion": "apps/v1",
+			"kind":       req.TargetRef.Kind,
+			"name":       req.TargetRef.Name,
+		},
+		"updatePolicy": map[string]interface{}{"updateMode": updateMode},
+	}
+
+	policies := make([]interface{}, 0, len(req.ContainerPolicies))
+	for _, policy := range req.ContainerPolicies {
+		item := map[string]interface{}{"containerName": policy.ContainerName}
+		if policy.Mode != "" {
+			item["mode"] = policy.Mode
+		}
+		for field, values := range map[string]map[string]string{"minAllowed": policy.MinAllowed, "maxAllowed": policy.MaxAllowed} {
+			if len(values) == 0 {
+				continue
+			}
+			list := make(map[string]interface{}, len(values))
+			for name, value := range values {
+				if _, err := resource.ParseQuantity(value); err != nil {
+					helper.BadRequest(fmt.Sprintf("容器 %s 的%s.%s无效: %s", policy.ContainerName, field, name, value))
+					return nil, nil, false
+				}
+				list[name] = value
+			}
+			item[field] = list
+		}
+		if len(policy.ControlledResources) > 0 {
+			resources := make([]interface{}, 0, len(policy.ControlledResources))
+			for _, name := range policy.ControlledResources {
+				resources = append(resources, name)
+			}
+			item["controlledResources"] = resources
+		}
+		policies = append(policies, item)
+	}
+	if len(policies) > 0 {
+		spec["resourcePolicy"] = map[string]interface{}{"containerPolicies": policies}
+	}
+	return dynamicClient, spec, true
+}
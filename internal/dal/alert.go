@@ -0,0 +1,116 @@
+package dal
+
+import (
+	"time"
+)
+
+// 告警规则条件类型常量
+const (
+	AlertRuleTypeNodeNotReady         = "node_not_ready"
+	AlertRuleTypePodCrashLoop         = "pod_crashloop"
+	AlertRuleTypeK8sWarningEvent      = "k8s_warning_event"
+	AlertRuleTypeEsClusterHealth      = "es_cluster_health"
+	AlertRuleTypePipelineRunFailed    = "pipeline_run_failed"
+	AlertRuleTypeConnectionTestFailed = "connection_test_failed"
+)
+
+// 告警通知渠道类型常量
+const (
+	AlertChannelTypeWebhook  = "webhook"
+	AlertChannelTypeEmail    = "email"
+	AlertChannelTypeDingTalk = "dingtalk"
+	AlertChannelTypeWeCom    = "wecom"
+	AlertChannelTypeSlack    = "slack"
+)
+
+// 告警级别常量
+const (
+	AlertSeverityCritical = "critical"
+	AlertSeverityWarning  = "warning"
+	AlertSeverityInfo     = "info"
+)
+
+// 告警记录状态常量
+const (
+	AlertStatusFiring   = "firing"
+	AlertStatusResolved = "resolved"
+)
+
+// AlertRule 告警规则模型
+type AlertRule struct {
+	ID             uint      `gorm:"primaryKey;autoIncrement;column:id" json:"id"`
+	Name           string    `gorm:"not null;column:name;size:255" json:"name"`
+	Type           string    `gorm:"not null;index;column:type;size:64" json:"type"`            // 条件类型，见 AlertRuleType* 常量
+	InstanceID     uint      `gorm:"index;column:instance_id" json:"instance_id"`               // 作用的实例，0表示所有适用实例
+	Params         string    `gorm:"type:text;column:params" json:"params"`                     // 条件参数，JSON格式
+	Severity       string    `gorm:"column:severity;size:32;default:'warning'" json:"severity"` // 告警级别
+	ChannelIDs     string    `gorm:"column:channel_ids;size:500" json:"channel_ids"`            // 通知渠道ID列表，逗号分隔
+	RepeatInterval int       `gorm:"column:repeat_interval" json:"repeat_interval"`             // 重复通知间隔（分钟），0表示只通知一次
+	SendResolved   bool      `gorm:"column:send_resolved" json:"send_resolved"`                 // 恢复时是否通知
+	Enabled        bool      `gorm:"column:enabled" json:"enabled"`
+	Description    string    `gorm:"column:description;size:500" json:"description"`
+	CreatedAt      time.Time `gorm:"column:created_at" json:"created_at"`
+	UpdatedAt      time.Time `gorm:"column:updated_at" json:"updated_at"`
+}
+
+// TableName 指定表名
+func (AlertRule) TableName() string {
+	return "alert_rule"
+}
+
+// AlertChannel 告警通知渠道模型
+type AlertChannel struct {
+	ID        uint      `gorm:"primaryKey;autoIncrement;column:id" json:"id"`
+	Name      string    `gorm:"uniqueIndex;not null;column:name;size:255" json:"name"`
+	Type      string    `gorm:"not null;column:type;size:32" json:"type"` // 渠道类型，见 AlertChannelType* 常量
+	Config    string    `gorm:"type:text;column:config" json:"config"`    // 渠道配置，JSON格式
+	Enabled   bool      `gorm:"column:enabled" json:"enabled"`
+	CreatedAt time.Time `gorm:"column:created_at" json:"created_at"`
+	UpdatedAt time.Time `gorm:"column:updated_at" json:"updated_at"`
+}
+
+// TableName 指定表名
+func (AlertChannel) TableName() string {
+	return "alert_channel"
+}
+
+// AlertSilence 告警静默模型
+type AlertSilence struct {
+	ID         uint      `gorm:"primaryKey;autoIncrement;column:id" json:"id"`
+	RuleID     uint      `gorm:"index;column:rule_id" json:"rule_id"`         // 0表示匹配所有规则
+	InstanceID uint      `gorm:"index;column:instance_id" json:"instance_id"` // 0表示匹配所有实例
+	Target     string    `gorm:"column:target;size:500" json:"target"`        // 告警对象包含该字符串时匹配，为空表示全部
+	Comment    string    `gorm:"column:comment;size:500" json:"comment"`
+	CreatedBy  string    `gorm:"column:created_by;size:64" json:"created_by"`
+	StartsAt   time.Time `gorm:"index;column:starts_at" json:"starts_at"`
+	EndsAt     time.Time `gorm:"index;column:ends_at" json:"ends_at"`
+	CreatedAt  time.Time `gorm:"column:created_at" json:"created_at"`
+}
+
+// TableName 指定表名
+func (AlertSilence) TableName() string {
+	return "alert_silence"
+}
+
+// AlertEvent 告警记录模型，同一规则同一指纹在未恢复前只保留一条记录
+type AlertEvent struct {
+	ID             uint       `gorm:"primaryKey;autoIncrement;column:id" json:"id"`
+	RuleID         uint       `gorm:"index;not null;column:rule_id" json:"rule_id"`
+	RuleName       string     `gorm:"column:rule_name;size:255" json:"rule_name"`
+	Severity       string     `gorm:"column:severity;size:32" json:"severity"`
+	Fingerprint    string     `gorm:"index;not null;column:fingerprint;size:255" json:"fingerprint"`
+	InstanceID     uint       `gorm:"index;column:instance_id" json:"instance_id"`
+	Target         string     `gorm:"column:target;size:500" json:"target"`
+	Summary        string     `gorm:"type:text;column:summary" json:"summary"`
+	Status         string     `gorm:"index;column:status;size:32" json:"status"`
+	Silenced       bool       `gorm:"column:silenced" json:"silenced"`
+	FirstSeenAt    time.Time  `gorm:"column:first_seen_at" json:"first_seen_at"`
+	LastSeenAt     time.Time  `gorm:"column:last_seen_at" json:"last_seen_at"`
+	LastNotifiedAt *time.Time `gorm:"column:last_notified_at" json:"last_notified_at"`
+	ResolvedAt     *time.Time `gorm:"column:resolved_at" json:"resolved_at"`
+}
+
+// TableName 指定表名
+func (AlertEvent) TableName() string {
+	return "alert_event"
+}
@@ -0,0 +1,31 @@
+package dal
+
+import (
+	"time"
+)
+
+// ConfigMap/Secret历史版本的产生方式
+const (
+	ConfigRevisionActionUpdate   = "update"   // 通过控制台更新前的内容
+	ConfigRevisionActionRollback = "rollback" // 回滚前的内容
+)
+
+// K8sConfigRevision ConfigMap/Secret历史版本，记录每次变更前的内容
+type K8sConfigRevision struct {
+	ID         uint      `gorm:"primaryKey;autoIncrement;column:id" json:"id"`
+	InstanceID uint      `gorm:"not null;uniqueIndex:uk_k8s_config_revision,priority:1;column:instance_id" json:"instance_id"`
+	Kind       string    `gorm:"not null;uniqueIndex:uk_k8s_config_revision,priority:2;column:kind;size:20" json:"kind"` // ConfigMap 或 Secret
+	Namespace  string    `gorm:"not null;uniqueIndex:uk_k8s_config_revision,priority:3;column:namespace;size:255" json:"namespace"`
+	Name       string    `gorm:"not null;uniqueIndex:uk_k8s_config_revision,priority:4;column:name;size:255" json:"name"`
+	Version    int       `gorm:"not null;uniqueIndex:uk_k8s_config_revision,priority:5;column:version" json:"version"`
+	Content    string    `gorm:"type:mediumtext;column:content" json:"-"` // JSON格式，Secret为加密后的内容
+	Encrypted  bool      `gorm:"column:encrypted" json:"encrypted"`
+	Action     string    `gorm:"column:action;size:20" json:"action"`
+	Operator   string    `gorm:"column:operator;size:191" json:"operator"`
+	CreatedAt  time.Time `gorm:"column:created_at" json:"created_at"`
+}
+
+// TableName 指定表名
+func (K8sConfigRevision) TableName() string {
+	return "k8s_config_revision"
+}
@@ -0,0 +1,53 @@
+package dal
+
+import (
+	"time"
+)
+
+// K8sEvent 归档的K8s事件模型，同一集群内按事件UID去重
+type K8sEvent struct {
+	ID              uint      `gorm:"primaryKey;autoIncrement;column:id" json:"id"`
+	InstanceID      uint      `gorm:"not null;uniqueIndex:uk_k8s_event_uid,priority:1;index:idx_k8s_event_object,priority:1;column:instance_id" json:"instance_id"`
+	UID             string    `gorm:"not null;uniqueIndex:uk_k8s_event_uid,priority:2;column:uid;size:64" json:"uid"`
+	Name            string    `gorm:"column:name;size:255" json:"name"`
+	Namespace       string    `gorm:"index:idx_k8s_event_object,priority:2;column:namespace;size:255" json:"namespace"`
+	Type            string    `gorm:"index;column:type;size:32" json:"type"` // Normal, Warning
+	Reason          string    `gorm:"index;column:reason;size:128" json:"reason"`
+	Message         string    `gorm:"type:text;column:message" json:"message"`
+	InvolvedKind    string    `gorm:"index:idx_k8s_event_object,priority:3;column:involved_kind;size:64" json:"involved_kind"`
+	InvolvedName    string    `gorm:"index:idx_k8s_event_object,priority:4;column:involved_name;size:255" json:"involved_name"`
+	InvolvedUID     string    `gorm:"column:involved_uid;size:64" json:"involved_uid"`
+	Source          string    `gorm:"column:source;size:255" json:"source"`
+	Count           int32     `gorm:"column:count" json:"count"`
+	FirstTimestamp  time.Time `gorm:"column:first_timestamp" json:"first_timestamp"`
+	LastTimestamp   time.Time `gorm:"index;column:last_timestamp" json:"last_timestamp"`
+	ResourceVersion string    `gorm:"column:resource_version;size:64" json:"resource_version"`
+	CreatedAt       time.Time `gorm:"column:created_at" json:"created_at"`
+	UpdatedAt       time.Time `gorm:"column:updated_at" json:"updated_at"`
+}
+
+// TableName 指定表名
+func (K8sEvent) TableName() string {
+	return "k8s_event"
+}
+
+// K8sEventFilter 归档事件查询条件
+type K8sEventFilter struct {
+	InstanceID   uint
+	Namespace    string
+	InvolvedKind string
+	InvolvedName string
+	Reason       string
+	Type         string
+	StartTime    *time.Time
+	EndTime      *time.Time
+}
+
+// K8sEventWarningSummary Warning事件按原因聚合结果
+type K8sEventWarningSummary struct {
+	Reason        string    `json:"reason"`
+	Count         int64     `json:"count"`         // 事件发生总次数
+	Objects       int64     `json:"objects"`       // 涉及对象数
+	LastTimestamp time.Time `json:"lastTimestamp"` // 最近一次发生时间
+	LastMessage   string    `json:"lastMessage"`   // 最近一次事件消息
+}
@@ -0,0 +1,24 @@
+package dal
+
+import (
+	"time"
+)
+
+// NamespaceTemplateLabel 记录命名空间由哪个模板创建，用于漂移检查
+const NamespaceTemplateLabel = "devops-console/namespace-template"
+
+// K8sNamespaceTemplate 命名空间开通模板
+type K8sNamespaceTemplate struct {
+	ID          uint      `gorm:"primaryKey;autoIncrement;column:id" json:"id"`
+	Name        string    `gorm:"uniqueIndex;not null;column:name;size:63" json:"name"`
+	Description string    `gorm:"column:description;size:500" json:"description"`
+	Spec        string    `gorm:"type:text;column:spec" json:"spec"` // 模板内容，JSON格式，见 k8s.NamespaceTemplateSpec
+	CreatedBy   string    `gorm:"column:created_by;size:191" json:"created_by"`
+	CreatedAt   time.Time `gorm:"column:created_at" json:"created_at"`
+	UpdatedAt   time.Time `gorm:"column:updated_at" json:"updated_at"`
+}
+
+// TableName 指定表名
+func (K8sNamespaceTemplate) TableName() string {
+	return "k8s_namespace_template"
+}
@@ -0,0 +1,32 @@
+package dal
+
+import (
+	"time"
+)
+
+// K8sPortForwardSession 端口转发会话审计记录
+type K8sPortForwardSession struct {
+	ID         uint       `gorm:"primaryKey;autoIncrement;column:id" json:"id"`
+	SessionID  string     `gorm:"uniqueIndex;not null;column:session_id;size:64" json:"session_id"`
+	UserID     int64      `gorm:"index;column:user_id" json:"user_id"`
+	Username   string     `gorm:"column:username;size:191" json:"username"`
+	InstanceID uint       `gorm:"column:instance_id" json:"instance_id"`
+	Namespace  string     `gorm:"column:namespace;size:255" json:"namespace"`
+	TargetKind string     `gorm:"column:target_kind;size:20" json:"target_kind"` // pod, service
+	TargetName string     `gorm:"column:target_name;size:255" json:"target_name"`
+	Pod        string     `gorm:"column:pod;size:255" json:"pod"`
+	Port       int32      `gorm:"column:port" json:"port"` // Pod上的目标端口
+	ClientIP   string     `gorm:"column:client_ip;size:64" json:"client_ip"`
+	Status     string     `gorm:"column:status;size:20" json:"status"`
+	BytesIn    int64      `gorm:"column:bytes_in" json:"bytes_in"`   // 客户端发往Pod的字节数
+	BytesOut   int64      `gorm:"column:bytes_out" json:"bytes_out"` // Pod返回客户端的字节数
+	KilledBy   string     `gorm:"column:killed_by;size:191" json:"killed_by"`
+	StartedAt  time.Time  `gorm:"index;column:started_at" json:"started_at"`
+	ExpiresAt  time.Time  `gorm:"column:expires_at" json:"expires_at"`
+	EndedAt    *time.Time `gorm:"column:ended_at" json:"ended_at"`
+}
+
+// TableName 指定表名
+func (K8sPortForwardSession) TableName() string {
+	return "k8s_port_forward_session"
+}
@@ -0,0 +1,86 @@
+package dal
+
+import (
+	"time"
+)
+
+// 扫描任务状态
+const (
+	ScanStatusRunning = "running"
+	ScanStatusSuccess = "success"
+	ScanStatusFailed  = "failed"
+)
+
+// K8sScanRun 集群最佳实践与安全扫描记录
+type K8sScanRun struct {
+	ID         uint       `gorm:"primaryKey;autoIncrement;column:id" json:"id"`
+	InstanceID uint       `gorm:"not null;index:idx_k8s_scan_run_instance;column:instance_id" json:"instance_id"`
+	Status     string     `gorm:"column:status;size:20" json:"status"`
+	Score      int        `gorm:"column:score" json:"score"` // 各命名空间得分的平均值，0-100
+	Critical   int        `gorm:"column:critical" json:"critical"`
+	Warning    int        `gorm:"column:warning" json:"warning"`
+	Info       int        `gorm:"column:info" json:"info"`
+	Error      string     `gorm:"column:error;type:text" json:"error"`
+	Operator   string     `gorm:"column:operator;size:191" json:"operator"` // 定时扫描为空
+	StartedAt  time.Time  `gorm:"index:idx_k8s_scan_run_instance;column:started_at" json:"started_at"`
+	FinishedAt *time.Time `gorm:"column:finished_at" json:"finished_at"`
+}
+
+// TableName 指定表名
+func (K8sScanRun) TableName() string {
+	return "k8s_scan_run"
+}
+
+// K8sScanNamespaceScore 单次扫描中各命名空间的得分，用于趋势分析
+type K8sScanNamespaceScore struct {
+	ID         uint      `gorm:"primaryKey;autoIncrement;column:id" json:"id"`
+	RunID      uint      `gorm:"not null;index;column:run_id" json:"run_id"`
+	InstanceID uint      `gorm:"not null;index:idx_k8s_scan_score_ns;column:instance_id" json:"instance_id"`
+	Namespace  string    `gorm:"not null;index:idx_k8s_scan_score_ns;column:namespace;size:255" json:"namespace"`
+	Score      int       `gorm:"column:score" json:"score"`
+	Critical   int       `gorm:"column:critical" json:"critical"`
+	Warning    int       `gorm:"column:warning" json:"warning"`
+	Info       int       `gorm:"column:info" json:"info"`
+	CreatedAt  time.Time `gorm:"column:created_at" json:"created_at"`
+}
+
+// TableName 指定表名
+func (K8sScanNamespaceScore) TableName() string {
+	return "k8s_scan_namespace_score"
+}
+
+// K8sScanFinding 扫描发现的问题
+type K8sScanFinding struct {
+	ID         uint      `gorm:"primaryKey;autoIncrement;column:id" json:"id"`
+	RunID      uint      `gorm:"not null;index;column:run_id" json:"run_id"`
+	InstanceID uint      `gorm:"not null;column:instance_id" json:"instance_id"`
+	Namespace  string    `gorm:"column:namespace;size:255" json:"namespace"`
+	Rule       string    `gorm:"column:rule;size:64" json:"rule"`
+	Severity   string    `gorm:"column:severity;size:20" json:"severity"`
+	Kind       string    `gorm:"column:kind;size:64" json:"kind"`
+	Name       string    `gorm:"column:name;size:255" json:"name"`
+	Message    string    `gorm:"column:message;type:text" json:"message"`
+	CreatedAt  time.Time `gorm:"column:created_at" json:"created_at"`
+}
+
+// TableName 指定表名
+func (K8sScanFinding) TableName() string {
+	return "k8s_scan_finding"
+}
+
+// K8sScanFindingFilter 扫描问题查询条件
+type K8sScanFindingFilter struct {
+	RunID     uint
+	Namespace string
+	Rule      string
+	Severity  string
+}
+
+// K8sScanTrendPoint 命名空间得分趋势中的一个点
+type K8sScanTrendPoint struct {
+	RunID     uint      `json:"run_id"`
+	StartedAt time.Time `json:"started_at"`
+	Score     int       `json:"score"`
+	Critical  int       `json:"critical"`
+	Warning   int       `json:"warning"`
+}
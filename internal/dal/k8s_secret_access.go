@@ -0,0 +1,23 @@
+package dal
+
+import (
+	"time"
+)
+
+// K8sSecretAccessLog Secret明文查看审计记录
+type K8sSecretAccessLog struct {
+	ID         uint      `gorm:"primaryKey;autoIncrement;column:id" json:"id"`
+	UserID     int64     `gorm:"index;column:user_id" json:"user_id"`
+	Username   string    `gorm:"column:username;size:191" json:"username"`
+	InstanceID uint      `gorm:"column:instance_id" json:"instance_id"`
+	Namespace  string    `gorm:"column:namespace;size:255" json:"namespace"`
+	Name       string    `gorm:"column:name;size:255" json:"name"`
+	Keys       string    `gorm:"column:keys;size:1024" json:"keys"` // 查看的键，逗号分隔
+	ClientIP   string    `gorm:"column:client_ip;size:64" json:"client_ip"`
+	CreatedAt  time.Time `gorm:"index;column:created_at" json:"created_at"`
+}
+
+// TableName 指定表名
+func (K8sSecretAccessLog) TableName() string {
+	return "k8s_secret_access_log"
+}
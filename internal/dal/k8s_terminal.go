@@ -0,0 +1,62 @@
+package dal
+
+import (
+	"time"
+)
+
+// 终端和端口转发会话状态
+const (
+	ExecSessionStatusActive  = "active"  // 进行中
+	ExecSessionStatusClosed  = "closed"  // 正常结束
+	ExecSessionStatusKilled  = "killed"  // 被管理员终止
+	ExecSessionStatusExpired = "expired" // 超过有效期自动关闭
+)
+
+// 命名空间操作权限
+const (
+	K8sActionExec        = "exec"         // 进入容器终端
+	K8sActionPortForward = "port-forward" // 端口转发
+	K8sActionSecretView  = "secret-view"  // 查看Secret明文
+	K8sActionWatch       = "watch"        // 通过WebSocket监听资源变更
+	K8sActionLogs        = "logs"         // 通过WebSocket聚合查看多个Pod日志
+)
+
+// K8sExecSession 容器终端会话审计记录
+type K8sExecSession struct {
+	ID         uint       `gorm:"primaryKey;autoIncrement;column:id" json:"id"`
+	SessionID  string     `gorm:"uniqueIndex;not null;column:session_id;size:64" json:"session_id"`
+	UserID     int64      `gorm:"index;column:user_id" json:"user_id"`
+	Username   string     `gorm:"column:username;size:191" json:"username"`
+	InstanceID uint       `gorm:"column:instance_id" json:"instance_id"`
+	Namespace  string     `gorm:"column:namespace;size:255" json:"namespace"`
+	Pod        string     `gorm:"column:pod;size:255" json:"pod"`
+	Container  string     `gorm:"column:container;size:255" json:"container"`
+	Command    string     `gorm:"column:command;size:255" json:"command"`
+	ClientIP   string     `gorm:"column:client_ip;size:64" json:"client_ip"`
+	Status     string     `gorm:"column:status;size:20" json:"status"`
+	RecordPath string     `gorm:"column:record_path;size:512" json:"-"`
+	KilledBy   string     `gorm:"column:killed_by;size:191" json:"killed_by"`
+	StartedAt  time.Time  `gorm:"index;column:started_at" json:"started_at"`
+	EndedAt    *time.Time `gorm:"column:ended_at" json:"ended_at"`
+}
+
+// TableName 指定表名
+func (K8sExecSession) TableName() string {
+	return "k8s_exec_session"
+}
+
+// K8sNamespacePermission 用户在集群命名空间上的操作授权，Namespace为*表示全部命名空间
+type K8sNamespacePermission struct {
+	ID         uint      `gorm:"primaryKey;autoIncrement;column:id" json:"id"`
+	UserID     int64     `gorm:"not null;uniqueIndex:uk_k8s_ns_permission,priority:1;column:user_id" json:"user_id"`
+	InstanceID uint      `gorm:"not null;uniqueIndex:uk_k8s_ns_permission,priority:2;column:instance_id" json:"instance_id"`
+	Namespace  string    `gorm:"not null;uniqueIndex:uk_k8s_ns_permission,priority:3;column:namespace;size:255" json:"namespace"`
+	Action     string    `gorm:"not null;uniqueIndex:uk_k8s_ns_permission,priority:4;column:action;size:32" json:"action"`
+	CreatedBy  string    `gorm:"column:created_by;size:191" json:"created_by"`
+	CreatedAt  time.Time `gorm:"column:created_at" json:"created_at"`
+}
+
+// TableName 指定表名
+func (K8sNamespacePermission) TableName() string {
+	return "k8s_namespace_permission"
+}
@@ -0,0 +1,101 @@
+package alert
+
+// AlertRule Request/Response Types
+
+// RuleCreateRequest 创建告警规则请求
+type RuleCreateRequest struct {
+	Name           string `json:"name" binding:"required"`
+	Type           string `json:"type" binding:"required"`
+	InstanceID     uint   `json:"instance_id"`
+	Params         string `json:"params"`
+	Severity       string `json:"severity" binding:"omitempty,oneof=critical warning info"`
+	ChannelIDs     []uint `json:"channel_ids"`
+	RepeatInterval *int   `json:"repeat_interval" binding:"omitempty,min=0"` // 未指定时为60分钟，0表示只通知一次
+	SendResolved   *bool  `json:"send_resolved"`
+	Enabled        *bool  `json:"enabled"`
+	Description    string `json:"description"`
+}
+
+// RuleUpdateRequest 更新告警规则请求
+type RuleUpdateRequest struct {
+	Name           string  `json:"name"`
+	Type           string  `json:"type"`
+	InstanceID     *uint   `json:"instance_id"`
+	Params         *string `json:"params"`
+	Severity       string  `json:"severity" binding:"omitempty,oneof=critical warning info"`
+	ChannelIDs     []uint  `json:"channel_ids"`
+	RepeatInterval *int    `json:"repeat_interval" binding:"omitempty,min=0"`
+	SendResolved   *bool   `json:"send_resolved"`
+	Enabled        *bool   `json:"enabled"`
+	Description    *string `json:"description"`
+}
+
+// RuleListItem 告警规则列表项
+type RuleListItem struct {
+	ID             uint   `json:"id"`
+	Name           string `json:"name"`
+	Type           string `json:"type"`
+	InstanceID     uint   `json:"instance_id"`
+	Params         string `json:"params"`
+	Severity       string `json:"severity"`
+	ChannelIDs     []uint `json:"channel_ids"`
+	RepeatInterval int    `json:"repeat_interval"`
+	SendResolved   bool   `json:"send_resolved"`
+	Enabled        bool   `json:"enabled"`
+	Description    string `json:"description"`
+	CreatedAt      int64  `json:"created_at"`
+	UpdatedAt      int64  `json:"updated_at"`
+}
+
+// AlertChannel Request/Response Types
+
+// ChannelCreateRequest 创建通知渠道请求
+type ChannelCreateRequest struct {
+	Name    string `json:"name" binding:"required"`
+	Type    string `json:"type" binding:"required,oneof=webhook email dingtalk wecom slack"`
+	Config  string `json:"config" binding:"required"`
+	Enabled *bool  `json:"enabled"`
+}
+
+// ChannelUpdateRequest 更新通知渠道请求
+type ChannelUpdateRequest struct {
+	Name    string  `json:"name"`
+	Type    string  `json:"type" binding:"omitempty,oneof=webhook email dingtalk wecom slack"`
+	Config  *string `json:"config"` // 包含掩码******的敏感字段保留原值
+	Enabled *bool   `json:"enabled"`
+}
+
+// ChannelListItem 通知渠道列表项
+type ChannelListItem struct {
+	ID        uint   `json:"id"`
+	Name      string `json:"name"`
+	Type      string `json:"type"`
+	Config    string `json:"config"` // 敏感字段已隐藏
+	Enabled   bool   `json:"enabled"`
+	CreatedAt int64  `json:"created_at"`
+	UpdatedAt int64  `json:"updated_at"`
+}
+
+// AlertSilence Request/Response Types
+
+// SilenceCreateRequest 创建静默请求
+type SilenceCreateRequest struct {
+	RuleID     uint   `json:"rule_id"`
+	InstanceID uint   `json:"instance_id"`
+	Target     string `json:"target"`
+	Comment    string `json:"comment"`
+	StartsAt   int64  `json:"starts_at"`                  // 开始时间（Unix秒），为空表示立即生效
+	EndsAt     int64  `json:"ends_at" binding:"required"` // 结束时间（Unix秒）
+}
+
+// AlertEvent Request/Response Types
+
+// EventListRequest 告警记录列表请求
+type EventListRequest struct {
+	RuleID     uint   `form:"rule_id"`
+	InstanceID uint   `form:"instance_id"`
+	Status     string `form:"status" binding:"omitempty,oneof=firing resolved"`
+	Severity   string `form:"severity"`
+	Page       int    `form:"page" binding:"required,min=1"`
+	PageSize   int    `form:"page_size" binding:"required,min=1,max=100"`
+}
@@ -0,0 +1,35 @@
+package k8s
+
+import "time"
+
+// ConfigRevisionItem ConfigMap/Secret历史版本，每个版本为对应变更前的内容
+type ConfigRevisionItem struct {
+	Version   int       `json:"version"`
+	Action    string    `json:"action"`
+	Operator  string    `json:"operator"`
+	Encrypted bool      `json:"encrypted"`
+	Keys      []string  `json:"keys"`
+	CreatedAt time.Time `json:"createdAt"`
+}
+
+// ConfigDiffItem 两个版本之间单个键的差异，Secret只给出键级别的变化
+type ConfigDiffItem struct {
+	Key    string `json:"key"`
+	Status string `json:"status"`         // added、removed、changed
+	Diff   string `json:"diff,omitempty"` // ConfigMap文本内容的unified diff
+}
+
+// ConfigRollbackRequest 回滚到历史版本请求
+type ConfigRollbackRequest struct {
+	Version          int  `json:"version" binding:"required,min=1"`
+	RestartWorkloads bool `json:"restartWorkloads"` // 回滚后滚动重启引用该对象的工作负载
+}
+
+// ConfigRestartResult 工作负载重启结果
+type ConfigRestartResult struct {
+	Kind      string   `json:"kind"`
+	Namespace string   `json:"namespace"`
+	Name      string   `json:"name"`
+	Via       []string `json:"via"`
+	Error     string   `json:"error,omitempty"`
+}
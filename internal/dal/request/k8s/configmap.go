@@ -18,6 +18,7 @@ type ConfigMapCreateRequest struct {
 
 // ConfigMapUpdateRequest 更新ConfigMap请求
 type ConfigMapUpdateRequest struct {
-	Data map[string]string `json:"data"`
-	YAML string            `json:"yaml"`
+	Data             map[string]string `json:"data"`
+	YAML             string            `json:"yaml"`
+	RestartWorkloads bool              `json:"restartWorkloads"` // 更新后滚动重启引用该ConfigMap的工作负载
 }
@@ -0,0 +1,14 @@
+package k8s
+
+// CustomResourceUpdateRequest 以YAML更新自定义资源请求
+type CustomResourceUpdateRequest struct {
+	YAML string `json:"yaml" binding:"required"`
+}
+
+// CustomResourceColumn 自定义资源列表列定义，来自CRD的additionalPrinterColumns
+type CustomResourceColumn struct {
+	Name        string `json:"name"`
+	Type        string `json:"type"` // string、integer、number、boolean、date
+	Description string `json:"description"`
+	Priority    int32  `json:"priority"` // 大于0的列对应kubectl -o wide
+}
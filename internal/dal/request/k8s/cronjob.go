@@ -2,20 +2,25 @@ package k8s
 
 // CronJobCreateRequest 创建CronJob请求
 type CronJobCreateRequest struct {
-	Name          string   `json:"name" binding:"required"`
-	Namespace     string   `json:"namespace" binding:"required"`
-	ContainerName string   `json:"containername" binding:"required"`
-	Image         string   `json:"image" binding:"required"`
-	Command       []string `json:"command"`
-	Schedule      string   `json:"schedule" binding:"required"`
+	Name              string   `json:"name" binding:"required"`
+	Namespace         string   `json:"namespace" binding:"required"`
+	ContainerName     string   `json:"containername" binding:"required"`
+	Image             string   `json:"image" binding:"required"`
+	Command           []string `json:"command"`
+	Schedule          string   `json:"schedule" binding:"required"`
+	TimeZone          string   `json:"timeZone"`                                                         // IANA时区，如Asia/Shanghai
+	ConcurrencyPolicy string   `json:"concurrencyPolicy" binding:"omitempty,oneof=Allow Forbid Replace"` // 并发策略
+	Suspend           bool     `json:"suspend"`
 }
 
 // CronJobUpdateRequest 更新CronJob请求
 type CronJobUpdateRequest struct {
-	Name      string  `json:"name" binding:"required"`
-	Namespace string  `json:"namespace" binding:"required"`
-	Schedule  *string `json:"schedule"`
-	Image     *string `json:"image"`
+	Name              string  `json:"name" binding:"required"`
+	Namespace         string  `json:"namespace" binding:"required"`
+	Schedule          *string `json:"schedule"`
+	Image             *string `json:"image"`
+	TimeZone          *string `json:"timeZone"`
+	ConcurrencyPolicy *string `json:"concurrencyPolicy" binding:"omitempty,oneof=Allow Forbid Replace"`
 }
 
 // CronJobDeleteRequest 删除CronJob请求
@@ -26,25 +31,49 @@ type CronJobDeleteRequest struct {
 
 // CronJobListItem CronJob列表项
 type CronJobListItem struct {
-	Name          string   `json:"name"`
-	Namespace     string   `json:"namespace"`
-	ContainerName string   `json:"containername"`
-	Image         string   `json:"image"`
-	Command       []string `json:"command"`
-	Schedule      string   `json:"schedule"`
-	Status        string   `json:"status"`
-	Age           int64    `json:"age"`
+	Name             string   `json:"name"`
+	Namespace        string   `json:"namespace"`
+	ContainerName    string   `json:"containername"`
+	Image            string   `json:"image"`
+	Command          []string `json:"command"`
+	Schedule         string   `json:"schedule"`
+	TimeZone         string   `json:"timeZone"`
+	Suspend          bool     `json:"suspend"`
+	Status           string   `json:"status"`
+	ActiveJobs       int      `json:"activeJobs"`
+	LastScheduleTime string   `json:"lastScheduleTime"`
+	NextScheduleTime string   `json:"nextScheduleTime"` // 暂停或表达式无效时为空
+	Age              int64    `json:"age"`
 }
 
 // CronJobDetail CronJob详情
 type CronJobDetail struct {
-	Name          string            `json:"name"`
-	Namespace     string            `json:"namespace"`
-	ContainerName string            `json:"containername"`
-	Image         string            `json:"image"`
-	Command       []string          `json:"command"`
-	Schedule      string            `json:"schedule"`
-	Status        string            `json:"status"`
-	Age           int64             `json:"age"`
-	Labels        map[string]string `json:"labels"`
+	Name                       string            `json:"name"`
+	Namespace                  string            `json:"namespace"`
+	ContainerName              string            `json:"containername"`
+	Image                      string            `json:"image"`
+	Command                    []string          `json:"command"`
+	Schedule                   string            `json:"schedule"`
+	TimeZone                   string            `json:"timeZone"`
+	Suspend                    bool              `json:"suspend"`
+	ConcurrencyPolicy          string            `json:"concurrencyPolicy"`
+	StartingDeadlineSeconds    *int64            `json:"startingDeadlineSeconds"`
+	SuccessfulJobsHistoryLimit *int32            `json:"successfulJobsHistoryLimit"`
+	FailedJobsHistoryLimit     *int32            `json:"failedJobsHistoryLimit"`
+	Status                     string            `json:"status"`
+	ActiveJobs                 []string          `json:"activeJobs"`
+	LastScheduleTime           string            `json:"lastScheduleTime"`
+	LastSuccessfulTime         string            `json:"lastSuccessfulTime"`
+	NextScheduleTimes          []string          `json:"nextScheduleTimes"`
+	ScheduleError              string            `json:"scheduleError,omitempty"`
+	APIVersion                 string            `json:"apiVersion"` // 集群实际使用的API版本
+	Age                        int64             `json:"age"`
+	Labels                     map[string]string `json:"labels"`
+}
+
+// CronJobNextScheduleRequest 预览cron表达式调度时间请求
+type CronJobNextScheduleRequest struct {
+	Schedule string `form:"schedule" binding:"required"`
+	TimeZone string `form:"time_zone"`
+	Count    int    `form:"count" binding:"omitempty,min=1,max=50"`
 }
@@ -38,4 +38,6 @@ type DeploymentDetail struct {
 	Conditions []interface{}     `json:"conditions"`
 	Labels     map[string]string `json:"labels"`
 	Age        int64             `json:"age"`
+	// WarningEvents 最近的Warning事件聚合
+	WarningEvents interface{} `json:"warningEvents"`
 }
@@ -0,0 +1,20 @@
+package k8s
+
+// DiagnosisFinding 诊断发现的问题
+type DiagnosisFinding struct {
+	Rule       string   `json:"rule"`
+	Severity   string   `json:"severity"` // critical、warning、info
+	Message    string   `json:"message"`
+	Suggestion string   `json:"suggestion"`
+	Objects    []string `json:"objects"` // 涉及的对象，如 Pod/web-5d9c7-abcde
+}
+
+// DiagnosisResult Pod或工作负载的诊断结果
+type DiagnosisResult struct {
+	Kind      string             `json:"kind"`
+	Namespace string             `json:"namespace"`
+	Name      string             `json:"name"`
+	Healthy   bool               `json:"healthy"` // 没有critical和warning级别的问题
+	Pods      int                `json:"pods"`    // 参与诊断的Pod数
+	Findings  []DiagnosisFinding `json:"findings"`
+}
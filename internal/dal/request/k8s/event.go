@@ -14,3 +14,26 @@ type EventListItem struct {
 	FirstTimestamp int64  `json:"firstTimestamp"`
 	LastTimestamp  int64  `json:"lastTimestamp"`
 }
+
+// EventHistoryRequest 归档Event查询请求
+type EventHistoryRequest struct {
+	InstanceID uint   `form:"instance_id"`
+	Namespace  string `form:"namespace"`
+	Kind       string `form:"kind"`
+	Name       string `form:"name"`
+	Reason     string `form:"reason"`
+	Type       string `form:"type" binding:"omitempty,oneof=Normal Warning"`
+	StartTime  int64  `form:"start_time"` // 开始时间（Unix秒）
+	EndTime    int64  `form:"end_time"`   // 结束时间（Unix秒）
+	Page       int    `form:"page" binding:"required,min=1"`
+	PageSize   int    `form:"page_size" binding:"required,min=1,max=500"`
+}
+
+// EventWarningRequest Warning事件聚合请求
+type EventWarningRequest struct {
+	InstanceID uint   `form:"instance_id"`
+	Namespace  string `form:"namespace" binding:"required"`
+	Kind       string `form:"kind" binding:"required"`
+	Name       string `form:"name" binding:"required"`
+	Hours      int    `form:"hours" binding:"omitempty,min=1,max=720"` // 统计最近多少小时，默认24
+}
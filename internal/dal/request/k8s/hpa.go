@@ -0,0 +1,65 @@
+package k8s
+
+// HPAScaleTargetRef 扩缩容目标
+type HPAScaleTargetRef struct {
+	Kind       string `json:"kind" binding:"required"`
+	Name       string `json:"name" binding:"required"`
+	APIVersion string `json:"apiVersion"` // 为空时按Kind补全，如Deployment为apps/v1
+}
+
+// HPAMetricTarget 指标目标值
+type HPAMetricTarget struct {
+	Type               string `json:"type" binding:"required,oneof=Utilization AverageValue Value"`
+	AverageUtilization *int32 `json:"averageUtilization"` // Utilization时使用，百分比
+	AverageValue       string `json:"averageValue"`       // AverageValue时使用，如500m、1Gi
+	Value              string `json:"value"`              // Value时使用
+}
+
+// HPAObjectReference Object类型指标描述的对象
+type HPAObjectReference struct {
+	Kind       string `json:"kind" binding:"required"`
+	Name       string `json:"name" binding:"required"`
+	APIVersion string `json:"apiVersion"`
+}
+
+// HPAMetric 扩缩容指标
+type HPAMetric struct {
+	Type           string              `json:"type" binding:"required,oneof=Resource ContainerResource Pods Object External"`
+	ResourceName   string              `json:"resourceName"`   // Resource和ContainerResource使用，如cpu、memory
+	Container      string              `json:"container"`      // ContainerResource使用
+	MetricName     string              `json:"metricName"`     // Pods、Object、External使用
+	MetricSelector map[string]string   `json:"metricSelector"` // 指标标签过滤
+	Object         *HPAObjectReference `json:"object"`         // Object使用
+	Target         HPAMetricTarget     `json:"target"`
+}
+
+// HPAScalingPolicy 扩缩容速率策略
+type HPAScalingPolicy struct {
+	Type          string `json:"type" binding:"required,oneof=Pods Percent"`
+	Value         int32  `json:"value" binding:"required,min=1"`
+	PeriodSeconds int32  `json:"periodSeconds" binding:"required,min=1,max=1800"`
+}
+
+// HPAScalingRules 扩容或缩容规则
+type HPAScalingRules struct {
+	StabilizationWindowSeconds *int32             `json:"stabilizationWindowSeconds" binding:"omitempty,min=0,max=3600"`
+	SelectPolicy               string             `json:"selectPolicy" binding:"omitempty,oneof=Max Min Disabled"`
+	Policies                   []HPAScalingPolicy `json:"policies" binding:"dive"`
+}
+
+// HPABehavior 扩缩容行为
+type HPABehavior struct {
+	ScaleUp   *HPAScalingRules `json:"scaleUp"`
+	ScaleDown *HPAScalingRules `json:"scaleDown"`
+}
+
+// HPARequest 创建或更新HPA请求，更新时按name和namespace定位并整体替换spec
+type HPARequest struct {
+	Name           string            `json:"name" binding:"required"`
+	Namespace      string            `json:"namespace" binding:"required"`
+	ScaleTargetRef HPAScaleTargetRef `json:"scaleTargetRef" binding:"required"`
+	MinReplicas    *int32            `json:"minReplicas" binding:"omitempty,min=0"`
+	MaxReplicas    int32             `json:"maxReplicas" binding:"required,min=1"`
+	Metrics        []HPAMetric       `json:"metrics" binding:"dive"`
+	Behavior       *HPABehavior      `json:"behavior"`
+}
@@ -35,3 +35,48 @@ type JobDetail struct {
 	PodsStatuses   string `json:"podsStatuses"`
 	Age            int64  `json:"age"`
 }
+
+// JobPodStatus Job下Pod的状态摘要
+type JobPodStatus struct {
+	Name      string `json:"name"`
+	Phase     string `json:"phase"`
+	Node      string `json:"node"`
+	Restarts  int32  `json:"restarts"`
+	ExitCode  *int32 `json:"exitCode,omitempty"`
+	Reason    string `json:"reason,omitempty"`
+	StartTime string `json:"startTime"`
+}
+
+// JobHistoryItem Job执行记录
+type JobHistoryItem struct {
+	Name           string         `json:"name"`
+	Namespace      string         `json:"namespace"`
+	Status         string         `json:"status"`
+	Active         int32          `json:"active"`
+	Succeeded      int32          `json:"succeeded"`
+	Failed         int32          `json:"failed"`
+	Manual         bool           `json:"manual"` // 是否手动触发
+	CreatedAt      string         `json:"createdAt"`
+	StartTime      string         `json:"startTime"`
+	CompletionTime string         `json:"completionTime"`
+	Duration       int64          `json:"duration"` // 执行耗时（秒）
+	Pods           []JobPodStatus `json:"pods"`
+}
+
+// JobPodLog Job下Pod的日志
+type JobPodLog struct {
+	Pod       string `json:"pod"`
+	Container string `json:"container"`
+	Phase     string `json:"phase"`
+	Logs      string `json:"logs"`
+	Error     string `json:"error,omitempty"`
+}
+
+// JobCleanupRequest 批量清理已结束Job请求
+type JobCleanupRequest struct {
+	Namespace      string   `json:"namespace" binding:"required"`
+	OlderThanHours int      `json:"olderThanHours" binding:"required,min=1"`                 // 结束超过N小时
+	Statuses       []string `json:"statuses" binding:"omitempty,dive,oneof=Complete Failed"` // 为空时清理成功和失败的Job
+	IncludeOwned   bool     `json:"includeOwned"`                                            // 是否包含CronJob等控制器创建的Job
+	DryRun         bool     `json:"dryRun"`                                                  // 只返回将被删除的Job
+}
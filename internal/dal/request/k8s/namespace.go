@@ -21,3 +21,68 @@ type NamespaceDetail struct {
 	Annotations       map[string]string `json:"annotations,omitempty"`
 	Age               int64             `json:"age"`
 }
+
+// NamespaceLimitRange 容器默认资源和上下限，值为Kubernetes quantity格式
+type NamespaceLimitRange struct {
+	Default        map[string]string `json:"default"`        // 默认limits
+	DefaultRequest map[string]string `json:"defaultRequest"` // 默认requests
+	Max            map[string]string `json:"max"`
+	Min            map[string]string `json:"min"`
+}
+
+// NamespaceRoleBinding 将集群角色授予用户组
+type NamespaceRoleBinding struct {
+	Group       string `json:"group" binding:"required"`
+	ClusterRole string `json:"clusterRole" binding:"required"` // 如admin、edit、view
+}
+
+// NamespacePullSecret 从其他命名空间复制的镜像拉取凭证，并挂到default ServiceAccount
+type NamespacePullSecret struct {
+	Name            string `json:"name" binding:"required"`
+	SourceNamespace string `json:"sourceNamespace" binding:"required"`
+}
+
+// NamespaceTemplateSpec 命名空间模板内容
+type NamespaceTemplateSpec struct {
+	Labels           map[string]string      `json:"labels"`
+	Annotations      map[string]string      `json:"annotations"`
+	ResourceQuota    map[string]string      `json:"resourceQuota"`                                                                                                          // ResourceQuota的hard，如 {"requests.cpu":"10","pods":"50"}
+	LimitRange       *NamespaceLimitRange   `json:"limitRange"`                                                                                                             // 容器级LimitRange
+	NetworkPolicies  []string               `json:"networkPolicies" binding:"omitempty,dive,oneof=default-deny-ingress default-deny-egress allow-same-namespace allow-dns"` // 预置网络策略
+	RoleBindings     []NamespaceRoleBinding `json:"roleBindings" binding:"dive"`
+	ImagePullSecrets []NamespacePullSecret  `json:"imagePullSecrets" binding:"dive"`
+}
+
+// NamespaceTemplateRequest 创建或更新命名空间模板请求
+type NamespaceTemplateRequest struct {
+	Name        string                `json:"name" binding:"required,max=63"`
+	Description string                `json:"description"`
+	Spec        NamespaceTemplateSpec `json:"spec"`
+}
+
+// NamespaceTemplateItem 命名空间模板
+type NamespaceTemplateItem struct {
+	ID          uint                  `json:"id"`
+	Name        string                `json:"name"`
+	Description string                `json:"description"`
+	Spec        NamespaceTemplateSpec `json:"spec"`
+	CreatedBy   string                `json:"createdBy"`
+	CreatedAt   time.Time             `json:"createdAt"`
+	UpdatedAt   time.Time             `json:"updatedAt"`
+}
+
+// NamespaceProvisionRequest 按模板开通命名空间请求
+type NamespaceProvisionRequest struct {
+	Namespace  string `json:"namespace" binding:"required,max=63"`
+	TemplateID uint   `json:"templateId" binding:"required"`
+}
+
+// NamespaceDriftItem 命名空间与模板的差异
+type NamespaceDriftItem struct {
+	Kind     string `json:"kind"`
+	Name     string `json:"name"`
+	Field    string `json:"field"`
+	Status   string `json:"status"` // missing：缺失，changed：与模板不一致
+	Expected string `json:"expected"`
+	Actual   string `json:"actual"`
+}
@@ -0,0 +1,58 @@
+package k8s
+
+import networkingv1 "k8s.io/api/networking/v1"
+
+// NetworkPolicyListItem NetworkPolicy列表项
+type NetworkPolicyListItem struct {
+	Name        string            `json:"name"`
+	Namespace   string            `json:"namespace"`
+	PodSelector map[string]string `json:"podSelector"` // 仅展示matchLabels，为空表示选中全部Pod
+	PolicyTypes []string          `json:"policyTypes"`
+	Ingress     int               `json:"ingress"` // 入站规则数
+	Egress      int               `json:"egress"`  // 出站规则数
+	Age         int64             `json:"age"`
+}
+
+// NetworkPolicyCreateRequest 创建NetworkPolicy请求，spec与yaml二选一
+type NetworkPolicyCreateRequest struct {
+	Name      string                          `json:"name"`
+	Namespace string                          `json:"namespace" binding:"required"`
+	Labels    map[string]string               `json:"labels"`
+	Spec      *networkingv1.NetworkPolicySpec `json:"spec"`
+	YAML      string                          `json:"yaml"`
+}
+
+// NetworkPolicyUpdateRequest 更新NetworkPolicy请求，spec与yaml二选一
+type NetworkPolicyUpdateRequest struct {
+	Labels map[string]string               `json:"labels"`
+	Spec   *networkingv1.NetworkPolicySpec `json:"spec"`
+	YAML   string                          `json:"yaml"`
+}
+
+// NetworkPolicyAnalyzeRequest 网络连通性分析请求，未指定源Pod时按sourceLabels模拟源命名空间中的Pod
+type NetworkPolicyAnalyzeRequest struct {
+	SourceNamespace      string            `json:"sourceNamespace" binding:"required"`
+	SourcePod            string            `json:"sourcePod"`
+	SourceLabels         map[string]string `json:"sourceLabels"`
+	DestinationNamespace string            `json:"destinationNamespace" binding:"required"`
+	DestinationPod       string            `json:"destinationPod" binding:"required"`
+	Port                 int32             `json:"port" binding:"required,min=1,max=65535"`
+	Protocol             string            `json:"protocol" binding:"omitempty,oneof=TCP UDP SCTP"` // 默认TCP
+}
+
+// NetworkPolicyDirection 单方向的策略判定结果
+type NetworkPolicyDirection struct {
+	Isolated  bool     `json:"isolated"` // 是否有策略选中该Pod，未被选中时不受限制
+	Allowed   bool     `json:"allowed"`
+	AllowedBy []string `json:"allowedBy"` // 放行的策略，namespace/name
+	DeniedBy  []string `json:"deniedBy"`  // 选中该Pod但未放行的策略
+	Reason    string   `json:"reason"`
+}
+
+// NetworkPolicyAnalysis 网络连通性分析结果，出站和入站都放行时流量才可达
+type NetworkPolicyAnalysis struct {
+	Allowed bool                   `json:"allowed"`
+	Egress  NetworkPolicyDirection `json:"egress"`  // 源Pod的出站判定
+	Ingress NetworkPolicyDirection `json:"ingress"` // 目标Pod的入站判定
+	Notes   []string               `json:"notes,omitempty"`
+}
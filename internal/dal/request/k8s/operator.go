@@ -0,0 +1,13 @@
+package k8s
+
+// OperatorInstallRequest 通过OLM安装Operator请求
+type OperatorInstallRequest struct {
+	Namespace        string   `json:"namespace" binding:"required"`   // 安装到的命名空间
+	PackageName      string   `json:"packageName" binding:"required"` // PackageManifest名称
+	Channel          string   `json:"channel"`                        // 为空时使用默认频道
+	Source           string   `json:"source" binding:"required"`      // CatalogSource名称
+	SourceNamespace  string   `json:"sourceNamespace" binding:"required"`
+	Approval         string   `json:"approval" binding:"omitempty,oneof=Automatic Manual"` // InstallPlan审批策略，默认Automatic
+	StartingCSV      string   `json:"startingCSV"`
+	TargetNamespaces []string `json:"targetNamespaces"` // OperatorGroup监听的命名空间，为空表示全部命名空间
+}
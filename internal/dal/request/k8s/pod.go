@@ -72,3 +72,46 @@ type PodUpdateRequest struct {
 	Imagename string `json:"imagename" binding:"required"`
 	Image     string `json:"image" binding:"required"`
 }
+
+// PodLogSearchRequest 通过ES检索容器日志请求
+type PodLogSearchRequest struct {
+	EsInstanceID uint   `form:"es_instance_id" binding:"required"` // 存放集群日志的ES实例
+	Index        string `form:"index"`                             // 索引，为空时使用配置
+	Namespace    string `form:"namespace"`
+	Pod          string `form:"pod"` // 支持*通配
+	Container    string `form:"container"`
+	Query        string `form:"query"`      // 日志内容检索条件
+	StartTime    int64  `form:"start_time"` // 开始时间（Unix秒）
+	EndTime      int64  `form:"end_time"`   // 结束时间（Unix秒）
+	Page         int    `form:"page" binding:"required,min=1"`
+	PageSize     int    `form:"page_size" binding:"required,min=1,max=500"`
+}
+
+// PodLogSearchItem 日志检索结果
+type PodLogSearchItem struct {
+	Timestamp string   `json:"timestamp"`
+	Namespace string   `json:"namespace"`
+	Pod       string   `json:"pod"`
+	Container string   `json:"container"`
+	Message   string   `json:"message"`
+	Highlight []string `json:"highlight,omitempty"`
+	Index     string   `json:"index"`
+}
+
+// PodDebugRequest 注入临时调试容器请求
+type PodDebugRequest struct {
+	Image           string   `json:"image"`           // 调试镜像，为空时使用配置的默认镜像
+	TargetContainer string   `json:"targetContainer"` // 共享进程命名空间的目标容器
+	Command         []string `json:"command"`         // 调试容器启动命令，为空时使用镜像默认命令
+}
+
+// PodDebugCopyRequest 复制Pod进行调试请求，对应kubectl debug --copy-to
+type PodDebugCopyRequest struct {
+	CopyTo         string            `json:"copyTo"`         // 副本名称，为空时使用<原名>-debug
+	Image          string            `json:"image"`          // 调试镜像，为空时使用配置的默认镜像
+	Command        []string          `json:"command"`        // 调试容器启动命令
+	SetImage       map[string]string `json:"setImage"`       // 替换副本中已有容器的镜像，容器名->镜像
+	ShareProcesses *bool             `json:"shareProcesses"` // 是否共享进程命名空间，默认true
+	SameNode       bool              `json:"sameNode"`       // 是否调度到原Pod所在节点
+	KeepLabels     bool              `json:"keepLabels"`     // 是否保留标签，默认去除以免被Service选中
+}
@@ -0,0 +1,66 @@
+package k8s
+
+// ResourceQuotaListItem ResourceQuota列表项
+type ResourceQuotaListItem struct {
+	Name      string            `json:"name"`
+	Namespace string            `json:"namespace"`
+	Hard      map[string]string `json:"hard"`
+	Used      map[string]string `json:"used"`
+	Scopes    []string          `json:"scopes,omitempty"`
+	Age       int64             `json:"age"`
+}
+
+// ResourceQuotaCreateRequest 创建ResourceQuota请求
+type ResourceQuotaCreateRequest struct {
+	Name      string            `json:"name" binding:"required"`
+	Namespace string            `json:"namespace" binding:"required"`
+	Hard      map[string]string `json:"hard" binding:"required"` // 如 {"requests.cpu":"10","pods":"50"}
+	Scopes    []string          `json:"scopes"`
+}
+
+// ResourceQuotaUpdateRequest 更新ResourceQuota请求
+type ResourceQuotaUpdateRequest struct {
+	Hard   map[string]string `json:"hard" binding:"required"`
+	Scopes []string          `json:"scopes"`
+}
+
+// LimitRangeItem LimitRange限制条目，值为Kubernetes quantity格式
+type LimitRangeItem struct {
+	Type                 string            `json:"type" binding:"required,oneof=Container Pod PersistentVolumeClaim"`
+	Default              map[string]string `json:"default,omitempty"`
+	DefaultRequest       map[string]string `json:"defaultRequest,omitempty"`
+	Max                  map[string]string `json:"max,omitempty"`
+	Min                  map[string]string `json:"min,omitempty"`
+	MaxLimitRequestRatio map[string]string `json:"maxLimitRequestRatio,omitempty"`
+}
+
+// LimitRangeListItem LimitRange列表项
+type LimitRangeListItem struct {
+	Name      string           `json:"name"`
+	Namespace string           `json:"namespace"`
+	Limits    []LimitRangeItem `json:"limits"`
+	Age       int64            `json:"age"`
+}
+
+// LimitRangeCreateRequest 创建LimitRange请求
+type LimitRangeCreateRequest struct {
+	Name      string           `json:"name" binding:"required"`
+	Namespace string           `json:"namespace" binding:"required"`
+	Limits    []LimitRangeItem `json:"limits" binding:"required,min=1,dive"`
+}
+
+// LimitRangeUpdateRequest 更新LimitRange请求
+type LimitRangeUpdateRequest struct {
+	Limits []LimitRangeItem `json:"limits" binding:"required,min=1,dive"`
+}
+
+// QuotaUsageItem 配额使用情况
+type QuotaUsageItem struct {
+	Quota    string  `json:"quota"`    // 所属ResourceQuota
+	Resource string  `json:"resource"` // 资源名，如requests.cpu
+	Category string  `json:"category"` // cpu、memory、pods、pvc、storage、other
+	Hard     string  `json:"hard"`
+	Used     string  `json:"used"`
+	Percent  float64 `json:"percent"`
+	Warning  bool    `json:"warning"` // 使用率达到告警阈值
+}
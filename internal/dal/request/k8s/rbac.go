@@ -0,0 +1,79 @@
+package k8s
+
+// RBACListItem RBAC对象列表项，按类型填充对应字段
+type RBACListItem struct {
+	Kind       string   `json:"kind"`
+	Name       string   `json:"name"`
+	Namespace  string   `json:"namespace,omitempty"`
+	RuleCount  int      `json:"ruleCount,omitempty"`  // Role/ClusterRole的规则数
+	Aggregated bool     `json:"aggregated,omitempty"` // ClusterRole是否为聚合角色
+	RoleRef    string   `json:"roleRef,omitempty"`    // 绑定引用的角色，Kind/Name
+	Subjects   []string `json:"subjects,omitempty"`   // 绑定的主体，Kind:namespace/name
+	Secrets    int      `json:"secrets,omitempty"`    // ServiceAccount关联的Secret数
+	Age        int64    `json:"age"`
+}
+
+// RBACWriteRequest 创建或更新RBAC对象请求，内容为YAML
+type RBACWriteRequest struct {
+	Namespace string `json:"namespace"` // 命名空间级对象必填，YAML中的命名空间会被覆盖
+	YAML      string `json:"yaml" binding:"required"`
+}
+
+// RBACSubject RBAC主体
+type RBACSubject struct {
+	Kind      string `json:"kind" binding:"required,oneof=User Group ServiceAccount"`
+	Name      string `json:"name" binding:"required"`
+	Namespace string `json:"namespace"` // ServiceAccount必填
+}
+
+// RBACAccessQuery 访问权限查询，如 delete pods 或 get deployments/scale
+type RBACAccessQuery struct {
+	Verb         string `json:"verb" form:"verb" binding:"required"`
+	Group        string `json:"group" form:"group"` // 核心API组为空
+	Resource     string `json:"resource" form:"resource" binding:"required"`
+	Subresource  string `json:"subresource" form:"subresource"`
+	ResourceName string `json:"resourceName" form:"resourceName"`
+	Namespace    string `json:"namespace" form:"namespace"` // 为空表示集群级
+}
+
+// RBACWhoCanRequest who-can查询请求
+type RBACWhoCanRequest struct {
+	RBACAccessQuery
+	CrossCheck bool `json:"crossCheck" form:"crossCheck"` // 是否用SubjectAccessReview逐个复核
+}
+
+// RBACGrant 授予权限的来源
+type RBACGrant struct {
+	Subject   RBACSubject `json:"subject"`
+	Binding   string      `json:"binding"` // Kind/namespace/name
+	Role      string      `json:"role"`    // Kind/name
+	Namespace string      `json:"namespace,omitempty"`
+	Verified  *bool       `json:"verified,omitempty"` // SubjectAccessReview复核结果，未复核时为空
+}
+
+// RBACSubjectRule 主体通过某个绑定获得的规则
+type RBACSubjectRule struct {
+	Binding         string   `json:"binding"`
+	Role            string   `json:"role"`
+	Namespace       string   `json:"namespace,omitempty"` // 为空表示集群范围生效
+	Verbs           []string `json:"verbs"`
+	APIGroups       []string `json:"apiGroups,omitempty"`
+	Resources       []string `json:"resources,omitempty"`
+	ResourceNames   []string `json:"resourceNames,omitempty"`
+	NonResourceURLs []string `json:"nonResourceURLs,omitempty"`
+}
+
+// RBACSubjectRequest 查询主体权限请求，指定verb和resource时同时给出判定结果
+type RBACSubjectRequest struct {
+	Subject RBACSubject      `json:"subject" binding:"required"`
+	Groups  []string         `json:"groups"` // User所属的组
+	Check   *RBACAccessQuery `json:"check"`
+}
+
+// RBACAccessDecision 单次权限判定结果
+type RBACAccessDecision struct {
+	Allowed     bool        `json:"allowed"`
+	GrantedBy   []RBACGrant `json:"grantedBy"`
+	Verified    *bool       `json:"verified,omitempty"`    // SubjectAccessReview结果
+	VerifyError string      `json:"verifyError,omitempty"` // 复核失败原因，如无权限创建SubjectAccessReview
+}
@@ -0,0 +1,26 @@
+package k8s
+
+// RelationNode 关系图中的资源节点
+type RelationNode struct {
+	ID        string `json:"id"` // Kind/Namespace/Name，集群级资源为 Kind/Name
+	Kind      string `json:"kind"`
+	Namespace string `json:"namespace,omitempty"`
+	Name      string `json:"name"`
+	Status    string `json:"status"` // healthy、progressing、degraded、missing、unknown
+	Message   string `json:"message,omitempty"`
+	Root      bool   `json:"root,omitempty"`
+}
+
+// RelationEdge 关系图中的有向边
+type RelationEdge struct {
+	From string `json:"from"`
+	To   string `json:"to"`
+	Type string `json:"type"` // routes、endpoints、targets、owns、scales、mounts、bound、provisions
+}
+
+// RelationGraph 以某个工作负载、Service或Ingress为起点的资源关系图
+type RelationGraph struct {
+	Nodes     []RelationNode `json:"nodes"`
+	Edges     []RelationEdge `json:"edges"`
+	Truncated bool           `json:"truncated"` // 节点数超过上限时为true
+}
@@ -0,0 +1,29 @@
+package k8s
+
+// ScanRunListRequest 扫描记录列表请求
+type ScanRunListRequest struct {
+	InstanceID uint `form:"instance_id"`
+	Page       int  `form:"page" binding:"required,min=1"`
+	PageSize   int  `form:"page_size" binding:"required,min=1,max=100"`
+}
+
+// ScanFindingListRequest 扫描问题列表请求
+type ScanFindingListRequest struct {
+	Namespace string `form:"namespace"`
+	Rule      string `form:"rule"`
+	Severity  string `form:"severity" binding:"omitempty,oneof=critical warning info"`
+	Page      int    `form:"page" binding:"required,min=1"`
+	PageSize  int    `form:"page_size" binding:"required,min=1,max=500"`
+}
+
+// ScanTrendRequest 扫描得分趋势请求，namespace为空时返回集群整体得分
+type ScanTrendRequest struct {
+	Namespace string `form:"namespace"`
+	Days      int    `form:"days" binding:"omitempty,min=1,max=365"`
+}
+
+// ScanRuleItem 扫描规则
+type ScanRuleItem struct {
+	ID          string `json:"id"`
+	Description string `json:"description"`
+}
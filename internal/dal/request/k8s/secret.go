@@ -1,12 +1,15 @@
 package k8s
 
+import "time"
+
 // SecretListItem Secret列表项
 type SecretListItem struct {
-	Name      string `json:"name"`
-	Namespace string `json:"namespace"`
-	Type      string `json:"type"`
-	DataCount int    `json:"dataCount"`
-	Age       int64  `json:"age"`
+	Name        string           `json:"name"`
+	Namespace   string           `json:"namespace"`
+	Type        string           `json:"type"`
+	DataCount   int              `json:"dataCount"`
+	Age         int64            `json:"age"`
+	Certificate *CertificateInfo `json:"certificate,omitempty"` // TLS类型Secret的证书信息
 }
 
 // SecretCreateRequest 创建Secret请求
@@ -20,6 +23,69 @@ type SecretCreateRequest struct {
 
 // SecretUpdateRequest 更新Secret请求
 type SecretUpdateRequest struct {
-	Data map[string]string `json:"data"` // base64 encoded values
-	YAML string            `json:"yaml"`
+	Data             map[string]string `json:"data"` // base64 encoded values
+	YAML             string            `json:"yaml"`
+	RestartWorkloads bool              `json:"restartWorkloads"` // 更新后滚动重启引用该Secret的工作负载
+}
+
+// SecretKeyInfo 脱敏后的Secret键信息
+type SecretKeyInfo struct {
+	Key  string `json:"key"`
+	Size int    `json:"size"` // 值的字节数
+}
+
+// CertificateInfo 证书信息
+type CertificateInfo struct {
+	Subject       string    `json:"subject"`
+	Issuer        string    `json:"issuer"`
+	DNSNames      []string  `json:"dnsNames,omitempty"`
+	NotBefore     time.Time `json:"notBefore"`
+	NotAfter      time.Time `json:"notAfter"`
+	DaysRemaining int       `json:"daysRemaining"` // 已过期时为负数
+	Expired       bool      `json:"expired"`
+	Error         string    `json:"error,omitempty"` // 证书解析失败原因
+}
+
+// SecretRevealRequest 查看Secret明文请求，keys为空时返回全部键
+type SecretRevealRequest struct {
+	Keys []string `json:"keys"`
+}
+
+// SecretRevealedValue Secret明文值，非UTF-8内容以base64返回
+type SecretRevealedValue struct {
+	Key      string `json:"key"`
+	Value    string `json:"value"`
+	Encoding string `json:"encoding"` // text 或 base64
+}
+
+// DockerRegistryAuth 镜像仓库凭证
+type DockerRegistryAuth struct {
+	Server   string `json:"server" binding:"required"`
+	Username string `json:"username" binding:"required"`
+	Password string `json:"password" binding:"required"`
+	Email    string `json:"email"`
+}
+
+// TLSKeyPair TLS证书和私钥，PEM格式
+type TLSKeyPair struct {
+	Cert string `json:"cert" binding:"required"`
+	Key  string `json:"key" binding:"required"`
+}
+
+// BasicAuth 用户名密码
+type BasicAuth struct {
+	Username string `json:"username" binding:"required"`
+	Password string `json:"password" binding:"required"`
+}
+
+// SecretTypedCreateRequest 按类型创建Secret请求，根据type填写对应字段
+type SecretTypedCreateRequest struct {
+	Name           string              `json:"name" binding:"required"`
+	Namespace      string              `json:"namespace" binding:"required"`
+	Type           string              `json:"type" binding:"required,oneof=opaque docker-registry tls basic-auth"`
+	Labels         map[string]string   `json:"labels"`
+	Data           map[string]string   `json:"data"` // opaque类型的键值，值为明文
+	DockerRegistry *DockerRegistryAuth `json:"dockerRegistry"`
+	TLS            *TLSKeyPair         `json:"tls"`
+	BasicAuth      *BasicAuth          `json:"basicAuth"`
 }
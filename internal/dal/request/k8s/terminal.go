@@ -0,0 +1,15 @@
+package k8s
+
+// SessionListRequest 终端、端口转发会话及Secret查看审计记录列表请求
+type SessionListRequest struct {
+	Page     int `form:"page" binding:"required,min=1"`
+	PageSize int `form:"page_size" binding:"required,min=1,max=100"`
+}
+
+// NamespacePermissionCreateRequest 命名空间操作授权请求
+type NamespacePermissionCreateRequest struct {
+	UserID     int64  `json:"userId" binding:"required"`
+	InstanceID uint   `json:"instanceId" binding:"required"`
+	Namespace  string `json:"namespace" binding:"required"` // *表示全部命名空间
+	Action     string `json:"action" binding:"required,oneof=exec port-forward secret-view watch logs"`
+}
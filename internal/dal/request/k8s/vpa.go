@@ -0,0 +1,53 @@
+package k8s
+
+// VPATargetRef VPA作用的工作负载
+type VPATargetRef struct {
+	Kind string `json:"kind" binding:"required,oneof=Deployment StatefulSet DaemonSet"`
+	Name string `json:"name" binding:"required"`
+}
+
+// VPAContainerPolicy 容器级资源策略
+type VPAContainerPolicy struct {
+	ContainerName       string            `json:"containerName" binding:"required"` // *表示全部容器
+	Mode                string            `json:"mode" binding:"omitempty,oneof=Auto Off"`
+	MinAllowed          map[string]string `json:"minAllowed"` // 如 {"cpu":"100m","memory":"128Mi"}
+	MaxAllowed          map[string]string `json:"maxAllowed"`
+	ControlledResources []string          `json:"controlledResources" binding:"omitempty,dive,oneof=cpu memory"`
+}
+
+// VPARequest 创建或更新VPA请求，UpdateMode默认Off即只给出建议不自动调整
+type VPARequest struct {
+	Name              string               `json:"name" binding:"required"`
+	Namespace         string               `json:"namespace" binding:"required"`
+	TargetRef         VPATargetRef         `json:"targetRef" binding:"required"`
+	UpdateMode        string               `json:"updateMode" binding:"omitempty,oneof=Off Initial Recreate InPlaceOrRecreate Auto"`
+	ContainerPolicies []VPAContainerPolicy `json:"containerPolicies" binding:"dive"`
+}
+
+// VPAContainerRecommendation 容器资源建议及当前配置
+type VPAContainerRecommendation struct {
+	ContainerName   string            `json:"containerName"`
+	Target          map[string]string `json:"target"`
+	LowerBound      map[string]string `json:"lowerBound"`
+	UpperBound      map[string]string `json:"upperBound"`
+	UncappedTarget  map[string]string `json:"uncappedTarget"`
+	CurrentRequests map[string]string `json:"currentRequests"`
+	CurrentLimits   map[string]string `json:"currentLimits"`
+}
+
+// VPAResourceChange 应用建议后的资源变化
+type VPAResourceChange struct {
+	Container string `json:"container"`
+	Field     string `json:"field"`    // requests或limits
+	Resource  string `json:"resource"` // cpu或memory
+	Current   string `json:"current"`  // 为空表示未设置
+	New       string `json:"new"`
+}
+
+// VPAApplyRequest 应用VPA建议到工作负载请求
+type VPAApplyRequest struct {
+	Bound       string   `json:"bound" binding:"omitempty,oneof=target lowerBound upperBound uncappedTarget"` // 使用的建议值，默认target
+	Containers  []string `json:"containers"`                                                                  // 为空时应用全部容器
+	ScaleLimits bool     `json:"scaleLimits"`                                                                 // 按原limits/requests比例调整limits
+	DryRun      bool     `json:"dryRun"`                                                                      // 只返回差异不修改
+}
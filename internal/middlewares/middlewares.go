@@ -9,6 +9,7 @@ import (
 	"devops-console-backend/pkg/database"
 	"devops-console-backend/pkg/utils"
 	"devops-console-backend/pkg/utils/jwt"
+	"errors"
 	"fmt"
 	"log"
 	"regexp"
@@ -99,6 +100,20 @@ func IPRateLimit() gin.HandlerFunc {
 	}
 }
 
+// blockedTokenKey 被下线token在redis中的key
+func blockedTokenKey(claim *jwt.Claims, token string) string {
+	return fmt.Sprintf("%v:%v:%v", common.BlockedTokenPrefix, claim.GetUserId(), token)
+}
+
+// IsTokenBlocked 判断token是否已被下线，供无法经过Authenticate中间件的WebSocket连接使用
+func IsTokenBlocked(claim *jwt.Claims, token string) (bool, error) {
+	client := database.GetRedisClient()
+	if client == nil {
+		return false, errors.New("redis 客户端未初始化")
+	}
+	return redis.NewClient(client).Get(blockedTokenKey(claim, token), false) != "", nil
+}
+
 // redis 相关操作
 func redisOperator(claim *jwt.Claims, c *gin.Context, token string) {
 	client := database.GetRedisClient()
@@ -106,7 +121,7 @@ func redisOperator(claim *jwt.Claims, c *gin.Context, token string) {
 		panic("redis 客户端未初始化")
 		return
 	}
-	key := fmt.Sprintf("%v:%v:%v", common.BlockedTokenPrefix, claim.GetUserId(), token)
+	key := blockedTokenKey(claim, token)
 	redisClient := redis.NewClient(client)
 	helper := utils.NewResponseHelper(c)
 	value := redisClient.Get(key, false)
@@ -143,7 +158,7 @@ func InstanceAuth() gin.HandlerFunc {
 			instanceID = 1
 		}
 
-		r.Set("instance_id", instanceID)
+		r.Set(common.InstanceIDKey, instanceID)
 		r.Next()
 	}
 }
@@ -0,0 +1,63 @@
+package alert
+
+import (
+	alertController "devops-console-backend/internal/controllers/alert"
+	alertService "devops-console-backend/internal/services/alert"
+
+	"github.com/gin-gonic/gin"
+	"gorm.io/gorm"
+)
+
+// AlertRoute 告警路由
+type AlertRoute struct {
+	ruleController    *alertController.RuleController
+	channelController *alertController.ChannelController
+	silenceController *alertController.SilenceController
+	eventController   *alertController.EventController
+}
+
+// NewAlertRoute 创建告警路由实例
+func NewAlertRoute(db *gorm.DB) *AlertRoute {
+	engine := alertService.SharedEngine(db)
+	return &AlertRoute{
+		ruleController:    alertController.NewRuleController(db, engine),
+		channelController: alertController.NewChannelController(db, engine),
+		silenceController: alertController.NewSilenceController(db),
+		eventController:   alertController.NewEventController(db),
+	}
+}
+
+// RegisterSubRouter 注册告警子路由
+func (r *AlertRoute) RegisterSubRouter(apiGroup *gin.RouterGroup) {
+	// 告警规则路由
+	ruleGroup := apiGroup.Group("/alert/rules")
+	{
+		ruleGroup.GET("", r.ruleController.GetRuleList)
+		ruleGroup.GET("/types", r.ruleController.GetRuleTypes)
+		ruleGroup.POST("", r.ruleController.CreateRule)
+		ruleGroup.PUT("/:id", r.ruleController.UpdateRule)
+		ruleGroup.DELETE("/:id", r.ruleController.DeleteRule)
+		ruleGroup.POST("/:id/evaluate", r.ruleController.EvaluateRule)
+	}
+
+	// 通知渠道路由
+	channelGroup := apiGroup.Group("/alert/channels")
+	{
+		channelGroup.GET("", r.channelController.GetChannelList)
+		channelGroup.POST("", r.channelController.CreateChannel)
+		channelGroup.PUT("/:id", r.channelController.UpdateChannel)
+		channelGroup.DELETE("/:id", r.channelController.DeleteChannel)
+		channelGroup.POST("/:id/test", r.channelController.TestChannel)
+	}
+
+	// 静默路由
+	silenceGroup := apiGroup.Group("/alert/silences")
+	{
+		silenceGroup.GET("", r.silenceController.GetSilenceList)
+		silenceGroup.POST("", r.silenceController.CreateSilence)
+		silenceGroup.DELETE("/:id", r.silenceController.ExpireSilence)
+	}
+
+	// 告警记录路由
+	apiGroup.GET("/alert/events", r.eventController.GetEventList)
+}
@@ -9,12 +9,14 @@ import (
 type ConfigRoute struct {
 	configMapController *config.ConfigMapController
 	secretController    *config.SecretController
+	historyController   *config.ConfigHistoryController
 }
 
 func NewConfigRoute() *ConfigRoute {
 	return &ConfigRoute{
 		configMapController: config.NewConfigMapController(),
 		secretController:    config.NewSecretController(),
+		historyController:   config.NewConfigHistoryController(),
 	}
 }
 
@@ -37,7 +39,20 @@ func (r *ConfigRoute) RegisterSubRouter(apiGroup *gin.RouterGroup) {
 		secretGroup.GET("/list/all", r.secretController.GetSecretList)
 		secretGroup.GET("/detail/:namespace/:name", r.secretController.GetSecretDetail)
 		secretGroup.POST("/create", r.secretController.CreateSecret)
+		secretGroup.POST("/create/typed", r.secretController.CreateTypedSecret)
+		secretGroup.POST("/reveal/:namespace/:name", r.secretController.RevealSecret)
+		secretGroup.GET("/access/logs", r.secretController.GetSecretAccessLogs)
 		secretGroup.PUT("/update/:namespace/:name", r.secretController.UpdateSecret)
 		secretGroup.DELETE("/delete/:namespace/:name", r.secretController.DeleteSecret)
 	}
+
+	// ConfigMap/Secret历史版本，kind为configmap或secret
+	historyGroup := apiGroup.Group("/k8s/confighistory")
+	{
+		historyGroup.GET("/list/:kind/:namespace/:name", r.historyController.GetRevisionList)
+		historyGroup.GET("/diff/:kind/:namespace/:name", r.historyController.DiffRevision)
+		historyGroup.POST("/rollback/:kind/:namespace/:name", r.historyController.RollbackRevision)
+		historyGroup.GET("/consumers/:kind/:namespace/:name", r.historyController.GetConsumers)
+		historyGroup.POST("/restart/:kind/:namespace/:name", r.historyController.RestartConsumers)
+	}
 }
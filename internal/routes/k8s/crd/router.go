@@ -19,5 +19,9 @@ func (r *CrdRoute) RegisterSubRouter(apiGroup *gin.RouterGroup) {
 		crdGroup.GET("/list", cc.GetCRDList)
 		crdGroup.GET("/detail/:name", cc.GetCRDDetail)
 		crdGroup.DELETE("/delete/:name", cc.DeleteCRD)
+		crdGroup.GET("/instance/list/:crd", cc.GetCustomResourceList)
+		crdGroup.GET("/instance/detail/:crd/:name", cc.GetCustomResourceDetail)
+		crdGroup.PUT("/instance/update/:crd/:name", cc.UpdateCustomResource)
+		crdGroup.DELETE("/instance/delete/:crd/:name", cc.DeleteCustomResource)
 	}
 }
@@ -26,5 +26,12 @@ func (r *CronJobRoute) RegisterSubRouter(apiGroup *gin.RouterGroup) {
 		cronjobGroup.DELETE("/delete", r.controller.DeleteCronJob)
 		cronjobGroup.GET("/list/:namespace", r.controller.GetCronJobList)
 		cronjobGroup.PUT("/update", r.controller.UpdateCronJob)
+		cronjobGroup.GET("/detail/:namespace/:name", r.controller.GetCronJobDetail)
+		cronjobGroup.PUT("/suspend/:namespace/:name", r.controller.SuspendCronJob)
+		cronjobGroup.PUT("/resume/:namespace/:name", r.controller.ResumeCronJob)
+		cronjobGroup.POST("/trigger/:namespace/:name", r.controller.TriggerCronJob)
+		cronjobGroup.GET("/history/:namespace/:name", r.controller.GetCronJobHistory)
+		cronjobGroup.GET("/history/:namespace/:name/:jobName/logs", r.controller.GetCronJobJobLogs)
+		cronjobGroup.GET("/schedule/preview", r.controller.PreviewSchedule)
 	}
 }
@@ -0,0 +1,28 @@
+package diagnosis
+
+import (
+	"devops-console-backend/internal/controllers/k8s/diagnosis"
+
+	"github.com/gin-gonic/gin"
+)
+
+// DiagnosisRoute 工作负载诊断路由
+type DiagnosisRoute struct {
+	controller *diagnosis.DiagnosisController
+}
+
+// NewDiagnosisRoute 创建工作负载诊断路由实例
+func NewDiagnosisRoute() *DiagnosisRoute {
+	return &DiagnosisRoute{
+		controller: diagnosis.NewDiagnosisController(),
+	}
+}
+
+// RegisterSubRouter 注册子路由
+func (r *DiagnosisRoute) RegisterSubRouter(apiGroup *gin.RouterGroup) {
+	diagnosisGroup := apiGroup.Group("/k8s/diagnosis")
+	{
+		// kind为deployment、statefulset、daemonset或job；单个Pod的诊断见 /k8s/pod/diagnose
+		diagnosisGroup.GET("/:kind/:namespace/:name", r.controller.DiagnoseWorkload)
+	}
+}
@@ -22,5 +22,7 @@ func (r *EventRoute) RegisterSubRouter(apiGroup *gin.RouterGroup) {
 	{
 		eventGroup.GET("/list/:namespace", r.eventController.GetEventList)
 		eventGroup.GET("/list/all", r.eventController.GetEventList)
+		eventGroup.GET("/history", r.eventController.GetEventHistory)
+		eventGroup.GET("/warnings", r.eventController.GetWarningSummary)
 	}
 }
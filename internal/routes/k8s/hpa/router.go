@@ -19,5 +19,7 @@ func (r *HpaRoute) RegisterSubRouter(apiGroup *gin.RouterGroup) {
 		hpaGroup.GET("/list/:namespace", hc.GetHPAList)
 		hpaGroup.GET("/detail/:namespace/:name", hc.GetHPADetail)
 		hpaGroup.DELETE("/delete/:namespace/:name", hc.DeleteHPA)
+		hpaGroup.POST("/create", hc.CreateHPA)
+		hpaGroup.PUT("/update", hc.UpdateHPA)
 	}
 }
@@ -26,5 +26,10 @@ func (r *JobRoute) RegisterSubRouter(apiGroup *gin.RouterGroup) {
 		jobGroup.GET("/list/:namespace", r.controller.GetJobList)
 		jobGroup.POST("/create", r.controller.CreateJob)
 		jobGroup.DELETE("/delete/:namespace/:jobName", r.controller.DeleteJob)
+		jobGroup.POST("/rerun/:namespace/:jobName", r.controller.RerunJob)
+		jobGroup.GET("/logs/:namespace/:jobName", r.controller.GetJobLogs)
+		jobGroup.PUT("/suspend/:namespace/:jobName", r.controller.SuspendJob)
+		jobGroup.PUT("/resume/:namespace/:jobName", r.controller.ResumeJob)
+		jobGroup.POST("/cleanup", r.controller.CleanupJobs)
 	}
 }
@@ -7,6 +7,7 @@ import (
 	"devops-console-backend/internal/routes/k8s/cronjob"
 	"devops-console-backend/internal/routes/k8s/daemonset"
 	"devops-console-backend/internal/routes/k8s/deployment"
+	"devops-console-backend/internal/routes/k8s/diagnosis"
 	"devops-console-backend/internal/routes/k8s/event"
 	"devops-console-backend/internal/routes/k8s/hpa"
 	"devops-console-backend/internal/routes/k8s/job"
@@ -15,10 +16,16 @@ import (
 	"devops-console-backend/internal/routes/k8s/node"
 	"devops-console-backend/internal/routes/k8s/operator"
 	"devops-console-backend/internal/routes/k8s/pod"
+	"devops-console-backend/internal/routes/k8s/portforward"
+	"devops-console-backend/internal/routes/k8s/quota"
+	"devops-console-backend/internal/routes/k8s/rbac"
+	"devops-console-backend/internal/routes/k8s/relation"
 	"devops-console-backend/internal/routes/k8s/replicaset"
 	"devops-console-backend/internal/routes/k8s/replicationcontroller"
+	"devops-console-backend/internal/routes/k8s/scan"
 	"devops-console-backend/internal/routes/k8s/service"
 	"devops-console-backend/internal/routes/k8s/storage"
+	"devops-console-backend/internal/routes/k8s/terminal"
 	"devops-console-backend/internal/routes/k8s/vpa"
 
 	"github.com/gin-gonic/gin"
@@ -70,6 +77,14 @@ func RegisterK8sRoutes(apiGroup *gin.RouterGroup, db *gorm.DB) {
 	networkRoute := network.NewNetworkRoute()
 	networkRoute.RegisterSubRouter(apiGroup)
 
+	// 注册ResourceQuota与LimitRange路由
+	quotaRoute := quota.NewQuotaRoute()
+	quotaRoute.RegisterSubRouter(apiGroup)
+
+	// 注册RBAC路由
+	rbacRoute := rbac.NewRBACRoute()
+	rbacRoute.RegisterSubRouter(apiGroup)
+
 	// 注册Config路由
 	configRoute := config.NewConfigRoute()
 	configRoute.RegisterSubRouter(apiGroup)
@@ -101,4 +116,24 @@ func RegisterK8sRoutes(apiGroup *gin.RouterGroup, db *gorm.DB) {
 	// 注册Operator路由
 	operatorRoute := operator.NewOperatorRoute()
 	operatorRoute.RegisterSubRouter(apiGroup)
+
+	// 注册终端会话路由
+	terminalRoute := terminal.NewTerminalRoute()
+	terminalRoute.RegisterSubRouter(apiGroup)
+
+	// 注册端口转发路由
+	portForwardRoute := portforward.NewPortForwardRoute()
+	portForwardRoute.RegisterSubRouter(apiGroup)
+
+	// 注册资源关联关系路由
+	relationRoute := relation.NewRelationRoute()
+	relationRoute.RegisterSubRouter(apiGroup)
+
+	// 注册工作负载诊断路由
+	diagnosisRoute := diagnosis.NewDiagnosisRoute()
+	diagnosisRoute.RegisterSubRouter(apiGroup)
+
+	// 注册最佳实践与安全扫描路由
+	scanRoute := scan.NewScanRoute()
+	scanRoute.RegisterSubRouter(apiGroup)
 }
@@ -25,5 +25,13 @@ func (r *NamespaceRoute) RegisterSubRouter(apiGroup *gin.RouterGroup) {
 		namespaceGroup.POST("/create/:namespace", r.controller.CreateNamespace)
 		namespaceGroup.DELETE("/delete/:namespace", r.controller.DeleteNamespace)
 		namespaceGroup.GET("/list", r.controller.GetNamespaceList)
+		namespaceGroup.POST("/provision", r.controller.ProvisionNamespace)
+		namespaceGroup.GET("/drift/:namespace", r.controller.GetNamespaceDrift)
+
+		// 命名空间模板
+		namespaceGroup.GET("/template/list", r.controller.GetTemplateList)
+		namespaceGroup.POST("/template/create", r.controller.CreateTemplate)
+		namespaceGroup.PUT("/template/update/:id", r.controller.UpdateTemplate)
+		namespaceGroup.DELETE("/template/delete/:id", r.controller.DeleteTemplate)
 	}
 }
@@ -9,12 +9,14 @@ import (
 type NetworkRoute struct {
 	ingressController      *network.IngressController
 	ingressClassController *network.IngressClassController
+	policyController       *network.NetworkPolicyController
 }
 
 func NewNetworkRoute() *NetworkRoute {
 	return &NetworkRoute{
 		ingressController:      network.NewIngressController(),
 		ingressClassController: network.NewIngressClassController(),
+		policyController:       network.NewNetworkPolicyController(),
 	}
 }
 
@@ -36,4 +38,15 @@ func (r *NetworkRoute) RegisterSubRouter(apiGroup *gin.RouterGroup) {
 		ingressClassGroup.GET("/list", r.ingressClassController.GetIngressClassList)
 		ingressClassGroup.GET("/detail/:name", r.ingressClassController.GetIngressClassDetail)
 	}
+
+	// NetworkPolicy
+	policyGroup := apiGroup.Group("/k8s/networkpolicy")
+	{
+		policyGroup.GET("/list/:namespace", r.policyController.GetNetworkPolicyList)
+		policyGroup.GET("/detail/:namespace/:name", r.policyController.GetNetworkPolicyDetail)
+		policyGroup.POST("/create", r.policyController.CreateNetworkPolicy)
+		policyGroup.PUT("/update/:namespace/:name", r.policyController.UpdateNetworkPolicy)
+		policyGroup.DELETE("/delete/:namespace/:name", r.policyController.DeleteNetworkPolicy)
+		policyGroup.POST("/analyze", r.policyController.AnalyzeNetworkPolicy)
+	}
 }
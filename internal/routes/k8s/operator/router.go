@@ -19,5 +19,13 @@ func (r *OperatorRoute) RegisterSubRouter(apiGroup *gin.RouterGroup) {
 		opGroup.GET("/subscription/list/:namespace", oc.GetSubscriptionList)
 		opGroup.GET("/subscription/detail/:namespace/:name", oc.GetSubscriptionDetail)
 		opGroup.DELETE("/subscription/delete/:namespace/:name", oc.DeleteSubscription)
+		opGroup.GET("/catalog/list/:namespace", oc.GetCatalogSourceList)
+		opGroup.GET("/package/list/:namespace", oc.GetPackageManifestList)
+		opGroup.GET("/package/detail/:namespace/:name", oc.GetPackageManifestDetail)
+		opGroup.POST("/install", oc.InstallOperator)
+		opGroup.GET("/installplan/list/:namespace", oc.GetInstallPlanList)
+		opGroup.PUT("/installplan/approve/:namespace/:name", oc.ApproveInstallPlan)
+		opGroup.GET("/csv/list/:namespace", oc.GetCSVList)
+		opGroup.GET("/csv/detail/:namespace/:name", oc.GetCSVDetail)
 	}
 }
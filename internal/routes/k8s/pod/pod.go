@@ -26,7 +26,15 @@ func (r *PodRoute) RegisterSubRouter(apiGroup *gin.RouterGroup) {
 		podGroup.GET("/list/:namespace", r.controller.GetPodList)
 		podGroup.GET("/list/all", r.controller.GetPodList)
 		podGroup.GET("/events/:namespace/:podname", r.controller.GetPodEvents)
+		podGroup.GET("/diagnose/:namespace/:podname", r.controller.DiagnosePod)
 		podGroup.GET("/logs/:namespace/:podname", r.controller.GetPodLogs)
+		podGroup.GET("/logs/download/:namespace/:podname", r.controller.DownloadPodLogs)
+		podGroup.GET("/logs/search", r.controller.SearchPodLogs)
+		podGroup.GET("/files/:namespace/:podname", r.controller.ListPodFiles)
+		podGroup.GET("/files/:namespace/:podname/download", r.controller.DownloadPodFile)
+		podGroup.POST("/files/:namespace/:podname/upload", r.controller.UploadPodFile)
+		podGroup.POST("/debug/:namespace/:podname", r.controller.CreateDebugContainer)
+		podGroup.POST("/debug/:namespace/:podname/copy", r.controller.CopyPodForDebug)
 		podGroup.POST("/create", r.controller.CreatePod)
 		podGroup.PUT("/update", r.controller.UpdatePod)
 		podGroup.DELETE("/delete/:namespace/:podname", r.controller.DeletePod)
@@ -0,0 +1,29 @@
+package portforward
+
+import (
+	"devops-console-backend/internal/controllers/k8s/portforward"
+
+	"github.com/gin-gonic/gin"
+)
+
+// PortForwardRoute 端口转发路由
+type PortForwardRoute struct {
+	controller *portforward.PortForwardController
+}
+
+// NewPortForwardRoute 创建端口转发路由实例
+func NewPortForwardRoute() *PortForwardRoute {
+	return &PortForwardRoute{
+		controller: portforward.NewPortForwardController(),
+	}
+}
+
+// RegisterSubRouter 注册子路由
+func (r *PortForwardRoute) RegisterSubRouter(apiGroup *gin.RouterGroup) {
+	forwardGroup := apiGroup.Group("/k8s/portforward")
+	{
+		forwardGroup.GET("/sessions", r.controller.GetSessionList)
+		forwardGroup.GET("/live", r.controller.GetLiveSessionList)
+		forwardGroup.DELETE("/live/:sessionId", r.controller.CloseSession)
+	}
+}
@@ -0,0 +1,41 @@
+package quota
+
+import (
+	"devops-console-backend/internal/controllers/k8s/quota"
+
+	"github.com/gin-gonic/gin"
+)
+
+// QuotaRoute ResourceQuota与LimitRange路由
+type QuotaRoute struct {
+	controller *quota.QuotaController
+}
+
+// NewQuotaRoute 创建配额路由实例
+func NewQuotaRoute() *QuotaRoute {
+	return &QuotaRoute{
+		controller: quota.NewQuotaController(),
+	}
+}
+
+// RegisterSubRouter 注册子路由
+func (r *QuotaRoute) RegisterSubRouter(apiGroup *gin.RouterGroup) {
+	quotaGroup := apiGroup.Group("/k8s/resourcequota")
+	{
+		quotaGroup.GET("/list/:namespace", r.controller.GetResourceQuotaList)
+		quotaGroup.GET("/detail/:namespace/:name", r.controller.GetResourceQuotaDetail)
+		quotaGroup.POST("/create", r.controller.CreateResourceQuota)
+		quotaGroup.PUT("/update/:namespace/:name", r.controller.UpdateResourceQuota)
+		quotaGroup.DELETE("/delete/:namespace/:name", r.controller.DeleteResourceQuota)
+		quotaGroup.GET("/capacity/:namespace", r.controller.GetNamespaceCapacity)
+	}
+
+	limitRangeGroup := apiGroup.Group("/k8s/limitrange")
+	{
+		limitRangeGroup.GET("/list/:namespace", r.controller.GetLimitRangeList)
+		limitRangeGroup.GET("/detail/:namespace/:name", r.controller.GetLimitRangeDetail)
+		limitRangeGroup.POST("/create", r.controller.CreateLimitRange)
+		limitRangeGroup.PUT("/update/:namespace/:name", r.controller.UpdateLimitRange)
+		limitRangeGroup.DELETE("/delete/:namespace/:name", r.controller.DeleteLimitRange)
+	}
+}
@@ -0,0 +1,34 @@
+package rbac
+
+import (
+	"devops-console-backend/internal/controllers/k8s/rbac"
+
+	"github.com/gin-gonic/gin"
+)
+
+// RBACRoute RBAC路由
+type RBACRoute struct {
+	controller *rbac.RBACController
+}
+
+// NewRBACRoute 创建RBAC路由实例
+func NewRBACRoute() *RBACRoute {
+	return &RBACRoute{
+		controller: rbac.NewRBACController(),
+	}
+}
+
+// RegisterSubRouter 注册子路由，kind为role、clusterrole、rolebinding、clusterrolebinding、serviceaccount
+func (r *RBACRoute) RegisterSubRouter(apiGroup *gin.RouterGroup) {
+	rbacGroup := apiGroup.Group("/k8s/rbac")
+	{
+		rbacGroup.GET("/whocan", r.controller.WhoCan)
+		rbacGroup.POST("/subject", r.controller.GetSubjectPermissions)
+
+		rbacGroup.GET("/:kind/list", r.controller.GetRBACList)
+		rbacGroup.GET("/:kind/detail/:name", r.controller.GetRBACDetail)
+		rbacGroup.POST("/:kind/create", r.controller.CreateRBAC)
+		rbacGroup.PUT("/:kind/update/:name", r.controller.UpdateRBAC)
+		rbacGroup.DELETE("/:kind/delete/:name", r.controller.DeleteRBAC)
+	}
+}
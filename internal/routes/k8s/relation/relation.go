@@ -0,0 +1,30 @@
+package relation
+
+import (
+	"devops-console-backend/internal/controllers/k8s/relation"
+
+	"github.com/gin-gonic/gin"
+)
+
+// RelationRoute 资源关联关系路由
+type RelationRoute struct {
+	controller *relation.RelationController
+}
+
+// NewRelationRoute 创建资源关联关系路由实例
+func NewRelationRoute() *RelationRoute {
+	return &RelationRoute{
+		controller: relation.NewRelationController(),
+	}
+}
+
+// RegisterSubRouter 注册子路由
+func (r *RelationRoute) RegisterSubRouter(apiGroup *gin.RouterGroup) {
+	relationGroup := apiGroup.Group("/k8s/relation")
+	{
+		// kind为configmap、secret、pvc或serviceaccount
+		relationGroup.GET("/references/:kind/:namespace/:name", r.controller.GetReferences)
+		// kind为deployment、statefulset、daemonset、replicaset、job、cronjob、pod、service或ingress
+		relationGroup.GET("/graph/:kind/:namespace/:name", r.controller.GetGraph)
+	}
+}
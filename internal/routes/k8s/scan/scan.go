@@ -0,0 +1,32 @@
+package scan
+
+import (
+	"devops-console-backend/internal/controllers/k8s/scan"
+
+	"github.com/gin-gonic/gin"
+)
+
+// ScanRoute 最佳实践与安全扫描路由
+type ScanRoute struct {
+	controller *scan.ScanController
+}
+
+// NewScanRoute 创建扫描路由实例
+func NewScanRoute() *ScanRoute {
+	return &ScanRoute{
+		controller: scan.NewScanController(),
+	}
+}
+
+// RegisterSubRouter 注册子路由
+func (r *ScanRoute) RegisterSubRouter(apiGroup *gin.RouterGroup) {
+	scanGroup := apiGroup.Group("/k8s/scan")
+	{
+		scanGroup.POST("/run", r.controller.TriggerScan)
+		scanGroup.GET("/list", r.controller.GetRunList)
+		scanGroup.GET("/detail/:id", r.controller.GetRunDetail)
+		scanGroup.GET("/findings/:id", r.controller.GetFindingList)
+		scanGroup.GET("/trend", r.controller.GetTrend)
+		scanGroup.GET("/rules", r.controller.GetRuleList)
+	}
+}
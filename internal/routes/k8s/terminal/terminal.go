@@ -0,0 +1,33 @@
+package terminal
+
+import (
+	"devops-console-backend/internal/controllers/k8s/terminal"
+
+	"github.com/gin-gonic/gin"
+)
+
+// TerminalRoute 终端会话路由
+type TerminalRoute struct {
+	controller *terminal.TerminalController
+}
+
+// NewTerminalRoute 创建终端会话路由实例
+func NewTerminalRoute() *TerminalRoute {
+	return &TerminalRoute{
+		controller: terminal.NewTerminalController(),
+	}
+}
+
+// RegisterSubRouter 注册子路由
+func (r *TerminalRoute) RegisterSubRouter(apiGroup *gin.RouterGroup) {
+	terminalGroup := apiGroup.Group("/k8s/terminal")
+	{
+		terminalGroup.GET("/sessions", r.controller.GetSessionList)
+		terminalGroup.GET("/sessions/:sessionId/recording", r.controller.GetSessionRecording)
+		terminalGroup.GET("/live", r.controller.GetLiveSessionList)
+		terminalGroup.DELETE("/live/:sessionId", r.controller.KillSession)
+		terminalGroup.GET("/permissions", r.controller.GetPermissionList)
+		terminalGroup.POST("/permissions", r.controller.CreatePermission)
+		terminalGroup.DELETE("/permissions/:id", r.controller.DeletePermission)
+	}
+}
@@ -19,5 +19,9 @@ func (r *VpaRoute) RegisterSubRouter(apiGroup *gin.RouterGroup) {
 		vpaGroup.GET("/list/:namespace", vc.GetVPAList)
 		vpaGroup.GET("/detail/:namespace/:name", vc.GetVPADetail)
 		vpaGroup.DELETE("/delete/:namespace/:name", vc.DeleteVPA)
+		vpaGroup.POST("/create", vc.CreateVPA)
+		vpaGroup.PUT("/update", vc.UpdateVPA)
+		vpaGroup.GET("/recommendation/:namespace/:name", vc.GetVPARecommendation)
+		vpaGroup.POST("/apply/:namespace/:name", vc.ApplyVPARecommendation)
 	}
 }
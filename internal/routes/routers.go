@@ -2,6 +2,7 @@
 package routers
 
 import (
+	"devops-console-backend/internal/routes/alert"
 	"devops-console-backend/internal/routes/cicd"
 	"devops-console-backend/internal/routes/es/backup"
 	"devops-console-backend/internal/routes/es/elasticsearch"
@@ -37,5 +38,9 @@ func RegisterRouters(r *gin.Engine, db *gorm.DB) {
 
 		// CiCd 模块
 		cicd.RegisterCiCdRouters(apiGroup)
+
+		// 告警模块
+		alertRoute := alert.NewAlertRoute(db)
+		alertRoute.RegisterSubRouter(apiGroup)
 	}
 }
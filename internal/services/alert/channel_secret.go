@@ -0,0 +1,150 @@
+package alert
+
+import (
+	"devops-console-backend/pkg/configs"
+	"devops-console-backend/pkg/utils"
+	"encoding/json"
+	"errors"
+	"fmt"
+	"net/url"
+	"strings"
+)
+
+// encryptedPrefix 渠道配置中已加密字段的前缀
+const encryptedPrefix = "enc:"
+
+// MaskedValue 响应中敏感字段的掩码，更新时字段包含掩码表示保留原值
+const MaskedValue = "******"
+
+// ErrNoEncryptionKey 未配置加密密钥时不能保存含敏感字段的渠道配置
+var ErrNoEncryptionKey = errors.New("未配置security.encryption_key，无法保存通知渠道的密码和密钥")
+
+// secretFields 渠道配置中的敏感字段：webhook地址（含access_token等令牌）、钉钉加签密钥和SMTP密码
+func (cfg *ChannelConfig) secretFields() []*string {
+	return []*string{&cfg.URL, &cfg.Secret, &cfg.Password}
+}
+
+// parseChannelConfig 解析渠道配置
+func parseChannelConfig(raw string) (*ChannelConfig, error) {
+	cfg := &ChannelConfig{}
+	if strings.TrimSpace(raw) == "" {
+		return cfg, nil
+	}
+	if err := json.Unmarshal([]byte(raw), cfg); err != nil {
+		return nil, fmt.Errorf("渠道配置格式错误: %w", err)
+	}
+	return cfg, nil
+}
+
+// EncryptChannelConfig 加密渠道配置中的敏感字段后返回用于保存的配置。
+// previous为数据库中已保存的配置，新配置中包含掩码的字段沿用其原值
+func EncryptChannelConfig(raw, previous string) (string, error) {
+	cfg, err := parseChannelConfig(raw)
+	if err != nil {
+		return "", err
+	}
+	prev, err := parseChannelConfig(previous)
+	if err != nil {
+		prev = &ChannelConfig{}
+	}
+	key := configs.GetSecurityConfig().EncryptionKey
+
+	seal := func(value, old string) (string, error) {
+		if strings.Contains(value, MaskedValue) {
+			return old, nil
+		}
+		if value == "" || strings.HasPrefix(value, encryptedPrefix) {
+			return value, nil
+		}
+		if key == "" {
+			return "", ErrNoEncryptionKey
+		}
+		encrypted, err := utils.EncryptString([]byte(value), key)
+		if err != nil {
+			return "", fmt.Errorf("加密渠道配置失败: %w", err)
+		}
+		return encryptedPrefix + encrypted, nil
+	}
+
+	prevFields := prev.secretFields()
+	for i, field := range cfg.secretFields() {
+		if *field, err = seal(*field, *prevFields[i]); err != nil {
+			return "", err
+		}
+	}
+	// 自定义请求头通常携带认证信息，按敏感字段处理
+	for name, value := range cfg.Headers {
+		if cfg.Headers[name], err = seal(value, prev.Headers[name]); err != nil {
+			return "", err
+		}
+	}
+
+	data, err := json.Marshal(cfg)
+	if err != nil {
+		return "", err
+	}
+	return string(data), nil
+}
+
+// decryptChannelConfig 解密渠道配置中的敏感字段，未加密的历史数据原样返回
+func decryptChannelConfig(cfg *ChannelConfig) error {
+	key := configs.GetSecurityConfig().EncryptionKey
+	open := func(value string) (string, error) {
+		if !strings.HasPrefix(value, encryptedPrefix) {
+			return value, nil
+		}
+		if key == "" {
+			return "", ErrNoEncryptionKey
+		}
+		plain, err := utils.DecryptString(strings.TrimPrefix(value, encryptedPrefix), key)
+		if err != nil {
+			return "", fmt.Errorf("解密渠道配置失败: %w", err)
+		}
+		return string(plain), nil
+	}
+
+	var err error
+	for _, field := range cfg.secretFields() {
+		if *field, err = open(*field); err != nil {
+			return err
+		}
+	}
+	for name, value := range cfg.Headers {
+		if cfg.Headers[name], err = open(value); err != nil {
+			return err
+		}
+	}
+	return nil
+}
+
+// MaskChannelConfig 返回隐藏敏感字段后的渠道配置，webhook地址只保留协议和主机
+func MaskChannelConfig(raw string) string {
+	cfg, err := parseChannelConfig(raw)
+	if err != nil {
+		return ""
+	}
+	if cfg.URL != "" {
+		plain := &ChannelConfig{URL: cfg.URL}
+		masked := MaskedValue
+		if err := decryptChannelConfig(plain); err == nil {
+			if u, err := url.Parse(plain.URL); err == nil && u.Host != "" {
+				masked = u.Scheme + "://" + u.Host + "/" + MaskedValue
+			}
+		}
+		cfg.URL = masked
+	}
+	if cfg.Secret != "" {
+		cfg.Secret = MaskedValue
+	}
+	if cfg.Password != "" {
+		cfg.Password = MaskedValue
+	}
+	for name := range cfg.Headers {
+		cfg.Headers[name] = MaskedValue
+	}
+	data, err := json.Marshal(cfg)
+	if err != nil {
+		return ""
+	}
+	return string(data)
+}
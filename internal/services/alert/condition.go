@@ -0,0 +1,387 @@
+package alert
+
+import (
+	"context"
+	"devops-console-backend/internal/dal"
+	"devops-console-backend/internal/dal/model"
+	"devops-console-backend/pkg/configs"
+	"encoding/json"
+	"fmt"
+	"sort"
+	"strings"
+	"time"
+
+	"gorm.io/gorm"
+	corev1 "k8s.io/api/core/v1"
+	metav1 "k8s.io/apimachinery/pkg/apis/meta/v1"
+)
+
+// RuleParams 告警规则条件参数，不同条件类型使用其中的部分字段
+type RuleParams struct {
+	Minutes    int      `json:"minutes"`     // 持续时间或统计时间窗口（分钟）
+	Count      int      `json:"count"`       // 次数阈值
+	Namespace  string   `json:"namespace"`   // 命名空间，为空表示全部
+	Reasons    []string `json:"reasons"`     // K8s事件原因列表，为空表示全部Warning事件
+	Status     string   `json:"status"`      // ES集群健康状态阈值：red 或 yellow
+	PipelineID uint32   `json:"pipeline_id"` // 流水线ID，0表示全部
+}
+
+// Finding 一次规则评估产生的告警对象
+type Finding struct {
+	Fingerprint string
+	InstanceID  uint
+	Target      string
+	Summary     string
+}
+
+// conditionFunc 条件评估函数。部分实例评估失败时返回其余实例的结果和InstanceErrors
+type conditionFunc func(ctx context.Context, db *gorm.DB, rule *dal.AlertRule, params *RuleParams) ([]Finding, error)
+
+var conditions = map[string]conditionFunc{
+	dal.AlertRuleTypeNodeNotReady:         evalNodeNotReady,
+	dal.AlertRuleTypePodCrashLoop:         evalPodCrashLoop,
+	dal.AlertRuleTypeK8sWarningEvent:      evalK8sWarningEvent,
+	dal.AlertRuleTypeEsClusterHealth:      evalEsClusterHealth,
+	dal.AlertRuleTypePipelineRunFailed:    evalPipelineRunFailed,
+	dal.AlertRuleTypeConnectionTestFailed: evalConnectionTestFailed,
+}
+
+// SupportedRuleTypes 返回支持的条件类型
+func SupportedRuleTypes() []string {
+	types := make([]string, 0, len(conditions))
+	for t := range conditions {
+		types = append(types, t)
+	}
+	sort.Strings(types)
+	return types
+}
+
+// IsSupportedRuleType 判断条件类型是否支持
+func IsSupportedRuleType(ruleType string) bool {
+	_, ok := conditions[ruleType]
+	return ok
+}
+
+// ParseRuleParams 解析规则参数
+func ParseRuleParams(raw string) (*RuleParams, error) {
+	params := &RuleParams{}
+	if strings.TrimSpace(raw) == "" {
+		return params, nil
+	}
+	if err := json.Unmarshal([]byte(raw), params); err != nil {
+		return nil, fmt.Errorf("规则参数格式错误: %w", err)
+	}
+	return params, nil
+}
+
+// InstanceErrors 按实例记录的评估错误，其余实例的评估结果仍然有效
+type InstanceErrors map[uint]error
+
+func (e InstanceErrors) Error() string {
+	ids := make([]uint, 0, len(e))
+	for id := range e {
+		ids = append(ids, id)
+	}
+	sort.Slice(ids, func(i, j int) bool { return ids[i] < ids[j] })
+	messages := make([]string, 0, len(ids))
+	for _, id := range ids {
+		messages = append(messages, e[id].Error())
+	}
+	return strings.Join(messages, "; ")
+}
+
+// orNil 没有错误时返回nil，避免返回非nil的空map
+func (e InstanceErrors) orNil() error {
+	if len(e) == 0 {
+		return nil
+	}
+	return e
+}
+
+// k8sInstanceIDs 获取规则作用的K8s实例
+func k8sInstanceIDs(rule *dal.AlertRule) []uint {
+	if rule.InstanceID != 0 {
+		return []uint{rule.InstanceID}
+	}
+	return configs.GetK8sInstanceIDs()
+}
+
+// evalNodeNotReady 节点NotReady持续超过指定分钟数
+func evalNodeNotReady(ctx context.Context, _ *gorm.DB, rule *dal.AlertRule, params *RuleParams) ([]Finding, error) {
+	findings := make([]Finding, 0)
+	failed := InstanceErrors{}
+	threshold := time.Duration(params.Minutes) * time.Minute
+	for _, instanceID := range k8sInstanceIDs(rule) {
+		client, exists := configs.GetK8sClient(instanceID)
+		if !exists {
+			continue
+		}
+		nodes, err := client.CoreV1().Nodes().List(ctx, metav1.ListOptions{})
+		if err != nil {
+			failed[instanceID] = fmt.Errorf("实例 %d 获取Node列表失败: %w", instanceID, err)
+			continue
+		}
+		for _, node := range nodes.Items {
+			for _, cond := range node.Status.Conditions {
+				if cond.Type != corev1.NodeReady || cond.Status == corev1.ConditionTrue {
+					continue
+				}
+				if time.Since(cond.LastTransitionTime.Time) < threshold {
+					continue
+				}
+				findings = append(findings, Finding{
+					Fingerprint: fmt.Sprintf("node/%d/%s", instanceID, node.Name),
+					InstanceID:  instanceID,
+					Target:      "node/" + node.Name,
+					Summary: fmt.Sprintf("节点 %s 处于 NotReady 状态（%s）：%s",
+						node.Name, cond.Reason, cond.Message),
+				})
+			}
+		}
+	}
+	return findings, failed.orNil()
+}
+
+// evalPodCrashLoop 容器处于CrashLoopBackOff持续超过指定分钟数
+func evalPodCrashLoop(ctx context.Context, _ *gorm.DB, rule *dal.AlertRule, params *RuleParams) ([]Finding, error) {
+	findings := make([]Finding, 0)
+	failed := InstanceErrors{}
+	threshold := time.Duration(params.Minutes) * time.Minute
+	for _, instanceID := range k8sInstanceIDs(rule) {
+		client, exists := configs.GetK8sClient(instanceID)
+		if !exists {
+			continue
+		}
+		pods, err := client.CoreV1().Pods(params.Namespace).List(ctx, metav1.ListOptions{})
+		if err != nil {
+			failed[instanceID] = fmt.Errorf("实例 %d 获取Pod列表失败: %w", instanceID, err)
+			continue
+		}
+		for _, pod := range pods.Items {
+			// 以Ready条件最近一次变化时间作为异常开始时间
+			since := pod.CreationTimestamp.Time
+			for _, cond := range pod.Status.Conditions {
+				if cond.Type == corev1.PodReady && cond.Status != corev1.ConditionTrue {
+					since = cond.LastTransitionTime.Time
+				}
+			}
+			if time.Since(since) < threshold {
+				continue
+			}
+			for _, cs := range pod.Status.ContainerStatuses {
+				if cs.State.Waiting == nil || cs.State.Waiting.Reason != "CrashLoopBackOff" {
+					continue
+				}
+				findings = append(findings, Finding{
+					Fingerprint: fmt.Sprintf("pod/%d/%s/%s/%s", instanceID, pod.Namespace, pod.Name, cs.Name),
+					InstanceID:  instanceID,
+					Target:      fmt.Sprintf("pod/%s/%s", pod.Namespace, pod.Name),
+					Summary: fmt.Sprintf("Pod %s/%s 容器 %s 处于 CrashLoopBackOff，重启次数 %d",
+						pod.Namespace, pod.Name, cs.Name, cs.RestartCount),
+				})
+			}
+		}
+	}
+	return findings, failed.orNil()
+}
+
+// evalK8sWarningEvent 时间窗口内Warning事件次数达到阈值
+func evalK8sWarningEvent(ctx context.Context, _ *gorm.DB, rule *dal.AlertRule, params *RuleParams) ([]Finding, error) {
+	findings := make([]Finding, 0)
+	window := time.Duration(params.Minutes) * time.Minute
+	if window <= 0 {
+		window = 10 * time.Minute
+	}
+	count := int32(params.Count)
+	if count <= 0 {
+		count = 1
+	}
+	failed := InstanceErrors{}
+	reasons := make(map[string]bool, len(params.Reasons))
+	for _, reason := range params.Reasons {
+		reasons[reason] = true
+	}
+
+	for _, instanceID := range k8sInstanceIDs(rule) {
+		client, exists := configs.GetK8sClient(instanceID)
+		if !exists {
+			continue
+		}
+		events, err := client.CoreV1().Events(params.Namespace).List(ctx, metav1.ListOptions{
+			FieldSelector: "type=" + corev1.EventTypeWarning,
+		})
+		if err != nil {
+			failed[instanceID] = fmt.Errorf("实例 %d 获取Event列表失败: %w", instanceID, err)
+			continue
+		}
+
+		// 按涉及对象和原因聚合
+		aggregated := make(map[string]*Finding)
+		counts := make(map[string]int32)
+		for _, event := range events.Items {
+			if len(reasons) > 0 && !reasons[event.Reason] {
+				continue
+			}
+			last := event.LastTimestamp.Time
+			if last.IsZero() {
+				last = event.EventTime.Time
+			}
+			if time.Since(last) > window {
+				continue
+			}
+			obj := event.InvolvedObject
+			key := fmt.Sprintf("event/%d/%s/%s/%s/%s", instanceID, obj.Namespace, obj.Kind, obj.Name, event.Reason)
+			eventCount := event.Count
+			if eventCount == 0 {
+				eventCount = 1
+			}
+			counts[key] += eventCount
+			aggregated[key] = &Finding{
+				Fingerprint: key,
+				InstanceID:  instanceID,
+				Target:      fmt.Sprintf("%s/%s/%s", strings.ToLower(obj.Kind), obj.Namespace, obj.Name),
+				Summary:     fmt.Sprintf("%s %s/%s: %s", event.Reason, obj.Namespace, obj.Name, event.Message),
+			}
+		}
+		for key, finding := range aggregated {
+			if counts[key] < count {
+				continue
+			}
+			finding.Summary = fmt.Sprintf("%s（%d 分钟内 %d 次）", finding.Summary, int(window.Minutes()), counts[key])
+			findings = append(findings, *finding)
+		}
+	}
+	return findings, failed.orNil()
+}
+
+// evalEsClusterHealth ES集群健康状态达到阈值
+func evalEsClusterHealth(ctx context.Context, _ *gorm.DB, rule *dal.AlertRule, params *RuleParams) ([]Finding, error) {
+	findings := make([]Finding, 0)
+	failed := InstanceErrors{}
+	level := strings.ToLower(params.Status)
+	if level == "" {
+		level = "red"
+	}
+
+	instanceIDs := configs.GetEsInstanceIDs()
+	if rule.InstanceID != 0 {
+		instanceIDs = []uint{rule.InstanceID}
+	}
+	for _, instanceID := range instanceIDs {
+		client, exists := configs.GetEsClient(instanceID)
+		if !exists {
+			continue
+		}
+		res, err := client.Cluster.Health(client.Cluster.Health.WithContext(ctx))
+		if err != nil {
+			failed[instanceID] = fmt.Errorf("实例 %d 获取集群健康状态失败: %w", instanceID, err)
+			continue
+		}
+		var health struct {
+			ClusterName      string `json:"cluster_name"`
+			Status           string `json:"status"`
+			UnassignedShards int    `json:"unassigned_shards"`
+		}
+		err = json.NewDecoder(res.Body).Decode(&health)
+		_ = res.Body.Close()
+		if err != nil {
+			failed[instanceID] = fmt.Errorf("实例 %d 解析集群健康状态失败: %w", instanceID, err)
+			continue
+		}
+
+		if health.Status == "red" || (level == "yellow" && health.Status == "yellow") {
+			findings = append(findings, Finding{
+				Fingerprint: fmt.Sprintf("es/%d", instanceID),
+				InstanceID:  instanceID,
+				Target:      "elasticsearch/" + health.ClusterName,
+				Summary: fmt.Sprintf("ES集群 %s 状态为 %s，未分配分片 %d",
+					health.ClusterName, health.Status, health.UnassignedShards),
+			})
+		}
+	}
+	return findings, failed.orNil()
+}
+
+// evalPipelineRunFailed 时间窗口内失败的流水线执行
+func evalPipelineRunFailed(ctx context.Context, db *gorm.DB, _ *dal.AlertRule, params *RuleParams) ([]Finding, error) {
+	window := time.Duration(params.Minutes) * time.Minute
+	if window <= 0 {
+		window = 10 * time.Minute
+	}
+
+	query := db.WithContext(ctx).Model(&model.PipelineRun{}).
+		Where("status IN ? AND updated_at >= ?", []string{"Failed", "Error"}, time.Now().Add(-window))
+	if params.PipelineID != 0 {
+		query = query.Where("pipeline_id = ?", params.PipelineID)
+	}
+	var runs []model.PipelineRun
+	if err := query.Find(&runs).Error; err != nil {
+		return nil, fmt.Errorf("查询流水线执行记录失败: %w", err)
+	}
+
+	findings := make([]Finding, 0, len(runs))
+	for _, run := range runs {
+		status := ""
+		if run.Status != nil {
+			status = *run.Status
+		}
+		findings = append(findings, Finding{
+			Fingerprint: fmt.Sprintf("pipeline_run/%d", run.ID),
+			Target:      "workflow/" + run.WorkflowName,
+			Summary: fmt.Sprintf("流水线 %d 执行 %s 状态为 %s，执行人 %s",
+				run.PipelineID, run.WorkflowName, status, run.Operator),
+		})
+	}
+	return findings, nil
+}
+
+// evalConnectionTestFailed 实例最近连续N次连接测试失败
+func evalConnectionTestFailed(ctx context.Context, db *gorm.DB, rule *dal.AlertRule, params *RuleParams) ([]Finding, error) {
+	count := params.Count
+	if count <= 0 {
+		count = 3
+	}
+
+	var instances []dal.Instance
+	query := db.WithContext(ctx).Where("status = ?", "active")
+	if rule.InstanceID != 0 {
+		query = query.Where("id = ?", rule.InstanceID)
+	}
+	if err := query.Find(&instances).Error; err != nil {
+		return nil, fmt.Errorf("查询实例失败: %w", err)
+	}
+
+	findings := make([]Finding, 0)
+	failed := InstanceErrors{}
+	for _, instance := range instances {
+		var tests []dal.ConnectionTest
+		err := db.WithContext(ctx).
+			Where("resource_type = ? AND resource_id = ?", dal.ResourceTypeInstance, instance.ID).
+			Order("tested_at DESC").Limit(count).Find(&tests).Error
+		if err != nil {
+			failed[instance.ID] = fmt.Errorf("查询实例 %d 连接测试记录失败: %w", instance.ID, err)
+			continue
+		}
+		if len(tests) < count {
+			continue
+		}
+		allFailed := true
+		for _, test := range tests {
+			if test.TestResult == "success" {
+				allFailed = false
+				break
+			}
+		}
+		if !allFailed {
+			continue
+		}
+		findings = append(findings, Finding{
+			Fingerprint: fmt.Sprintf("connection_test/%d", instance.ID),
+			InstanceID:  instance.ID,
+			Target:      "instance/" + instance.Name,
+			Summary: fmt.Sprintf("实例 %s 最近 %d 次连接测试失败：%s",
+				instance.Name, count, tests[0].ErrorMessage),
+		})
+	}
+	return findings, failed.orNil()
+}
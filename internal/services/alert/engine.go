@@ -0,0 +1,315 @@
+package alert
+
+import (
+	"context"
+	"devops-console-backend/internal/dal"
+	"devops-console-backend/pkg/configs"
+	"devops-console-backend/pkg/utils/logs"
+	"errors"
+	"fmt"
+	"strconv"
+	"strings"
+	"sync"
+	"time"
+
+	"gorm.io/gorm"
+)
+
+// Engine 告警规则引擎，定时评估规则并发送通知
+type Engine struct {
+	db            *gorm.DB
+	interval      time.Duration
+	notifyTimeout time.Duration
+	ruleLocks     sync.Map // 规则ID -> *sync.Mutex，同一规则的评估串行执行
+}
+
+var (
+	sharedEngine     *Engine
+	sharedEngineOnce sync.Once
+)
+
+// SharedEngine 返回全局共享的告警引擎，后台定时评估和手动评估使用同一实例
+func SharedEngine(db *gorm.DB) *Engine {
+	sharedEngineOnce.Do(func() {
+		sharedEngine = NewEngineFromConfig(db)
+	})
+	return sharedEngine
+}
+
+// NewEngine 创建告警引擎实例
+func NewEngine(db *gorm.DB, interval, notifyTimeout time.Duration) *Engine {
+	if interval <= 0 {
+		interval = time.Minute
+	}
+	if notifyTimeout <= 0 {
+		notifyTimeout = 10 * time.Second
+	}
+	return &Engine{db: db, interval: interval, notifyTimeout: notifyTimeout}
+}
+
+// NewEngineFromConfig 根据全局告警配置创建告警引擎
+func NewEngineFromConfig(db *gorm.DB) *Engine {
+	cfg := configs.GetAlertConfig()
+	return NewEngine(db,
+		time.Duration(cfg.EvaluateInterval)*time.Second,
+		time.Duration(cfg.NotifyTimeout)*time.Second)
+}
+
+// Run 启动规则评估循环，直到stopCh关闭
+func (e *Engine) Run(stopCh <-chan struct{}) {
+	ticker := time.NewTicker(e.interval)
+	defer ticker.Stop()
+
+	logs.Info(map[string]interface{}{"interval": e.interval.String()}, "告警引擎已启动")
+	for {
+		select {
+		case <-stopCh:
+			logs.Info(nil, "告警引擎已停止")
+			return
+		case <-ticker.C:
+			e.evaluateAll()
+		}
+	}
+}
+
+// evaluateAll 评估所有启用的规则
+func (e *Engine) evaluateAll() {
+	var rules []dal.AlertRule
+	if err := e.db.Where("enabled = ?", true).Find(&rules).Error; err != nil {
+		logs.Error(map[string]interface{}{"error": err.Error()}, "查询告警规则失败")
+		return
+	}
+	for i := range rules {
+		if err := e.EvaluateRule(&rules[i]); err != nil {
+			logs.Warning(map[string]interface{}{
+				"rule_id": rules[i].ID,
+				"error":   err.Error(),
+			}, "告警规则评估失败")
+		}
+	}
+}
+
+// EvaluateRule 评估单条规则，维护告警记录并发送通知。
+// 同一规则的并发评估会串行执行，避免重复创建告警记录和重复通知
+func (e *Engine) EvaluateRule(rule *dal.AlertRule) error {
+	lock, _ := e.ruleLocks.LoadOrStore(rule.ID, &sync.Mutex{})
+	lock.(*sync.Mutex).Lock()
+	defer lock.(*sync.Mutex).Unlock()
+
+	cond, ok := conditions[rule.Type]
+	if !ok {
+		return fmt.Errorf("不支持的条件类型: %s", rule.Type)
+	}
+	params, err := ParseRuleParams(rule.Params)
+	if err != nil {
+		return err
+	}
+
+	ctx, cancel := context.WithTimeout(context.Background(), e.interval)
+	defer cancel()
+	// 评估失败时不处理恢复，避免因临时错误产生错误的恢复通知；
+	// 部分实例失败时仍处理其余实例，失败实例的告警保持原状态
+	findings, err := cond(ctx, e.db, rule, params)
+	var failed InstanceErrors
+	if err != nil && !errors.As(err, &failed) {
+		return err
+	}
+
+	now := time.Now()
+	silences, err := e.activeSilences(now)
+	if err != nil {
+		return err
+	}
+
+	active := make(map[string]bool, len(findings))
+	for _, finding := range findings {
+		active[finding.Fingerprint] = true
+		if err := e.fire(rule, finding, silences, now); err != nil {
+			logs.Error(map[string]interface{}{
+				"rule_id":     rule.ID,
+				"fingerprint": finding.Fingerprint,
+				"error":       err.Error(),
+			}, "处理告警失败")
+		}
+	}
+	if err := e.resolve(rule, active, failed, now); err != nil {
+		return err
+	}
+	return failed.orNil()
+}
+
+// fire 处理触发的告警：同一指纹去重，按重复间隔再次通知
+func (e *Engine) fire(rule *dal.AlertRule, finding Finding, silences []dal.AlertSilence, now time.Time) error {
+	silenced := isSilenced(silences, rule.ID, finding)
+
+	var event dal.AlertEvent
+	err := e.db.Where("rule_id = ? AND fingerprint = ? AND status = ?", rule.ID, finding.Fingerprint, dal.AlertStatusFiring).
+		First(&event).Error
+	if err != nil && !errors.Is(err, gorm.ErrRecordNotFound) {
+		return err
+	}
+
+	if errors.Is(err, gorm.ErrRecordNotFound) {
+		event = dal.AlertEvent{
+			RuleID:      rule.ID,
+			RuleName:    rule.Name,
+			Severity:    rule.Severity,
+			Fingerprint: finding.Fingerprint,
+			InstanceID:  finding.InstanceID,
+			Target:      finding.Target,
+			Summary:     finding.Summary,
+			Status:      dal.AlertStatusFiring,
+			Silenced:    silenced,
+			FirstSeenAt: now,
+			LastSeenAt:  now,
+		}
+		if err := e.db.Create(&event).Error; err != nil {
+			return err
+		}
+	} else {
+		event.Summary = finding.Summary
+		event.Silenced = silenced
+		event.LastSeenAt = now
+	}
+
+	repeat := time.Duration(rule.RepeatInterval) * time.Minute
+	if !silenced && (event.LastNotifiedAt == nil || (repeat > 0 && now.Sub(*event.LastNotifiedAt) >= repeat)) {
+		e.notify(rule, &Notification{
+			RuleID:     rule.ID,
+			RuleName:   rule.Name,
+			Severity:   rule.Severity,
+			Status:     dal.AlertStatusFiring,
+			InstanceID: event.InstanceID,
+			Target:     event.Target,
+			Summary:    event.Summary,
+			StartsAt:   event.FirstSeenAt,
+		})
+		event.LastNotifiedAt = &now
+	}
+
+	return e.db.Model(&dal.AlertEvent{}).Where("id = ?", event.ID).Updates(map[string]interface{}{
+		"summary":          event.Summary,
+		"silenced":         event.Silenced,
+		"last_seen_at":     event.LastSeenAt,
+		"last_notified_at": event.LastNotifiedAt,
+	}).Error
+}
+
+// resolve 将本次评估未出现的告警标记为已恢复，跳过评估失败的实例
+func (e *Engine) resolve(rule *dal.AlertRule, active map[string]bool, failed InstanceErrors, now time.Time) error {
+	var firing []dal.AlertEvent
+	if err := e.db.Where("rule_id = ? AND status = ?", rule.ID, dal.AlertStatusFiring).Find(&firing).Error; err != nil {
+		return err
+	}
+	for _, event := range firing {
+		if active[event.Fingerprint] {
+			continue
+		}
+		if _, ok := failed[event.InstanceID]; ok {
+			continue
+		}
+		err := e.db.Model(&dal.AlertEvent{}).Where("id = ?", event.ID).Updates(map[string]interface{}{
+			"status":      dal.AlertStatusResolved,
+			"resolved_at": now,
+		}).Error
+		if err != nil {
+			return err
+		}
+		// 只有发送过告警通知的记录才发送恢复通知
+		if rule.SendResolved && !event.Silenced && event.LastNotifiedAt != nil {
+			e.notify(rule, &Notification{
+				RuleID:     rule.ID,
+				RuleName:   rule.Name,
+				Severity:   rule.Severity,
+				Status:     dal.AlertStatusResolved,
+				InstanceID: event.InstanceID,
+				Target:     event.Target,
+				Summary:    event.Summary,
+				StartsAt:   event.FirstSeenAt,
+				ResolvedAt: &now,
+			})
+		}
+	}
+	return nil
+}
+
+// activeSilences 获取当前生效的静默
+func (e *Engine) activeSilences(now time.Time) ([]dal.AlertSilence, error) {
+	var silences []dal.AlertSilence
+	err := e.db.Where("starts_at <= ? AND ends_at > ?", now, now).Find(&silences).Error
+	return silences, err
+}
+
+// isSilenced 判断告警是否命中静默
+func isSilenced(silences []dal.AlertSilence, ruleID uint, finding Finding) bool {
+	for _, s := range silences {
+		if s.RuleID != 0 && s.RuleID != ruleID {
+			continue
+		}
+		if s.InstanceID != 0 && s.InstanceID != finding.InstanceID {
+			continue
+		}
+		if s.Target != "" && !strings.Contains(finding.Target, s.Target) {
+			continue
+		}
+		return true
+	}
+	return false
+}
+
+// notify 向规则配置的所有渠道发送通知
+func (e *Engine) notify(rule *dal.AlertRule, n *Notification) {
+	ids := ParseChannelIDs(rule.ChannelIDs)
+	if len(ids) == 0 {
+		return
+	}
+	var channels []dal.AlertChannel
+	if err := e.db.Where("id IN ? AND enabled = ?", ids, true).Find(&channels).Error; err != nil {
+		logs.Error(map[string]interface{}{"rule_id": rule.ID, "error": err.Error()}, "查询通知渠道失败")
+		return
+	}
+	for i := range channels {
+		if err := e.Send(&channels[i], n); err != nil {
+			logs.Error(map[string]interface{}{
+				"rule_id":    rule.ID,
+				"channel_id": channels[i].ID,
+				"error":      err.Error(),
+			}, "发送告警通知失败")
+		}
+	}
+}
+
+// Send 通过指定渠道发送通知
+func (e *Engine) Send(channel *dal.AlertChannel, n *Notification) error {
+	notifier, err := NewNotifier(channel, e.notifyTimeout)
+	if err != nil {
+		return err
+	}
+	ctx, cancel := context.WithTimeout(context.Background(), e.notifyTimeout)
+	defer cancel()
+	return notifier.Send(ctx, n)
+}
+
+// ParseChannelIDs 解析逗号分隔的渠道ID列表
+func ParseChannelIDs(raw string) []uint {
+	ids := make([]uint, 0)
+	for _, part := range strings.Split(raw, ",") {
+		part = strings.TrimSpace(part)
+		if part == "" {
+			continue
+		}
+		if id, err := strconv.ParseUint(part, 10, 32); err == nil {
+			ids = append(ids, uint(id))
+		}
+	}
+	return ids
+}
+
+// FormatChannelIDs 将渠道ID列表格式化为逗号分隔字符串
+func FormatChannelIDs(ids []uint) string {
+	parts := make([]string, 0, len(ids))
+	for _, id := range ids {
+		parts = append(parts, strconv.FormatUint(uint64(id), 10))
+	}
+	return strings.Join(parts, ",")
+}
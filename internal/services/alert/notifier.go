@@ -0,0 +1,248 @@
+package alert
+
+import (
+	"bytes"
+	"context"
+	"crypto/hmac"
+	"crypto/sha256"
+	"crypto/tls"
+	"devops-console-backend/internal/dal"
+	"encoding/base64"
+	"encoding/json"
+	"errors"
+	"fmt"
+	"io"
+	"net"
+	"net/http"
+	"net/smtp"
+	"net/url"
+	"strconv"
+	"strings"
+	"time"
+)
+
+// Notification 告警通知内容
+type Notification struct {
+	RuleID     uint       `json:"rule_id"`
+	RuleName   string     `json:"rule_name"`
+	Severity   string     `json:"severity"`
+	Status     string     `json:"status"` // firing 或 resolved
+	InstanceID uint       `json:"instance_id"`
+	Target     string     `json:"target"`
+	Summary    string     `json:"summary"`
+	StartsAt   time.Time  `json:"starts_at"`
+	ResolvedAt *time.Time `json:"resolved_at,omitempty"`
+}
+
+// Title 通知标题
+func (n *Notification) Title() string {
+	status := "告警"
+	if n.Status == dal.AlertStatusResolved {
+		status = "恢复"
+	}
+	return fmt.Sprintf("[%s][%s] %s", status, strings.ToUpper(n.Severity), n.RuleName)
+}
+
+// Text 通知正文（Markdown）
+func (n *Notification) Text() string {
+	var b strings.Builder
+	b.WriteString("### " + n.Title() + "\n\n")
+	b.WriteString("- 对象：" + n.Target + "\n")
+	if n.InstanceID != 0 {
+		b.WriteString(fmt.Sprintf("- 实例ID：%d\n", n.InstanceID))
+	}
+	b.WriteString("- 详情：" + n.Summary + "\n")
+	b.WriteString("- 开始时间：" + n.StartsAt.Format("2006-01-02 15:04:05") + "\n")
+	if n.ResolvedAt != nil {
+		b.WriteString("- 恢复时间：" + n.ResolvedAt.Format("2006-01-02 15:04:05") + "\n")
+	}
+	return b.String()
+}
+
+// Notifier 通知渠道
+type Notifier interface {
+	Send(ctx context.Context, n *Notification) error
+}
+
+// ChannelConfig 通知渠道配置，不同渠道类型使用其中的部分字段
+type ChannelConfig struct {
+	URL      string            `json:"url,omitempty"`      // webhook地址
+	Secret   string            `json:"secret,omitempty"`   // 钉钉加签密钥
+	Headers  map[string]string `json:"headers,omitempty"`  // 通用webhook自定义请求头
+	Host     string            `json:"host,omitempty"`     // SMTP服务器
+	Port     int               `json:"port,omitempty"`     // SMTP端口，465使用SSL
+	Username string            `json:"username,omitempty"` // SMTP用户名
+	Password string            `json:"password,omitempty"` // SMTP密码，与URL、Secret、Headers一起加密保存
+	From     string            `json:"from,omitempty"`     // 发件人
+	To       []string          `json:"to,omitempty"`       // 收件人
+}
+
+// NewNotifier 根据渠道配置创建通知器
+func NewNotifier(channel *dal.AlertChannel, timeout time.Duration) (Notifier, error) {
+	parsed, err := parseChannelConfig(channel.Config)
+	if err != nil {
+		return nil, err
+	}
+	if err := decryptChannelConfig(parsed); err != nil {
+		return nil, err
+	}
+	cfg := *parsed
+	httpClient := &http.Client{Timeout: timeout}
+
+	switch channel.Type {
+	case dal.AlertChannelTypeWebhook, dal.AlertChannelTypeDingTalk, dal.AlertChannelTypeWeCom, dal.AlertChannelTypeSlack:
+		if cfg.URL == "" {
+			return nil, errors.New("webhook地址不能为空")
+		}
+		return &webhookNotifier{kind: channel.Type, cfg: cfg, client: httpClient}, nil
+	case dal.AlertChannelTypeEmail:
+		if cfg.Host == "" || cfg.From == "" || len(cfg.To) == 0 {
+			return nil, errors.New("邮件渠道需要配置 host、from 和 to")
+		}
+		if cfg.Port == 0 {
+			cfg.Port = 25
+		}
+		return &emailNotifier{cfg: cfg, timeout: timeout}, nil
+	default:
+		return nil, fmt.Errorf("不支持的通知渠道类型: %s", channel.Type)
+	}
+}
+
+// webhookNotifier 通用webhook以及钉钉、企业微信、Slack机器人
+type webhookNotifier struct {
+	kind   string
+	cfg    ChannelConfig
+	client *http.Client
+}
+
+func (w *webhookNotifier) Send(ctx context.Context, n *Notification) error {
+	target := w.cfg.URL
+	var payload interface{}
+	switch w.kind {
+	case dal.AlertChannelTypeDingTalk:
+		payload = map[string]interface{}{
+			"msgtype":  "markdown",
+			"markdown": map[string]string{"title": n.Title(), "text": n.Text()},
+		}
+		if w.cfg.Secret != "" {
+			target = signDingTalkURL(target, w.cfg.Secret, time.Now())
+		}
+	case dal.AlertChannelTypeWeCom:
+		payload = map[string]interface{}{
+			"msgtype":  "markdown",
+			"markdown": map[string]string{"content": n.Text()},
+		}
+	case dal.AlertChannelTypeSlack:
+		payload = map[string]string{"text": n.Text()}
+	default:
+		payload = n
+	}
+
+	body, err := json.Marshal(payload)
+	if err != nil {
+		return err
+	}
+	req, err := http.NewRequestWithContext(ctx, http.MethodPost, target, bytes.NewReader(body))
+	if err != nil {
+		return err
+	}
+	req.Header.Set("Content-Type", "application/json")
+	for k, v := range w.cfg.Headers {
+		req.Header.Set(k, v)
+	}
+
+	resp, err := w.client.Do(req)
+	if err != nil {
+		return fmt.Errorf("发送webhook失败: %w", err)
+	}
+	defer func() { _ = resp.Body.Close() }()
+	if resp.StatusCode < 200 || resp.StatusCode >= 300 {
+		respBody, _ := io.ReadAll(io.LimitReader(resp.Body, 1024))
+		return fmt.Errorf("webhook返回错误状态码 %d: %s", resp.StatusCode, string(respBody))
+	}
+	return nil
+}
+
+// signDingTalkURL 钉钉机器人加签
+func signDingTalkURL(rawURL, secret string, now time.Time) string {
+	timestamp := strconv.FormatInt(now.UnixMilli(), 10)
+	mac := hmac.New(sha256.New, []byte(secret))
+	mac.Write([]byte(timestamp + "\n" + secret))
+	sign := url.QueryEscape(base64.StdEncoding.EncodeToString(mac.Sum(nil)))
+	sep := "?"
+	if strings.Contains(rawURL, "?") {
+		sep = "&"
+	}
+	return fmt.Sprintf("%s%stimestamp=%s&sign=%s", rawURL, sep, timestamp, sign)
+}
+
+// emailNotifier SMTP邮件通知
+type emailNotifier struct {
+	cfg     ChannelConfig
+	timeout time.Duration
+}
+
+func (e *emailNotifier) Send(ctx context.Context, n *Notification) error {
+	addr := net.JoinHostPort(e.cfg.Host, strconv.Itoa(e.cfg.Port))
+	dialer := &net.Dialer{Timeout: e.timeout}
+
+	var conn net.Conn
+	var err error
+	if e.cfg.Port == 465 {
+		conn, err = tls.DialWithDialer(dialer, "tcp", addr, &tls.Config{ServerName: e.cfg.Host})
+	} else {
+		conn, err = dialer.DialContext(ctx, "tcp", addr)
+	}
+	if err != nil {
+		return fmt.Errorf("连接SMTP服务器失败: %w", err)
+	}
+	if deadline, ok := ctx.Deadline(); ok {
+		_ = conn.SetDeadline(deadline)
+	}
+
+	client, err := smtp.NewClient(conn, e.cfg.Host)
+	if err != nil {
+		_ = conn.Close()
+		return fmt.Errorf("创建SMTP客户端失败: %w", err)
+	}
+	defer func() { _ = client.Close() }()
+
+	if ok, _ := client.Extension("STARTTLS"); ok && e.cfg.Port != 465 {
+		if err := client.StartTLS(&tls.Config{ServerName: e.cfg.Host}); err != nil {
+			return fmt.Errorf("STARTTLS失败: %w", err)
+		}
+	}
+	if e.cfg.Username != "" {
+		if err := client.Auth(smtp.PlainAuth("", e.cfg.Username, e.cfg.Password, e.cfg.Host)); err != nil {
+			return fmt.Errorf("SMTP认证失败: %w", err)
+		}
+	}
+	if err := client.Mail(e.cfg.From); err != nil {
+		return err
+	}
+	for _, to := range e.cfg.To {
+		if err := client.Rcpt(to); err != nil {
+			return err
+		}
+	}
+	writer, err := client.Data()
+	if err != nil {
+		return err
+	}
+
+	var msg strings.Builder
+	msg.WriteString("From: " + e.cfg.From + "\r\n")
+	msg.WriteString("To: " + strings.Join(e.cfg.To, ",") + "\r\n")
+	msg.WriteString("Subject: =?UTF-8?B?" + base64.StdEncoding.EncodeToString([]byte(n.Title())) + "?=\r\n")
+	msg.WriteString("MIME-Version: 1.0\r\n")
+	msg.WriteString("Content-Type: text/plain; charset=UTF-8\r\n\r\n")
+	msg.WriteString(strings.ReplaceAll(n.Text(), "\n", "\r\n"))
+	if _, err := writer.Write([]byte(msg.String())); err != nil {
+		_ = writer.Close()
+		return err
+	}
+	if err := writer.Close(); err != nil {
+		return err
+	}
+	return client.Quit()
+}
@@ -0,0 +1,123 @@
+package capacity
+
+import (
+	"devops-console-backend/internal/dal/request/k8s"
+	"fmt"
+	"sort"
+	"strings"
+
+	corev1 "k8s.io/api/core/v1"
+	"k8s.io/apimachinery/pkg/api/resource"
+)
+
+// 容量视图关注的资源分类
+const (
+	CategoryCPU     = "cpu"
+	CategoryMemory  = "memory"
+	CategoryPods    = "pods"
+	CategoryPVC     = "pvc"
+	CategoryStorage = "storage"
+	CategoryOther   = "other"
+)
+
+// resourceCategory 配额资源名到分类的映射
+var resourceCategory = map[corev1.ResourceName]string{
+	corev1.ResourceCPU:                    CategoryCPU,
+	corev1.ResourceRequestsCPU:            CategoryCPU,
+	corev1.ResourceLimitsCPU:              CategoryCPU,
+	corev1.ResourceMemory:                 CategoryMemory,
+	corev1.ResourceRequestsMemory:         CategoryMemory,
+	corev1.ResourceLimitsMemory:           CategoryMemory,
+	corev1.ResourcePods:                   CategoryPods,
+	corev1.ResourcePersistentVolumeClaims: CategoryPVC,
+	corev1.ResourceRequestsStorage:        CategoryStorage,
+}
+
+// categoryOrder 分类展示顺序
+var categoryOrder = map[string]int{
+	CategoryCPU:     0,
+	CategoryMemory:  1,
+	CategoryPods:    2,
+	CategoryPVC:     3,
+	CategoryStorage: 4,
+	CategoryOther:   5,
+}
+
+// ParseResourceList 解析资源数量，值为Kubernetes quantity格式
+func ParseResourceList(values map[string]string) (corev1.ResourceList, error) {
+	if len(values) == 0 {
+		return nil, nil
+	}
+	list := corev1.ResourceList{}
+	for name, value := range values {
+		quantity, err := resource.ParseQuantity(value)
+		if err != nil {
+			return nil, fmt.Errorf("%s 的值 %q 无效: %w", name, value, err)
+		}
+		list[corev1.ResourceName(name)] = quantity
+	}
+	return list, nil
+}
+
+// FormatResourceList 将资源数量转换为字符串
+func FormatResourceList(list corev1.ResourceList) map[string]string {
+	if len(list) == 0 {
+		return nil
+	}
+	values := make(map[string]string, len(list))
+	for name, quantity := range list {
+		values[string(name)] = quantity.String()
+	}
+	return values
+}
+
+// Category 返回配额资源的分类，按存储类限制的requests.storage也归为storage
+func Category(name corev1.ResourceName) string {
+	if category, ok := resourceCategory[name]; ok {
+		return category
+	}
+	if strings.HasSuffix(string(name), "/"+string(corev1.ResourceRequestsStorage)) {
+		return CategoryStorage
+	}
+	return CategoryOther
+}
+
+// Usage 计算命名空间内各配额的使用率，threshold为告警百分比
+func Usage(quotas []corev1.ResourceQuota, threshold float64) ([]k8s.QuotaUsageItem, []string) {
+	items := []k8s.QuotaUsageItem{}
+	warnings := []string{}
+	for _, quota := range quotas {
+		for name, hard := range quota.Status.Hard {
+			used := quota.Status.Used[name]
+			item := k8s.QuotaUsageItem{
+				Quota:    quota.Name,
+				Resource: string(name),
+				Category: Category(name),
+				Hard:     hard.String(),
+				Used:     used.String(),
+			}
+			if hardValue := hard.AsApproximateFloat64(); hardValue > 0 {
+				item.Percent = used.AsApproximateFloat64() / hardValue * 100
+			} else if !used.IsZero() {
+				item.Percent = 100
+			}
+			if item.Percent >= threshold {
+				item.Warning = true
+				warnings = append(warnings, fmt.Sprintf("%s 的 %s 已使用 %.1f%%（%s/%s）",
+					quota.Name, name, item.Percent, item.Used, item.Hard))
+			}
+			items = append(items, item)
+		}
+	}
+	sort.Slice(items, func(i, j int) bool {
+		if items[i].Category != items[j].Category {
+			return categoryOrder[items[i].Category] < categoryOrder[items[j].Category]
+		}
+		if items[i].Resource != items[j].Resource {
+			return items[i].Resource < items[j].Resource
+		}
+		return items[i].Quota < items[j].Quota
+	})
+	sort.Strings(warnings)
+	return items, warnings
+}
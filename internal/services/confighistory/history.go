@@ -0,0 +1,185 @@
+package confighistory
+
+import (
+	"bytes"
+	"devops-console-backend/internal/dal"
+	"devops-console-backend/internal/dal/request/k8s"
+	"devops-console-backend/internal/services/workload"
+	"devops-console-backend/pkg/configs"
+	"devops-console-backend/pkg/utils"
+	"encoding/json"
+	"errors"
+	"fmt"
+	"sort"
+	"time"
+
+	"github.com/pmezard/go-difflib/difflib"
+	corev1 "k8s.io/api/core/v1"
+)
+
+// ErrNoEncryptionKey 未配置加密密钥时不保存Secret历史，Secret修改不受影响
+var ErrNoEncryptionKey = errors.New("未配置security.encryption_key，跳过保存Secret历史版本")
+
+// 差异状态
+const (
+	DiffAdded   = "added"
+	DiffRemoved = "removed"
+	DiffChanged = "changed"
+)
+
+// Content ConfigMap或Secret的数据内容
+type Content struct {
+	Type       string            `json:"type,omitempty"`       // Secret类型
+	Data       map[string]string `json:"data,omitempty"`       // ConfigMap文本数据
+	BinaryData map[string][]byte `json:"binaryData,omitempty"` // ConfigMap二进制数据
+	SecretData map[string][]byte `json:"secretData,omitempty"` // Secret数据
+}
+
+// ConfigMapContent 提取ConfigMap的数据
+func ConfigMapContent(configMap *corev1.ConfigMap) *Content {
+	return &Content{Data: configMap.Data, BinaryData: configMap.BinaryData}
+}
+
+// SecretContent 提取Secret的数据
+func SecretContent(secret *corev1.Secret) *Content {
+	return &Content{Type: string(secret.Type), SecretData: secret.Data}
+}
+
+// ApplyToConfigMap 用历史内容覆盖ConfigMap的数据
+func ApplyToConfigMap(configMap *corev1.ConfigMap, content *Content) {
+	configMap.Data = content.Data
+	configMap.BinaryData = content.BinaryData
+}
+
+// ApplyToSecret 用历史内容覆盖Secret的数据，Secret类型不可变因此不恢复
+func ApplyToSecret(secret *corev1.Secret, content *Content) {
+	secret.Data = content.SecretData
+	secret.StringData = nil
+}
+
+// Keys 返回内容中的全部键
+func (c *Content) Keys() []string {
+	keys := make([]string, 0, len(c.Data)+len(c.BinaryData)+len(c.SecretData))
+	for key := range c.Data {
+		keys = append(keys, key)
+	}
+	for key := range c.BinaryData {
+		keys = append(keys, key)
+	}
+	for key := range c.SecretData {
+		keys = append(keys, key)
+	}
+	sort.Strings(keys)
+	return keys
+}
+
+// Record 保存变更前的内容，Secret内容加密存储
+func Record(instanceID uint, kind, namespace, name string, content *Content, action, operator string) (*dal.K8sConfigRevision, error) {
+	data, err := json.Marshal(content)
+	if err != nil {
+		return nil, err
+	}
+	revision := &dal.K8sConfigRevision{
+		InstanceID: instanceID,
+		Kind:       kind,
+		Namespace:  namespace,
+		Name:       name,
+		Content:    string(data),
+		Action:     action,
+		Operator:   operator,
+		CreatedAt:  time.Now(),
+	}
+	if kind == workload.RefKindSecret {
+		key := configs.GetSecurityConfig().EncryptionKey
+		if key == "" {
+			return nil, ErrNoEncryptionKey
+		}
+		encrypted, err := utils.EncryptString(data, key)
+		if err != nil {
+			return nil, fmt.Errorf("加密Secret内容失败: %w", err)
+		}
+		revision.Content = encrypted
+		revision.Encrypted = true
+	}
+	if err := configs.NewK8sConfigRevisionRepository().Create(revision); err != nil {
+		return nil, err
+	}
+	return revision, nil
+}
+
+// Load 读取历史版本的内容
+func Load(revision *dal.K8sConfigRevision) (*Content, error) {
+	data := []byte(revision.Content)
+	if revision.Encrypted {
+		decrypted, err := utils.DecryptString(revision.Content, configs.GetSecurityConfig().EncryptionKey)
+		if err != nil {
+			return nil, fmt.Errorf("解密历史版本失败: %w", err)
+		}
+		data = decrypted
+	}
+	var content Content
+	if err := json.Unmarshal(data, &content); err != nil {
+		return nil, fmt.Errorf("解析历史版本失败: %w", err)
+	}
+	return &content, nil
+}
+
+// Diff 比较两个版本，ConfigMap文本内容给出unified diff，二进制内容和Secret只给出键级别的变化
+func Diff(from, to *Content) []k8s.ConfigDiffItem {
+	items := []k8s.ConfigDiffItem{}
+	for _, key := range unionKeys(from, to) {
+		oldValue, oldText, oldOK := from.value(key)
+		newValue, newText, newOK := to.value(key)
+		item := k8s.ConfigDiffItem{Key: key}
+		switch {
+		case !oldOK:
+			item.Status = DiffAdded
+		case !newOK:
+			item.Status = DiffRemoved
+		case bytes.Equal(oldValue, newValue) && oldText == newText:
+			continue
+		default:
+			item.Status = DiffChanged
+		}
+		if oldText || newText {
+			item.Diff, _ = difflib.GetUnifiedDiffString(difflib.UnifiedDiff{
+				A:        difflib.SplitLines(string(oldValue)),
+				B:        difflib.SplitLines(string(newValue)),
+				FromFile: key,
+				ToFile:   key,
+				Context:  3,
+			})
+		}
+		items = append(items, item)
+	}
+	return items
+}
+
+// value 返回键的值及是否为可展示的ConfigMap文本
+func (c *Content) value(key string) ([]byte, bool, bool) {
+	if value, ok := c.Data[key]; ok {
+		return []byte(value), true, true
+	}
+	if value, ok := c.BinaryData[key]; ok {
+		return value, false, true
+	}
+	value, ok := c.SecretData[key]
+	return value, false, ok
+}
+
+// unionKeys 返回两个版本的键并集
+func unionKeys(a, b *Content) []string {
+	set := map[string]struct{}{}
+	for _, key := range a.Keys() {
+		set[key] = struct{}{}
+	}
+	for _, key := range b.Keys() {
+		set[key] = struct{}{}
+	}
+	keys := make([]string, 0, len(set))
+	for key := range set {
+		keys = append(keys, key)
+	}
+	sort.Strings(keys)
+	return keys
+}
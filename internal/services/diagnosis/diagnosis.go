@@ -0,0 +1,332 @@
+package diagnosis
+
+import (
+	"context"
+	"devops-console-backend/internal/dal/request/k8s"
+	"devops-console-backend/internal/services/workload"
+	"errors"
+	"fmt"
+	"sort"
+
+	corev1 "k8s.io/api/core/v1"
+	apierrors "k8s.io/apimachinery/pkg/api/errors"
+	metav1 "k8s.io/apimachinery/pkg/apis/meta/v1"
+	"k8s.io/apimachinery/pkg/labels"
+	"k8s.io/client-go/kubernetes"
+)
+
+// maxPods 工作负载诊断时最多检查的Pod数，优先检查未就绪的Pod
+const maxPods = 20
+
+// ErrNotFound 诊断对象不存在
+var ErrNotFound = errors.New("资源不存在")
+
+// WorkloadKinds 支持诊断的工作负载类型，key为路径参数
+var WorkloadKinds = map[string]string{
+	"deployment":  "Deployment",
+	"statefulset": "StatefulSet",
+	"daemonset":   "DaemonSet",
+	"job":         "Job",
+}
+
+// PVCProblem 未绑定PVC的说明
+type PVCProblem struct {
+	Name       string
+	Message    string
+	Suggestion string
+}
+
+// RefStatus Pod模板引用对象的检查结果
+type RefStatus struct {
+	MissingConfigMaps  []string
+	MissingSecrets     []string
+	MissingPullSecrets []string
+	UnboundPVCs        []PVCProblem
+}
+
+// CheckRefs 检查Pod模板引用的ConfigMap、Secret和PVC是否存在、PVC是否已绑定
+func CheckRefs(ctx context.Context, client kubernetes.Interface, namespace string, spec *corev1.PodSpec) (*RefStatus, error) {
+	status := &RefStatus{}
+	pullSecrets := map[string]bool{}
+	for _, ref := range spec.ImagePullSecrets {
+		pullSecrets[ref.Name] = true
+	}
+
+	for _, ref := range workload.PodSpecNamedRefs(spec, workload.RefKindConfigMap) {
+		_, err := client.CoreV1().ConfigMaps(namespace).Get(ctx, ref.Name, metav1.GetOptions{})
+		if apierrors.IsNotFound(err) {
+			if ref.Required {
+				status.MissingConfigMaps = append(status.MissingConfigMaps, ref.Name)
+			}
+		} else if err != nil {
+			return nil, fmt.Errorf("获取ConfigMap %s 失败: %w", ref.Name, err)
+		}
+	}
+
+	for _, ref := range workload.PodSpecNamedRefs(spec, workload.RefKindSecret) {
+		_, err := client.CoreV1().Secrets(namespace).Get(ctx, ref.Name, metav1.GetOptions{})
+		if apierrors.IsNotFound(err) {
+			// 同一个Secret可能既是镜像凭据又被挂载，挂载引用优先按必需处理
+			if ref.Required {
+				status.MissingSecrets = append(status.MissingSecrets, ref.Name)
+			} else if pullSecrets[ref.Name] {
+				status.MissingPullSecrets = append(status.MissingPullSecrets, ref.Name)
+			}
+		} else if err != nil {
+			return nil, fmt.Errorf("获取Secret %s 失败: %w", ref.Name, err)
+		}
+	}
+
+	for _, name := range workload.PodSpecRefNames(spec, workload.RefKindPersistentVolumeClaim) {
+		pvc, err := client.CoreV1().PersistentVolumeClaims(namespace).Get(ctx, name, metav1.GetOptions{})
+		if apierrors.IsNotFound(err) {
+			status.UnboundPVCs = append(status.UnboundPVCs, PVCProblem{
+				Name:       name,
+				Message:    "引用的PVC " + name + " 不存在",
+				Suggestion: "创建该PVC，StatefulSet的volumeClaimTemplates会自动创建",
+			})
+			continue
+		}
+		if err != nil {
+			return nil, fmt.Errorf("获取PVC %s 失败: %w", name, err)
+		}
+		if pvc.Status.Phase == corev1.ClaimBound {
+			continue
+		}
+		status.UnboundPVCs = append(status.UnboundPVCs, pvcProblem(ctx, client, pvc))
+	}
+	return status, nil
+}
+
+// pvcProblem 根据PVC事件说明未绑定原因
+func pvcProblem(ctx context.Context, client kubernetes.Interface, pvc *corev1.PersistentVolumeClaim) PVCProblem {
+	problem := PVCProblem{
+		Name:       pvc.Name,
+		Message:    fmt.Sprintf("PVC %s 处于 %s 状态，未绑定PV", pvc.Name, pvc.Status.Phase),
+		Suggestion: "检查StorageClass是否存在、存储供应器是否正常，或手动创建匹配的PV",
+	}
+	if pvc.Status.Phase == corev1.ClaimLost {
+		problem.Suggestion = "绑定的PV已丢失，需要恢复PV或重建PVC"
+	}
+	if pvc.Spec.StorageClassName != nil {
+		problem.Message += "，StorageClass: " + *pvc.Spec.StorageClassName
+	}
+
+	events, err := ListEvents(ctx, client, pvc.Namespace, "PersistentVolumeClaim", pvc.Name)
+	if err != nil {
+		return problem
+	}
+	for _, reason := range []string{"ProvisioningFailed", "FailedBinding"} {
+		if list := latestEvents(events, reason); len(list) > 0 {
+			problem.Message += "，" + list[0].Message
+			break
+		}
+	}
+	if list := latestEvents(events, "WaitForFirstConsumer"); len(list) > 0 {
+		problem.Suggestion = "StorageClass为WaitForFirstConsumer模式，PVC会在Pod调度后绑定，优先排查Pod调度问题"
+	}
+	return problem
+}
+
+// DiagnosePod 对单个Pod执行全部诊断规则
+func DiagnosePod(ctx context.Context, client kubernetes.Interface, pod *corev1.Pod, events []corev1.Event) (*k8s.DiagnosisResult, error) {
+	refs, err := CheckRefs(ctx, client, pod.Namespace, &pod.Spec)
+	if err != nil {
+		return nil, err
+	}
+	result := &k8s.DiagnosisResult{Kind: "Pod", Namespace: pod.Namespace, Name: pod.Name, Pods: 1}
+	result.Findings = merge(nil, runPodRules(&PodInput{Pod: pod, Events: events, Refs: refs}))
+	finish(result)
+	return result, nil
+}
+
+// runPodRules 执行Pod规则，并将Pod名称记录到发现的问题中
+func runPodRules(in *PodInput) []k8s.DiagnosisFinding {
+	var findings []k8s.DiagnosisFinding
+	for _, rule := range podRules {
+		for _, finding := range rule(in) {
+			finding.Objects = []string{"Pod/" + in.Pod.Name}
+			findings = append(findings, finding)
+		}
+	}
+	return findings
+}
+
+// workloadTarget 工作负载的Pod选择器、Pod模板和负责创建Pod的控制器对象
+type workloadTarget struct {
+	selector    labels.Selector
+	template    *corev1.PodSpec
+	controllers []objectRef // 创建Pod的对象
+}
+
+// objectRef 同一命名空间内的对象
+type objectRef struct {
+	kind string
+	name string
+}
+
+func (r objectRef) String() string {
+	return r.kind + "/" + r.name
+}
+
+func loadWorkload(ctx context.Context, client kubernetes.Interface, kind, namespace, name string) (*workloadTarget, error) {
+	var selector *metav1.LabelSelector
+	target := &workloadTarget{controllers: []objectRef{{kind, name}}}
+	switch kind {
+	case "Deployment":
+		item, err := client.AppsV1().Deployments(namespace).Get(ctx, name, metav1.GetOptions{})
+		if err != nil {
+			return nil, err
+		}
+		selector, target.template = item.Spec.Selector, &item.Spec.Template.Spec
+		// Deployment的Pod由ReplicaSet创建，创建失败的事件记录在ReplicaSet上
+		replicaSets, err := client.AppsV1().ReplicaSets(namespace).List(ctx, metav1.ListOptions{})
+		if err != nil {
+			return nil, err
+		}
+		for i := range replicaSets.Items {
+			owner := metav1.GetControllerOf(&replicaSets.Items[i])
+			if owner != nil && owner.Kind == kind && owner.UID == item.UID {
+				target.controllers = append(target.controllers, objectRef{"ReplicaSet", replicaSets.Items[i].Name})
+			}
+		}
+	case "StatefulSet":
+		item, err := client.AppsV1().StatefulSets(namespace).Get(ctx, name, metav1.GetOptions{})
+		if err != nil {
+			return nil, err
+		}
+		selector, target.template = item.Spec.Selector, &item.Spec.Template.Spec
+	case "DaemonSet":
+		item, err := client.AppsV1().DaemonSets(namespace).Get(ctx, name, metav1.GetOptions{})
+		if err != nil {
+			return nil, err
+		}
+		selector, target.template = item.Spec.Selector, &item.Spec.Template.Spec
+	case "Job":
+		item, err := client.BatchV1().Jobs(namespace).Get(ctx, name, metav1.GetOptions{})
+		if err != nil {
+			return nil, err
+		}
+		selector, target.template = item.Spec.Selector, &item.Spec.Template.Spec
+	default:
+		return nil, fmt.Errorf("不支持诊断的类型: %s", kind)
+	}
+
+	parsed, err := metav1.LabelSelectorAsSelector(selector)
+	if err != nil {
+		return nil, fmt.Errorf("解析selector失败: %w", err)
+	}
+	target.selector = parsed
+	return target, nil
+}
+
+// DiagnoseWorkload 诊断工作负载：检查Pod模板引用、控制器创建Pod的失败事件，并对其Pod执行诊断规则
+func DiagnoseWorkload(ctx context.Context, client kubernetes.Interface, kind, namespace, name string) (*k8s.DiagnosisResult, error) {
+	target, err := loadWorkload(ctx, client, kind, namespace, name)
+	if err != nil {
+		if apierrors.IsNotFound(err) {
+			return nil, ErrNotFound
+		}
+		return nil, err
+	}
+	result := &k8s.DiagnosisResult{Kind: kind, Namespace: namespace, Name: name}
+
+	// 引用检查基于Pod模板只做一次
+	refs, err := CheckRefs(ctx, client, namespace, target.template)
+	if err != nil {
+		return nil, err
+	}
+	var findings []k8s.DiagnosisFinding
+	for _, finding := range checkRefs(&PodInput{Refs: refs}) {
+		finding.Objects = []string{kind + "/" + name}
+		findings = append(findings, finding)
+	}
+
+	for _, controller := range target.controllers {
+		events, err := ListEvents(ctx, client, namespace, controller.kind, controller.name)
+		if err != nil {
+			return nil, fmt.Errorf("获取%s事件失败: %w", controller, err)
+		}
+		findings = merge(findings, checkCreateFailures(events, controller.String()))
+	}
+
+	pods, err := client.CoreV1().Pods(namespace).List(ctx, metav1.ListOptions{LabelSelector: target.selector.String()})
+	if err != nil {
+		return nil, fmt.Errorf("获取Pod列表失败: %w", err)
+	}
+	items := pods.Items
+	sort.SliceStable(items, func(i, j int) bool { return !podReady(&items[i]) && podReady(&items[j]) })
+	if len(items) > maxPods {
+		items = items[:maxPods]
+	}
+	for i := range items {
+		events, err := ListEvents(ctx, client, namespace, "Pod", items[i].Name)
+		if err != nil {
+			return nil, fmt.Errorf("获取Pod事件失败: %w", err)
+		}
+		// 引用问题已在模板级别报告
+		findings = merge(findings, runPodRules(&PodInput{Pod: &items[i], Events: events}))
+	}
+	result.Pods = len(items)
+	result.Findings = findings
+
+	if len(pods.Items) == 0 && len(findings) == 0 {
+		result.Findings = append(result.Findings, k8s.DiagnosisFinding{
+			Rule:       RuleCreateFailed,
+			Severity:   SeverityInfo,
+			Message:    "没有匹配的Pod",
+			Suggestion: "检查副本数是否为0，或Job是否已完成并被清理",
+			Objects:    []string{kind + "/" + name},
+		})
+	}
+	finish(result)
+	return result, nil
+}
+
+func podReady(pod *corev1.Pod) bool {
+	if pod.Status.Phase == corev1.PodSucceeded {
+		return true
+	}
+	for _, condition := range pod.Status.Conditions {
+		if condition.Type == corev1.PodReady {
+			return condition.Status == corev1.ConditionTrue
+		}
+	}
+	return false
+}
+
+// merge 合并问题列表，规则和描述相同的问题合并涉及的对象
+func merge(findings, more []k8s.DiagnosisFinding) []k8s.DiagnosisFinding {
+	for _, finding := range more {
+		merged := false
+		for i := range findings {
+			if findings[i].Rule == finding.Rule && findings[i].Message == finding.Message {
+				findings[i].Objects = append(findings[i].Objects, finding.Objects...)
+				merged = true
+				break
+			}
+		}
+		if !merged {
+			findings = append(findings, finding)
+		}
+	}
+	return findings
+}
+
+var severityOrder = map[string]int{SeverityCritical: 0, SeverityWarning: 1, SeverityInfo: 2}
+
+// finish 按严重程度排序并计算整体健康状态
+func finish(result *k8s.DiagnosisResult) {
+	if result.Findings == nil {
+		result.Findings = []k8s.DiagnosisFinding{}
+	}
+	sort.SliceStable(result.Findings, func(i, j int) bool {
+		return severityOrder[result.Findings[i].Severity] < severityOrder[result.Findings[j].Severity]
+	})
+	result.Healthy = true
+	for _, finding := range result.Findings {
+		if finding.Severity != SeverityInfo {
+			result.Healthy = false
+		}
+	}
+}
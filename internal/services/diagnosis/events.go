@@ -0,0 +1,70 @@
+package diagnosis
+
+import (
+	"context"
+	"fmt"
+	"sort"
+
+	corev1 "k8s.io/api/core/v1"
+	metav1 "k8s.io/apimachinery/pkg/apis/meta/v1"
+	"k8s.io/client-go/kubernetes"
+)
+
+// eventLimit 每个对象最多读取的事件数
+const eventLimit = 50
+
+// ListEvents 获取对象的事件，kind为空时只按名称过滤
+func ListEvents(ctx context.Context, client kubernetes.Interface, namespace, kind, name string) ([]corev1.Event, error) {
+	fieldSelector := fmt.Sprintf("involvedObject.name=%s", name)
+	if kind != "" {
+		fieldSelector += ",involvedObject.kind=" + kind
+	}
+	events, err := client.CoreV1().Events(namespace).List(ctx, metav1.ListOptions{
+		FieldSelector: fieldSelector,
+		Limit:         eventLimit,
+	})
+	if err != nil {
+		return nil, err
+	}
+	return events.Items, nil
+}
+
+// eventTime 返回事件最近一次发生的时间
+func eventTime(event *corev1.Event) metav1.Time {
+	switch {
+	case !event.LastTimestamp.IsZero():
+		return event.LastTimestamp
+	case event.Series != nil:
+		return metav1.Time{Time: event.Series.LastObservedTime.Time}
+	case !event.EventTime.IsZero():
+		return metav1.Time{Time: event.EventTime.Time}
+	default:
+		return event.FirstTimestamp
+	}
+}
+
+// latestEvents 返回指定原因的事件，按发生时间从新到旧排序
+func latestEvents(events []corev1.Event, reason string) []*corev1.Event {
+	var result []*corev1.Event
+	for i := range events {
+		if events[i].Reason == reason {
+			result = append(result, &events[i])
+		}
+	}
+	sort.SliceStable(result, func(i, j int) bool {
+		ti, tj := eventTime(result[i]), eventTime(result[j])
+		return tj.Before(&ti)
+	})
+	return result
+}
+
+// eventCount 返回事件发生次数
+func eventCount(event *corev1.Event) int32 {
+	if event.Series != nil && event.Series.Count > 0 {
+		return event.Series.Count
+	}
+	if event.Count > 0 {
+		return event.Count
+	}
+	return 1
+}
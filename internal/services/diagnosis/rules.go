@@ -0,0 +1,384 @@
+package diagnosis
+
+import (
+	"devops-console-backend/internal/dal/request/k8s"
+	"fmt"
+	"regexp"
+	"strings"
+
+	corev1 "k8s.io/api/core/v1"
+)
+
+// 问题严重程度
+const (
+	SeverityCritical = "critical"
+	SeverityWarning  = "warning"
+	SeverityInfo     = "info"
+)
+
+// 诊断规则
+const (
+	RuleUnschedulable = "unschedulable"
+	RuleImagePull     = "image-pull"
+	RuleOOMKilled     = "oom-killed"
+	RuleCrashLoop     = "crash-loop"
+	RuleProbeFailed   = "probe-failed"
+	RuleMissingConfig = "missing-config"
+	RuleUnboundPVC    = "unbound-pvc"
+	RuleQuotaExceeded = "quota-exceeded"
+	RuleCreateFailed  = "create-failed"
+)
+
+// PodInput 诊断单个Pod所需的数据
+type PodInput struct {
+	Pod    *corev1.Pod
+	Events []corev1.Event
+	Refs   *RefStatus
+}
+
+// podRule Pod诊断规则
+type podRule func(in *PodInput) []k8s.DiagnosisFinding
+
+var podRules = []podRule{
+	checkUnschedulable,
+	checkImagePull,
+	checkOOMKilled,
+	checkCrashLoop,
+	checkProbes,
+	checkRefs,
+}
+
+// schedulingHints 调度失败原因与处理建议，按顺序匹配
+var schedulingHints = []struct {
+	keyword    string
+	suggestion string
+}{
+	{"Insufficient", "降低容器的资源requests，或为集群扩容节点"},
+	{"untolerated taint", "为Pod添加对应的tolerations，或移除节点上的污点"},
+	{"had taint", "为Pod添加对应的tolerations，或移除节点上的污点"},
+	{"node affinity/selector", "检查nodeSelector和nodeAffinity是否与节点标签匹配"},
+	{"pod affinity", "检查podAffinity规则，确认目标Pod存在于可调度节点上"},
+	{"anti-affinity", "检查podAntiAffinity规则，副本数可能超过了可用的拓扑域数量"},
+	{"topology spread", "检查topologySpreadConstraints的maxSkew和whenUnsatisfiable设置"},
+	{"unbound immediate PersistentVolumeClaims", "检查Pod使用的PVC是否已绑定"},
+	{"volume node affinity conflict", "PV所在可用区与可调度节点不一致，检查StorageClass的volumeBindingMode"},
+	{"Too many pods", "节点Pod数量已达上限，扩容节点或调整maxPods"},
+	{"free ports", "hostPort端口冲突，去掉hostPort或更换端口"},
+	{"unschedulable", "节点已被cordon，执行uncordon或等待维护结束"},
+	{"not ready", "节点未就绪，检查节点状态"},
+}
+
+// schedulingCount 匹配调度失败消息中的单个原因，如 "2 Insufficient cpu"
+var schedulingCount = regexp.MustCompile(`^(\d+)\s+(.+)$`)
+
+// parseSchedulingReasons 解析FailedScheduling事件消息，
+// 如 "0/3 nodes are available: 1 Insufficient cpu, 2 node(s) had untolerated taint {...}. preemption: ..."
+func parseSchedulingReasons(message string) []string {
+	if i := strings.Index(message, ". preemption:"); i >= 0 {
+		message = message[:i]
+	}
+	i := strings.Index(message, "available: ")
+	if i < 0 {
+		return []string{strings.TrimSpace(message)}
+	}
+	var reasons []string
+	for _, part := range strings.Split(strings.TrimSuffix(message[i+len("available: "):], "."), ", ") {
+		if part = strings.TrimSpace(part); part != "" {
+			reasons = append(reasons, part)
+		}
+	}
+	return reasons
+}
+
+func schedulingSuggestion(reason string) string {
+	for _, hint := range schedulingHints {
+		if strings.Contains(reason, hint.keyword) {
+			return hint.suggestion
+		}
+	}
+	return "检查Pod的调度约束（资源请求、nodeSelector、亲和性、容忍）"
+}
+
+func checkUnschedulable(in *PodInput) []k8s.DiagnosisFinding {
+	if in.Pod.Spec.NodeName != "" {
+		return nil
+	}
+	message := ""
+	if events := latestEvents(in.Events, "FailedScheduling"); len(events) > 0 {
+		message = events[0].Message
+	} else {
+		for _, condition := range in.Pod.Status.Conditions {
+			if condition.Type == corev1.PodScheduled && condition.Status == corev1.ConditionFalse {
+				message = condition.Message
+			}
+		}
+	}
+	if message == "" {
+		return nil
+	}
+
+	var findings []k8s.DiagnosisFinding
+	for _, reason := range parseSchedulingReasons(message) {
+		text := reason
+		if match := schedulingCount.FindStringSubmatch(reason); match != nil {
+			text = fmt.Sprintf("%s 个节点: %s", match[1], match[2])
+		}
+		findings = append(findings, k8s.DiagnosisFinding{
+			Rule:       RuleUnschedulable,
+			Severity:   SeverityCritical,
+			Message:    "无法调度，" + text,
+			Suggestion: schedulingSuggestion(reason),
+		})
+	}
+	return findings
+}
+
+// imagePullSuggestion 根据拉取失败的错误信息给出建议
+func imagePullSuggestion(message string) string {
+	lower := strings.ToLower(message)
+	switch {
+	case strings.Contains(lower, "unauthorized"), strings.Contains(lower, "authentication required"),
+		strings.Contains(lower, "pull access denied"), strings.Contains(lower, "forbidden"):
+		return "镜像仓库鉴权失败，检查imagePullSecrets是否存在且凭据有效"
+	case strings.Contains(lower, "not found"), strings.Contains(lower, "manifest unknown"):
+		return "镜像或tag不存在，检查镜像名称和tag"
+	case strings.Contains(lower, "timeout"), strings.Contains(lower, "dial tcp"), strings.Contains(lower, "no such host"):
+		return "节点无法访问镜像仓库，检查网络、DNS和代理配置"
+	case strings.Contains(lower, "invalid reference format"):
+		return "镜像名称格式错误"
+	default:
+		return "检查镜像地址、tag和imagePullSecrets"
+	}
+}
+
+func checkImagePull(in *PodInput) []k8s.DiagnosisFinding {
+	images := map[string]string{}
+	for _, container := range append(append([]corev1.Container{}, in.Pod.Spec.InitContainers...), in.Pod.Spec.Containers...) {
+		images[container.Name] = container.Image
+	}
+
+	pullMessage := ""
+	if events := latestEvents(in.Events, "Failed"); len(events) > 0 {
+		for _, event := range events {
+			if strings.Contains(event.Message, "pull") {
+				pullMessage = event.Message
+				break
+			}
+		}
+	}
+
+	var findings []k8s.DiagnosisFinding
+	for _, status := range containerStatuses(in.Pod) {
+		waiting := status.State.Waiting
+		if waiting == nil {
+			continue
+		}
+		switch waiting.Reason {
+		case "ErrImagePull", "ImagePullBackOff", "InvalidImageName":
+		default:
+			continue
+		}
+		detail := waiting.Message
+		if pullMessage != "" {
+			detail = pullMessage
+		}
+		findings = append(findings, k8s.DiagnosisFinding{
+			Rule:       RuleImagePull,
+			Severity:   SeverityCritical,
+			Message:    fmt.Sprintf("容器 %s 拉取镜像 %s 失败(%s): %s", status.Name, images[status.Name], waiting.Reason, detail),
+			Suggestion: imagePullSuggestion(detail),
+		})
+	}
+	return findings
+}
+
+func checkOOMKilled(in *PodInput) []k8s.DiagnosisFinding {
+	limits := map[string]string{}
+	for _, container := range append(append([]corev1.Container{}, in.Pod.Spec.InitContainers...), in.Pod.Spec.Containers...) {
+		if limit, ok := container.Resources.Limits[corev1.ResourceMemory]; ok {
+			limits[container.Name] = limit.String()
+		}
+	}
+
+	var findings []k8s.DiagnosisFinding
+	for _, status := range containerStatuses(in.Pod) {
+		oom := status.State.Terminated != nil && status.State.Terminated.Reason == "OOMKilled"
+		oom = oom || status.LastTerminationState.Terminated != nil && status.LastTerminationState.Terminated.Reason == "OOMKilled"
+		if !oom {
+			continue
+		}
+		limit := limits[status.Name]
+		if limit == "" {
+			limit = "未设置（受节点内存或LimitRange限制）"
+		}
+		findings = append(findings, k8s.DiagnosisFinding{
+			Rule:       RuleOOMKilled,
+			Severity:   SeverityCritical,
+			Message:    fmt.Sprintf("容器 %s 因内存不足被终止(OOMKilled)，内存limit: %s，已重启 %d 次", status.Name, limit, status.RestartCount),
+			Suggestion: "提高容器内存limit，或排查应用内存泄漏（如JVM堆大小是否超过limit）",
+		})
+	}
+	return findings
+}
+
+// exitCodeHint 常见退出码的含义
+func exitCodeHint(code int32) string {
+	switch code {
+	case 1:
+		return "应用错误"
+	case 126:
+		return "命令无法执行"
+	case 127:
+		return "命令不存在"
+	case 137:
+		return "被SIGKILL终止"
+	case 139:
+		return "段错误"
+	case 143:
+		return "被SIGTERM终止"
+	default:
+		return ""
+	}
+}
+
+func checkCrashLoop(in *PodInput) []k8s.DiagnosisFinding {
+	var findings []k8s.DiagnosisFinding
+	for _, status := range containerStatuses(in.Pod) {
+		if status.State.Waiting == nil || status.State.Waiting.Reason != "CrashLoopBackOff" {
+			continue
+		}
+		last := status.LastTerminationState.Terminated
+		// OOMKilled 由单独的规则给出
+		if last != nil && last.Reason == "OOMKilled" {
+			continue
+		}
+		message := fmt.Sprintf("容器 %s 反复崩溃，已重启 %d 次", status.Name, status.RestartCount)
+		suggestion := "查看容器上一次运行的日志（previous=true）定位启动失败原因"
+		if last != nil {
+			message += fmt.Sprintf("，上次退出码 %d", last.ExitCode)
+			if hint := exitCodeHint(last.ExitCode); hint != "" {
+				message += "（" + hint + "）"
+			}
+			if last.ExitCode == 126 || last.ExitCode == 127 {
+				suggestion = "检查容器的command/args和镜像中的可执行文件"
+			}
+		}
+		findings = append(findings, k8s.DiagnosisFinding{
+			Rule:       RuleCrashLoop,
+			Severity:   SeverityCritical,
+			Message:    message,
+			Suggestion: suggestion,
+		})
+	}
+	return findings
+}
+
+func checkProbes(in *PodInput) []k8s.DiagnosisFinding {
+	var findings []k8s.DiagnosisFinding
+	seen := map[string]bool{}
+	for _, event := range latestEvents(in.Events, "Unhealthy") {
+		probe := ""
+		for _, kind := range []string{"Liveness", "Readiness", "Startup"} {
+			if strings.HasPrefix(event.Message, kind+" probe") {
+				probe = kind
+			}
+		}
+		if probe == "" || seen[probe] {
+			continue
+		}
+		seen[probe] = true
+
+		finding := k8s.DiagnosisFinding{
+			Rule:       RuleProbeFailed,
+			Severity:   SeverityWarning,
+			Message:    fmt.Sprintf("%s探针失败 %d 次: %s", probe, eventCount(event), event.Message),
+			Suggestion: "检查探针的路径、端口和命令，必要时调大initialDelaySeconds、timeoutSeconds或failureThreshold",
+		}
+		if probe != "Readiness" {
+			finding.Severity = SeverityCritical
+			finding.Suggestion = "探针失败会导致容器被重启；" + finding.Suggestion + "，启动较慢的应用建议配置startupProbe"
+		}
+		findings = append(findings, finding)
+	}
+	return findings
+}
+
+func checkRefs(in *PodInput) []k8s.DiagnosisFinding {
+	if in.Refs == nil {
+		return nil
+	}
+	var findings []k8s.DiagnosisFinding
+	for _, name := range in.Refs.MissingConfigMaps {
+		findings = append(findings, k8s.DiagnosisFinding{
+			Rule:       RuleMissingConfig,
+			Severity:   SeverityCritical,
+			Message:    "引用的ConfigMap " + name + " 不存在",
+			Suggestion: "创建该ConfigMap，或将引用设置为optional",
+		})
+	}
+	for _, name := range in.Refs.MissingSecrets {
+		findings = append(findings, k8s.DiagnosisFinding{
+			Rule:       RuleMissingConfig,
+			Severity:   SeverityCritical,
+			Message:    "引用的Secret " + name + " 不存在",
+			Suggestion: "创建该Secret，或将引用设置为optional",
+		})
+	}
+	for _, name := range in.Refs.MissingPullSecrets {
+		findings = append(findings, k8s.DiagnosisFinding{
+			Rule:       RuleMissingConfig,
+			Severity:   SeverityWarning,
+			Message:    "imagePullSecrets中的Secret " + name + " 不存在",
+			Suggestion: "创建镜像仓库凭据Secret，私有镜像将无法拉取",
+		})
+	}
+	for _, pvc := range in.Refs.UnboundPVCs {
+		findings = append(findings, k8s.DiagnosisFinding{
+			Rule:       RuleUnboundPVC,
+			Severity:   SeverityCritical,
+			Message:    pvc.Message,
+			Suggestion: pvc.Suggestion,
+		})
+	}
+	return findings
+}
+
+// containerStatuses 返回初始化容器和普通容器的状态
+func containerStatuses(pod *corev1.Pod) []corev1.ContainerStatus {
+	statuses := make([]corev1.ContainerStatus, 0, len(pod.Status.InitContainerStatuses)+len(pod.Status.ContainerStatuses))
+	statuses = append(statuses, pod.Status.InitContainerStatuses...)
+	return append(statuses, pod.Status.ContainerStatuses...)
+}
+
+// quotaSuggestion 根据FailedCreate事件给出配额相关建议，非配额问题返回空
+func quotaSuggestion(message string) string {
+	switch {
+	case strings.Contains(message, "exceeded quota"):
+		return "命名空间资源配额已用尽，申请提高ResourceQuota，或降低副本数和资源requests"
+	case strings.Contains(message, "must specify"):
+		return "命名空间配置了ResourceQuota，容器必须设置对应的requests/limits，或通过LimitRange设置默认值"
+	default:
+		return ""
+	}
+}
+
+// checkCreateFailures 检查控制器创建Pod失败的事件，包括配额超限
+func checkCreateFailures(events []corev1.Event, object string) []k8s.DiagnosisFinding {
+	list := latestEvents(events, "FailedCreate")
+	if len(list) == 0 {
+		return nil
+	}
+	event := list[0]
+	finding := k8s.DiagnosisFinding{
+		Rule:       RuleCreateFailed,
+		Severity:   SeverityCritical,
+		Message:    fmt.Sprintf("创建Pod失败 %d 次: %s", eventCount(event), event.Message),
+		Suggestion: "根据错误信息检查Pod模板、准入策略（如PodSecurity、Webhook）和ServiceAccount",
+		Objects:    []string{object},
+	}
+	if suggestion := quotaSuggestion(event.Message); suggestion != "" {
+		finding.Rule = RuleQuotaExceeded
+		finding.Suggestion = suggestion
+	}
+	return []k8s.DiagnosisFinding{finding}
+}
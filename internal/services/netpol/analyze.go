@@ -0,0 +1,224 @@
+package netpol
+
+import (
+	"devops-console-backend/internal/dal/request/k8s"
+	"fmt"
+	"net"
+
+	corev1 "k8s.io/api/core/v1"
+	networkingv1 "k8s.io/api/networking/v1"
+	metav1 "k8s.io/apimachinery/pkg/apis/meta/v1"
+	"k8s.io/apimachinery/pkg/labels"
+)
+
+// Endpoint 参与分析的一端，Pod的Namespace字段需与Namespace对象一致
+type Endpoint struct {
+	Pod       *corev1.Pod
+	Namespace *corev1.Namespace
+}
+
+// Analyze 根据集群中的NetworkPolicy判断源到目标指定端口的流量是否放行，
+// 只做纯计算，policies应包含源和目标命名空间中的全部策略
+func Analyze(policies []networkingv1.NetworkPolicy, src, dst Endpoint, port int32, protocol corev1.Protocol) k8s.NetworkPolicyAnalysis {
+	if protocol == "" {
+		protocol = corev1.ProtocolTCP
+	}
+	result := k8s.NetworkPolicyAnalysis{
+		Egress:  evaluate(policies, networkingv1.PolicyTypeEgress, src, dst, dst.Pod, port, protocol),
+		Ingress: evaluate(policies, networkingv1.PolicyTypeIngress, dst, src, dst.Pod, port, protocol),
+	}
+	if src.Pod.Spec.HostNetwork {
+		result.Egress = k8s.NetworkPolicyDirection{Allowed: true, Reason: "源Pod使用主机网络，不受NetworkPolicy约束"}
+	}
+	if dst.Pod.Spec.HostNetwork {
+		result.Ingress = k8s.NetworkPolicyDirection{Allowed: true, Reason: "目标Pod使用主机网络，不受NetworkPolicy约束"}
+	}
+	result.Allowed = result.Egress.Allowed && result.Ingress.Allowed
+	// 入站规则的ipBlock匹配源Pod的IP，出站规则的ipBlock匹配目标Pod的IP
+	if src.Pod.Status.PodIP == "" {
+		result.Notes = append(result.Notes, "源Pod没有IP，入站规则中的ipBlock未参与匹配")
+	}
+	if dst.Pod.Status.PodIP == "" {
+		result.Notes = append(result.Notes, "目标Pod没有IP，出站规则中的ipBlock未参与匹配")
+	}
+	result.Notes = append(result.Notes, "分析结果基于NetworkPolicy语义，实际效果取决于集群网络插件是否支持NetworkPolicy")
+	return result
+}
+
+// evaluate 判定self一侧在指定方向上是否放行与peer之间的流量，target为端口所在的目标Pod
+func evaluate(policies []networkingv1.NetworkPolicy, direction networkingv1.PolicyType, self, peer Endpoint,
+	target *corev1.Pod, port int32, protocol corev1.Protocol) k8s.NetworkPolicyDirection {
+	result := k8s.NetworkPolicyDirection{AllowedBy: []string{}, DeniedBy: []string{}}
+	for i := range policies {
+		policy := &policies[i]
+		if policy.Namespace != self.Pod.Namespace || !hasPolicyType(policy, direction) {
+			continue
+		}
+		if !selectorMatches(&policy.Spec.PodSelector, self.Pod.Labels) {
+			continue
+		}
+		result.Isolated = true
+		name := policy.Namespace + "/" + policy.Name
+		if ruleAllows(policy, direction, peer, target, port, protocol) {
+			result.AllowedBy = append(result.AllowedBy, name)
+		} else {
+			result.DeniedBy = append(result.DeniedBy, name)
+		}
+	}
+
+	directionName := "出站"
+	if direction == networkingv1.PolicyTypeIngress {
+		directionName = "入站"
+	}
+	switch {
+	case !result.Isolated:
+		result.Allowed = true
+		result.Reason = fmt.Sprintf("没有策略限制该Pod的%s流量", directionName)
+	case len(result.AllowedBy) > 0:
+		result.Allowed = true
+		result.Reason = fmt.Sprintf("%s流量被 %s 放行", directionName, result.AllowedBy[0])
+	default:
+		result.Reason = fmt.Sprintf("该Pod的%s流量受 %d 条策略隔离，且没有规则放行", directionName, len(result.DeniedBy))
+	}
+	return result
+}
+
+// hasPolicyType 判断策略是否作用于指定方向，未声明policyTypes时按API默认规则推断
+func hasPolicyType(policy *networkingv1.NetworkPolicy, direction networkingv1.PolicyType) bool {
+	if len(policy.Spec.PolicyTypes) == 0 {
+		if direction == networkingv1.PolicyTypeIngress {
+			return true
+		}
+		return len(policy.Spec.Egress) > 0
+	}
+	for _, policyType := range policy.Spec.PolicyTypes {
+		if policyType == direction {
+			return true
+		}
+	}
+	return false
+}
+
+// ruleAllows 判断策略中是否有规则放行与peer之间的流量
+func ruleAllows(policy *networkingv1.NetworkPolicy, direction networkingv1.PolicyType, peer Endpoint,
+	target *corev1.Pod, port int32, protocol corev1.Protocol) bool {
+	if direction == networkingv1.PolicyTypeIngress {
+		for _, rule := range policy.Spec.Ingress {
+			if peersMatch(rule.From, policy.Namespace, peer) && portsMatch(rule.Ports, target, port, protocol) {
+				return true
+			}
+		}
+		return false
+	}
+	for _, rule := range policy.Spec.Egress {
+		if peersMatch(rule.To, policy.Namespace, peer) && portsMatch(rule.Ports, target, port, protocol) {
+			return true
+		}
+	}
+	return false
+}
+
+// peersMatch 判断peer是否命中规则的来源或目标，列表为空表示全部
+func peersMatch(peers []networkingv1.NetworkPolicyPeer, policyNamespace string, peer Endpoint) bool {
+	if len(peers) == 0 {
+		return true
+	}
+	for _, p := range peers {
+		if p.IPBlock != nil {
+			if ipBlockMatches(p.IPBlock, peer.Pod.Status.PodIP) {
+				return true
+			}
+			continue
+		}
+		if p.NamespaceSelector != nil {
+			if !selectorMatches(p.NamespaceSelector, peer.Namespace.Labels) {
+				continue
+			}
+		} else if peer.Pod.Namespace != policyNamespace {
+			// 只有podSelector时限定在策略所在命名空间
+			continue
+		}
+		if p.PodSelector != nil && !selectorMatches(p.PodSelector, peer.Pod.Labels) {
+			continue
+		}
+		return true
+	}
+	return false
+}
+
+// ipBlockMatches 判断IP是否在CIDR内且不在排除范围内
+func ipBlockMatches(block *networkingv1.IPBlock, podIP string) bool {
+	ip := net.ParseIP(podIP)
+	if ip == nil {
+		return false
+	}
+	_, cidr, err := net.ParseCIDR(block.CIDR)
+	if err != nil || !cidr.Contains(ip) {
+		return false
+	}
+	for _, except := range block.Except {
+		if _, exceptNet, err := net.ParseCIDR(except); err == nil && exceptNet.Contains(ip) {
+			return false
+		}
+	}
+	return true
+}
+
+// portsMatch 判断端口是否命中规则，列表为空表示全部端口，命名端口按目标Pod的容器端口解析
+func portsMatch(ports []networkingv1.NetworkPolicyPort, target *corev1.Pod, port int32, protocol corev1.Protocol) bool {
+	if len(ports) == 0 {
+		return true
+	}
+	for _, p := range ports {
+		ruleProtocol := corev1.ProtocolTCP
+		if p.Protocol != nil {
+			ruleProtocol = *p.Protocol
+		}
+		if ruleProtocol != protocol {
+			continue
+		}
+		if p.Port == nil {
+			return true
+		}
+		if p.Port.StrVal != "" {
+			if namedPortMatches(target, p.Port.StrVal, port, protocol) {
+				return true
+			}
+			continue
+		}
+		start := p.Port.IntVal
+		end := start
+		if p.EndPort != nil {
+			end = *p.EndPort
+		}
+		if port >= start && port <= end {
+			return true
+		}
+	}
+	return false
+}
+
+// namedPortMatches 判断目标Pod中名为name的容器端口是否为指定端口
+func namedPortMatches(pod *corev1.Pod, name string, port int32, protocol corev1.Protocol) bool {
+	for _, container := range pod.Spec.Containers {
+		for _, containerPort := range container.Ports {
+			portProtocol := containerPort.Protocol
+			if portProtocol == "" {
+				portProtocol = corev1.ProtocolTCP
+			}
+			if containerPort.Name == name && containerPort.ContainerPort == port && portProtocol == protocol {
+				return true
+			}
+		}
+	}
+	return false
+}
+
+// selectorMatches 判断标签是否命中选择器，空选择器命中全部
+func selectorMatches(selector *metav1.LabelSelector, set map[string]string) bool {
+	s, err := metav1.LabelSelectorAsSelector(selector)
+	if err != nil {
+		return false
+	}
+	return s.Matches(labels.Set(set))
+}
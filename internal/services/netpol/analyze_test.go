@@ -0,0 +1,188 @@
+package netpol
+
+import (
+	"strings"
+	"testing"
+
+	corev1 "k8s.io/api/core/v1"
+	networkingv1 "k8s.io/api/networking/v1"
+	metav1 "k8s.io/apimachinery/pkg/apis/meta/v1"
+	"k8s.io/apimachinery/pkg/util/intstr"
+)
+
+func newEndpoint(namespace string, nsLabels map[string]string, name string, podLabels map[string]string, ip string) Endpoint {
+	return Endpoint{
+		Pod: &corev1.Pod{
+			ObjectMeta: metav1.ObjectMeta{Name: name, Namespace: namespace, Labels: podLabels},
+			Status:     corev1.PodStatus{PodIP: ip},
+		},
+		Namespace: &corev1.Namespace{ObjectMeta: metav1.ObjectMeta{Name: namespace, Labels: nsLabels}},
+	}
+}
+
+func newPolicy(namespace, name string, selector map[string]string, types ...networkingv1.PolicyType) networkingv1.NetworkPolicy {
+	return networkingv1.NetworkPolicy{
+		ObjectMeta: metav1.ObjectMeta{Name: name, Namespace: namespace},
+		Spec: networkingv1.NetworkPolicySpec{
+			PodSelector: metav1.LabelSelector{MatchLabels: selector},
+			PolicyTypes: types,
+		},
+	}
+}
+
+func withIngress(policy networkingv1.NetworkPolicy, rules ...networkingv1.NetworkPolicyIngressRule) networkingv1.NetworkPolicy {
+	policy.Spec.Ingress = rules
+	return policy
+}
+
+func withEgress(policy networkingv1.NetworkPolicy, rules ...networkingv1.NetworkPolicyEgressRule) networkingv1.NetworkPolicy {
+	policy.Spec.Egress = rules
+	return policy
+}
+
+func tcpPort(port intstr.IntOrString, endPort *int32) networkingv1.NetworkPolicyPort {
+	protocol := corev1.ProtocolTCP
+	return networkingv1.NetworkPolicyPort{Protocol: &protocol, Port: &port, EndPort: endPort}
+}
+
+func int32Ptr(v int32) *int32 {
+	return &v
+}
+
+func TestAnalyze(t *testing.T) {
+	frontend := newEndpoint("web", map[string]string{"team": "a"}, "fe", map[string]string{"app": "fe"}, "10.2.0.10")
+	backend := newEndpoint("web", map[string]string{"team": "a"}, "be", map[string]string{"app": "be"}, "10.2.0.20")
+	backend.Pod.Spec.Containers = []corev1.Container{{
+		Name:  "be",
+		Ports: []corev1.ContainerPort{{Name: "http", ContainerPort: 8080}},
+	}}
+	otherFrontend := newEndpoint("other", map[string]string{"team": "b"}, "fe", map[string]string{"app": "fe"}, "10.3.0.10")
+	teamAWorker := newEndpoint("jobs", map[string]string{"team": "a"}, "worker", map[string]string{"app": "worker"}, "10.4.0.10")
+	teamAFrontend := newEndpoint("jobs", map[string]string{"team": "a"}, "fe", map[string]string{"app": "fe"}, "10.4.0.11")
+	excludedBackend := newEndpoint("web", nil, "be-legacy", map[string]string{"app": "be"}, "10.1.0.20")
+	hostBackend := newEndpoint("web", nil, "be-host", map[string]string{"app": "be"}, "192.168.1.5")
+	hostBackend.Pod.Spec.HostNetwork = true
+
+	denyIngress := newPolicy("web", "deny-ingress", nil, networkingv1.PolicyTypeIngress)
+	denyEgress := newPolicy("web", "deny-egress", nil, networkingv1.PolicyTypeEgress)
+	allowFrontend := withIngress(newPolicy("web", "allow-fe", map[string]string{"app": "be"}, networkingv1.PolicyTypeIngress),
+		networkingv1.NetworkPolicyIngressRule{From: []networkingv1.NetworkPolicyPeer{
+			{PodSelector: &metav1.LabelSelector{MatchLabels: map[string]string{"app": "fe"}}},
+		}})
+	allowTeamFrontend := withIngress(newPolicy("web", "allow-team-fe", map[string]string{"app": "be"}, networkingv1.PolicyTypeIngress),
+		networkingv1.NetworkPolicyIngressRule{From: []networkingv1.NetworkPolicyPeer{{
+			NamespaceSelector: &metav1.LabelSelector{MatchLabels: map[string]string{"team": "a"}},
+			PodSelector:       &metav1.LabelSelector{MatchLabels: map[string]string{"app": "fe"}},
+		}}})
+	egressCIDR := withEgress(newPolicy("web", "egress-cidr", map[string]string{"app": "fe"}, networkingv1.PolicyTypeEgress),
+		networkingv1.NetworkPolicyEgressRule{To: []networkingv1.NetworkPolicyPeer{{
+			IPBlock: &networkingv1.IPBlock{CIDR: "10.0.0.0/8", Except: []string{"10.1.0.0/16"}},
+		}}})
+	// 未声明policyTypes：总是作用于入站，有egress规则时同时作用于出站
+	implicitTypes := withEgress(newPolicy("web", "implicit", map[string]string{"app": "fe"}),
+		networkingv1.NetworkPolicyEgressRule{To: []networkingv1.NetworkPolicyPeer{
+			{PodSelector: &metav1.LabelSelector{MatchLabels: map[string]string{"app": "db"}}},
+		}})
+	implicitIngressOnly := newPolicy("web", "implicit-ingress", map[string]string{"app": "be"})
+	namedPort := withIngress(newPolicy("web", "named-port", map[string]string{"app": "be"}, networkingv1.PolicyTypeIngress),
+		networkingv1.NetworkPolicyIngressRule{Ports: []networkingv1.NetworkPolicyPort{tcpPort(intstr.FromString("http"), nil)}})
+	portRange := withIngress(newPolicy("web", "port-range", map[string]string{"app": "be"}, networkingv1.PolicyTypeIngress),
+		networkingv1.NetworkPolicyIngressRule{Ports: []networkingv1.NetworkPolicyPort{tcpPort(intstr.FromInt32(8000), int32Ptr(8100))}})
+
+	tests := []struct {
+		name        string
+		policies    []networkingv1.NetworkPolicy
+		src, dst    Endpoint
+		port        int32
+		protocol    corev1.Protocol
+		wantEgress  bool
+		wantIngress bool
+	}{
+		{name: "no policies", src: frontend, dst: backend, port: 80, wantEgress: true, wantIngress: true},
+		{name: "default deny ingress", policies: []networkingv1.NetworkPolicy{denyIngress}, src: frontend, dst: backend, port: 80,
+			wantEgress: true, wantIngress: false},
+		{name: "default deny egress", policies: []networkingv1.NetworkPolicy{denyEgress}, src: frontend, dst: otherFrontend, port: 80,
+			wantEgress: false, wantIngress: true},
+		{name: "implicit types isolate egress when egress rules exist", policies: []networkingv1.NetworkPolicy{implicitTypes},
+			src: frontend, dst: backend, port: 80, wantEgress: false, wantIngress: true},
+		{name: "implicit types without egress rules only isolate ingress", policies: []networkingv1.NetworkPolicy{implicitIngressOnly},
+			src: backend, dst: frontend, port: 80, wantEgress: true, wantIngress: true},
+		{name: "implicit types isolate ingress", policies: []networkingv1.NetworkPolicy{implicitIngressOnly},
+			src: frontend, dst: backend, port: 80, wantEgress: true, wantIngress: false},
+		{name: "pod selector peer in policy namespace", policies: []networkingv1.NetworkPolicy{allowFrontend},
+			src: frontend, dst: backend, port: 80, wantEgress: true, wantIngress: true},
+		{name: "pod selector peer in other namespace", policies: []networkingv1.NetworkPolicy{allowFrontend},
+			src: otherFrontend, dst: backend, port: 80, wantEgress: true, wantIngress: false},
+		{name: "namespace and pod selector both match", policies: []networkingv1.NetworkPolicy{allowTeamFrontend},
+			src: teamAFrontend, dst: backend, port: 80, wantEgress: true, wantIngress: true},
+		{name: "namespace matches but pod does not", policies: []networkingv1.NetworkPolicy{allowTeamFrontend},
+			src: teamAWorker, dst: backend, port: 80, wantEgress: true, wantIngress: false},
+		{name: "pod matches but namespace does not", policies: []networkingv1.NetworkPolicy{allowTeamFrontend},
+			src: otherFrontend, dst: backend, port: 80, wantEgress: true, wantIngress: false},
+		{name: "ip block contains destination", policies: []networkingv1.NetworkPolicy{egressCIDR},
+			src: frontend, dst: backend, port: 80, wantEgress: true, wantIngress: true},
+		{name: "ip block except excludes destination", policies: []networkingv1.NetworkPolicy{egressCIDR},
+			src: frontend, dst: excludedBackend, port: 80, wantEgress: false, wantIngress: true},
+		{name: "named port matches container port", policies: []networkingv1.NetworkPolicy{namedPort},
+			src: frontend, dst: backend, port: 8080, wantEgress: true, wantIngress: true},
+		{name: "named port does not match other port", policies: []networkingv1.NetworkPolicy{namedPort},
+			src: frontend, dst: backend, port: 9090, wantEgress: true, wantIngress: false},
+		{name: "named port protocol mismatch", policies: []networkingv1.NetworkPolicy{namedPort},
+			src: frontend, dst: backend, port: 8080, protocol: corev1.ProtocolUDP, wantEgress: true, wantIngress: false},
+		{name: "port inside end port range", policies: []networkingv1.NetworkPolicy{portRange},
+			src: frontend, dst: backend, port: 8100, wantEgress: true, wantIngress: true},
+		{name: "port outside end port range", policies: []networkingv1.NetworkPolicy{portRange},
+			src: frontend, dst: backend, port: 8101, wantEgress: true, wantIngress: false},
+		{name: "host network destination ignores ingress policies", policies: []networkingv1.NetworkPolicy{denyIngress},
+			src: frontend, dst: hostBackend, port: 80, wantEgress: true, wantIngress: true},
+		{name: "host network source ignores egress policies", policies: []networkingv1.NetworkPolicy{denyEgress},
+			src: hostBackend, dst: otherFrontend, port: 80, wantEgress: true, wantIngress: true},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			result := Analyze(tt.policies, tt.src, tt.dst, tt.port, tt.protocol)
+			if result.Egress.Allowed != tt.wantEgress {
+				t.Errorf("egress allowed = %v, want %v (%s)", result.Egress.Allowed, tt.wantEgress, result.Egress.Reason)
+			}
+			if result.Ingress.Allowed != tt.wantIngress {
+				t.Errorf("ingress allowed = %v, want %v (%s)", result.Ingress.Allowed, tt.wantIngress, result.Ingress.Reason)
+			}
+			if want := tt.wantEgress && tt.wantIngress; result.Allowed != want {
+				t.Errorf("allowed = %v, want %v", result.Allowed, want)
+			}
+		})
+	}
+}
+
+func TestAnalyzeMissingIPNotes(t *testing.T) {
+	tests := []struct {
+		name    string
+		srcIP   string
+		dstIP   string
+		want    []string
+		notWant []string
+	}{
+		{name: "both have IP", srcIP: "10.0.0.1", dstIP: "10.0.0.2", notWant: []string{"源Pod没有IP", "目标Pod没有IP"}},
+		{name: "source without IP", dstIP: "10.0.0.2", want: []string{"源Pod没有IP"}, notWant: []string{"目标Pod没有IP"}},
+		{name: "destination without IP", srcIP: "10.0.0.1", want: []string{"目标Pod没有IP"}, notWant: []string{"源Pod没有IP"}},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			src := newEndpoint("a", nil, "src", nil, tt.srcIP)
+			dst := newEndpoint("b", nil, "dst", nil, tt.dstIP)
+			notes := strings.Join(Analyze(nil, src, dst, 80, "").Notes, "\n")
+			for _, want := range tt.want {
+				if !strings.Contains(notes, want) {
+					t.Errorf("notes %q missing %q", notes, want)
+				}
+			}
+			for _, notWant := range tt.notWant {
+				if strings.Contains(notes, notWant) {
+					t.Errorf("notes %q should not contain %q", notes, notWant)
+				}
+			}
+		})
+	}
+}
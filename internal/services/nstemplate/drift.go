@@ -0,0 +1,188 @@
+package nstemplate
+
+import (
+	"context"
+	"devops-console-backend/internal/dal/request/k8s"
+	"encoding/json"
+	"fmt"
+	"sort"
+
+	corev1 "k8s.io/api/core/v1"
+	"k8s.io/apimachinery/pkg/api/equality"
+	apierrors "k8s.io/apimachinery/pkg/api/errors"
+	metav1 "k8s.io/apimachinery/pkg/apis/meta/v1"
+	"k8s.io/client-go/kubernetes"
+)
+
+// 漂移状态
+const (
+	DriftMissing = "missing"
+	DriftChanged = "changed"
+)
+
+// Drift 比较命名空间与模板，只检查模板中定义的内容，命名空间中额外的资源不计为漂移
+func Drift(ctx context.Context, client kubernetes.Interface, namespace string,
+	spec *k8s.NamespaceTemplateSpec) ([]k8s.NamespaceDriftItem, error) {
+	objects, err := Build(namespace, spec)
+	if err != nil {
+		return nil, err
+	}
+	ns, err := client.CoreV1().Namespaces().Get(ctx, namespace, metav1.GetOptions{})
+	if err != nil {
+		return nil, fmt.Errorf("获取命名空间失败: %w", err)
+	}
+
+	items := []k8s.NamespaceDriftItem{}
+	items = append(items, compareStringMap("Namespace", namespace, "labels", spec.Labels, ns.Labels)...)
+	items = append(items, compareStringMap("Namespace", namespace, "annotations", spec.Annotations, ns.Annotations)...)
+
+	if expected := objects.ResourceQuota; expected != nil {
+		actual, err := client.CoreV1().ResourceQuotas(namespace).Get(ctx, expected.Name, metav1.GetOptions{})
+		switch {
+		case apierrors.IsNotFound(err):
+			items = append(items, missingItem("ResourceQuota", expected.Name))
+		case err != nil:
+			return nil, fmt.Errorf("获取ResourceQuota失败: %w", err)
+		default:
+			items = append(items, compareResourceList("ResourceQuota", expected.Name, "hard", expected.Spec.Hard, actual.Spec.Hard)...)
+		}
+	}
+
+	if expected := objects.LimitRange; expected != nil {
+		actual, err := client.CoreV1().LimitRanges(namespace).Get(ctx, expected.Name, metav1.GetOptions{})
+		switch {
+		case apierrors.IsNotFound(err):
+			items = append(items, missingItem("LimitRange", expected.Name))
+		case err != nil:
+			return nil, fmt.Errorf("获取LimitRange失败: %w", err)
+		default:
+			want := expected.Spec.Limits[0]
+			var got corev1.LimitRangeItem
+			for _, item := range actual.Spec.Limits {
+				if item.Type == corev1.LimitTypeContainer {
+					got = item
+					break
+				}
+			}
+			items = append(items, compareResourceList("LimitRange", expected.Name, "default", want.Default, got.Default)...)
+			items = append(items, compareResourceList("LimitRange", expected.Name, "defaultRequest", want.DefaultRequest, got.DefaultRequest)...)
+			items = append(items, compareResourceList("LimitRange", expected.Name, "max", want.Max, got.Max)...)
+			items = append(items, compareResourceList("LimitRange", expected.Name, "min", want.Min, got.Min)...)
+		}
+	}
+
+	for _, expected := range objects.NetworkPolicies {
+		actual, err := client.NetworkingV1().NetworkPolicies(namespace).Get(ctx, expected.Name, metav1.GetOptions{})
+		switch {
+		case apierrors.IsNotFound(err):
+			items = append(items, missingItem("NetworkPolicy", expected.Name))
+		case err != nil:
+			return nil, fmt.Errorf("获取NetworkPolicy失败: %w", err)
+		case !equality.Semantic.DeepEqual(expected.Spec, actual.Spec):
+			items = append(items, changedItem("NetworkPolicy", expected.Name, "spec", expected.Spec, actual.Spec))
+		}
+	}
+
+	for _, expected := range objects.RoleBindings {
+		actual, err := client.RbacV1().RoleBindings(namespace).Get(ctx, expected.Name, metav1.GetOptions{})
+		switch {
+		case apierrors.IsNotFound(err):
+			items = append(items, missingItem("RoleBinding", expected.Name))
+		case err != nil:
+			return nil, fmt.Errorf("获取RoleBinding失败: %w", err)
+		default:
+			if !equality.Semantic.DeepEqual(expected.RoleRef, actual.RoleRef) {
+				items = append(items, changedItem("RoleBinding", expected.Name, "roleRef", expected.RoleRef, actual.RoleRef))
+			}
+			if !equality.Semantic.DeepEqual(expected.Subjects, actual.Subjects) {
+				items = append(items, changedItem("RoleBinding", expected.Name, "subjects", expected.Subjects, actual.Subjects))
+			}
+		}
+	}
+
+	if len(spec.ImagePullSecrets) > 0 {
+		sa, err := client.CoreV1().ServiceAccounts(namespace).Get(ctx, defaultServiceAccount, metav1.GetOptions{})
+		if err != nil && !apierrors.IsNotFound(err) {
+			return nil, fmt.Errorf("获取ServiceAccount失败: %w", err)
+		}
+		for _, ref := range spec.ImagePullSecrets {
+			_, err := client.CoreV1().Secrets(namespace).Get(ctx, ref.Name, metav1.GetOptions{})
+			if apierrors.IsNotFound(err) {
+				items = append(items, missingItem("Secret", ref.Name))
+			} else if err != nil {
+				return nil, fmt.Errorf("获取Secret失败: %w", err)
+			}
+			if !hasPullSecret(sa.ImagePullSecrets, ref.Name) {
+				items = append(items, k8s.NamespaceDriftItem{
+					Kind: "ServiceAccount", Name: defaultServiceAccount, Field: "imagePullSecrets",
+					Status: DriftMissing, Expected: ref.Name,
+				})
+			}
+		}
+	}
+
+	return items, nil
+}
+
+// compareStringMap 比较标签或注解
+func compareStringMap(kind, name, field string, expected, actual map[string]string) []k8s.NamespaceDriftItem {
+	var items []k8s.NamespaceDriftItem
+	for _, key := range sortedKeys(expected) {
+		value, ok := actual[key]
+		switch {
+		case !ok:
+			items = append(items, k8s.NamespaceDriftItem{Kind: kind, Name: name, Field: field + "." + key,
+				Status: DriftMissing, Expected: expected[key]})
+		case value != expected[key]:
+			items = append(items, k8s.NamespaceDriftItem{Kind: kind, Name: name, Field: field + "." + key,
+				Status: DriftChanged, Expected: expected[key], Actual: value})
+		}
+	}
+	return items
+}
+
+// compareResourceList 按数量语义比较资源，如1000m与1视为相同
+func compareResourceList(kind, name, field string, expected, actual corev1.ResourceList) []k8s.NamespaceDriftItem {
+	var items []k8s.NamespaceDriftItem
+	keys := make([]string, 0, len(expected))
+	for key := range expected {
+		keys = append(keys, string(key))
+	}
+	sort.Strings(keys)
+	for _, key := range keys {
+		want := expected[corev1.ResourceName(key)]
+		got, ok := actual[corev1.ResourceName(key)]
+		switch {
+		case !ok:
+			items = append(items, k8s.NamespaceDriftItem{Kind: kind, Name: name, Field: field + "." + key,
+				Status: DriftMissing, Expected: want.String()})
+		case want.Cmp(got) != 0:
+			items = append(items, k8s.NamespaceDriftItem{Kind: kind, Name: name, Field: field + "." + key,
+				Status: DriftChanged, Expected: want.String(), Actual: got.String()})
+		}
+	}
+	return items
+}
+
+// missingItem 资源缺失
+func missingItem(kind, name string) k8s.NamespaceDriftItem {
+	return k8s.NamespaceDriftItem{Kind: kind, Name: name, Status: DriftMissing}
+}
+
+// changedItem 资源字段与模板不一致，期望值和实际值以JSON展示
+func changedItem(kind, name, field string, expected, actual interface{}) k8s.NamespaceDriftItem {
+	want, _ := json.Marshal(expected)
+	got, _ := json.Marshal(actual)
+	return k8s.NamespaceDriftItem{Kind: kind, Name: name, Field: field, Status: DriftChanged,
+		Expected: string(want), Actual: string(got)}
+}
+
+// sortedKeys 返回排序后的键
+func sortedKeys(m map[string]string) []string {
+	keys := make([]string, 0, len(m))
+	for key := range m {
+		keys = append(keys, key)
+	}
+	sort.Strings(keys)
+	return keys
+}
@@ -0,0 +1,166 @@
+package nstemplate
+
+import (
+	"devops-console-backend/internal/dal/request/k8s"
+	"devops-console-backend/internal/services/capacity"
+	"fmt"
+	"regexp"
+	"strings"
+
+	corev1 "k8s.io/api/core/v1"
+	networkingv1 "k8s.io/api/networking/v1"
+	rbacv1 "k8s.io/api/rbac/v1"
+	metav1 "k8s.io/apimachinery/pkg/apis/meta/v1"
+	"k8s.io/apimachinery/pkg/util/intstr"
+)
+
+const (
+	// ResourceQuotaName 模板创建的ResourceQuota名称
+	ResourceQuotaName = "template-quota"
+	// LimitRangeName 模板创建的LimitRange名称
+	LimitRangeName = "template-limits"
+	// defaultServiceAccount 挂载镜像拉取凭证的ServiceAccount
+	defaultServiceAccount = "default"
+)
+
+// 预置网络策略
+const (
+	PolicyDefaultDenyIngress = "default-deny-ingress"
+	PolicyDefaultDenyEgress  = "default-deny-egress"
+	PolicyAllowSameNamespace = "allow-same-namespace"
+	PolicyAllowDNS           = "allow-dns"
+)
+
+var invalidNameChars = regexp.MustCompile(`[^a-z0-9-]+`)
+
+// Objects 模板在命名空间中生成的资源
+type Objects struct {
+	ResourceQuota   *corev1.ResourceQuota
+	LimitRange      *corev1.LimitRange
+	NetworkPolicies []*networkingv1.NetworkPolicy
+	RoleBindings    []*rbacv1.RoleBinding
+}
+
+// Build 根据模板生成命名空间内的资源，同时校验资源数量格式
+func Build(namespace string, spec *k8s.NamespaceTemplateSpec) (*Objects, error) {
+	objects := &Objects{}
+
+	if len(spec.ResourceQuota) > 0 {
+		hard, err := capacity.ParseResourceList(spec.ResourceQuota)
+		if err != nil {
+			return nil, fmt.Errorf("ResourceQuota: %w", err)
+		}
+		objects.ResourceQuota = &corev1.ResourceQuota{
+			ObjectMeta: metav1.ObjectMeta{Name: ResourceQuotaName, Namespace: namespace},
+			Spec:       corev1.ResourceQuotaSpec{Hard: hard},
+		}
+	}
+
+	if spec.LimitRange != nil {
+		item, err := buildLimitRangeItem(spec.LimitRange)
+		if err != nil {
+			return nil, fmt.Errorf("LimitRange: %w", err)
+		}
+		objects.LimitRange = &corev1.LimitRange{
+			ObjectMeta: metav1.ObjectMeta{Name: LimitRangeName, Namespace: namespace},
+			Spec:       corev1.LimitRangeSpec{Limits: []corev1.LimitRangeItem{item}},
+		}
+	}
+
+	for _, name := range spec.NetworkPolicies {
+		policySpec, err := networkPolicySpec(name)
+		if err != nil {
+			return nil, err
+		}
+		objects.NetworkPolicies = append(objects.NetworkPolicies, &networkingv1.NetworkPolicy{
+			ObjectMeta: metav1.ObjectMeta{Name: name, Namespace: namespace},
+			Spec:       policySpec,
+		})
+	}
+
+	for _, binding := range spec.RoleBindings {
+		if binding.Group == "" || binding.ClusterRole == "" {
+			return nil, fmt.Errorf("RoleBinding的group和clusterRole不能为空")
+		}
+		objects.RoleBindings = append(objects.RoleBindings, &rbacv1.RoleBinding{
+			ObjectMeta: metav1.ObjectMeta{Name: RoleBindingName(binding), Namespace: namespace},
+			RoleRef: rbacv1.RoleRef{
+				APIGroup: rbacv1.GroupName,
+				Kind:     "ClusterRole",
+				Name:     binding.ClusterRole,
+			},
+			Subjects: []rbacv1.Subject{{
+				APIGroup: rbacv1.GroupName,
+				Kind:     rbacv1.GroupKind,
+				Name:     binding.Group,
+			}},
+		})
+	}
+
+	return objects, nil
+}
+
+// RoleBindingName 根据组和角色生成RoleBinding名称
+func RoleBindingName(binding k8s.NamespaceRoleBinding) string {
+	name := strings.ToLower(binding.Group + "-" + binding.ClusterRole)
+	name = strings.Trim(invalidNameChars.ReplaceAllString(name, "-"), "-")
+	if len(name) > 63 {
+		name = strings.TrimRight(name[:63], "-")
+	}
+	return name
+}
+
+// buildLimitRangeItem 生成容器级LimitRange条目
+func buildLimitRangeItem(limits *k8s.NamespaceLimitRange) (corev1.LimitRangeItem, error) {
+	item := corev1.LimitRangeItem{Type: corev1.LimitTypeContainer}
+	var err error
+	if item.Default, err = capacity.ParseResourceList(limits.Default); err != nil {
+		return item, err
+	}
+	if item.DefaultRequest, err = capacity.ParseResourceList(limits.DefaultRequest); err != nil {
+		return item, err
+	}
+	if item.Max, err = capacity.ParseResourceList(limits.Max); err != nil {
+		return item, err
+	}
+	if item.Min, err = capacity.ParseResourceList(limits.Min); err != nil {
+		return item, err
+	}
+	return item, nil
+}
+
+// networkPolicySpec 返回预置网络策略的内容
+func networkPolicySpec(name string) (networkingv1.NetworkPolicySpec, error) {
+	switch name {
+	case PolicyDefaultDenyIngress:
+		return networkingv1.NetworkPolicySpec{
+			PolicyTypes: []networkingv1.PolicyType{networkingv1.PolicyTypeIngress},
+		}, nil
+	case PolicyDefaultDenyEgress:
+		return networkingv1.NetworkPolicySpec{
+			PolicyTypes: []networkingv1.PolicyType{networkingv1.PolicyTypeEgress},
+		}, nil
+	case PolicyAllowSameNamespace:
+		return networkingv1.NetworkPolicySpec{
+			Ingress: []networkingv1.NetworkPolicyIngressRule{{
+				From: []networkingv1.NetworkPolicyPeer{{PodSelector: &metav1.LabelSelector{}}},
+			}},
+			PolicyTypes: []networkingv1.PolicyType{networkingv1.PolicyTypeIngress},
+		}, nil
+	case PolicyAllowDNS:
+		udp, tcp := corev1.ProtocolUDP, corev1.ProtocolTCP
+		port := intstr.FromInt32(53)
+		return networkingv1.NetworkPolicySpec{
+			Egress: []networkingv1.NetworkPolicyEgressRule{{
+				To: []networkingv1.NetworkPolicyPeer{{NamespaceSelector: &metav1.LabelSelector{}}},
+				Ports: []networkingv1.NetworkPolicyPort{
+					{Protocol: &udp, Port: &port},
+					{Protocol: &tcp, Port: &port},
+				},
+			}},
+			PolicyTypes: []networkingv1.PolicyType{networkingv1.PolicyTypeEgress},
+		}, nil
+	default:
+		return networkingv1.NetworkPolicySpec{}, fmt.Errorf("不支持的预置网络策略: %s", name)
+	}
+}
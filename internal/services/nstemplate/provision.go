@@ -0,0 +1,154 @@
+package nstemplate
+
+import (
+	"context"
+	"devops-console-backend/internal/dal"
+	"devops-console-backend/internal/dal/request/k8s"
+	"fmt"
+	"strconv"
+
+	corev1 "k8s.io/api/core/v1"
+	apierrors "k8s.io/apimachinery/pkg/api/errors"
+	metav1 "k8s.io/apimachinery/pkg/apis/meta/v1"
+	"k8s.io/client-go/kubernetes"
+	"k8s.io/client-go/util/retry"
+)
+
+// Provision 按模板创建命名空间及其资源，任一步骤失败时删除命名空间回滚，返回已创建的资源列表
+func Provision(ctx context.Context, client kubernetes.Interface, namespace string, templateID uint,
+	spec *k8s.NamespaceTemplateSpec) ([]string, error) {
+	objects, err := Build(namespace, spec)
+	if err != nil {
+		return nil, err
+	}
+
+	// 先读取凭证来源，避免创建命名空间后才发现来源不存在
+	pullSecrets := make([]*corev1.Secret, 0, len(spec.ImagePullSecrets))
+	for _, ref := range spec.ImagePullSecrets {
+		source, err := client.CoreV1().Secrets(ref.SourceNamespace).Get(ctx, ref.Name, metav1.GetOptions{})
+		if err != nil {
+			return nil, fmt.Errorf("读取镜像拉取凭证 %s/%s 失败: %w", ref.SourceNamespace, ref.Name, err)
+		}
+		pullSecrets = append(pullSecrets, &corev1.Secret{
+			ObjectMeta: metav1.ObjectMeta{Name: source.Name, Namespace: namespace},
+			Type:       source.Type,
+			Data:       source.Data,
+		})
+	}
+
+	labels := map[string]string{}
+	for k, v := range spec.Labels {
+		labels[k] = v
+	}
+	labels[dal.NamespaceTemplateLabel] = strconv.FormatUint(uint64(templateID), 10)
+	ns := &corev1.Namespace{
+		ObjectMeta: metav1.ObjectMeta{
+			Name:        namespace,
+			Labels:      labels,
+			Annotations: spec.Annotations,
+		},
+	}
+	if _, err := client.CoreV1().Namespaces().Create(ctx, ns, metav1.CreateOptions{}); err != nil {
+		return nil, fmt.Errorf("创建命名空间失败: %w", err)
+	}
+	created := []string{"Namespace/" + namespace}
+
+	if err := applyObjects(ctx, client, namespace, objects, pullSecrets, &created); err != nil {
+		// 资源都在新建的命名空间内，删除命名空间即可整体回滚
+		policy := metav1.DeletePropagationBackground
+		if rollbackErr := client.CoreV1().Namespaces().Delete(context.Background(), namespace,
+			metav1.DeleteOptions{PropagationPolicy: &policy}); rollbackErr != nil {
+			return created, fmt.Errorf("%w；回滚删除命名空间失败: %v", err, rollbackErr)
+		}
+		return created, fmt.Errorf("%w，已回滚", err)
+	}
+	return created, nil
+}
+
+// applyObjects 在命名空间中依次创建模板资源
+func applyObjects(ctx context.Context, client kubernetes.Interface, namespace string, objects *Objects,
+	pullSecrets []*corev1.Secret, created *[]string) error {
+	if objects.ResourceQuota != nil {
+		if _, err := client.CoreV1().ResourceQuotas(namespace).Create(ctx, objects.ResourceQuota, metav1.CreateOptions{}); err != nil {
+			return fmt.Errorf("创建ResourceQuota失败: %w", err)
+		}
+		*created = append(*created, "ResourceQuota/"+objects.ResourceQuota.Name)
+	}
+	if objects.LimitRange != nil {
+		if _, err := client.CoreV1().LimitRanges(namespace).Create(ctx, objects.LimitRange, metav1.CreateOptions{}); err != nil {
+			return fmt.Errorf("创建LimitRange失败: %w", err)
+		}
+		*created = append(*created, "LimitRange/"+objects.LimitRange.Name)
+	}
+	for _, policy := range objects.NetworkPolicies {
+		if _, err := client.NetworkingV1().NetworkPolicies(namespace).Create(ctx, policy, metav1.CreateOptions{}); err != nil {
+			return fmt.Errorf("创建NetworkPolicy %s 失败: %w", policy.Name, err)
+		}
+		*created = append(*created, "NetworkPolicy/"+policy.Name)
+	}
+	for _, binding := range objects.RoleBindings {
+		if _, err := client.RbacV1().RoleBindings(namespace).Create(ctx, binding, metav1.CreateOptions{}); err != nil {
+			return fmt.Errorf("创建RoleBinding %s 失败: %w", binding.Name, err)
+		}
+		*created = append(*created, "RoleBinding/"+binding.Name)
+	}
+
+	if len(pullSecrets) == 0 {
+		return nil
+	}
+	names := make([]string, 0, len(pullSecrets))
+	for _, secret := range pullSecrets {
+		if _, err := client.CoreV1().Secrets(namespace).Create(ctx, secret, metav1.CreateOptions{}); err != nil {
+			return fmt.Errorf("创建镜像拉取凭证 %s 失败: %w", secret.Name, err)
+		}
+		*created = append(*created, "Secret/"+secret.Name)
+		names = append(names, secret.Name)
+	}
+	if err := attachPullSecrets(ctx, client, namespace, names); err != nil {
+		return fmt.Errorf("关联镜像拉取凭证到ServiceAccount失败: %w", err)
+	}
+	return nil
+}
+
+// attachPullSecrets 将镜像拉取凭证挂到default ServiceAccount，
+// 新命名空间的default ServiceAccount由控制器异步创建，不存在时直接创建
+func attachPullSecrets(ctx context.Context, client kubernetes.Interface, namespace string, names []string) error {
+	retriable := func(err error) bool {
+		return apierrors.IsConflict(err) || apierrors.IsAlreadyExists(err)
+	}
+	return retry.OnError(retry.DefaultRetry, retriable, func() error {
+		sa, err := client.CoreV1().ServiceAccounts(namespace).Get(ctx, defaultServiceAccount, metav1.GetOptions{})
+		if apierrors.IsNotFound(err) {
+			sa = &corev1.ServiceAccount{ObjectMeta: metav1.ObjectMeta{Name: defaultServiceAccount, Namespace: namespace}}
+			sa.ImagePullSecrets = mergePullSecrets(nil, names)
+			_, err = client.CoreV1().ServiceAccounts(namespace).Create(ctx, sa, metav1.CreateOptions{})
+			return err
+		}
+		if err != nil {
+			return err
+		}
+		sa.ImagePullSecrets = mergePullSecrets(sa.ImagePullSecrets, names)
+		_, err = client.CoreV1().ServiceAccounts(namespace).Update(ctx, sa, metav1.UpdateOptions{})
+		return err
+	})
+}
+
+// mergePullSecrets 追加尚未引用的凭证
+func mergePullSecrets(refs []corev1.LocalObjectReference, names []string) []corev1.LocalObjectReference {
+	for _, name := range names {
+		if !hasPullSecret(refs, name) {
+			refs = append(refs, corev1.LocalObjectReference{Name: name})
+		}
+	}
+	return refs
+}
+
+// hasPullSecret 判断是否已引用凭证
+func hasPullSecret(refs []corev1.LocalObjectReference, name string) bool {
+	for _, ref := range refs {
+		if ref.Name == name {
+			return true
+		}
+	}
+	return false
+}
@@ -0,0 +1,227 @@
+package podfile
+
+import (
+	"archive/tar"
+	"bytes"
+	"context"
+	"errors"
+	"fmt"
+	"io"
+	"path"
+	"strconv"
+	"strings"
+	"time"
+
+	corev1 "k8s.io/api/core/v1"
+	"k8s.io/client-go/kubernetes"
+	"k8s.io/client-go/kubernetes/scheme"
+	"k8s.io/client-go/rest"
+	"k8s.io/client-go/tools/remotecommand"
+)
+
+// ErrSizeLimit 传输大小超过上限
+var ErrSizeLimit = errors.New("文件大小超过限制")
+
+// FileEntry 容器内的文件信息
+type FileEntry struct {
+	Name    string `json:"name"`
+	Path    string `json:"path"`
+	Type    string `json:"type"` // file, directory, symlink, other
+	Size    int64  `json:"size"`
+	Mode    string `json:"mode"`
+	ModTime int64  `json:"modTime"`
+}
+
+// Client 通过exec子资源在容器内读写文件，与kubectl cp的方式相同
+type Client struct {
+	client    kubernetes.Interface
+	config    *rest.Config
+	namespace string
+	pod       string
+	container string
+}
+
+// NewClient 创建容器文件操作客户端
+func NewClient(client kubernetes.Interface, config *rest.Config, namespace, pod, container string) *Client {
+	return &Client{
+		client:    client,
+		config:    config,
+		namespace: namespace,
+		pod:       pod,
+		container: container,
+	}
+}
+
+// CleanPath 校验并规范化容器内路径，只接受绝对路径
+func CleanPath(p string) (string, error) {
+	if p == "" || !strings.HasPrefix(p, "/") {
+		return "", fmt.Errorf("路径必须是绝对路径")
+	}
+	return path.Clean(p), nil
+}
+
+// exec 在容器中执行命令
+func (c *Client) exec(ctx context.Context, command []string, stdin io.Reader, stdout io.Writer) error {
+	req := c.client.CoreV1().RESTClient().Post().
+		Resource("pods").
+		Name(c.pod).
+		Namespace(c.namespace).
+		SubResource("exec").
+		VersionedParams(&corev1.PodExecOptions{
+			Container: c.container,
+			Command:   command,
+			Stdin:     stdin != nil,
+			Stdout:    true,
+			Stderr:    true,
+		}, scheme.ParameterCodec)
+
+	executor, err := remotecommand.NewSPDYExecutor(c.config, "POST", req.URL())
+	if err != nil {
+		return fmt.Errorf("创建executor失败: %w", err)
+	}
+
+	var stderr bytes.Buffer
+	err = executor.StreamWithContext(ctx, remotecommand.StreamOptions{
+		Stdin:  stdin,
+		Stdout: stdout,
+		Stderr: &stderr,
+	})
+	if err != nil {
+		if msg := strings.TrimSpace(stderr.String()); msg != "" {
+			return fmt.Errorf("%s: %w", msg, err)
+		}
+		return err
+	}
+	return nil
+}
+
+// Stat 获取单个路径的信息
+func (c *Client) Stat(ctx context.Context, p string) (*FileEntry, error) {
+	var out bytes.Buffer
+	if err := c.exec(ctx, []string{"stat", "-c", statFormat, p}, nil, &out); err != nil {
+		return nil, err
+	}
+	entries := parseStat(out.String())
+	if len(entries) == 0 {
+		return nil, fmt.Errorf("无法获取文件信息: %s", p)
+	}
+	return &entries[0], nil
+}
+
+// List 列出目录内容
+func (c *Client) List(ctx context.Context, dir string) ([]FileEntry, error) {
+	var out bytes.Buffer
+	command := []string{"find", dir, "-mindepth", "1", "-maxdepth", "1", "-exec", "stat", "-c", statFormat, "{}", "+"}
+	if err := c.exec(ctx, command, nil, &out); err != nil {
+		return nil, err
+	}
+	return parseStat(out.String()), nil
+}
+
+// statFormat stat输出格式: 类型|大小|修改时间|权限|路径，路径放最后以兼容包含分隔符的文件名
+const statFormat = "%F|%s|%Y|%A|%n"
+
+// parseStat 解析stat输出
+func parseStat(output string) []FileEntry {
+	entries := make([]FileEntry, 0)
+	for _, line := range strings.Split(strings.TrimSpace(output), "\n") {
+		parts := strings.SplitN(line, "|", 5)
+		if len(parts) != 5 {
+			continue
+		}
+		size, _ := strconv.ParseInt(parts[1], 10, 64)
+		modTime, _ := strconv.ParseInt(parts[2], 10, 64)
+		entries = append(entries, FileEntry{
+			Name:    path.Base(parts[4]),
+			Path:    parts[4],
+			Type:    fileType(parts[0]),
+			Size:    size,
+			Mode:    parts[3],
+			ModTime: modTime,
+		})
+	}
+	return entries
+}
+
+// fileType 将stat的%F输出转换为简单类型
+func fileType(t string) string {
+	switch {
+	case strings.Contains(t, "directory"):
+		return "directory"
+	case strings.Contains(t, "symbolic link"):
+		return "symlink"
+	case strings.Contains(t, "regular"):
+		return "file"
+	default:
+		return "other"
+	}
+}
+
+// ReadFile 读取单个文件内容写入w，limit小于等于0表示不限制，超过limit字节时返回ErrSizeLimit
+func (c *Client) ReadFile(ctx context.Context, p string, w io.Writer, limit int64) error {
+	return c.readLimited(ctx, []string{"cat", p}, w, limit)
+}
+
+// ReadDir 将目录打包为tar写入w，limit小于等于0表示不限制，超过limit字节时返回ErrSizeLimit
+func (c *Client) ReadDir(ctx context.Context, dir string, w io.Writer, limit int64) error {
+	parent, base := path.Split(dir)
+	if parent == "" {
+		parent = "/"
+	}
+	return c.readLimited(ctx, []string{"tar", "cf", "-", "-C", parent, base}, w, limit)
+}
+
+// readLimited 执行命令并限制输出大小
+func (c *Client) readLimited(ctx context.Context, command []string, w io.Writer, limit int64) error {
+	lw := &limitWriter{w: w, remain: limit}
+	if limit <= 0 {
+		lw.remain = -1
+	}
+	err := c.exec(ctx, command, nil, lw)
+	if lw.exceeded {
+		return ErrSizeLimit
+	}
+	return err
+}
+
+// WriteFile 将内容以tar方式解压到容器目录dir下，文件名为name
+func (c *Client) WriteFile(ctx context.Context, dir, name string, size int64, content io.Reader) error {
+	reader, writer := io.Pipe()
+	go func() {
+		tw := tar.NewWriter(writer)
+		err := tw.WriteHeader(&tar.Header{
+			Name:    name,
+			Mode:    0o644,
+			Size:    size,
+			ModTime: time.Now(),
+		})
+		if err == nil {
+			_, err = io.CopyN(tw, content, size)
+		}
+		if err == nil {
+			err = tw.Close()
+		}
+		_ = writer.CloseWithError(err)
+	}()
+
+	return c.exec(ctx, []string{"tar", "xmf", "-", "-C", dir}, reader, io.Discard)
+}
+
+// limitWriter 限制写入总量的Writer，remain为负数时不限制
+type limitWriter struct {
+	w        io.Writer
+	remain   int64
+	exceeded bool
+}
+
+// Write 实现io.Writer接口
+func (l *limitWriter) Write(p []byte) (int, error) {
+	if l.remain >= 0 {
+		if int64(len(p)) > l.remain {
+			l.exceeded = true
+			return 0, ErrSizeLimit
+		}
+		l.remain -= int64(len(p))
+	}
+	return l.w.Write(p)
+}
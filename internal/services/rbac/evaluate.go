@@ -0,0 +1,222 @@
+package rbac
+
+import (
+	"context"
+	"devops-console-backend/internal/dal/request/k8s"
+	"fmt"
+
+	rbacv1 "k8s.io/api/rbac/v1"
+	metav1 "k8s.io/apimachinery/pkg/apis/meta/v1"
+	"k8s.io/client-go/kubernetes"
+)
+
+// Snapshot 集群RBAC对象快照，权限判定只基于快照做本地计算
+type Snapshot struct {
+	Roles               []rbacv1.Role
+	ClusterRoles        []rbacv1.ClusterRole
+	RoleBindings        []rbacv1.RoleBinding
+	ClusterRoleBindings []rbacv1.ClusterRoleBinding
+}
+
+// LoadSnapshot 读取集群中全部RBAC对象
+func LoadSnapshot(ctx context.Context, client kubernetes.Interface) (*Snapshot, error) {
+	roles, err := client.RbacV1().Roles("").List(ctx, metav1.ListOptions{})
+	if err != nil {
+		return nil, fmt.Errorf("获取Role列表失败: %w", err)
+	}
+	clusterRoles, err := client.RbacV1().ClusterRoles().List(ctx, metav1.ListOptions{})
+	if err != nil {
+		return nil, fmt.Errorf("获取ClusterRole列表失败: %w", err)
+	}
+	roleBindings, err := client.RbacV1().RoleBindings("").List(ctx, metav1.ListOptions{})
+	if err != nil {
+		return nil, fmt.Errorf("获取RoleBinding列表失败: %w", err)
+	}
+	clusterRoleBindings, err := client.RbacV1().ClusterRoleBindings().List(ctx, metav1.ListOptions{})
+	if err != nil {
+		return nil, fmt.Errorf("获取ClusterRoleBinding列表失败: %w", err)
+	}
+	return &Snapshot{
+		Roles:               roles.Items,
+		ClusterRoles:        clusterRoles.Items,
+		RoleBindings:        roleBindings.Items,
+		ClusterRoleBindings: clusterRoleBindings.Items,
+	}, nil
+}
+
+// binding 统一RoleBinding和ClusterRoleBinding，namespace为空表示集群范围
+type binding struct {
+	kind      string
+	namespace string
+	name      string
+	roleRef   rbacv1.RoleRef
+	subjects  []rbacv1.Subject
+}
+
+// ref 返回绑定的展示名称
+func (b *binding) ref() string {
+	if b.namespace == "" {
+		return b.kind + "/" + b.name
+	}
+	return b.kind + "/" + b.namespace + "/" + b.name
+}
+
+// bindings 返回对指定命名空间生效的绑定，namespace为空时只返回集群范围绑定，"*"表示全部
+func (s *Snapshot) bindings(namespace string) []binding {
+	result := make([]binding, 0, len(s.ClusterRoleBindings))
+	for _, crb := range s.ClusterRoleBindings {
+		result = append(result, binding{kind: "ClusterRoleBinding", name: crb.Name, roleRef: crb.RoleRef, subjects: crb.Subjects})
+	}
+	if namespace == "" {
+		return result
+	}
+	for _, rb := range s.RoleBindings {
+		if namespace != "*" && rb.Namespace != namespace {
+			continue
+		}
+		result = append(result, binding{kind: "RoleBinding", namespace: rb.Namespace, name: rb.Name, roleRef: rb.RoleRef, subjects: rb.Subjects})
+	}
+	return result
+}
+
+// roleRules 返回绑定引用角色的规则，角色不存在时返回nil
+func (s *Snapshot) roleRules(b *binding) []rbacv1.PolicyRule {
+	switch b.roleRef.Kind {
+	case "ClusterRole":
+		for _, role := range s.ClusterRoles {
+			if role.Name == b.roleRef.Name {
+				return role.Rules
+			}
+		}
+	case "Role":
+		for _, role := range s.Roles {
+			if role.Namespace == b.namespace && role.Name == b.roleRef.Name {
+				return role.Rules
+			}
+		}
+	}
+	return nil
+}
+
+// WhoCan 返回可以执行指定操作的主体及其授权来源
+func WhoCan(s *Snapshot, q *k8s.RBACAccessQuery) []k8s.RBACGrant {
+	grants := []k8s.RBACGrant{}
+	for _, b := range s.bindings(q.Namespace) {
+		if !rulesAllow(s.roleRules(&b), q) {
+			continue
+		}
+		for _, subject := range b.subjects {
+			namespace := subject.Namespace
+			if subject.Kind == rbacv1.ServiceAccountKind && namespace == "" {
+				namespace = b.namespace
+			}
+			grants = append(grants, k8s.RBACGrant{
+				Subject:   k8s.RBACSubject{Kind: subject.Kind, Name: subject.Name, Namespace: namespace},
+				Binding:   b.ref(),
+				Role:      b.roleRef.Kind + "/" + b.roleRef.Name,
+				Namespace: b.namespace,
+			})
+		}
+	}
+	return grants
+}
+
+// Check 判断主体能否执行指定操作，返回授予该权限的绑定
+func Check(s *Snapshot, subject *k8s.RBACSubject, groups []string, q *k8s.RBACAccessQuery) []k8s.RBACGrant {
+	identity := newIdentity(subject, groups)
+	grants := []k8s.RBACGrant{}
+	for _, b := range s.bindings(q.Namespace) {
+		if !identity.boundBy(&b) || !rulesAllow(s.roleRules(&b), q) {
+			continue
+		}
+		grants = append(grants, k8s.RBACGrant{
+			Subject:   *subject,
+			Binding:   b.ref(),
+			Role:      b.roleRef.Kind + "/" + b.roleRef.Name,
+			Namespace: b.namespace,
+		})
+	}
+	return grants
+}
+
+// SubjectRules 返回主体在全部命名空间中获得的规则
+func SubjectRules(s *Snapshot, subject *k8s.RBACSubject, groups []string) []k8s.RBACSubjectRule {
+	identity := newIdentity(subject, groups)
+	rules := []k8s.RBACSubjectRule{}
+	for _, b := range s.bindings("*") {
+		if !identity.boundBy(&b) {
+			continue
+		}
+		for _, rule := range s.roleRules(&b) {
+			rules = append(rules, k8s.RBACSubjectRule{
+				Binding:         b.ref(),
+				Role:            b.roleRef.Kind + "/" + b.roleRef.Name,
+				Namespace:       b.namespace,
+				Verbs:           rule.Verbs,
+				APIGroups:       rule.APIGroups,
+				Resources:       rule.Resources,
+				ResourceNames:   rule.ResourceNames,
+				NonResourceURLs: rule.NonResourceURLs,
+			})
+		}
+	}
+	return rules
+}
+
+// rulesAllow 判断规则集合是否允许资源操作
+func rulesAllow(rules []rbacv1.PolicyRule, q *k8s.RBACAccessQuery) bool {
+	for i := range rules {
+		if ruleAllows(&rules[i], q) {
+			return true
+		}
+	}
+	return false
+}
+
+// ruleAllows 按RBAC授权器的语义匹配单条规则
+func ruleAllows(rule *rbacv1.PolicyRule, q *k8s.RBACAccessQuery) bool {
+	if !containsOrWildcard(rule.Verbs, q.Verb) || !containsOrWildcard(rule.APIGroups, q.Group) {
+		return false
+	}
+	if !resourceMatches(rule.Resources, q.Resource, q.Subresource) {
+		return false
+	}
+	return len(rule.ResourceNames) == 0 || (q.ResourceName != "" && contains(rule.ResourceNames, q.ResourceName))
+}
+
+// resourceMatches 匹配资源与子资源，支持 * 和 */subresource
+func resourceMatches(resources []string, resource, subresource string) bool {
+	combined := resource
+	if subresource != "" {
+		combined = resource + "/" + subresource
+	}
+	for _, r := range resources {
+		if r == rbacv1.ResourceAll || r == combined {
+			return true
+		}
+		if subresource != "" && r == rbacv1.ResourceAll+"/"+subresource {
+			return true
+		}
+	}
+	return false
+}
+
+// containsOrWildcard 判断列表包含值或通配符
+func containsOrWildcard(values []string, value string) bool {
+	for _, v := range values {
+		if v == value || v == rbacv1.VerbAll {
+			return true
+		}
+	}
+	return false
+}
+
+// contains 判断列表包含值
+func contains(values []string, value string) bool {
+	for _, v := range values {
+		if v == value {
+			return true
+		}
+	}
+	return false
+}
@@ -0,0 +1,85 @@
+package rbac
+
+import (
+	"context"
+	"devops-console-backend/internal/dal/request/k8s"
+
+	authorizationv1 "k8s.io/api/authorization/v1"
+	rbacv1 "k8s.io/api/rbac/v1"
+	metav1 "k8s.io/apimachinery/pkg/apis/meta/v1"
+	"k8s.io/apiserver/pkg/authentication/serviceaccount"
+	"k8s.io/client-go/kubernetes"
+)
+
+// identity 认证后的身份，ServiceAccount会带上内置的用户名和组
+type identity struct {
+	subject  *k8s.RBACSubject
+	username string
+	groups   []string
+}
+
+// newIdentity 根据主体构造身份，groups为User额外所属的组
+func newIdentity(subject *k8s.RBACSubject, groups []string) *identity {
+	id := &identity{subject: subject}
+	switch subject.Kind {
+	case rbacv1.UserKind:
+		id.username = subject.Name
+		id.groups = append(append(id.groups, groups...), "system:authenticated")
+	case rbacv1.GroupKind:
+		id.groups = []string{subject.Name}
+	case rbacv1.ServiceAccountKind:
+		id.username = serviceaccount.MakeUsername(subject.Namespace, subject.Name)
+		id.groups = append(serviceaccount.MakeGroupNames(subject.Namespace), "system:authenticated")
+	}
+	return id
+}
+
+// boundBy 判断身份是否为绑定的主体之一
+func (id *identity) boundBy(b *binding) bool {
+	for _, subject := range b.subjects {
+		switch subject.Kind {
+		case rbacv1.UserKind:
+			if id.username != "" && subject.Name == id.username {
+				return true
+			}
+		case rbacv1.GroupKind:
+			if contains(id.groups, subject.Name) {
+				return true
+			}
+		case rbacv1.ServiceAccountKind:
+			namespace := subject.Namespace
+			if namespace == "" {
+				namespace = b.namespace
+			}
+			if id.subject.Kind == rbacv1.ServiceAccountKind && subject.Name == id.subject.Name && namespace == id.subject.Namespace {
+				return true
+			}
+		}
+	}
+	return false
+}
+
+// Verify 用SubjectAccessReview向API Server复核主体的权限
+func Verify(ctx context.Context, client kubernetes.Interface, subject *k8s.RBACSubject, groups []string,
+	q *k8s.RBACAccessQuery) (bool, error) {
+	id := newIdentity(subject, groups)
+	review := &authorizationv1.SubjectAccessReview{
+		Spec: authorizationv1.SubjectAccessReviewSpec{
+			User:   id.username,
+			Groups: id.groups,
+			ResourceAttributes: &authorizationv1.ResourceAttributes{
+				Namespace:   q.Namespace,
+				Verb:        q.Verb,
+				Group:       q.Group,
+				Resource:    q.Resource,
+				Subresource: q.Subresource,
+				Name:        q.ResourceName,
+			},
+		},
+	}
+	result, err := client.AuthorizationV1().SubjectAccessReviews().Create(ctx, review, metav1.CreateOptions{})
+	if err != nil {
+		return false, err
+	}
+	return result.Status.Allowed, nil
+}
@@ -0,0 +1,594 @@
+package relation
+
+import (
+	"context"
+	"devops-console-backend/internal/dal/request/k8s"
+	"devops-console-backend/internal/services/workload"
+	"errors"
+	"fmt"
+
+	appsv1 "k8s.io/api/apps/v1"
+	autoscalingv2 "k8s.io/api/autoscaling/v2"
+	batchv1 "k8s.io/api/batch/v1"
+	corev1 "k8s.io/api/core/v1"
+	discoveryv1 "k8s.io/api/discovery/v1"
+	networkingv1 "k8s.io/api/networking/v1"
+	apierrors "k8s.io/apimachinery/pkg/api/errors"
+	metav1 "k8s.io/apimachinery/pkg/apis/meta/v1"
+	"k8s.io/apimachinery/pkg/apis/meta/v1/unstructured"
+	"k8s.io/apimachinery/pkg/labels"
+	"k8s.io/apimachinery/pkg/runtime/schema"
+	"k8s.io/client-go/dynamic"
+	"k8s.io/client-go/kubernetes"
+)
+
+// 关系类型
+const (
+	EdgeRoutes     = "routes"     // Ingress → Service
+	EdgeEndpoints  = "endpoints"  // Service → EndpointSlice
+	EdgeTargets    = "targets"    // EndpointSlice/Service → Pod
+	EdgeOwns       = "owns"       // 属主 → 被管理对象
+	EdgeScales     = "scales"     // HPA/VPA → 工作负载
+	EdgeMounts     = "mounts"     // 工作负载 → ConfigMap/Secret/PVC
+	EdgeBound      = "bound"      // PVC → PV
+	EdgeProvisions = "provisions" // PVC/PV → StorageClass
+)
+
+// maxNodes 关系图节点上限，避免大命名空间中图无限扩张
+const maxNodes = 300
+
+// ErrRootNotFound 起点资源不存在
+var ErrRootNotFound = errors.New("资源不存在")
+
+var vpaGVR = schema.GroupVersionResource{
+	Group:    "autoscaling.k8s.io",
+	Version:  "v1",
+	Resource: "verticalpodautoscalers",
+}
+
+// RootKinds 可作为关系图起点的资源类型，key为路径参数
+var RootKinds = map[string]string{
+	"deployment":  "Deployment",
+	"statefulset": "StatefulSet",
+	"daemonset":   "DaemonSet",
+	"replicaset":  "ReplicaSet",
+	"job":         "Job",
+	"cronjob":     "CronJob",
+	"pod":         "Pod",
+	"service":     "Service",
+	"ingress":     "Ingress",
+}
+
+// snapshot 命名空间内相关资源的快照，构建关系图时只读取一次
+type snapshot struct {
+	pods         map[string]*corev1.Pod
+	replicaSets  map[string]*appsv1.ReplicaSet
+	deployments  map[string]*appsv1.Deployment
+	statefulSets map[string]*appsv1.StatefulSet
+	daemonSets   map[string]*appsv1.DaemonSet
+	jobs         map[string]*batchv1.Job
+	cronJobs     map[string]*batchv1.CronJob
+	services     map[string]*corev1.Service
+	slices       map[string]*discoveryv1.EndpointSlice
+	ingresses    map[string]*networkingv1.Ingress
+	hpas         map[string]*autoscalingv2.HorizontalPodAutoscaler
+	vpas         map[string]*unstructured.Unstructured
+	pvcs         map[string]*corev1.PersistentVolumeClaim
+}
+
+func loadSnapshot(ctx context.Context, client kubernetes.Interface, dyn dynamic.Interface, namespace string) (*snapshot, error) {
+	s := &snapshot{
+		pods:         map[string]*corev1.Pod{},
+		replicaSets:  map[string]*appsv1.ReplicaSet{},
+		deployments:  map[string]*appsv1.Deployment{},
+		statefulSets: map[string]*appsv1.StatefulSet{},
+		daemonSets:   map[string]*appsv1.DaemonSet{},
+		jobs:         map[string]*batchv1.Job{},
+		cronJobs:     map[string]*batchv1.CronJob{},
+		services:     map[string]*corev1.Service{},
+		slices:       map[string]*discoveryv1.EndpointSlice{},
+		ingresses:    map[string]*networkingv1.Ingress{},
+		hpas:         map[string]*autoscalingv2.HorizontalPodAutoscaler{},
+		vpas:         map[string]*unstructured.Unstructured{},
+		pvcs:         map[string]*corev1.PersistentVolumeClaim{},
+	}
+	opts := metav1.ListOptions{}
+
+	pods, err := client.CoreV1().Pods(namespace).List(ctx, opts)
+	if err != nil {
+		return nil, fmt.Errorf("获取Pod列表失败: %w", err)
+	}
+	for i := range pods.Items {
+		s.pods[pods.Items[i].Name] = &pods.Items[i]
+	}
+	replicaSets, err := client.AppsV1().ReplicaSets(namespace).List(ctx, opts)
+	if err != nil {
+		return nil, fmt.Errorf("获取ReplicaSet列表失败: %w", err)
+	}
+	for i := range replicaSets.Items {
+		s.replicaSets[replicaSets.Items[i].Name] = &replicaSets.Items[i]
+	}
+	deployments, err := client.AppsV1().Deployments(namespace).List(ctx, opts)
+	if err != nil {
+		return nil, fmt.Errorf("获取Deployment列表失败: %w", err)
+	}
+	for i := range deployments.Items {
+		s.deployments[deployments.Items[i].Name] = &deployments.Items[i]
+	}
+	statefulSets, err := client.AppsV1().StatefulSets(namespace).List(ctx, opts)
+	if err != nil {
+		return nil, fmt.Errorf("获取StatefulSet列表失败: %w", err)
+	}
+	for i := range statefulSets.Items {
+		s.statefulSets[statefulSets.Items[i].Name] = &statefulSets.Items[i]
+	}
+	daemonSets, err := client.AppsV1().DaemonSets(namespace).List(ctx, opts)
+	if err != nil {
+		return nil, fmt.Errorf("获取DaemonSet列表失败: %w", err)
+	}
+	for i := range daemonSets.Items {
+		s.daemonSets[daemonSets.Items[i].Name] = &daemonSets.Items[i]
+	}
+	jobs, err := client.BatchV1().Jobs(namespace).List(ctx, opts)
+	if err != nil {
+		return nil, fmt.Errorf("获取Job列表失败: %w", err)
+	}
+	for i := range jobs.Items {
+		s.jobs[jobs.Items[i].Name] = &jobs.Items[i]
+	}
+	cronJobs, err := client.BatchV1().CronJobs(namespace).List(ctx, opts)
+	if err != nil {
+		return nil, fmt.Errorf("获取CronJob列表失败: %w", err)
+	}
+	for i := range cronJobs.Items {
+		s.cronJobs[cronJobs.Items[i].Name] = &cronJobs.Items[i]
+	}
+	services, err := client.CoreV1().Services(namespace).List(ctx, opts)
+	if err != nil {
+		return nil, fmt.Errorf("获取Service列表失败: %w", err)
+	}
+	for i := range services.Items {
+		s.services[services.Items[i].Name] = &services.Items[i]
+	}
+	slices, err := client.DiscoveryV1().EndpointSlices(namespace).List(ctx, opts)
+	if err != nil {
+		return nil, fmt.Errorf("获取EndpointSlice列表失败: %w", err)
+	}
+	for i := range slices.Items {
+		s.slices[slices.Items[i].Name] = &slices.Items[i]
+	}
+	ingresses, err := client.NetworkingV1().Ingresses(namespace).List(ctx, opts)
+	if err != nil {
+		return nil, fmt.Errorf("获取Ingress列表失败: %w", err)
+	}
+	for i := range ingresses.Items {
+		s.ingresses[ingresses.Items[i].Name] = &ingresses.Items[i]
+	}
+	hpas, err := client.AutoscalingV2().HorizontalPodAutoscalers(namespace).List(ctx, opts)
+	if err != nil {
+		return nil, fmt.Errorf("获取HPA列表失败: %w", err)
+	}
+	for i := range hpas.Items {
+		s.hpas[hpas.Items[i].Name] = &hpas.Items[i]
+	}
+	pvcs, err := client.CoreV1().PersistentVolumeClaims(namespace).List(ctx, opts)
+	if err != nil {
+		return nil, fmt.Errorf("获取PVC列表失败: %w", err)
+	}
+	for i := range pvcs.Items {
+		s.pvcs[pvcs.Items[i].Name] = &pvcs.Items[i]
+	}
+
+	// VPA为可选组件，未安装CRD时忽略
+	if dyn != nil {
+		if vpas, err := dyn.Resource(vpaGVR).Namespace(namespace).List(ctx, opts); err == nil {
+			for i := range vpas.Items {
+				s.vpas[vpas.Items[i].GetName()] = &vpas.Items[i]
+			}
+		}
+	}
+	return s, nil
+}
+
+// builder 从起点开始广度优先展开关联资源
+type builder struct {
+	ctx       context.Context
+	client    kubernetes.Interface
+	namespace string
+	snap      *snapshot
+	graph     *k8s.RelationGraph
+	index     map[string]int // 节点ID → graph.Nodes下标
+	edges     map[string]bool
+	queue     []k8s.RelationNode
+}
+
+func nodeID(kind, namespace, name string) string {
+	if namespace == "" {
+		return kind + "/" + name
+	}
+	return kind + "/" + namespace + "/" + name
+}
+
+// Build 构建以指定资源为起点的关系图，kind为RootKinds中的资源类型
+func Build(ctx context.Context, client kubernetes.Interface, dyn dynamic.Interface, kind, namespace, name string) (*k8s.RelationGraph, error) {
+	snap, err := loadSnapshot(ctx, client, dyn, namespace)
+	if err != nil {
+		return nil, err
+	}
+	b := &builder{
+		ctx:       ctx,
+		client:    client,
+		namespace: namespace,
+		snap:      snap,
+		graph:     &k8s.RelationGraph{Nodes: []k8s.RelationNode{}, Edges: []k8s.RelationEdge{}},
+		index:     map[string]int{},
+		edges:     map[string]bool{},
+	}
+
+	root := b.node(kind, namespace, name)
+	if b.graph.Nodes[b.index[root]].Status == StatusMissing {
+		return nil, ErrRootNotFound
+	}
+	b.graph.Nodes[b.index[root]].Root = true
+
+	for len(b.queue) > 0 {
+		current := b.queue[0]
+		b.queue = b.queue[1:]
+		b.expand(current)
+	}
+	return b.graph, nil
+}
+
+// node 添加节点并返回ID，新节点会计算健康状态并加入待展开队列
+func (b *builder) node(kind, namespace, name string) string {
+	id := nodeID(kind, namespace, name)
+	if _, ok := b.index[id]; ok {
+		return id
+	}
+	if len(b.graph.Nodes) >= maxNodes {
+		b.graph.Truncated = true
+		return ""
+	}
+	h := b.health(kind, namespace, name)
+	node := k8s.RelationNode{ID: id, Kind: kind, Namespace: namespace, Name: name, Status: h.status, Message: h.message}
+	b.index[id] = len(b.graph.Nodes)
+	b.graph.Nodes = append(b.graph.Nodes, node)
+	if h.status != StatusMissing {
+		b.queue = append(b.queue, node)
+	}
+	return id
+}
+
+// edge 添加有向边，端点为空（超出节点上限）时忽略
+func (b *builder) edge(from, to, edgeType string) {
+	if from == "" || to == "" {
+		return
+	}
+	key := from + "|" + to + "|" + edgeType
+	if b.edges[key] {
+		return
+	}
+	b.edges[key] = true
+	b.graph.Edges = append(b.graph.Edges, k8s.RelationEdge{From: from, To: to, Type: edgeType})
+}
+
+// link 添加节点并建立从from到该节点的边
+func (b *builder) link(from, kind, namespace, name, edgeType string) {
+	b.edge(from, b.node(kind, namespace, name), edgeType)
+}
+
+// linkFrom 添加节点并建立从该节点到to的边
+func (b *builder) linkFrom(kind, namespace, name, to, edgeType string) {
+	b.edge(b.node(kind, namespace, name), to, edgeType)
+}
+
+func (b *builder) health(kind, namespace, name string) health {
+	s := b.snap
+	switch kind {
+	case "Deployment":
+		if item, ok := s.deployments[name]; ok {
+			return deploymentHealth(item)
+		}
+	case "StatefulSet":
+		if item, ok := s.statefulSets[name]; ok {
+			return statefulSetHealth(item)
+		}
+	case "DaemonSet":
+		if item, ok := s.daemonSets[name]; ok {
+			return daemonSetHealth(item)
+		}
+	case "ReplicaSet":
+		if item, ok := s.replicaSets[name]; ok {
+			return replicaSetHealth(item)
+		}
+	case "Job":
+		if item, ok := s.jobs[name]; ok {
+			return jobHealth(item)
+		}
+	case "CronJob":
+		if item, ok := s.cronJobs[name]; ok {
+			return cronJobHealth(item)
+		}
+	case "Pod":
+		if item, ok := s.pods[name]; ok {
+			return podHealth(item)
+		}
+	case "Service":
+		if item, ok := s.services[name]; ok {
+			return serviceHealth(item, b.slicesOf(name))
+		}
+	case "EndpointSlice":
+		if item, ok := s.slices[name]; ok {
+			return endpointSliceHealth(item)
+		}
+	case "Ingress":
+		if item, ok := s.ingresses[name]; ok {
+			return ingressHealth(item)
+		}
+	case "HorizontalPodAutoscaler":
+		if item, ok := s.hpas[name]; ok {
+			return hpaHealth(item)
+		}
+	case "VerticalPodAutoscaler":
+		if _, ok := s.vpas[name]; ok {
+			return health{StatusHealthy, ""}
+		}
+	case workload.RefKindPersistentVolumeClaim:
+		if item, ok := s.pvcs[name]; ok {
+			return pvcHealth(item)
+		}
+	case workload.RefKindConfigMap:
+		return b.existence(func() error {
+			_, err := b.client.CoreV1().ConfigMaps(namespace).Get(b.ctx, name, metav1.GetOptions{})
+			return err
+		})
+	case workload.RefKindSecret:
+		return b.existence(func() error {
+			_, err := b.client.CoreV1().Secrets(namespace).Get(b.ctx, name, metav1.GetOptions{})
+			return err
+		})
+	case "PersistentVolume":
+		item, err := b.client.CoreV1().PersistentVolumes().Get(b.ctx, name, metav1.GetOptions{})
+		if err == nil {
+			return pvHealth(item)
+		}
+		return b.existence(func() error { return err })
+	case "StorageClass":
+		return b.existence(func() error {
+			_, err := b.client.StorageV1().StorageClasses().Get(b.ctx, name, metav1.GetOptions{})
+			return err
+		})
+	default:
+		return health{StatusUnknown, ""}
+	}
+	return health{StatusMissing, "资源不存在"}
+}
+
+// existence 通过Get判断资源是否存在
+func (b *builder) existence(get func() error) health {
+	err := get()
+	switch {
+	case err == nil:
+		return health{StatusHealthy, ""}
+	case apierrors.IsNotFound(err):
+		return health{StatusMissing, "资源不存在"}
+	default:
+		return health{StatusUnknown, err.Error()}
+	}
+}
+
+// slicesOf 返回Service对应的EndpointSlice
+func (b *builder) slicesOf(service string) []*discoveryv1.EndpointSlice {
+	var result []*discoveryv1.EndpointSlice
+	for _, slice := range b.snap.slices {
+		if slice.Labels[discoveryv1.LabelServiceName] == service {
+			result = append(result, slice)
+		}
+	}
+	return result
+}
+
+// expand 展开节点的关联资源
+func (b *builder) expand(node k8s.RelationNode) {
+	s := b.snap
+	ns := b.namespace
+	switch node.Kind {
+	case "Ingress":
+		b.expandIngress(node.ID, s.ingresses[node.Name])
+	case "Service":
+		b.expandService(node.ID, s.services[node.Name])
+	case "EndpointSlice":
+		for _, endpoint := range s.slices[node.Name].Endpoints {
+			if endpoint.TargetRef != nil && endpoint.TargetRef.Kind == "Pod" {
+				b.link(node.ID, "Pod", ns, endpoint.TargetRef.Name, EdgeTargets)
+			}
+		}
+	case "Pod":
+		pod := s.pods[node.Name]
+		b.expandOwners(node.ID, pod.ObjectMeta)
+		if metav1.GetControllerOf(pod) == nil {
+			b.expandPodSpec(node.ID, &pod.Spec)
+		}
+		for _, service := range s.services {
+			if len(service.Spec.Selector) > 0 && labels.SelectorFromSet(service.Spec.Selector).Matches(labels.Set(pod.Labels)) {
+				b.node("Service", ns, service.Name)
+			}
+		}
+	case "ReplicaSet":
+		rs := s.replicaSets[node.Name]
+		b.expandOwners(node.ID, rs.ObjectMeta)
+		b.expandOwned(node.ID, "ReplicaSet", node.Name)
+		if metav1.GetControllerOf(rs) == nil {
+			b.expandPodSpec(node.ID, &rs.Spec.Template.Spec)
+		}
+	case "Deployment":
+		b.expandOwned(node.ID, "Deployment", node.Name)
+		b.expandPodSpec(node.ID, &s.deployments[node.Name].Spec.Template.Spec)
+		b.expandAutoscalers(node.ID, "Deployment", node.Name)
+	case "StatefulSet":
+		sts := s.statefulSets[node.Name]
+		b.expandOwned(node.ID, "StatefulSet", node.Name)
+		b.expandPodSpec(node.ID, &sts.Spec.Template.Spec)
+		b.expandAutoscalers(node.ID, "StatefulSet", node.Name)
+		for _, pvc := range s.pvcs {
+			if workload.IsVolumeClaimOf(sts, pvc.Name) {
+				b.link(node.ID, workload.RefKindPersistentVolumeClaim, ns, pvc.Name, EdgeMounts)
+			}
+		}
+	case "DaemonSet":
+		b.expandOwned(node.ID, "DaemonSet", node.Name)
+		b.expandPodSpec(node.ID, &s.daemonSets[node.Name].Spec.Template.Spec)
+		b.expandAutoscalers(node.ID, "DaemonSet", node.Name)
+	case "Job":
+		job := s.jobs[node.Name]
+		b.expandOwners(node.ID, job.ObjectMeta)
+		b.expandOwned(node.ID, "Job", node.Name)
+		if metav1.GetControllerOf(job) == nil {
+			b.expandPodSpec(node.ID, &job.Spec.Template.Spec)
+		}
+	case "CronJob":
+		b.expandOwned(node.ID, "CronJob", node.Name)
+		b.expandPodSpec(node.ID, &s.cronJobs[node.Name].Spec.JobTemplate.Spec.Template.Spec)
+	case "HorizontalPodAutoscaler":
+		target := s.hpas[node.Name].Spec.ScaleTargetRef
+		b.link(node.ID, target.Kind, ns, target.Name, EdgeScales)
+	case "VerticalPodAutoscaler":
+		kind, _, _ := unstructured.NestedString(s.vpas[node.Name].Object, "spec", "targetRef", "kind")
+		name, _, _ := unstructured.NestedString(s.vpas[node.Name].Object, "spec", "targetRef", "name")
+		if kind != "" && name != "" {
+			b.link(node.ID, kind, ns, name, EdgeScales)
+		}
+	case workload.RefKindPersistentVolumeClaim:
+		pvc := s.pvcs[node.Name]
+		if pvc.Spec.VolumeName != "" {
+			b.link(node.ID, "PersistentVolume", "", pvc.Spec.VolumeName, EdgeBound)
+		} else if pvc.Spec.StorageClassName != nil && *pvc.Spec.StorageClassName != "" {
+			b.link(node.ID, "StorageClass", "", *pvc.Spec.StorageClassName, EdgeProvisions)
+		}
+	case "PersistentVolume":
+		pv, err := b.client.CoreV1().PersistentVolumes().Get(b.ctx, node.Name, metav1.GetOptions{})
+		if err == nil && pv.Spec.StorageClassName != "" {
+			b.link(node.ID, "StorageClass", "", pv.Spec.StorageClassName, EdgeProvisions)
+		}
+	}
+}
+
+func (b *builder) expandIngress(id string, ingress *networkingv1.Ingress) {
+	addBackend := func(backend *networkingv1.IngressBackend) {
+		if backend != nil && backend.Service != nil {
+			b.link(id, "Service", b.namespace, backend.Service.Name, EdgeRoutes)
+		}
+	}
+	addBackend(ingress.Spec.DefaultBackend)
+	for _, rule := range ingress.Spec.Rules {
+		if rule.HTTP == nil {
+			continue
+		}
+		for i := range rule.HTTP.Paths {
+			addBackend(&rule.HTTP.Paths[i].Backend)
+		}
+	}
+}
+
+func (b *builder) expandService(id string, service *corev1.Service) {
+	for _, ingress := range b.snap.ingresses {
+		if ingressRoutesTo(ingress, service.Name) {
+			b.linkFrom("Ingress", b.namespace, ingress.Name, id, EdgeRoutes)
+		}
+	}
+
+	slices := b.slicesOf(service.Name)
+	for _, slice := range slices {
+		b.link(id, "EndpointSlice", b.namespace, slice.Name, EdgeEndpoints)
+	}
+	// 没有EndpointSlice时按selector直接关联Pod
+	if len(slices) == 0 && len(service.Spec.Selector) > 0 {
+		selector := labels.SelectorFromSet(service.Spec.Selector)
+		for _, pod := range b.snap.pods {
+			if selector.Matches(labels.Set(pod.Labels)) {
+				b.link(id, "Pod", b.namespace, pod.Name, EdgeTargets)
+			}
+		}
+	}
+}
+
+// expandOwners 关联对象的控制器属主
+func (b *builder) expandOwners(id string, meta metav1.ObjectMeta) {
+	for _, owner := range meta.OwnerReferences {
+		if owner.Controller != nil && *owner.Controller {
+			b.linkFrom(owner.Kind, b.namespace, owner.Name, id, EdgeOwns)
+		}
+	}
+}
+
+// expandOwned 关联由该对象控制的下级资源；Deployment只展开仍有副本的ReplicaSet
+func (b *builder) expandOwned(id, kind, name string) {
+	ownedBy := func(meta metav1.ObjectMeta) bool {
+		owner := metav1.GetControllerOfNoCopy(&meta)
+		return owner != nil && owner.Kind == kind && owner.Name == name
+	}
+	switch kind {
+	case "Deployment":
+		for _, rs := range b.snap.replicaSets {
+			active := rs.Status.Replicas > 0 || (rs.Spec.Replicas != nil && *rs.Spec.Replicas > 0)
+			if active && ownedBy(rs.ObjectMeta) {
+				b.link(id, "ReplicaSet", b.namespace, rs.Name, EdgeOwns)
+			}
+		}
+	case "CronJob":
+		for _, job := range b.snap.jobs {
+			if ownedBy(job.ObjectMeta) {
+				b.link(id, "Job", b.namespace, job.Name, EdgeOwns)
+			}
+		}
+	default:
+		for _, pod := range b.snap.pods {
+			if ownedBy(pod.ObjectMeta) {
+				b.link(id, "Pod", b.namespace, pod.Name, EdgeOwns)
+			}
+		}
+	}
+}
+
+// expandPodSpec 关联Pod模板挂载或引用的ConfigMap、Secret和PVC
+func (b *builder) expandPodSpec(id string, spec *corev1.PodSpec) {
+	for _, kind := range []string{workload.RefKindConfigMap, workload.RefKindSecret, workload.RefKindPersistentVolumeClaim} {
+		for _, name := range workload.PodSpecRefNames(spec, kind) {
+			b.link(id, kind, b.namespace, name, EdgeMounts)
+		}
+	}
+}
+
+// expandAutoscalers 关联以该工作负载为目标的HPA和VPA
+func (b *builder) expandAutoscalers(id, kind, name string) {
+	for _, hpa := range b.snap.hpas {
+		if hpa.Spec.ScaleTargetRef.Kind == kind && hpa.Spec.ScaleTargetRef.Name == name {
+			b.linkFrom("HorizontalPodAutoscaler", b.namespace, hpa.Name, id, EdgeScales)
+		}
+	}
+	for _, vpa := range b.snap.vpas {
+		targetKind, _, _ := unstructured.NestedString(vpa.Object, "spec", "targetRef", "kind")
+		targetName, _, _ := unstructured.NestedString(vpa.Object, "spec", "targetRef", "name")
+		if targetKind == kind && targetName == name {
+			b.linkFrom("VerticalPodAutoscaler", b.namespace, vpa.GetName(), id, EdgeScales)
+		}
+	}
+}
+
+// ingressRoutesTo 判断Ingress是否将流量转发到指定Service
+func ingressRoutesTo(ingress *networkingv1.Ingress, service string) bool {
+	if backend := ingress.Spec.DefaultBackend; backend != nil && backend.Service != nil && backend.Service.Name == service {
+		return true
+	}
+	for _, rule := range ingress.Spec.Rules {
+		if rule.HTTP == nil {
+			continue
+		}
+		for _, path := range rule.HTTP.Paths {
+			if path.Backend.Service != nil && path.Backend.Service.Name == service {
+				return true
+			}
+		}
+	}
+	return false
+}
@@ -0,0 +1,212 @@
+package relation
+
+import (
+	"fmt"
+
+	appsv1 "k8s.io/api/apps/v1"
+	autoscalingv2 "k8s.io/api/autoscaling/v2"
+	batchv1 "k8s.io/api/batch/v1"
+	corev1 "k8s.io/api/core/v1"
+	discoveryv1 "k8s.io/api/discovery/v1"
+	networkingv1 "k8s.io/api/networking/v1"
+)
+
+// 节点健康状态
+const (
+	StatusHealthy     = "healthy"
+	StatusProgressing = "progressing"
+	StatusDegraded    = "degraded"
+	StatusMissing     = "missing" // 被引用但不存在
+	StatusUnknown     = "unknown"
+)
+
+// health 节点健康状态及说明
+type health struct {
+	status  string
+	message string
+}
+
+func replicaHealth(desired, ready int32) health {
+	message := fmt.Sprintf("%d/%d 就绪", ready, desired)
+	switch {
+	case ready >= desired:
+		return health{StatusHealthy, message}
+	case ready == 0:
+		return health{StatusDegraded, message}
+	default:
+		return health{StatusProgressing, message}
+	}
+}
+
+func deploymentHealth(item *appsv1.Deployment) health {
+	desired := int32(1)
+	if item.Spec.Replicas != nil {
+		desired = *item.Spec.Replicas
+	}
+	for _, condition := range item.Status.Conditions {
+		if condition.Type == appsv1.DeploymentProgressing && condition.Reason == "ProgressDeadlineExceeded" {
+			return health{StatusDegraded, condition.Message}
+		}
+	}
+	result := replicaHealth(desired, item.Status.AvailableReplicas)
+	if result.status == StatusHealthy && item.Status.UpdatedReplicas < desired {
+		result.status = StatusProgressing
+	}
+	return result
+}
+
+func statefulSetHealth(item *appsv1.StatefulSet) health {
+	desired := int32(1)
+	if item.Spec.Replicas != nil {
+		desired = *item.Spec.Replicas
+	}
+	return replicaHealth(desired, item.Status.ReadyReplicas)
+}
+
+func daemonSetHealth(item *appsv1.DaemonSet) health {
+	return replicaHealth(item.Status.DesiredNumberScheduled, item.Status.NumberReady)
+}
+
+func replicaSetHealth(item *appsv1.ReplicaSet) health {
+	desired := int32(1)
+	if item.Spec.Replicas != nil {
+		desired = *item.Spec.Replicas
+	}
+	return replicaHealth(desired, item.Status.ReadyReplicas)
+}
+
+func jobHealth(item *batchv1.Job) health {
+	for _, condition := range item.Status.Conditions {
+		if condition.Status != corev1.ConditionTrue {
+			continue
+		}
+		switch condition.Type {
+		case batchv1.JobComplete:
+			return health{StatusHealthy, "已完成"}
+		case batchv1.JobFailed:
+			return health{StatusDegraded, condition.Reason + ": " + condition.Message}
+		}
+	}
+	return health{StatusProgressing, fmt.Sprintf("运行中 %d，成功 %d，失败 %d", item.Status.Active, item.Status.Succeeded, item.Status.Failed)}
+}
+
+func cronJobHealth(item *batchv1.CronJob) health {
+	if item.Spec.Suspend != nil && *item.Spec.Suspend {
+		return health{StatusHealthy, "已暂停"}
+	}
+	return health{StatusHealthy, fmt.Sprintf("运行中的Job %d 个", len(item.Status.Active))}
+}
+
+func podHealth(item *corev1.Pod) health {
+	switch item.Status.Phase {
+	case corev1.PodSucceeded:
+		return health{StatusHealthy, "已完成"}
+	case corev1.PodFailed:
+		return health{StatusDegraded, item.Status.Reason + " " + item.Status.Message}
+	}
+
+	statuses := make([]corev1.ContainerStatus, 0, len(item.Status.InitContainerStatuses)+len(item.Status.ContainerStatuses))
+	statuses = append(statuses, item.Status.InitContainerStatuses...)
+	statuses = append(statuses, item.Status.ContainerStatuses...)
+	for _, status := range statuses {
+		if status.State.Waiting == nil {
+			continue
+		}
+		switch status.State.Waiting.Reason {
+		case "CrashLoopBackOff", "ImagePullBackOff", "ErrImagePull", "CreateContainerConfigError", "InvalidImageName":
+			return health{StatusDegraded, status.Name + ": " + status.State.Waiting.Reason}
+		}
+	}
+
+	if item.Status.Phase == corev1.PodPending {
+		for _, condition := range item.Status.Conditions {
+			if condition.Type == corev1.PodScheduled && condition.Status == corev1.ConditionFalse {
+				return health{StatusDegraded, condition.Reason + ": " + condition.Message}
+			}
+		}
+		return health{StatusProgressing, "Pending"}
+	}
+	for _, condition := range item.Status.Conditions {
+		if condition.Type == corev1.PodReady && condition.Status != corev1.ConditionTrue {
+			return health{StatusProgressing, "未就绪"}
+		}
+	}
+	return health{StatusHealthy, string(item.Status.Phase)}
+}
+
+func endpointSliceHealth(item *discoveryv1.EndpointSlice) health {
+	ready := 0
+	for _, endpoint := range item.Endpoints {
+		if endpoint.Conditions.Ready == nil || *endpoint.Conditions.Ready {
+			ready++
+		}
+	}
+	message := fmt.Sprintf("%d/%d 端点就绪", ready, len(item.Endpoints))
+	if ready == 0 {
+		return health{StatusDegraded, message}
+	}
+	return health{StatusHealthy, message}
+}
+
+func serviceHealth(item *corev1.Service, slices []*discoveryv1.EndpointSlice) health {
+	if item.Spec.Type == corev1.ServiceTypeExternalName {
+		return health{StatusHealthy, "ExternalName: " + item.Spec.ExternalName}
+	}
+	ready := 0
+	for _, slice := range slices {
+		for _, endpoint := range slice.Endpoints {
+			if endpoint.Conditions.Ready == nil || *endpoint.Conditions.Ready {
+				ready++
+			}
+		}
+	}
+	if ready == 0 {
+		if len(item.Spec.Selector) == 0 {
+			return health{StatusUnknown, "未设置selector"}
+		}
+		return health{StatusDegraded, "没有就绪的端点"}
+	}
+	if item.Spec.Type == corev1.ServiceTypeLoadBalancer && len(item.Status.LoadBalancer.Ingress) == 0 {
+		return health{StatusProgressing, "等待分配LoadBalancer地址"}
+	}
+	return health{StatusHealthy, fmt.Sprintf("%d 个就绪端点", ready)}
+}
+
+func ingressHealth(item *networkingv1.Ingress) health {
+	if len(item.Status.LoadBalancer.Ingress) == 0 {
+		return health{StatusProgressing, "等待分配地址"}
+	}
+	return health{StatusHealthy, ""}
+}
+
+func hpaHealth(item *autoscalingv2.HorizontalPodAutoscaler) health {
+	for _, condition := range item.Status.Conditions {
+		if condition.Status == corev1.ConditionFalse &&
+			(condition.Type == autoscalingv2.AbleToScale || condition.Type == autoscalingv2.ScalingActive) {
+			return health{StatusDegraded, condition.Reason + ": " + condition.Message}
+		}
+	}
+	return health{StatusHealthy, fmt.Sprintf("当前副本 %d，期望副本 %d", item.Status.CurrentReplicas, item.Status.DesiredReplicas)}
+}
+
+func pvcHealth(item *corev1.PersistentVolumeClaim) health {
+	switch item.Status.Phase {
+	case corev1.ClaimBound:
+		return health{StatusHealthy, "Bound"}
+	case corev1.ClaimLost:
+		return health{StatusDegraded, "Lost"}
+	default:
+		return health{StatusProgressing, string(item.Status.Phase)}
+	}
+}
+
+func pvHealth(item *corev1.PersistentVolume) health {
+	switch item.Status.Phase {
+	case corev1.VolumeBound, corev1.VolumeAvailable:
+		return health{StatusHealthy, string(item.Status.Phase)}
+	case corev1.VolumeFailed:
+		return health{StatusDegraded, item.Status.Message}
+	default:
+		return health{StatusProgressing, string(item.Status.Phase)}
+	}
+}
@@ -0,0 +1,217 @@
+package scanner
+
+import (
+	"context"
+	"fmt"
+	"sort"
+	"sync"
+	"time"
+
+	corev1 "k8s.io/api/core/v1"
+	networkingv1 "k8s.io/api/networking/v1"
+	metav1 "k8s.io/apimachinery/pkg/apis/meta/v1"
+	"k8s.io/client-go/kubernetes"
+)
+
+// 问题严重程度
+const (
+	SeverityCritical = "critical"
+	SeverityWarning  = "warning"
+	SeverityInfo     = "info"
+)
+
+// Finding 规则发现的问题，Rule由扫描器填充
+type Finding struct {
+	Rule      string
+	Severity  string
+	Namespace string
+	Kind      string
+	Name      string
+	Message   string
+}
+
+// Rule 扫描规则。规则只读取Inventory，不直接访问集群，
+// 可以用fake clientset构造Inventory进行单元测试
+type Rule interface {
+	ID() string
+	Description() string
+	Check(inv *Inventory) []Finding
+}
+
+// ruleFunc 以函数实现的规则
+type ruleFunc struct {
+	id          string
+	description string
+	check       func(inv *Inventory) []Finding
+}
+
+func (r ruleFunc) ID() string                     { return r.id }
+func (r ruleFunc) Description() string            { return r.description }
+func (r ruleFunc) Check(inv *Inventory) []Finding { return r.check(inv) }
+
+// NewRule 以函数创建规则
+func NewRule(id, description string, check func(inv *Inventory) []Finding) Rule {
+	return ruleFunc{id: id, description: description, check: check}
+}
+
+var (
+	registryMu sync.RWMutex
+	registry   = map[string]Rule{}
+)
+
+// Register 注册规则，ID重复时覆盖已有规则
+func Register(rules ...Rule) {
+	registryMu.Lock()
+	defer registryMu.Unlock()
+	for _, rule := range rules {
+		registry[rule.ID()] = rule
+	}
+}
+
+// Rules 返回已注册的规则，按ID排序
+func Rules() []Rule {
+	registryMu.RLock()
+	defer registryMu.RUnlock()
+	rules := make([]Rule, 0, len(registry))
+	for _, rule := range registry {
+		rules = append(rules, rule)
+	}
+	sort.Slice(rules, func(i, j int) bool { return rules[i].ID() < rules[j].ID() })
+	return rules
+}
+
+// Workload 带Pod模板的对象，包括未被控制器管理的Pod
+type Workload struct {
+	Kind      string
+	Namespace string
+	Name      string
+	Spec      *corev1.PodSpec
+}
+
+// Inventory 一次扫描读取的集群资源
+type Inventory struct {
+	Now        time.Time
+	Namespaces []string // 参与扫描的命名空间
+	Workloads  []Workload
+	Pods       []corev1.Pod
+	Services   []corev1.Service
+	Ingresses  []networkingv1.Ingress
+	PVCs       []corev1.PersistentVolumeClaim
+}
+
+// LoadInventory 读取集群中除排除命名空间外的资源
+func LoadInventory(ctx context.Context, client kubernetes.Interface, exclude []string, now time.Time) (*Inventory, error) {
+	excluded := map[string]bool{}
+	for _, ns := range exclude {
+		excluded[ns] = true
+	}
+	inv := &Inventory{Now: now}
+	opts := metav1.ListOptions{}
+
+	namespaces, err := client.CoreV1().Namespaces().List(ctx, opts)
+	if err != nil {
+		return nil, fmt.Errorf("获取命名空间列表失败: %w", err)
+	}
+	for _, ns := range namespaces.Items {
+		if !excluded[ns.Name] {
+			inv.Namespaces = append(inv.Namespaces, ns.Name)
+		}
+	}
+	sort.Strings(inv.Namespaces)
+
+	deployments, err := client.AppsV1().Deployments("").List(ctx, opts)
+	if err != nil {
+		return nil, fmt.Errorf("获取Deployment列表失败: %w", err)
+	}
+	for i := range deployments.Items {
+		item := &deployments.Items[i]
+		inv.addWorkload(excluded, "Deployment", item.ObjectMeta, &item.Spec.Template.Spec)
+	}
+	statefulSets, err := client.AppsV1().StatefulSets("").List(ctx, opts)
+	if err != nil {
+		return nil, fmt.Errorf("获取StatefulSet列表失败: %w", err)
+	}
+	for i := range statefulSets.Items {
+		item := &statefulSets.Items[i]
+		inv.addWorkload(excluded, "StatefulSet", item.ObjectMeta, &item.Spec.Template.Spec)
+	}
+	daemonSets, err := client.AppsV1().DaemonSets("").List(ctx, opts)
+	if err != nil {
+		return nil, fmt.Errorf("获取DaemonSet列表失败: %w", err)
+	}
+	for i := range daemonSets.Items {
+		item := &daemonSets.Items[i]
+		inv.addWorkload(excluded, "DaemonSet", item.ObjectMeta, &item.Spec.Template.Spec)
+	}
+	cronJobs, err := client.BatchV1().CronJobs("").List(ctx, opts)
+	if err != nil {
+		return nil, fmt.Errorf("获取CronJob列表失败: %w", err)
+	}
+	for i := range cronJobs.Items {
+		item := &cronJobs.Items[i]
+		inv.addWorkload(excluded, "CronJob", item.ObjectMeta, &item.Spec.JobTemplate.Spec.Template.Spec)
+	}
+	jobs, err := client.BatchV1().Jobs("").List(ctx, opts)
+	if err != nil {
+		return nil, fmt.Errorf("获取Job列表失败: %w", err)
+	}
+	for i := range jobs.Items {
+		item := &jobs.Items[i]
+		// CronJob创建的Job已通过CronJob的模板检查
+		if metav1.GetControllerOf(item) == nil {
+			inv.addWorkload(excluded, "Job", item.ObjectMeta, &item.Spec.Template.Spec)
+		}
+	}
+
+	pods, err := client.CoreV1().Pods("").List(ctx, opts)
+	if err != nil {
+		return nil, fmt.Errorf("获取Pod列表失败: %w", err)
+	}
+	for i := range pods.Items {
+		item := &pods.Items[i]
+		if excluded[item.Namespace] {
+			continue
+		}
+		inv.Pods = append(inv.Pods, *item)
+		// 由控制器管理的Pod已通过控制器的模板检查
+		if metav1.GetControllerOf(item) == nil {
+			inv.addWorkload(excluded, "Pod", item.ObjectMeta, &item.Spec)
+		}
+	}
+
+	services, err := client.CoreV1().Services("").List(ctx, opts)
+	if err != nil {
+		return nil, fmt.Errorf("获取Service列表失败: %w", err)
+	}
+	for _, item := range services.Items {
+		if !excluded[item.Namespace] {
+			inv.Services = append(inv.Services, item)
+		}
+	}
+	ingresses, err := client.NetworkingV1().Ingresses("").List(ctx, opts)
+	if err != nil {
+		return nil, fmt.Errorf("获取Ingress列表失败: %w", err)
+	}
+	for _, item := range ingresses.Items {
+		if !excluded[item.Namespace] {
+			inv.Ingresses = append(inv.Ingresses, item)
+		}
+	}
+	pvcs, err := client.CoreV1().PersistentVolumeClaims("").List(ctx, opts)
+	if err != nil {
+		return nil, fmt.Errorf("获取PVC列表失败: %w", err)
+	}
+	for _, item := range pvcs.Items {
+		if !excluded[item.Namespace] {
+			inv.PVCs = append(inv.PVCs, item)
+		}
+	}
+	return inv, nil
+}
+
+func (inv *Inventory) addWorkload(excluded map[string]bool, kind string, meta metav1.ObjectMeta, spec *corev1.PodSpec) {
+	if excluded[meta.Namespace] {
+		return
+	}
+	inv.Workloads = append(inv.Workloads, Workload{Kind: kind, Namespace: meta.Namespace, Name: meta.Name, Spec: spec})
+}
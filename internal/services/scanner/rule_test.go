@@ -0,0 +1,119 @@
+package scanner
+
+import (
+	"context"
+	"reflect"
+	"testing"
+	"time"
+
+	appsv1 "k8s.io/api/apps/v1"
+	batchv1 "k8s.io/api/batch/v1"
+	corev1 "k8s.io/api/core/v1"
+	networkingv1 "k8s.io/api/networking/v1"
+	metav1 "k8s.io/apimachinery/pkg/apis/meta/v1"
+	"k8s.io/apimachinery/pkg/runtime"
+	"k8s.io/client-go/kubernetes/fake"
+)
+
+func meta(namespace, name string, owner ...metav1.OwnerReference) metav1.ObjectMeta {
+	return metav1.ObjectMeta{Namespace: namespace, Name: name, OwnerReferences: owner}
+}
+
+func controllerRef(kind, name string) metav1.OwnerReference {
+	return metav1.OwnerReference{Kind: kind, Name: name, Controller: boolPtr(true)}
+}
+
+func workloadKeys(workloads []Workload) []string {
+	keys := make([]string, 0, len(workloads))
+	for _, w := range workloads {
+		keys = append(keys, w.Kind+":"+w.Namespace+"/"+w.Name)
+	}
+	return keys
+}
+
+func TestLoadInventory(t *testing.T) {
+	now := time.Date(2024, 5, 1, 12, 0, 0, 0, time.UTC)
+	objects := []runtime.Object{
+		&corev1.Namespace{ObjectMeta: metav1.ObjectMeta{Name: "web"}},
+		&corev1.Namespace{ObjectMeta: metav1.ObjectMeta{Name: "batch"}},
+		&corev1.Namespace{ObjectMeta: metav1.ObjectMeta{Name: "kube-system"}},
+		&appsv1.Deployment{ObjectMeta: meta("web", "api")},
+		&appsv1.Deployment{ObjectMeta: meta("kube-system", "coredns")},
+		&appsv1.StatefulSet{ObjectMeta: meta("web", "db")},
+		&appsv1.DaemonSet{ObjectMeta: meta("kube-system", "kube-proxy")},
+		&batchv1.CronJob{ObjectMeta: meta("batch", "backup")},
+		&batchv1.Job{ObjectMeta: meta("batch", "backup-28000000", controllerRef("CronJob", "backup"))},
+		&batchv1.Job{ObjectMeta: meta("batch", "migrate")},
+		&corev1.Pod{ObjectMeta: meta("web", "api-7d9f-abcde", controllerRef("ReplicaSet", "api-7d9f"))},
+		&corev1.Pod{ObjectMeta: meta("web", "debug")},
+		&corev1.Pod{ObjectMeta: meta("kube-system", "etcd")},
+		&corev1.Service{ObjectMeta: meta("web", "api")},
+		&corev1.Service{ObjectMeta: meta("kube-system", "kube-dns")},
+		&networkingv1.Ingress{ObjectMeta: meta("web", "site")},
+		&corev1.PersistentVolumeClaim{ObjectMeta: meta("web", "data-db-0")},
+		&corev1.PersistentVolumeClaim{ObjectMeta: meta("kube-system", "etcd-data")},
+	}
+	client := fake.NewSimpleClientset(objects...)
+
+	inv, err := LoadInventory(context.Background(), client, []string{"kube-system"}, now)
+	if err != nil {
+		t.Fatalf("LoadInventory() error = %v", err)
+	}
+
+	if !inv.Now.Equal(now) {
+		t.Errorf("Now = %v, want %v", inv.Now, now)
+	}
+	if want := []string{"batch", "web"}; !reflect.DeepEqual(inv.Namespaces, want) {
+		t.Errorf("Namespaces = %v, want %v", inv.Namespaces, want)
+	}
+	// 排除命名空间的对象、CronJob创建的Job和控制器管理的Pod都不作为工作负载
+	wantWorkloads := []string{
+		"Deployment:web/api",
+		"StatefulSet:web/db",
+		"CronJob:batch/backup",
+		"Job:batch/migrate",
+		"Pod:web/debug",
+	}
+	if got := workloadKeys(inv.Workloads); !reflect.DeepEqual(got, wantWorkloads) {
+		t.Errorf("Workloads = %v, want %v", got, wantWorkloads)
+	}
+	for _, w := range inv.Workloads {
+		if w.Spec == nil {
+			t.Errorf("workload %s/%s has nil spec", w.Kind, w.Name)
+		}
+	}
+	// 控制器管理的Pod仍用于Service匹配
+	if len(inv.Pods) != 2 {
+		t.Errorf("len(Pods) = %d, want 2", len(inv.Pods))
+	}
+	if len(inv.Services) != 1 || inv.Services[0].Name != "api" {
+		t.Errorf("Services = %v, want only web/api", inv.Services)
+	}
+	if len(inv.Ingresses) != 1 {
+		t.Errorf("len(Ingresses) = %d, want 1", len(inv.Ingresses))
+	}
+	if len(inv.PVCs) != 1 || inv.PVCs[0].Name != "data-db-0" {
+		t.Errorf("PVCs = %v, want only web/data-db-0", inv.PVCs)
+	}
+}
+
+func TestRulesRegistered(t *testing.T) {
+	want := []string{
+		"host-path",
+		"ingress-missing-service",
+		"latest-tag",
+		"missing-probes",
+		"privileged",
+		"pvc-unbound",
+		"resource-limits",
+		"run-as-root",
+		"service-no-pods",
+	}
+	var got []string
+	for _, rule := range Rules() {
+		got = append(got, rule.ID())
+	}
+	if !reflect.DeepEqual(got, want) {
+		t.Errorf("Rules() = %v, want %v", got, want)
+	}
+}
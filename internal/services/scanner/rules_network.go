@@ -0,0 +1,93 @@
+package scanner
+
+import (
+	"fmt"
+
+	corev1 "k8s.io/api/core/v1"
+	networkingv1 "k8s.io/api/networking/v1"
+	"k8s.io/apimachinery/pkg/labels"
+)
+
+func init() {
+	Register(
+		NewRule("service-no-pods", "Service的selector没有匹配任何Pod", checkServiceNoPods),
+		NewRule("ingress-missing-service", "Ingress指向不存在的Service", checkIngressMissingService),
+	)
+}
+
+func checkServiceNoPods(inv *Inventory) []Finding {
+	podsByNamespace := map[string][]*corev1.Pod{}
+	for i := range inv.Pods {
+		pod := &inv.Pods[i]
+		podsByNamespace[pod.Namespace] = append(podsByNamespace[pod.Namespace], pod)
+	}
+
+	var findings []Finding
+	for _, service := range inv.Services {
+		// 未设置selector的Service由用户自行维护Endpoints
+		if service.Spec.Type == corev1.ServiceTypeExternalName || len(service.Spec.Selector) == 0 {
+			continue
+		}
+		selector := labels.SelectorFromSet(service.Spec.Selector)
+		matched := false
+		for _, pod := range podsByNamespace[service.Namespace] {
+			if selector.Matches(labels.Set(pod.Labels)) {
+				matched = true
+				break
+			}
+		}
+		if !matched {
+			findings = append(findings, Finding{
+				Severity:  SeverityWarning,
+				Namespace: service.Namespace,
+				Kind:      "Service",
+				Name:      service.Name,
+				Message:   fmt.Sprintf("selector %s 没有匹配任何Pod", selector.String()),
+			})
+		}
+	}
+	return findings
+}
+
+func checkIngressMissingService(inv *Inventory) []Finding {
+	services := map[string]bool{}
+	for _, service := range inv.Services {
+		services[service.Namespace+"/"+service.Name] = true
+	}
+
+	var findings []Finding
+	for _, ingress := range inv.Ingresses {
+		var backends []*networkingv1.IngressBackend
+		if ingress.Spec.DefaultBackend != nil {
+			backends = append(backends, ingress.Spec.DefaultBackend)
+		}
+		for _, rule := range ingress.Spec.Rules {
+			if rule.HTTP == nil {
+				continue
+			}
+			for i := range rule.HTTP.Paths {
+				backends = append(backends, &rule.HTTP.Paths[i].Backend)
+			}
+		}
+
+		reported := map[string]bool{}
+		for _, backend := range backends {
+			if backend.Service == nil {
+				continue
+			}
+			name := backend.Service.Name
+			if services[ingress.Namespace+"/"+name] || reported[name] {
+				continue
+			}
+			reported[name] = true
+			findings = append(findings, Finding{
+				Severity:  SeverityCritical,
+				Namespace: ingress.Namespace,
+				Kind:      "Ingress",
+				Name:      ingress.Name,
+				Message:   fmt.Sprintf("后端Service %s 不存在", name),
+			})
+		}
+	}
+	return findings
+}
@@ -0,0 +1,97 @@
+package scanner
+
+import (
+	"testing"
+
+	corev1 "k8s.io/api/core/v1"
+	networkingv1 "k8s.io/api/networking/v1"
+	metav1 "k8s.io/apimachinery/pkg/apis/meta/v1"
+)
+
+func newService(namespace, name string, selector map[string]string) corev1.Service {
+	return corev1.Service{
+		ObjectMeta: metav1.ObjectMeta{Namespace: namespace, Name: name},
+		Spec:       corev1.ServiceSpec{Selector: selector},
+	}
+}
+
+func newPod(namespace, name string, podLabels map[string]string) corev1.Pod {
+	return corev1.Pod{ObjectMeta: metav1.ObjectMeta{Namespace: namespace, Name: name, Labels: podLabels}}
+}
+
+func serviceBackend(name string) networkingv1.IngressBackend {
+	return networkingv1.IngressBackend{Service: &networkingv1.IngressServiceBackend{Name: name}}
+}
+
+func TestCheckServiceNoPods(t *testing.T) {
+	externalName := newService("web", "external", map[string]string{"app": "gone"})
+	externalName.Spec.Type = corev1.ServiceTypeExternalName
+
+	tests := []struct {
+		name     string
+		services []corev1.Service
+		pods     []corev1.Pod
+		want     []string
+	}{
+		{name: "selector matches pod", services: []corev1.Service{newService("web", "api", map[string]string{"app": "api"})},
+			pods: []corev1.Pod{newPod("web", "api-1", map[string]string{"app": "api", "version": "v1"})}},
+		{name: "selector matches nothing", services: []corev1.Service{newService("web", "api", map[string]string{"app": "api"})},
+			pods: []corev1.Pod{newPod("web", "db-1", map[string]string{"app": "db"})}, want: []string{"warning:Service/api"}},
+		{name: "pod in other namespace does not count", services: []corev1.Service{newService("web", "api", map[string]string{"app": "api"})},
+			pods: []corev1.Pod{newPod("other", "api-1", map[string]string{"app": "api"})}, want: []string{"warning:Service/api"}},
+		{name: "service without selector skipped", services: []corev1.Service{newService("web", "manual", nil)}},
+		{name: "external name service skipped", services: []corev1.Service{externalName}},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			checkFindings(t, checkServiceNoPods(&Inventory{Services: tt.services, Pods: tt.pods}), tt.want)
+		})
+	}
+}
+
+func TestCheckIngressMissingService(t *testing.T) {
+	defaultBackend := serviceBackend("fallback")
+	resourceBackend := networkingv1.IngressBackend{Resource: &corev1.TypedLocalObjectReference{Kind: "Bucket", Name: "static"}}
+	newIngress := func(backends ...networkingv1.IngressBackend) networkingv1.Ingress {
+		paths := make([]networkingv1.HTTPIngressPath, 0, len(backends))
+		for _, backend := range backends {
+			paths = append(paths, networkingv1.HTTPIngressPath{Path: "/", Backend: backend})
+		}
+		return networkingv1.Ingress{
+			ObjectMeta: metav1.ObjectMeta{Namespace: "web", Name: "site"},
+			Spec: networkingv1.IngressSpec{Rules: []networkingv1.IngressRule{
+				{IngressRuleValue: networkingv1.IngressRuleValue{HTTP: &networkingv1.HTTPIngressRuleValue{Paths: paths}}},
+				{Host: "no-http.example.com"},
+			}},
+		}
+	}
+	withDefault := newIngress()
+	withDefault.Spec.DefaultBackend = &defaultBackend
+
+	tests := []struct {
+		name     string
+		ingress  networkingv1.Ingress
+		services []corev1.Service
+		want     []string
+	}{
+		{name: "backend exists", ingress: newIngress(serviceBackend("api")),
+			services: []corev1.Service{newService("web", "api", nil)}},
+		{name: "backend missing", ingress: newIngress(serviceBackend("api")), want: []string{"critical:Ingress/site"}},
+		{name: "backend in other namespace", ingress: newIngress(serviceBackend("api")),
+			services: []corev1.Service{newService("other", "api", nil)}, want: []string{"critical:Ingress/site"}},
+		{name: "missing service reported once", ingress: newIngress(serviceBackend("api"), serviceBackend("api")),
+			want: []string{"critical:Ingress/site"}},
+		{name: "each missing service reported", ingress: newIngress(serviceBackend("api"), serviceBackend("web")),
+			want: []string{"critical:Ingress/site", "critical:Ingress/site"}},
+		{name: "default backend checked", ingress: withDefault, want: []string{"critical:Ingress/site"}},
+		{name: "resource backend skipped", ingress: newIngress(resourceBackend)},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			inv := &Inventory{Services: tt.services, Ingresses: []networkingv1.Ingress{tt.ingress}}
+			checkFindings(t, checkIngressMissingService(inv), tt.want)
+		})
+	}
+}
@@ -0,0 +1,36 @@
+package scanner
+
+import (
+	"fmt"
+	"time"
+
+	corev1 "k8s.io/api/core/v1"
+)
+
+// pvcUnboundThreshold PVC未绑定超过该时长才报告
+const pvcUnboundThreshold = 24 * time.Hour
+
+func init() {
+	Register(NewRule("pvc-unbound", "PVC超过一天未绑定", checkPVCUnbound))
+}
+
+func checkPVCUnbound(inv *Inventory) []Finding {
+	var findings []Finding
+	for _, pvc := range inv.PVCs {
+		if pvc.Status.Phase == corev1.ClaimBound {
+			continue
+		}
+		age := inv.Now.Sub(pvc.CreationTimestamp.Time)
+		if age < pvcUnboundThreshold {
+			continue
+		}
+		findings = append(findings, Finding{
+			Severity:  SeverityWarning,
+			Namespace: pvc.Namespace,
+			Kind:      "PersistentVolumeClaim",
+			Name:      pvc.Name,
+			Message:   fmt.Sprintf("处于 %s 状态已超过 %d 天", pvc.Status.Phase, int(age.Hours()/24)),
+		})
+	}
+	return findings
+}
@@ -0,0 +1,39 @@
+package scanner
+
+import (
+	"testing"
+	"time"
+
+	corev1 "k8s.io/api/core/v1"
+	metav1 "k8s.io/apimachinery/pkg/apis/meta/v1"
+)
+
+func TestCheckPVCUnbound(t *testing.T) {
+	now := time.Date(2024, 5, 1, 12, 0, 0, 0, time.UTC)
+	newPVC := func(phase corev1.PersistentVolumeClaimPhase, age time.Duration) corev1.PersistentVolumeClaim {
+		return corev1.PersistentVolumeClaim{
+			ObjectMeta: metav1.ObjectMeta{Namespace: "data", Name: "pvc", CreationTimestamp: metav1.NewTime(now.Add(-age))},
+			Status:     corev1.PersistentVolumeClaimStatus{Phase: phase},
+		}
+	}
+
+	tests := []struct {
+		name string
+		pvc  corev1.PersistentVolumeClaim
+		want []string
+	}{
+		{name: "bound", pvc: newPVC(corev1.ClaimBound, 72*time.Hour)},
+		{name: "pending under threshold", pvc: newPVC(corev1.ClaimPending, 23*time.Hour)},
+		{name: "pending at threshold", pvc: newPVC(corev1.ClaimPending, 24*time.Hour),
+			want: []string{"warning:PersistentVolumeClaim/pvc"}},
+		{name: "lost over threshold", pvc: newPVC(corev1.ClaimLost, 72*time.Hour),
+			want: []string{"warning:PersistentVolumeClaim/pvc"}},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			inv := &Inventory{Now: now, PVCs: []corev1.PersistentVolumeClaim{tt.pvc}}
+			checkFindings(t, checkPVCUnbound(inv), tt.want)
+		})
+	}
+}
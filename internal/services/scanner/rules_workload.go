@@ -0,0 +1,180 @@
+package scanner
+
+import (
+	"fmt"
+	"strings"
+
+	corev1 "k8s.io/api/core/v1"
+)
+
+func init() {
+	Register(
+		NewRule("resource-limits", "容器未设置CPU或内存limit", checkResourceLimits),
+		NewRule("missing-probes", "长期运行的容器未配置存活或就绪探针", checkProbes),
+		NewRule("latest-tag", "镜像使用latest标签或未指定标签", checkLatestTag),
+		NewRule("privileged", "容器以特权模式运行", checkPrivileged),
+		NewRule("host-path", "挂载了宿主机目录(hostPath)", checkHostPath),
+		NewRule("run-as-root", "容器以root用户运行或未限制非root运行", checkRunAsRoot),
+	)
+}
+
+// workloadFinding 创建针对工作负载的问题
+func workloadFinding(w Workload, severity, message string) Finding {
+	return Finding{Severity: severity, Namespace: w.Namespace, Kind: w.Kind, Name: w.Name, Message: message}
+}
+
+// allContainers 返回初始化容器和普通容器
+func allContainers(spec *corev1.PodSpec) []corev1.Container {
+	containers := make([]corev1.Container, 0, len(spec.InitContainers)+len(spec.Containers))
+	containers = append(containers, spec.InitContainers...)
+	return append(containers, spec.Containers...)
+}
+
+func checkResourceLimits(inv *Inventory) []Finding {
+	var findings []Finding
+	for _, w := range inv.Workloads {
+		var problems []string
+		for _, container := range allContainers(w.Spec) {
+			var missing []string
+			for _, name := range []corev1.ResourceName{corev1.ResourceCPU, corev1.ResourceMemory} {
+				if _, ok := container.Resources.Limits[name]; !ok {
+					missing = append(missing, string(name))
+				}
+			}
+			if len(missing) > 0 {
+				problems = append(problems, fmt.Sprintf("容器 %s 未设置 %s limit", container.Name, strings.Join(missing, "、")))
+			}
+		}
+		if len(problems) > 0 {
+			findings = append(findings, workloadFinding(w, SeverityWarning, strings.Join(problems, "；")))
+		}
+	}
+	return findings
+}
+
+func checkProbes(inv *Inventory) []Finding {
+	var findings []Finding
+	for _, w := range inv.Workloads {
+		// 一次性任务不需要探针
+		if w.Kind == "Job" || w.Kind == "CronJob" || (w.Kind == "Pod" && w.Spec.RestartPolicy == corev1.RestartPolicyNever) {
+			continue
+		}
+		var problems []string
+		for _, container := range w.Spec.Containers {
+			var missing []string
+			if container.LivenessProbe == nil {
+				missing = append(missing, "livenessProbe")
+			}
+			if container.ReadinessProbe == nil {
+				missing = append(missing, "readinessProbe")
+			}
+			if len(missing) > 0 {
+				problems = append(problems, fmt.Sprintf("容器 %s 未配置 %s", container.Name, strings.Join(missing, "、")))
+			}
+		}
+		if len(problems) > 0 {
+			findings = append(findings, workloadFinding(w, SeverityWarning, strings.Join(problems, "；")))
+		}
+	}
+	return findings
+}
+
+// imageTag 返回镜像标签，使用digest时返回digest
+func imageTag(image string) string {
+	if i := strings.Index(image, "@"); i >= 0 {
+		return image[i+1:]
+	}
+	// 冒号在最后一个斜杠之后才是标签，否则是仓库端口
+	name := image
+	if i := strings.LastIndex(name, "/"); i >= 0 {
+		name = name[i+1:]
+	}
+	if i := strings.LastIndex(name, ":"); i >= 0 {
+		return name[i+1:]
+	}
+	return ""
+}
+
+func checkLatestTag(inv *Inventory) []Finding {
+	var findings []Finding
+	for _, w := range inv.Workloads {
+		for _, container := range allContainers(w.Spec) {
+			switch imageTag(container.Image) {
+			case "":
+				findings = append(findings, workloadFinding(w, SeverityWarning,
+					fmt.Sprintf("容器 %s 的镜像 %s 未指定标签（默认latest）", container.Name, container.Image)))
+			case "latest":
+				findings = append(findings, workloadFinding(w, SeverityWarning,
+					fmt.Sprintf("容器 %s 的镜像 %s 使用latest标签", container.Name, container.Image)))
+			}
+		}
+	}
+	return findings
+}
+
+func checkPrivileged(inv *Inventory) []Finding {
+	var findings []Finding
+	for _, w := range inv.Workloads {
+		for _, container := range allContainers(w.Spec) {
+			sc := container.SecurityContext
+			if sc != nil && sc.Privileged != nil && *sc.Privileged {
+				findings = append(findings, workloadFinding(w, SeverityCritical,
+					fmt.Sprintf("容器 %s 以特权模式运行", container.Name)))
+			}
+		}
+	}
+	return findings
+}
+
+func checkHostPath(inv *Inventory) []Finding {
+	var findings []Finding
+	for _, w := range inv.Workloads {
+		for _, volume := range w.Spec.Volumes {
+			if volume.HostPath != nil {
+				findings = append(findings, workloadFinding(w, SeverityCritical,
+					fmt.Sprintf("卷 %s 挂载了宿主机目录 %s", volume.Name, volume.HostPath.Path)))
+			}
+		}
+	}
+	return findings
+}
+
+func checkRunAsRoot(inv *Inventory) []Finding {
+	var findings []Finding
+	for _, w := range inv.Workloads {
+		var podUser *int64
+		podNonRoot := false
+		if psc := w.Spec.SecurityContext; psc != nil {
+			podUser = psc.RunAsUser
+			podNonRoot = psc.RunAsNonRoot != nil && *psc.RunAsNonRoot
+		}
+
+		var root, unrestricted []string
+		for _, container := range allContainers(w.Spec) {
+			user, nonRoot := podUser, podNonRoot
+			if sc := container.SecurityContext; sc != nil {
+				if sc.RunAsUser != nil {
+					user = sc.RunAsUser
+				}
+				if sc.RunAsNonRoot != nil {
+					nonRoot = *sc.RunAsNonRoot
+				}
+			}
+			switch {
+			case user != nil && *user == 0:
+				root = append(root, container.Name)
+			case user == nil && !nonRoot:
+				unrestricted = append(unrestricted, container.Name)
+			}
+		}
+		if len(root) > 0 {
+			findings = append(findings, workloadFinding(w, SeverityCritical,
+				fmt.Sprintf("容器 %s 指定以root用户(runAsUser: 0)运行", strings.Join(root, "、"))))
+		}
+		if len(unrestricted) > 0 {
+			findings = append(findings, workloadFinding(w, SeverityWarning,
+				fmt.Sprintf("容器 %s 未设置runAsNonRoot或runAsUser，将以镜像默认用户运行（通常为root）", strings.Join(unrestricted, "、"))))
+		}
+	}
+	return findings
+}
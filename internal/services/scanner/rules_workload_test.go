@@ -0,0 +1,248 @@
+package scanner
+
+import (
+	"reflect"
+	"sort"
+	"strings"
+	"testing"
+
+	corev1 "k8s.io/api/core/v1"
+	"k8s.io/apimachinery/pkg/api/resource"
+)
+
+// findingKeys 将问题转换为 严重程度:Kind/Name 形式并排序，便于比较
+func findingKeys(findings []Finding) []string {
+	keys := make([]string, 0, len(findings))
+	for _, finding := range findings {
+		keys = append(keys, finding.Severity+":"+finding.Kind+"/"+finding.Name)
+	}
+	sort.Strings(keys)
+	return keys
+}
+
+func checkFindings(t *testing.T, findings []Finding, want []string) {
+	t.Helper()
+	got := findingKeys(findings)
+	sort.Strings(want)
+	if len(got) == 0 && len(want) == 0 {
+		return
+	}
+	if !reflect.DeepEqual(got, want) {
+		t.Errorf("findings = %v, want %v", got, want)
+	}
+}
+
+func newWorkload(kind, name string, containers ...corev1.Container) Workload {
+	return Workload{Kind: kind, Namespace: "default", Name: name, Spec: &corev1.PodSpec{Containers: containers}}
+}
+
+func boolPtr(v bool) *bool {
+	return &v
+}
+
+func int64Ptr(v int64) *int64 {
+	return &v
+}
+
+func limits(cpu, memory string) corev1.ResourceRequirements {
+	list := corev1.ResourceList{}
+	if cpu != "" {
+		list[corev1.ResourceCPU] = resource.MustParse(cpu)
+	}
+	if memory != "" {
+		list[corev1.ResourceMemory] = resource.MustParse(memory)
+	}
+	return corev1.ResourceRequirements{Limits: list}
+}
+
+func TestCheckResourceLimits(t *testing.T) {
+	withInit := newWorkload("Deployment", "init-no-limit", corev1.Container{Name: "app", Resources: limits("1", "1Gi")})
+	withInit.Spec.InitContainers = []corev1.Container{{Name: "init"}}
+
+	tests := []struct {
+		name      string
+		workloads []Workload
+		want      []string
+		message   string
+	}{
+		{name: "both limits set", workloads: []Workload{
+			newWorkload("Deployment", "ok", corev1.Container{Name: "app", Resources: limits("500m", "256Mi")}),
+		}},
+		{name: "missing memory limit", workloads: []Workload{
+			newWorkload("Deployment", "no-mem", corev1.Container{Name: "app", Resources: limits("500m", "")}),
+		}, want: []string{"warning:Deployment/no-mem"}, message: "memory"},
+		{name: "missing both limits", workloads: []Workload{
+			newWorkload("StatefulSet", "none", corev1.Container{Name: "app"}),
+		}, want: []string{"warning:StatefulSet/none"}, message: "cpu、memory"},
+		{name: "init container checked", workloads: []Workload{withInit},
+			want: []string{"warning:Deployment/init-no-limit"}, message: "容器 init"},
+		{name: "one finding per workload", workloads: []Workload{
+			newWorkload("Deployment", "multi", corev1.Container{Name: "a"}, corev1.Container{Name: "b"}),
+		}, want: []string{"warning:Deployment/multi"}, message: "容器 b"},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			findings := checkResourceLimits(&Inventory{Workloads: tt.workloads})
+			checkFindings(t, findings, tt.want)
+			if tt.message != "" && len(findings) > 0 && !strings.Contains(findings[0].Message, tt.message) {
+				t.Errorf("message %q missing %q", findings[0].Message, tt.message)
+			}
+		})
+	}
+}
+
+func TestCheckProbes(t *testing.T) {
+	probe := &corev1.Probe{}
+	bare := corev1.Container{Name: "app"}
+	onePod := newWorkload("Pod", "one-shot", bare)
+	onePod.Spec.RestartPolicy = corev1.RestartPolicyNever
+	alwaysPod := newWorkload("Pod", "always", bare)
+	alwaysPod.Spec.RestartPolicy = corev1.RestartPolicyAlways
+	withInit := newWorkload("Deployment", "init-only", corev1.Container{Name: "app", LivenessProbe: probe, ReadinessProbe: probe})
+	withInit.Spec.InitContainers = []corev1.Container{{Name: "init"}}
+
+	tests := []struct {
+		name      string
+		workloads []Workload
+		want      []string
+	}{
+		{name: "both probes set", workloads: []Workload{
+			newWorkload("Deployment", "ok", corev1.Container{Name: "app", LivenessProbe: probe, ReadinessProbe: probe}),
+		}},
+		{name: "missing readiness probe", workloads: []Workload{
+			newWorkload("Deployment", "no-ready", corev1.Container{Name: "app", LivenessProbe: probe}),
+		}, want: []string{"warning:Deployment/no-ready"}},
+		{name: "daemonset checked", workloads: []Workload{newWorkload("DaemonSet", "agent", bare)},
+			want: []string{"warning:DaemonSet/agent"}},
+		{name: "jobs and cronjobs skipped", workloads: []Workload{
+			newWorkload("Job", "migrate", bare), newWorkload("CronJob", "backup", bare),
+		}},
+		{name: "pod with restartPolicy Never skipped", workloads: []Workload{onePod}},
+		{name: "long running pod checked", workloads: []Workload{alwaysPod}, want: []string{"warning:Pod/always"}},
+		{name: "init containers do not need probes", workloads: []Workload{withInit}},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			checkFindings(t, checkProbes(&Inventory{Workloads: tt.workloads}), tt.want)
+		})
+	}
+}
+
+func TestImageTag(t *testing.T) {
+	tests := []struct {
+		image string
+		want  string
+	}{
+		{image: "nginx", want: ""},
+		{image: "nginx:1.25", want: "1.25"},
+		{image: "nginx:latest", want: "latest"},
+		{image: "library/nginx", want: ""},
+		{image: "registry.local:5000/team/app", want: ""},
+		{image: "registry.local:5000/team/app:v2", want: "v2"},
+		{image: "nginx@sha256:abcd", want: "sha256:abcd"},
+		{image: "registry.local:5000/app:v1@sha256:abcd", want: "sha256:abcd"},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.image, func(t *testing.T) {
+			if got := imageTag(tt.image); got != tt.want {
+				t.Errorf("imageTag(%q) = %q, want %q", tt.image, got, tt.want)
+			}
+		})
+	}
+}
+
+func TestCheckLatestTag(t *testing.T) {
+	tests := []struct {
+		name  string
+		image string
+		want  []string
+	}{
+		{name: "pinned tag", image: "nginx:1.25"},
+		{name: "digest", image: "nginx@sha256:abcd"},
+		{name: "latest tag", image: "nginx:latest", want: []string{"warning:Deployment/web"}},
+		{name: "no tag", image: "nginx", want: []string{"warning:Deployment/web"}},
+		{name: "registry port without tag", image: "registry.local:5000/nginx", want: []string{"warning:Deployment/web"}},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			inv := &Inventory{Workloads: []Workload{newWorkload("Deployment", "web", corev1.Container{Name: "app", Image: tt.image})}}
+			checkFindings(t, checkLatestTag(inv), tt.want)
+		})
+	}
+}
+
+func TestCheckPrivileged(t *testing.T) {
+	tests := []struct {
+		name      string
+		container corev1.Container
+		want      []string
+	}{
+		{name: "no security context", container: corev1.Container{Name: "app"}},
+		{name: "privileged false", container: corev1.Container{Name: "app",
+			SecurityContext: &corev1.SecurityContext{Privileged: boolPtr(false)}}},
+		{name: "privileged true", container: corev1.Container{Name: "app",
+			SecurityContext: &corev1.SecurityContext{Privileged: boolPtr(true)}}, want: []string{"critical:DaemonSet/agent"}},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			inv := &Inventory{Workloads: []Workload{newWorkload("DaemonSet", "agent", tt.container)}}
+			checkFindings(t, checkPrivileged(inv), tt.want)
+		})
+	}
+}
+
+func TestCheckHostPath(t *testing.T) {
+	tests := []struct {
+		name    string
+		volumes []corev1.Volume
+		want    []string
+	}{
+		{name: "no volumes"},
+		{name: "emptyDir", volumes: []corev1.Volume{{Name: "tmp", VolumeSource: corev1.VolumeSource{EmptyDir: &corev1.EmptyDirVolumeSource{}}}}},
+		{name: "hostPath per volume", volumes: []corev1.Volume{
+			{Name: "logs", VolumeSource: corev1.VolumeSource{HostPath: &corev1.HostPathVolumeSource{Path: "/var/log"}}},
+			{Name: "sock", VolumeSource: corev1.VolumeSource{HostPath: &corev1.HostPathVolumeSource{Path: "/var/run/docker.sock"}}},
+		}, want: []string{"critical:DaemonSet/agent", "critical:DaemonSet/agent"}},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			w := newWorkload("DaemonSet", "agent", corev1.Container{Name: "app"})
+			w.Spec.Volumes = tt.volumes
+			checkFindings(t, checkHostPath(&Inventory{Workloads: []Workload{w}}), tt.want)
+		})
+	}
+}
+
+func TestCheckRunAsRoot(t *testing.T) {
+	tests := []struct {
+		name      string
+		pod       *corev1.PodSecurityContext
+		container *corev1.SecurityContext
+		want      []string
+	}{
+		{name: "unrestricted", want: []string{"warning:Deployment/web"}},
+		{name: "pod runAsNonRoot", pod: &corev1.PodSecurityContext{RunAsNonRoot: boolPtr(true)}},
+		{name: "pod runAsUser non-zero", pod: &corev1.PodSecurityContext{RunAsUser: int64Ptr(1000)}},
+		{name: "pod runAsUser zero", pod: &corev1.PodSecurityContext{RunAsUser: int64Ptr(0)},
+			want: []string{"critical:Deployment/web"}},
+		{name: "container overrides pod user with root", pod: &corev1.PodSecurityContext{RunAsUser: int64Ptr(1000)},
+			container: &corev1.SecurityContext{RunAsUser: int64Ptr(0)}, want: []string{"critical:Deployment/web"}},
+		{name: "container disables pod runAsNonRoot", pod: &corev1.PodSecurityContext{RunAsNonRoot: boolPtr(true)},
+			container: &corev1.SecurityContext{RunAsNonRoot: boolPtr(false)}, want: []string{"warning:Deployment/web"}},
+		{name: "container runAsUser non-zero", container: &corev1.SecurityContext{RunAsUser: int64Ptr(1000)}},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			w := newWorkload("Deployment", "web", corev1.Container{Name: "app", SecurityContext: tt.container})
+			w.Spec.SecurityContext = tt.pod
+			checkFindings(t, checkRunAsRoot(&Inventory{Workloads: []Workload{w}}), tt.want)
+		})
+	}
+}
@@ -0,0 +1,196 @@
+package scanner
+
+import (
+	"context"
+	"devops-console-backend/internal/dal"
+	"devops-console-backend/pkg/configs"
+	"devops-console-backend/pkg/utils/logs"
+	"errors"
+	"sync"
+	"time"
+)
+
+// scanTimeout 单个集群扫描的超时时间
+const scanTimeout = 10 * time.Minute
+
+// ErrScanRunning 该集群已有进行中的扫描
+var ErrScanRunning = errors.New("该集群正在扫描中")
+
+// running 进行中扫描的集群ID，避免同一集群并发扫描
+var running sync.Map
+
+// Runner 定时扫描已注册集群并保存结果
+type Runner struct {
+	interval  time.Duration
+	retention time.Duration
+	exclude   []string
+}
+
+// NewRunner 创建扫描任务实例
+func NewRunner(interval, retention time.Duration, exclude []string) *Runner {
+	if interval <= 0 {
+		interval = 24 * time.Hour
+	}
+	return &Runner{interval: interval, retention: retention, exclude: exclude}
+}
+
+// NewRunnerFromConfig 根据全局扫描配置创建扫描任务
+func NewRunnerFromConfig() *Runner {
+	cfg := configs.GetKubernetesConfig().Scan
+	return NewRunner(
+		time.Duration(cfg.Interval)*time.Hour,
+		time.Duration(cfg.RetentionDays)*24*time.Hour,
+		cfg.ExcludeNamespaces)
+}
+
+// Run 启动后立即扫描一次，之后按间隔定时扫描，直到stopCh关闭
+func (r *Runner) Run(stopCh <-chan struct{}) {
+	ticker := time.NewTicker(r.interval)
+	defer ticker.Stop()
+
+	logs.Info(map[string]interface{}{"interval": r.interval.String()}, "集群扫描任务已启动")
+	r.scanAll()
+	for {
+		select {
+		case <-stopCh:
+			logs.Info(nil, "集群扫描任务已停止")
+			return
+		case <-ticker.C:
+			r.scanAll()
+		}
+	}
+}
+
+// scanAll 依次扫描所有已注册集群并清理过期结果
+func (r *Runner) scanAll() {
+	for _, instanceID := range configs.GetK8sInstanceIDs() {
+		run, err := r.start(instanceID, "")
+		if err != nil {
+			logs.Warning(map[string]interface{}{
+				"instance_id": instanceID,
+				"error":       err.Error(),
+			}, "创建扫描任务失败")
+			continue
+		}
+		r.execute(run)
+	}
+
+	if r.retention > 0 {
+		deleted, err := configs.NewK8sScanRepository().DeleteBefore(time.Now().Add(-r.retention))
+		if err != nil {
+			logs.Warning(map[string]interface{}{"error": err.Error()}, "清理过期扫描结果失败")
+		} else if deleted > 0 {
+			logs.Info(map[string]interface{}{"deleted": deleted}, "已清理过期扫描结果")
+		}
+	}
+}
+
+// Trigger 手动触发扫描，扫描在后台执行，返回创建的扫描记录
+func (r *Runner) Trigger(instanceID uint, operator string) (*dal.K8sScanRun, error) {
+	run, err := r.start(instanceID, operator)
+	if err != nil {
+		return nil, err
+	}
+	go r.execute(run)
+	return run, nil
+}
+
+// start 创建扫描记录并占用集群扫描标记
+func (r *Runner) start(instanceID uint, operator string) (*dal.K8sScanRun, error) {
+	if _, exists := configs.GetK8sClient(instanceID); !exists {
+		return nil, errors.New("K8s客户端未初始化")
+	}
+	if _, loaded := running.LoadOrStore(instanceID, true); loaded {
+		return nil, ErrScanRunning
+	}
+
+	run := &dal.K8sScanRun{
+		InstanceID: instanceID,
+		Status:     dal.ScanStatusRunning,
+		Operator:   operator,
+		StartedAt:  time.Now(),
+	}
+	if err := configs.NewK8sScanRepository().CreateRun(run); err != nil {
+		running.Delete(instanceID)
+		return nil, err
+	}
+	return run, nil
+}
+
+// execute 执行扫描并保存结果
+func (r *Runner) execute(run *dal.K8sScanRun) {
+	defer running.Delete(run.InstanceID)
+	repo := configs.NewK8sScanRepository()
+
+	fail := func(err error) {
+		now := time.Now()
+		run.Status = dal.ScanStatusFailed
+		run.Error = err.Error()
+		run.FinishedAt = &now
+		if err := repo.FinishRun(run); err != nil {
+			logs.Error(map[string]interface{}{"run_id": run.ID, "error": err.Error()}, "更新扫描状态失败")
+		}
+		logs.Warning(map[string]interface{}{"run_id": run.ID, "instance_id": run.InstanceID, "error": run.Error}, "集群扫描失败")
+	}
+
+	client, exists := configs.GetK8sClient(run.InstanceID)
+	if !exists {
+		fail(errors.New("K8s客户端未初始化"))
+		return
+	}
+	ctx, cancel := context.WithTimeout(context.Background(), scanTimeout)
+	defer cancel()
+
+	inv, err := LoadInventory(ctx, client, r.exclude, run.StartedAt)
+	if err != nil {
+		fail(err)
+		return
+	}
+	report := Scan(inv, Rules())
+
+	now := time.Now()
+	scores := make([]dal.K8sScanNamespaceScore, 0, len(report.Namespaces))
+	for _, ns := range report.Namespaces {
+		scores = append(scores, dal.K8sScanNamespaceScore{
+			RunID:      run.ID,
+			InstanceID: run.InstanceID,
+			Namespace:  ns.Namespace,
+			Score:      ns.Score,
+			Critical:   ns.Critical,
+			Warning:    ns.Warning,
+			Info:       ns.Info,
+			CreatedAt:  now,
+		})
+	}
+	findings := make([]dal.K8sScanFinding, 0, len(report.Findings))
+	for _, finding := range report.Findings {
+		findings = append(findings, dal.K8sScanFinding{
+			RunID:      run.ID,
+			InstanceID: run.InstanceID,
+			Namespace:  finding.Namespace,
+			Rule:       finding.Rule,
+			Severity:   finding.Severity,
+			Kind:       finding.Kind,
+			Name:       finding.Name,
+			Message:    finding.Message,
+			CreatedAt:  now,
+		})
+	}
+
+	run.Status = dal.ScanStatusSuccess
+	run.Score = report.Score
+	run.Critical = report.Critical
+	run.Warning = report.Warning
+	run.Info = report.Info
+	run.FinishedAt = &now
+	if err := repo.SaveResults(run, scores, findings); err != nil {
+		fail(err)
+		return
+	}
+	logs.Info(map[string]interface{}{
+		"run_id":      run.ID,
+		"instance_id": run.InstanceID,
+		"score":       run.Score,
+		"findings":    len(findings),
+	}, "集群扫描完成")
+}
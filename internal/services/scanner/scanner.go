@@ -0,0 +1,108 @@
+package scanner
+
+import (
+	"devops-console-backend/pkg/utils/logs"
+	"fmt"
+	"sort"
+)
+
+// 各级别问题的扣分，命名空间得分为 100 - 扣分，最低为0
+var severityPenalty = map[string]int{
+	SeverityCritical: 10,
+	SeverityWarning:  3,
+	SeverityInfo:     1,
+}
+
+// NamespaceScore 命名空间得分
+type NamespaceScore struct {
+	Namespace string
+	Score     int
+	Critical  int
+	Warning   int
+	Info      int
+}
+
+// Report 扫描结果
+type Report struct {
+	Findings   []Finding
+	Namespaces []NamespaceScore
+	Score      int // 各命名空间得分的平均值
+	Critical   int
+	Warning    int
+	Info       int
+}
+
+// Scan 对Inventory执行规则并计算得分
+func Scan(inv *Inventory, rules []Rule) *Report {
+	report := &Report{}
+	for _, rule := range rules {
+		report.Findings = append(report.Findings, runRule(rule, inv)...)
+	}
+
+	scores := map[string]*NamespaceScore{}
+	for _, ns := range inv.Namespaces {
+		scores[ns] = &NamespaceScore{Namespace: ns}
+	}
+	for _, finding := range report.Findings {
+		score, ok := scores[finding.Namespace]
+		if !ok {
+			score = &NamespaceScore{Namespace: finding.Namespace}
+			scores[finding.Namespace] = score
+		}
+		switch finding.Severity {
+		case SeverityCritical:
+			score.Critical++
+			report.Critical++
+		case SeverityWarning:
+			score.Warning++
+			report.Warning++
+		default:
+			score.Info++
+			report.Info++
+		}
+	}
+
+	total := 0
+	for _, score := range scores {
+		penalty := score.Critical*severityPenalty[SeverityCritical] +
+			score.Warning*severityPenalty[SeverityWarning] +
+			score.Info*severityPenalty[SeverityInfo]
+		score.Score = 100 - penalty
+		if score.Score < 0 {
+			score.Score = 0
+		}
+		total += score.Score
+		report.Namespaces = append(report.Namespaces, *score)
+	}
+	sort.Slice(report.Namespaces, func(i, j int) bool {
+		return report.Namespaces[i].Namespace < report.Namespaces[j].Namespace
+	})
+
+	report.Score = 100
+	if len(report.Namespaces) > 0 {
+		report.Score = (total + len(report.Namespaces)/2) / len(report.Namespaces)
+	}
+	return report
+}
+
+// runRule 执行单条规则，规则异常时记录日志并跳过
+func runRule(rule Rule, inv *Inventory) (findings []Finding) {
+	defer func() {
+		if r := recover(); r != nil {
+			logs.Error(map[string]interface{}{
+				"rule":  rule.ID(),
+				"panic": fmt.Sprint(r),
+			}, "扫描规则执行异常")
+			findings = nil
+		}
+	}()
+
+	for _, finding := range rule.Check(inv) {
+		finding.Rule = rule.ID()
+		if _, ok := severityPenalty[finding.Severity]; !ok {
+			finding.Severity = SeverityInfo
+		}
+		findings = append(findings, finding)
+	}
+	return findings
+}
@@ -0,0 +1,92 @@
+package scanner
+
+import (
+	"reflect"
+	"testing"
+)
+
+// staticRule 返回固定问题的规则
+func staticRule(id string, findings ...Finding) Rule {
+	return NewRule(id, id, func(*Inventory) []Finding { return findings })
+}
+
+func finding(namespace, severity string) Finding {
+	return Finding{Severity: severity, Namespace: namespace, Kind: "Deployment", Name: "app"}
+}
+
+func TestScan(t *testing.T) {
+	tests := []struct {
+		name       string
+		namespaces []string
+		rules      []Rule
+		wantScores []NamespaceScore
+		wantScore  int
+		wantCounts [3]int // critical, warning, info
+	}{
+		{name: "no namespaces", wantScore: 100},
+		{name: "clean namespaces", namespaces: []string{"a", "b"},
+			wantScores: []NamespaceScore{{Namespace: "a", Score: 100}, {Namespace: "b", Score: 100}}, wantScore: 100},
+		{name: "penalty per severity", namespaces: []string{"a"},
+			rules:      []Rule{staticRule("r", finding("a", SeverityCritical), finding("a", SeverityWarning), finding("a", SeverityInfo))},
+			wantScores: []NamespaceScore{{Namespace: "a", Score: 86, Critical: 1, Warning: 1, Info: 1}},
+			wantScore:  86, wantCounts: [3]int{1, 1, 1}},
+		{name: "score floors at zero", namespaces: []string{"a"},
+			rules: []Rule{staticRule("r",
+				finding("a", SeverityCritical), finding("a", SeverityCritical), finding("a", SeverityCritical),
+				finding("a", SeverityCritical), finding("a", SeverityCritical), finding("a", SeverityCritical),
+				finding("a", SeverityCritical), finding("a", SeverityCritical), finding("a", SeverityCritical),
+				finding("a", SeverityCritical), finding("a", SeverityCritical))},
+			wantScores: []NamespaceScore{{Namespace: "a", Score: 0, Critical: 11}},
+			wantScore:  0, wantCounts: [3]int{11, 0, 0}},
+		{name: "cluster score is rounded average", namespaces: []string{"a", "b"},
+			rules:      []Rule{staticRule("r", finding("a", SeverityWarning))},
+			wantScores: []NamespaceScore{{Namespace: "a", Score: 97, Warning: 1}, {Namespace: "b", Score: 100}},
+			wantScore:  99, wantCounts: [3]int{0, 1, 0}},
+		{name: "findings from unlisted namespace are scored", namespaces: []string{"b"},
+			rules:      []Rule{staticRule("r", finding("a", SeverityInfo))},
+			wantScores: []NamespaceScore{{Namespace: "a", Score: 99, Info: 1}, {Namespace: "b", Score: 100}},
+			wantScore:  100, wantCounts: [3]int{0, 0, 1}},
+		{name: "unknown severity counted as info", namespaces: []string{"a"},
+			rules:      []Rule{staticRule("r", finding("a", "urgent"))},
+			wantScores: []NamespaceScore{{Namespace: "a", Score: 99, Info: 1}},
+			wantScore:  99, wantCounts: [3]int{0, 0, 1}},
+		{name: "panicking rule is skipped", namespaces: []string{"a"},
+			rules: []Rule{
+				NewRule("boom", "boom", func(*Inventory) []Finding { panic("boom") }),
+				staticRule("r", finding("a", SeverityWarning)),
+			},
+			wantScores: []NamespaceScore{{Namespace: "a", Score: 97, Warning: 1}},
+			wantScore:  97, wantCounts: [3]int{0, 1, 0}},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			report := Scan(&Inventory{Namespaces: tt.namespaces}, tt.rules)
+			if !reflect.DeepEqual(report.Namespaces, tt.wantScores) {
+				t.Errorf("Namespaces = %+v, want %+v", report.Namespaces, tt.wantScores)
+			}
+			if report.Score != tt.wantScore {
+				t.Errorf("Score = %d, want %d", report.Score, tt.wantScore)
+			}
+			if got := [3]int{report.Critical, report.Warning, report.Info}; got != tt.wantCounts {
+				t.Errorf("counts = %v, want %v", got, tt.wantCounts)
+			}
+		})
+	}
+}
+
+func TestScanFillsRuleAndSeverity(t *testing.T) {
+	report := Scan(&Inventory{Namespaces: []string{"a"}}, []Rule{
+		staticRule("first", finding("a", SeverityCritical)),
+		staticRule("second", finding("a", "")),
+	})
+	if len(report.Findings) != 2 {
+		t.Fatalf("len(Findings) = %d, want 2", len(report.Findings))
+	}
+	if got := report.Findings[0]; got.Rule != "first" || got.Severity != SeverityCritical {
+		t.Errorf("Findings[0] = %+v, want rule first with critical severity", got)
+	}
+	if got := report.Findings[1]; got.Rule != "second" || got.Severity != SeverityInfo {
+		t.Errorf("Findings[1] = %+v, want rule second with info severity", got)
+	}
+}
@@ -0,0 +1,176 @@
+package secret
+
+import (
+	"crypto/tls"
+	"crypto/x509"
+	"devops-console-backend/internal/dal/request/k8s"
+	"encoding/base64"
+	"encoding/json"
+	"encoding/pem"
+	"errors"
+	"fmt"
+	"sort"
+	"time"
+	"unicode/utf8"
+
+	corev1 "k8s.io/api/core/v1"
+	metav1 "k8s.io/apimachinery/pkg/apis/meta/v1"
+)
+
+// lastAppliedAnnotation kubectl apply记录的上次配置，可能包含Secret明文
+const lastAppliedAnnotation = "kubectl.kubernetes.io/last-applied-configuration"
+
+// maskedValue 脱敏后的占位内容
+const maskedValue = "******"
+
+// Mask 返回脱敏后的Secret副本和键信息，data被清空，last-applied注解同样脱敏
+func Mask(secret *corev1.Secret) (*corev1.Secret, []k8s.SecretKeyInfo) {
+	masked := secret.DeepCopy()
+	masked.Data = nil
+	masked.StringData = nil
+	masked.ManagedFields = nil
+	if _, ok := masked.Annotations[lastAppliedAnnotation]; ok {
+		masked.Annotations[lastAppliedAnnotation] = maskedValue
+	}
+
+	keys := make([]k8s.SecretKeyInfo, 0, len(secret.Data))
+	for key, value := range secret.Data {
+		keys = append(keys, k8s.SecretKeyInfo{Key: key, Size: len(value)})
+	}
+	sort.Slice(keys, func(i, j int) bool { return keys[i].Key < keys[j].Key })
+	return masked, keys
+}
+
+// Reveal 返回指定键的明文，keys为空时返回全部键
+func Reveal(secret *corev1.Secret, keys []string) ([]k8s.SecretRevealedValue, error) {
+	if len(keys) == 0 {
+		for key := range secret.Data {
+			keys = append(keys, key)
+		}
+		sort.Strings(keys)
+	}
+	values := make([]k8s.SecretRevealedValue, 0, len(keys))
+	for _, key := range keys {
+		data, ok := secret.Data[key]
+		if !ok {
+			return nil, fmt.Errorf("键 %s 不存在", key)
+		}
+		value := k8s.SecretRevealedValue{Key: key, Value: string(data), Encoding: "text"}
+		if !utf8.Valid(data) {
+			value.Value = base64.StdEncoding.EncodeToString(data)
+			value.Encoding = "base64"
+		}
+		values = append(values, value)
+	}
+	return values, nil
+}
+
+// CertificateOf 返回TLS类型Secret的证书信息，其他类型返回nil
+func CertificateOf(secret *corev1.Secret) *k8s.CertificateInfo {
+	if secret.Type != corev1.SecretTypeTLS {
+		return nil
+	}
+	info, err := ParseCertificate(secret.Data[corev1.TLSCertKey])
+	if err != nil {
+		return &k8s.CertificateInfo{Error: err.Error()}
+	}
+	return info
+}
+
+// ParseCertificate 解析PEM证书链中的第一张证书
+func ParseCertificate(data []byte) (*k8s.CertificateInfo, error) {
+	block, _ := pem.Decode(data)
+	if block == nil || block.Type != "CERTIFICATE" {
+		return nil, errors.New("没有找到PEM格式的证书")
+	}
+	cert, err := x509.ParseCertificate(block.Bytes)
+	if err != nil {
+		return nil, fmt.Errorf("解析证书失败: %w", err)
+	}
+	remaining := time.Until(cert.NotAfter)
+	return &k8s.CertificateInfo{
+		Subject:       cert.Subject.String(),
+		Issuer:        cert.Issuer.String(),
+		DNSNames:      cert.DNSNames,
+		NotBefore:     cert.NotBefore,
+		NotAfter:      cert.NotAfter,
+		DaysRemaining: int(remaining.Hours() / 24),
+		Expired:       remaining <= 0,
+	}, nil
+}
+
+// BuildTyped 按类型构造Secret，TLS类型返回证书信息
+func BuildTyped(req *k8s.SecretTypedCreateRequest) (*corev1.Secret, *k8s.CertificateInfo, error) {
+	secret := &corev1.Secret{
+		ObjectMeta: metav1.ObjectMeta{Name: req.Name, Namespace: req.Namespace, Labels: req.Labels},
+		Data:       map[string][]byte{},
+	}
+	var certificate *k8s.CertificateInfo
+
+	switch req.Type {
+	case "opaque":
+		if len(req.Data) == 0 {
+			return nil, nil, errors.New("opaque类型需要提供data")
+		}
+		secret.Type = corev1.SecretTypeOpaque
+		for key, value := range req.Data {
+			secret.Data[key] = []byte(value)
+		}
+	case "docker-registry":
+		if req.DockerRegistry == nil {
+			return nil, nil, errors.New("docker-registry类型需要提供dockerRegistry")
+		}
+		config, err := dockerConfigJSON(req.DockerRegistry)
+		if err != nil {
+			return nil, nil, err
+		}
+		secret.Type = corev1.SecretTypeDockerConfigJson
+		secret.Data[corev1.DockerConfigJsonKey] = config
+	case "tls":
+		if req.TLS == nil {
+			return nil, nil, errors.New("tls类型需要提供tls")
+		}
+		if _, err := tls.X509KeyPair([]byte(req.TLS.Cert), []byte(req.TLS.Key)); err != nil {
+			return nil, nil, fmt.Errorf("证书与私钥无效或不匹配: %w", err)
+		}
+		info, err := ParseCertificate([]byte(req.TLS.Cert))
+		if err != nil {
+			return nil, nil, err
+		}
+		certificate = info
+		secret.Type = corev1.SecretTypeTLS
+		secret.Data[corev1.TLSCertKey] = []byte(req.TLS.Cert)
+		secret.Data[corev1.TLSPrivateKeyKey] = []byte(req.TLS.Key)
+	case "basic-auth":
+		if req.BasicAuth == nil {
+			return nil, nil, errors.New("basic-auth类型需要提供basicAuth")
+		}
+		secret.Type = corev1.SecretTypeBasicAuth
+		secret.Data[corev1.BasicAuthUsernameKey] = []byte(req.BasicAuth.Username)
+		secret.Data[corev1.BasicAuthPasswordKey] = []byte(req.BasicAuth.Password)
+	default:
+		return nil, nil, fmt.Errorf("不支持的Secret类型: %s", req.Type)
+	}
+	return secret, certificate, nil
+}
+
+// dockerConfigJSON 生成与kubectl create secret docker-registry一致的.dockerconfigjson
+func dockerConfigJSON(auth *k8s.DockerRegistryAuth) ([]byte, error) {
+	type entry struct {
+		Username string `json:"username"`
+		Password string `json:"password"`
+		Email    string `json:"email,omitempty"`
+		Auth     string `json:"auth"`
+	}
+	config := map[string]map[string]entry{
+		"auths": {
+			auth.Server: {
+				Username: auth.Username,
+				Password: auth.Password,
+				Email:    auth.Email,
+				Auth:     base64.StdEncoding.EncodeToString([]byte(auth.Username + ":" + auth.Password)),
+			},
+		},
+	}
+	return json.Marshal(config)
+}
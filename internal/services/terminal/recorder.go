@@ -0,0 +1,113 @@
+package terminal
+
+import (
+	"bufio"
+	"encoding/json"
+	"fmt"
+	"os"
+	"path/filepath"
+	"sync"
+	"time"
+)
+
+// Recorder 以asciinema v2（asciicast）格式录制终端输出
+type Recorder struct {
+	mu     sync.Mutex
+	file   *os.File
+	writer *bufio.Writer
+	start  time.Time
+	path   string
+}
+
+// castHeader asciicast文件头
+type castHeader struct {
+	Version   int               `json:"version"`
+	Width     uint16            `json:"width"`
+	Height    uint16            `json:"height"`
+	Timestamp int64             `json:"timestamp"`
+	Title     string            `json:"title,omitempty"`
+	Env       map[string]string `json:"env,omitempty"`
+}
+
+// NewRecorder 在dir下按日期创建录像文件并写入文件头
+func NewRecorder(dir, sessionID string, width, height uint16, title string) (*Recorder, error) {
+	now := time.Now()
+	path := filepath.Join(dir, now.Format("20060102"), sessionID+".cast")
+	if err := os.MkdirAll(filepath.Dir(path), 0o750); err != nil {
+		return nil, fmt.Errorf("创建录像目录失败: %w", err)
+	}
+	file, err := os.OpenFile(path, os.O_CREATE|os.O_WRONLY|os.O_TRUNC, 0o640)
+	if err != nil {
+		return nil, fmt.Errorf("创建录像文件失败: %w", err)
+	}
+
+	r := &Recorder{
+		file:   file,
+		writer: bufio.NewWriter(file),
+		start:  now,
+		path:   path,
+	}
+	header, _ := json.Marshal(castHeader{
+		Version:   2,
+		Width:     width,
+		Height:    height,
+		Timestamp: now.Unix(),
+		Title:     title,
+		Env:       map[string]string{"TERM": "xterm-256color"},
+	})
+	if err := r.writeLine(header); err != nil {
+		_ = file.Close()
+		return nil, err
+	}
+	return r, nil
+}
+
+// Path 录像文件路径
+func (r *Recorder) Path() string {
+	return r.path
+}
+
+// Output 记录终端输出
+func (r *Recorder) Output(data []byte) {
+	r.event("o", string(data))
+}
+
+// Resize 记录终端尺寸变化
+func (r *Recorder) Resize(cols, rows uint16) {
+	r.event("r", fmt.Sprintf("%dx%d", cols, rows))
+}
+
+// Close 刷新缓冲并关闭录像文件
+func (r *Recorder) Close() error {
+	r.mu.Lock()
+	defer r.mu.Unlock()
+	if r.file == nil {
+		return nil
+	}
+	_ = r.writer.Flush()
+	err := r.file.Close()
+	r.file = nil
+	return err
+}
+
+// event 写入一条事件: [相对秒数, 类型, 数据]
+func (r *Recorder) event(code, data string) {
+	line, err := json.Marshal([]interface{}{time.Since(r.start).Seconds(), code, data})
+	if err != nil {
+		return
+	}
+	_ = r.writeLine(line)
+}
+
+// writeLine 写入一行
+func (r *Recorder) writeLine(line []byte) error {
+	r.mu.Lock()
+	defer r.mu.Unlock()
+	if r.file == nil {
+		return nil
+	}
+	if _, err := r.writer.Write(line); err != nil {
+		return err
+	}
+	return r.writer.WriteByte('\n')
+}
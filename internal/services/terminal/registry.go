@@ -0,0 +1,97 @@
+package terminal
+
+import (
+	"sort"
+	"sync"
+	"time"
+)
+
+// LiveSession 进行中的终端或端口转发会话
+type LiveSession struct {
+	SessionID  string     `json:"sessionId"`
+	UserID     int64      `json:"userId"`
+	Username   string     `json:"username"`
+	InstanceID uint       `json:"instanceId"`
+	Namespace  string     `json:"namespace"`
+	Pod        string     `json:"pod"`
+	Container  string     `json:"container,omitempty"`
+	Target     string     `json:"target,omitempty"` // 端口转发目标，如 service/mysql
+	Port       int32      `json:"port,omitempty"`   // 端口转发的Pod端口
+	ClientIP   string     `json:"clientIp"`
+	StartedAt  time.Time  `json:"startedAt"`
+	ExpiresAt  *time.Time `json:"expiresAt,omitempty"`
+
+	kill func(operator string)
+}
+
+// Registry 进行中终端会话的并发安全登记表
+type Registry struct {
+	mu       sync.RWMutex
+	sessions map[string]*LiveSession
+}
+
+// DefaultRegistry 全局终端会话登记表
+var DefaultRegistry = NewRegistry()
+
+// PortForwardRegistry 全局端口转发会话登记表
+var PortForwardRegistry = NewRegistry()
+
+// NewRegistry 创建终端会话登记表
+func NewRegistry() *Registry {
+	return &Registry{
+		sessions: make(map[string]*LiveSession),
+	}
+}
+
+// Add 登记会话，kill用于强制结束会话
+func (r *Registry) Add(session *LiveSession, kill func(operator string)) {
+	session.kill = kill
+	r.mu.Lock()
+	defer r.mu.Unlock()
+	r.sessions[session.SessionID] = session
+}
+
+// Remove 移除会话
+func (r *Registry) Remove(sessionID string) {
+	r.mu.Lock()
+	defer r.mu.Unlock()
+	delete(r.sessions, sessionID)
+}
+
+// List 按开始时间返回全部进行中的会话
+func (r *Registry) List() []LiveSession {
+	r.mu.RLock()
+	list := make([]LiveSession, 0, len(r.sessions))
+	for _, session := range r.sessions {
+		list = append(list, *session)
+	}
+	r.mu.RUnlock()
+
+	sort.Slice(list, func(i, j int) bool {
+		return list[i].StartedAt.Before(list[j].StartedAt)
+	})
+	return list
+}
+
+// Get 获取会话
+func (r *Registry) Get(sessionID string) (LiveSession, bool) {
+	r.mu.RLock()
+	defer r.mu.RUnlock()
+	session, ok := r.sessions[sessionID]
+	if !ok {
+		return LiveSession{}, false
+	}
+	return *session, true
+}
+
+// Kill 强制结束会话，会话不存在时返回false
+func (r *Registry) Kill(sessionID, operator string) bool {
+	r.mu.RLock()
+	session, ok := r.sessions[sessionID]
+	r.mu.RUnlock()
+	if !ok {
+		return false
+	}
+	session.kill(operator)
+	return true
+}
@@ -0,0 +1,247 @@
+package workload
+
+import (
+	"context"
+	"devops-console-backend/internal/dal/request/k8s"
+	"fmt"
+	"io"
+	"sort"
+	"time"
+
+	batchv1 "k8s.io/api/batch/v1"
+	corev1 "k8s.io/api/core/v1"
+	metav1 "k8s.io/apimachinery/pkg/apis/meta/v1"
+	utilrand "k8s.io/apimachinery/pkg/util/rand"
+	"k8s.io/client-go/kubernetes"
+)
+
+// Job状态
+const (
+	JobStatusRunning   = "Running"
+	JobStatusComplete  = "Complete"
+	JobStatusFailed    = "Failed"
+	JobStatusSuspended = "Suspended"
+	JobStatusPending   = "Pending"
+)
+
+// maxJobLogBytes 单个Pod日志返回的最大字节数
+const maxJobLogBytes = 1024 * 1024
+
+// JobStatus 根据Job的Conditions和计数得出状态
+func JobStatus(job *batchv1.Job) string {
+	for _, condition := range job.Status.Conditions {
+		if condition.Status != corev1.ConditionTrue {
+			continue
+		}
+		switch condition.Type {
+		case batchv1.JobComplete:
+			return JobStatusComplete
+		case batchv1.JobFailed:
+			return JobStatusFailed
+		case batchv1.JobSuspended:
+			return JobStatusSuspended
+		}
+	}
+	if job.Spec.Suspend != nil && *job.Spec.Suspend {
+		return JobStatusSuspended
+	}
+	if job.Status.Active > 0 {
+		return JobStatusRunning
+	}
+	return JobStatusPending
+}
+
+// IsJobFinished 判断Job是否已结束（成功或失败）
+func IsJobFinished(job *batchv1.Job) bool {
+	status := JobStatus(job)
+	return status == JobStatusComplete || status == JobStatusFailed
+}
+
+// NewJobFromCronJob 按CronJob的jobTemplate生成手动触发的Job，与kubectl create job --from=cronjob一致
+func NewJobFromCronJob(cronJob *batchv1.CronJob) *batchv1.Job {
+	annotations := map[string]string{"cronjob.kubernetes.io/instantiate": "manual"}
+	for k, v := range cronJob.Spec.JobTemplate.Annotations {
+		annotations[k] = v
+	}
+
+	name := cronJob.Name
+	// Job名称上限63个字符，预留随机后缀
+	if len(name) > 52 {
+		name = name[:52]
+	}
+
+	return &batchv1.Job{
+		ObjectMeta: metav1.ObjectMeta{
+			Name:        name + "-manual-" + utilrand.String(3),
+			Namespace:   cronJob.Namespace,
+			Labels:      cronJob.Spec.JobTemplate.Labels,
+			Annotations: annotations,
+			OwnerReferences: []metav1.OwnerReference{
+				*metav1.NewControllerRef(cronJob, batchv1.SchemeGroupVersion.WithKind("CronJob")),
+			},
+		},
+		Spec: *cronJob.Spec.JobTemplate.Spec.DeepCopy(),
+	}
+}
+
+// ListJobPods 获取Job创建的Pod，按创建时间排序
+func ListJobPods(ctx context.Context, client kubernetes.Interface, job *batchv1.Job) ([]corev1.Pod, error) {
+	selector := "job-name=" + job.Name
+	if job.Spec.Selector != nil {
+		if s, err := metav1.LabelSelectorAsSelector(job.Spec.Selector); err == nil {
+			selector = s.String()
+		}
+	}
+
+	pods, err := client.CoreV1().Pods(job.Namespace).List(ctx, metav1.ListOptions{LabelSelector: selector})
+	if err != nil {
+		return nil, err
+	}
+	sort.Slice(pods.Items, func(i, j int) bool {
+		return pods.Items[i].CreationTimestamp.Before(&pods.Items[j].CreationTimestamp)
+	})
+	return pods.Items, nil
+}
+
+// ToJobPodStatus 转换Job下Pod的状态摘要
+func ToJobPodStatus(pod *corev1.Pod) k8s.JobPodStatus {
+	status := k8s.JobPodStatus{
+		Name:  pod.Name,
+		Phase: string(pod.Status.Phase),
+		Node:  pod.Spec.NodeName,
+	}
+	if pod.Status.StartTime != nil {
+		status.StartTime = pod.Status.StartTime.Format(time.RFC3339)
+	}
+	for _, cs := range pod.Status.ContainerStatuses {
+		status.Restarts += cs.RestartCount
+		if cs.State.Terminated != nil {
+			status.ExitCode = &cs.State.Terminated.ExitCode
+			status.Reason = cs.State.Terminated.Reason
+		} else if cs.State.Waiting != nil && status.Reason == "" {
+			status.Reason = cs.State.Waiting.Reason
+		}
+	}
+	return status
+}
+
+// ToJobHistoryItem 转换Job执行记录
+func ToJobHistoryItem(job *batchv1.Job, pods []corev1.Pod) k8s.JobHistoryItem {
+	item := k8s.JobHistoryItem{
+		Name:      job.Name,
+		Namespace: job.Namespace,
+		Status:    JobStatus(job),
+		Active:    job.Status.Active,
+		Succeeded: job.Status.Succeeded,
+		Failed:    job.Status.Failed,
+		Manual:    job.Annotations["cronjob.kubernetes.io/instantiate"] == "manual",
+		CreatedAt: job.CreationTimestamp.Format(time.RFC3339),
+		Pods:      make([]k8s.JobPodStatus, 0, len(pods)),
+	}
+	if job.Status.StartTime != nil {
+		item.StartTime = job.Status.StartTime.Format(time.RFC3339)
+	}
+	if job.Status.CompletionTime != nil {
+		item.CompletionTime = job.Status.CompletionTime.Format(time.RFC3339)
+		if job.Status.StartTime != nil {
+			item.Duration = int64(job.Status.CompletionTime.Sub(job.Status.StartTime.Time).Seconds())
+		}
+	}
+	for i := range pods {
+		item.Pods = append(item.Pods, ToJobPodStatus(&pods[i]))
+	}
+	return item
+}
+
+// CollectPodLogs 读取Pod日志，container为空时读取第一个容器
+func CollectPodLogs(ctx context.Context, client kubernetes.Interface, pods []corev1.Pod, container string, tailLines int64) []k8s.JobPodLog {
+	result := make([]k8s.JobPodLog, 0, len(pods))
+	limitBytes := int64(maxJobLogBytes)
+	for _, pod := range pods {
+		name := container
+		if name == "" && len(pod.Spec.Containers) > 0 {
+			name = pod.Spec.Containers[0].Name
+		}
+		item := k8s.JobPodLog{Pod: pod.Name, Container: name, Phase: string(pod.Status.Phase)}
+
+		opts := &corev1.PodLogOptions{Container: name, LimitBytes: &limitBytes}
+		if tailLines > 0 {
+			opts.TailLines = &tailLines
+		}
+		stream, err := client.CoreV1().Pods(pod.Namespace).GetLogs(pod.Name, opts).Stream(ctx)
+		if err != nil {
+			item.Error = fmt.Sprintf("获取日志失败: %v", err)
+			result = append(result, item)
+			continue
+		}
+		data, err := io.ReadAll(stream)
+		_ = stream.Close()
+		if err != nil {
+			item.Error = fmt.Sprintf("读取日志失败: %v", err)
+		}
+		item.Logs = string(data)
+		result = append(result, item)
+	}
+	return result
+}
+
+// jobControllerLabels Job控制器自动添加的标签，重跑时需要去除，否则新Job的selector校验失败
+var jobControllerLabels = []string{
+	"controller-uid",
+	"job-name",
+	batchv1.ControllerUidLabel,
+	batchv1.JobNameLabel,
+}
+
+// CloneJobForRerun 复制已结束的Job用于重跑，使用新名称并去除controller-uid相关的selector和标签
+func CloneJobForRerun(job *batchv1.Job) *batchv1.Job {
+	name := job.Name
+	// Job名称上限63个字符，预留随机后缀
+	if len(name) > 51 {
+		name = name[:51]
+	}
+
+	clone := &batchv1.Job{
+		ObjectMeta: metav1.ObjectMeta{
+			Name:        name + "-rerun-" + utilrand.String(5),
+			Namespace:   job.Namespace,
+			Labels:      stripLabels(job.Labels),
+			Annotations: job.Annotations,
+		},
+		Spec: *job.Spec.DeepCopy(),
+	}
+	clone.Spec.Selector = nil
+	clone.Spec.ManualSelector = nil
+	clone.Spec.Suspend = nil
+	clone.Spec.Template.Labels = stripLabels(clone.Spec.Template.Labels)
+	return clone
+}
+
+// stripLabels 返回去除Job控制器标签后的副本
+func stripLabels(labels map[string]string) map[string]string {
+	if labels == nil {
+		return nil
+	}
+	result := make(map[string]string, len(labels))
+	for k, v := range labels {
+		result[k] = v
+	}
+	for _, key := range jobControllerLabels {
+		delete(result, key)
+	}
+	return result
+}
+
+// JobFinishedTime 返回Job结束时间，失败的Job没有CompletionTime，取Failed条件的时间
+func JobFinishedTime(job *batchv1.Job) *time.Time {
+	if job.Status.CompletionTime != nil {
+		return &job.Status.CompletionTime.Time
+	}
+	for _, condition := range job.Status.Conditions {
+		if (condition.Type == batchv1.JobComplete || condition.Type == batchv1.JobFailed) &&
+			condition.Status == corev1.ConditionTrue {
+			return &condition.LastTransitionTime.Time
+		}
+	}
+	return nil
+}
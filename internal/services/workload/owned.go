@@ -0,0 +1,102 @@
+package workload
+
+import (
+	"context"
+	"fmt"
+	"sort"
+
+	metav1 "k8s.io/apimachinery/pkg/apis/meta/v1"
+	"k8s.io/apimachinery/pkg/types"
+	"k8s.io/client-go/kubernetes"
+)
+
+// OwnedUIDs 获取工作负载本身及其下属ReplicaSet、Job和Pod的UID，非工作负载类型返回nil
+func OwnedUIDs(ctx context.Context, client kubernetes.Interface, kind, namespace, name string) ([]string, error) {
+	var root metav1.Object
+	switch kind {
+	case "Deployment":
+		obj, err := client.AppsV1().Deployments(namespace).Get(ctx, name, metav1.GetOptions{})
+		if err != nil {
+			return nil, err
+		}
+		root = obj
+	case "StatefulSet":
+		obj, err := client.AppsV1().StatefulSets(namespace).Get(ctx, name, metav1.GetOptions{})
+		if err != nil {
+			return nil, err
+		}
+		root = obj
+	case "DaemonSet":
+		obj, err := client.AppsV1().DaemonSets(namespace).Get(ctx, name, metav1.GetOptions{})
+		if err != nil {
+			return nil, err
+		}
+		root = obj
+	case "Job":
+		obj, err := client.BatchV1().Jobs(namespace).Get(ctx, name, metav1.GetOptions{})
+		if err != nil {
+			return nil, err
+		}
+		root = obj
+	case "CronJob":
+		obj, err := client.BatchV1().CronJobs(namespace).Get(ctx, name, metav1.GetOptions{})
+		if err != nil {
+			return nil, err
+		}
+		root = obj
+	default:
+		return nil, nil
+	}
+	return DescendantUIDs(ctx, client, kind, root)
+}
+
+// DescendantUIDs 沿ownerReferences查找工作负载下属的对象，返回包括工作负载本身在内的UID。
+// 只能找到集群中仍存在的对象，已被删除的ReplicaSet、Job和Pod不包含在内
+func DescendantUIDs(ctx context.Context, client kubernetes.Interface, kind string, root metav1.Object) ([]string, error) {
+	namespace := root.GetNamespace()
+
+	// 中间层对象排在Pod之前，按顺序遍历一次即可展开所有层级
+	var children []metav1.Object
+	switch kind {
+	case "Deployment":
+		replicaSets, err := client.AppsV1().ReplicaSets(namespace).List(ctx, metav1.ListOptions{})
+		if err != nil {
+			return nil, fmt.Errorf("获取ReplicaSet列表失败: %w", err)
+		}
+		for i := range replicaSets.Items {
+			children = append(children, &replicaSets.Items[i])
+		}
+	case "CronJob":
+		jobs, err := client.BatchV1().Jobs(namespace).List(ctx, metav1.ListOptions{})
+		if err != nil {
+			return nil, fmt.Errorf("获取Job列表失败: %w", err)
+		}
+		for i := range jobs.Items {
+			children = append(children, &jobs.Items[i])
+		}
+	}
+	pods, err := client.CoreV1().Pods(namespace).List(ctx, metav1.ListOptions{})
+	if err != nil {
+		return nil, fmt.Errorf("获取Pod列表失败: %w", err)
+	}
+	for i := range pods.Items {
+		children = append(children, &pods.Items[i])
+	}
+
+	owned := map[types.UID]bool{root.GetUID(): true}
+	for _, child := range children {
+		for _, owner := range child.GetOwnerReferences() {
+			if owned[owner.UID] {
+				owned[child.GetUID()] = true
+				break
+			}
+		}
+	}
+
+	uids := make([]string, 0, len(owned))
+	for uid := range owned {
+		uids = append(uids, string(uid))
+	}
+	sort.Strings(uids)
+	return uids, nil
+}
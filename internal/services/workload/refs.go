@@ -0,0 +1,262 @@
+package workload
+
+import (
+	"context"
+	"fmt"
+	"strconv"
+	"strings"
+
+	appsv1 "k8s.io/api/apps/v1"
+	corev1 "k8s.io/api/core/v1"
+	metav1 "k8s.io/apimachinery/pkg/apis/meta/v1"
+	"k8s.io/client-go/kubernetes"
+)
+
+// 可被工作负载引用的对象类型
+const (
+	RefKindConfigMap             = "ConfigMap"
+	RefKindSecret                = "Secret"
+	RefKindPersistentVolumeClaim = "PersistentVolumeClaim"
+	RefKindServiceAccount        = "ServiceAccount"
+)
+
+// WorkloadRef 引用指定对象的工作负载
+type WorkloadRef struct {
+	Kind      string   `json:"kind"`
+	Namespace string   `json:"namespace"`
+	Name      string   `json:"name"`
+	Via       []string `json:"via"` // 引用方式，如 env、envFrom、volume、imagePullSecrets
+}
+
+// String 返回 Kind/Name 形式的描述
+func (r WorkloadRef) String() string {
+	return r.Kind + "/" + r.Name
+}
+
+// walkPodSpecRefs 遍历Pod模板对指定类型对象的引用，依次为容器的env、envFrom，volume、projected和imagePullSecrets，
+// visit 接收被引用对象名称、引用方式和optional标记
+func walkPodSpecRefs(spec *corev1.PodSpec, kind string, visit func(name, via string, optional *bool)) {
+	switch kind {
+	case RefKindServiceAccount:
+		serviceAccount := spec.ServiceAccountName
+		if serviceAccount == "" {
+			serviceAccount = spec.DeprecatedServiceAccount
+		}
+		// 未指定时Pod使用default账号
+		if serviceAccount == "" {
+			serviceAccount = "default"
+		}
+		visit(serviceAccount, "serviceAccountName", nil)
+		return
+	case RefKindPersistentVolumeClaim:
+		for _, volume := range spec.Volumes {
+			if volume.PersistentVolumeClaim != nil {
+				visit(volume.PersistentVolumeClaim.ClaimName, "volume", nil)
+			}
+		}
+		return
+	}
+
+	containers := make([]corev1.Container, 0, len(spec.InitContainers)+len(spec.Containers))
+	containers = append(containers, spec.InitContainers...)
+	containers = append(containers, spec.Containers...)
+	for _, container := range containers {
+		for _, env := range container.Env {
+			if env.ValueFrom == nil {
+				continue
+			}
+			if ref := env.ValueFrom.ConfigMapKeyRef; kind == RefKindConfigMap && ref != nil {
+				visit(ref.Name, "env", ref.Optional)
+			}
+			if ref := env.ValueFrom.SecretKeyRef; kind == RefKindSecret && ref != nil {
+				visit(ref.Name, "env", ref.Optional)
+			}
+		}
+		for _, envFrom := range container.EnvFrom {
+			if ref := envFrom.ConfigMapRef; kind == RefKindConfigMap && ref != nil {
+				visit(ref.Name, "envFrom", ref.Optional)
+			}
+			if ref := envFrom.SecretRef; kind == RefKindSecret && ref != nil {
+				visit(ref.Name, "envFrom", ref.Optional)
+			}
+		}
+	}
+
+	for _, volume := range spec.Volumes {
+		if kind == RefKindConfigMap && volume.ConfigMap != nil {
+			visit(volume.ConfigMap.Name, "volume", volume.ConfigMap.Optional)
+		}
+		if kind == RefKindSecret && volume.Secret != nil {
+			visit(volume.Secret.SecretName, "volume", volume.Secret.Optional)
+		}
+		if volume.Projected == nil {
+			continue
+		}
+		for _, source := range volume.Projected.Sources {
+			if kind == RefKindConfigMap && source.ConfigMap != nil {
+				visit(source.ConfigMap.Name, "projected", source.ConfigMap.Optional)
+			}
+			if kind == RefKindSecret && source.Secret != nil {
+				visit(source.Secret.Name, "projected", source.Secret.Optional)
+			}
+		}
+	}
+
+	if kind == RefKindSecret {
+		// 镜像凭据缺失时Pod仍会创建，只是拉取镜像失败，因此不视为必需引用
+		optional := true
+		for _, ref := range spec.ImagePullSecrets {
+			visit(ref.Name, "imagePullSecrets", &optional)
+		}
+	}
+}
+
+// PodSpecRefs 返回Pod模板引用指定对象的方式，未引用时返回空
+func PodSpecRefs(spec *corev1.PodSpec, kind, name string) []string {
+	var via []string
+	walkPodSpecRefs(spec, kind, func(refName, refVia string, _ *bool) {
+		if refName != name {
+			return
+		}
+		for _, existing := range via {
+			if existing == refVia {
+				return
+			}
+		}
+		via = append(via, refVia)
+	})
+	return via
+}
+
+// NamedRef Pod模板对某个对象的引用汇总
+type NamedRef struct {
+	Name     string
+	Required bool // 存在未标记optional的volume、env或envFrom引用；只被imagePullSecrets引用时为false
+}
+
+// PodSpecNamedRefs 返回Pod模板引用的指定类型（ConfigMap、Secret或PVC）对象，按首次出现顺序去重
+func PodSpecNamedRefs(spec *corev1.PodSpec, kind string) []NamedRef {
+	var refs []NamedRef
+	index := map[string]int{}
+	walkPodSpecRefs(spec, kind, func(name, _ string, optional *bool) {
+		if name == "" {
+			return
+		}
+		required := optional == nil || !*optional
+		if i, ok := index[name]; ok {
+			refs[i].Required = refs[i].Required || required
+			return
+		}
+		index[name] = len(refs)
+		refs = append(refs, NamedRef{Name: name, Required: required})
+	})
+	return refs
+}
+
+// PodSpecRefNames 返回Pod模板引用的指定类型（ConfigMap、Secret或PVC）对象名称，按首次出现顺序去重
+func PodSpecRefNames(spec *corev1.PodSpec, kind string) []string {
+	refs := PodSpecNamedRefs(spec, kind)
+	names := make([]string, 0, len(refs))
+	for _, ref := range refs {
+		names = append(names, ref.Name)
+	}
+	return names
+}
+
+// IsVolumeClaimOf 判断PVC是否由StatefulSet的volumeClaimTemplates创建（名称为 模板名-StatefulSet名-序号）
+func IsVolumeClaimOf(statefulSet *appsv1.StatefulSet, name string) bool {
+	for _, template := range statefulSet.Spec.VolumeClaimTemplates {
+		prefix := template.Name + "-" + statefulSet.Name + "-"
+		if !strings.HasPrefix(name, prefix) {
+			continue
+		}
+		if _, err := strconv.Atoi(strings.TrimPrefix(name, prefix)); err == nil {
+			return true
+		}
+	}
+	return false
+}
+
+// ownedByCronJob 判断Job是否由CronJob创建，此类Job随CronJob一起展示
+func ownedByCronJob(meta metav1.ObjectMeta) bool {
+	for _, owner := range meta.OwnerReferences {
+		if owner.Kind == "CronJob" {
+			return true
+		}
+	}
+	return false
+}
+
+// FindReferences 扫描命名空间中Deployment、StatefulSet、DaemonSet、Job和CronJob的Pod模板，
+// 查找引用指定ConfigMap、Secret、PVC或ServiceAccount的工作负载
+func FindReferences(ctx context.Context, client kubernetes.Interface, namespace, kind, name string) ([]WorkloadRef, error) {
+	refs := []WorkloadRef{}
+
+	deployments, err := client.AppsV1().Deployments(namespace).List(ctx, metav1.ListOptions{})
+	if err != nil {
+		return nil, fmt.Errorf("获取Deployment列表失败: %w", err)
+	}
+	for _, item := range deployments.Items {
+		if via := PodSpecRefs(&item.Spec.Template.Spec, kind, name); len(via) > 0 {
+			refs = append(refs, WorkloadRef{Kind: "Deployment", Namespace: item.Namespace, Name: item.Name, Via: via})
+		}
+	}
+
+	statefulSets, err := client.AppsV1().StatefulSets(namespace).List(ctx, metav1.ListOptions{})
+	if err != nil {
+		return nil, fmt.Errorf("获取StatefulSet列表失败: %w", err)
+	}
+	for i := range statefulSets.Items {
+		item := &statefulSets.Items[i]
+		via := PodSpecRefs(&item.Spec.Template.Spec, kind, name)
+		if kind == RefKindPersistentVolumeClaim && IsVolumeClaimOf(item, name) {
+			via = append(via, "volumeClaimTemplates")
+		}
+		if len(via) > 0 {
+			refs = append(refs, WorkloadRef{Kind: "StatefulSet", Namespace: item.Namespace, Name: item.Name, Via: via})
+		}
+	}
+
+	daemonSets, err := client.AppsV1().DaemonSets(namespace).List(ctx, metav1.ListOptions{})
+	if err != nil {
+		return nil, fmt.Errorf("获取DaemonSet列表失败: %w", err)
+	}
+	for _, item := range daemonSets.Items {
+		if via := PodSpecRefs(&item.Spec.Template.Spec, kind, name); len(via) > 0 {
+			refs = append(refs, WorkloadRef{Kind: "DaemonSet", Namespace: item.Namespace, Name: item.Name, Via: via})
+		}
+	}
+
+	jobs, err := client.BatchV1().Jobs(namespace).List(ctx, metav1.ListOptions{})
+	if err != nil {
+		return nil, fmt.Errorf("获取Job列表失败: %w", err)
+	}
+	for _, item := range jobs.Items {
+		if ownedByCronJob(item.ObjectMeta) {
+			continue
+		}
+		if via := PodSpecRefs(&item.Spec.Template.Spec, kind, name); len(via) > 0 {
+			refs = append(refs, WorkloadRef{Kind: "Job", Namespace: item.Namespace, Name: item.Name, Via: via})
+		}
+	}
+
+	cronJobs, err := client.BatchV1().CronJobs(namespace).List(ctx, metav1.ListOptions{})
+	if err != nil {
+		return nil, fmt.Errorf("获取CronJob列表失败: %w", err)
+	}
+	for _, item := range cronJobs.Items {
+		if via := PodSpecRefs(&item.Spec.JobTemplate.Spec.Template.Spec, kind, name); len(via) > 0 {
+			refs = append(refs, WorkloadRef{Kind: "CronJob", Namespace: item.Namespace, Name: item.Name, Via: via})
+		}
+	}
+	return refs, nil
+}
+
+// DescribeRefs 将引用列表格式化为 Kind/Name 的逗号分隔描述
+func DescribeRefs(refs []WorkloadRef) string {
+	names := make([]string, 0, len(refs))
+	for _, ref := range refs {
+		names = append(names, ref.String())
+	}
+	return strings.Join(names, ", ")
+}
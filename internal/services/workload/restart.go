@@ -0,0 +1,48 @@
+package workload
+
+import (
+	"context"
+	"fmt"
+	"time"
+
+	metav1 "k8s.io/apimachinery/pkg/apis/meta/v1"
+	"k8s.io/apimachinery/pkg/types"
+	"k8s.io/client-go/kubernetes"
+)
+
+// restartedAtAnnotation 与kubectl rollout restart使用相同的注解
+const restartedAtAnnotation = "kubectl.kubernetes.io/restartedAt"
+
+// FindConfigConsumers 查找引用指定ConfigMap或Secret、且支持滚动重启的工作负载（Deployment、StatefulSet、DaemonSet）
+func FindConfigConsumers(ctx context.Context, client kubernetes.Interface, namespace, kind, name string) ([]WorkloadRef, error) {
+	refs, err := FindReferences(ctx, client, namespace, kind, name)
+	if err != nil {
+		return nil, err
+	}
+	consumers := make([]WorkloadRef, 0, len(refs))
+	for _, ref := range refs {
+		switch ref.Kind {
+		case "Deployment", "StatefulSet", "DaemonSet":
+			consumers = append(consumers, ref)
+		}
+	}
+	return consumers, nil
+}
+
+// RolloutRestart 通过修改Pod模板注解触发滚动重启，效果同kubectl rollout restart
+func RolloutRestart(ctx context.Context, client kubernetes.Interface, ref WorkloadRef) error {
+	patch := []byte(fmt.Sprintf(`{"spec":{"template":{"metadata":{"annotations":{%q:%q}}}}}`,
+		restartedAtAnnotation, time.Now().Format(time.RFC3339)))
+	var err error
+	switch ref.Kind {
+	case "Deployment":
+		_, err = client.AppsV1().Deployments(ref.Namespace).Patch(ctx, ref.Name, types.StrategicMergePatchType, patch, metav1.PatchOptions{})
+	case "StatefulSet":
+		_, err = client.AppsV1().StatefulSets(ref.Namespace).Patch(ctx, ref.Name, types.StrategicMergePatchType, patch, metav1.PatchOptions{})
+	case "DaemonSet":
+		_, err = client.AppsV1().DaemonSets(ref.Namespace).Patch(ctx, ref.Name, types.StrategicMergePatchType, patch, metav1.PatchOptions{})
+	default:
+		return fmt.Errorf("不支持重启的工作负载类型: %s", ref.Kind)
+	}
+	return err
+}
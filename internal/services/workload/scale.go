@@ -0,0 +1,74 @@
+package workload
+
+import (
+	"context"
+	"fmt"
+	"strings"
+
+	metav1 "k8s.io/apimachinery/pkg/apis/meta/v1"
+	"k8s.io/apimachinery/pkg/runtime/schema"
+	"k8s.io/client-go/dynamic"
+	"k8s.io/client-go/kubernetes"
+)
+
+// defaultScaleTargetAPIVersion 常见扩缩容目标的默认apiVersion
+var defaultScaleTargetAPIVersion = map[string]string{
+	"Deployment":            "apps/v1",
+	"StatefulSet":           "apps/v1",
+	"ReplicaSet":            "apps/v1",
+	"ReplicationController": "v1",
+}
+
+// DefaultScaleTargetAPIVersion 返回扩缩容目标的apiVersion，未指定时按Kind补全
+func DefaultScaleTargetAPIVersion(kind, apiVersion string) string {
+	if apiVersion != "" {
+		return apiVersion
+	}
+	return defaultScaleTargetAPIVersion[kind]
+}
+
+// CheckScaleTarget 校验扩缩容目标存在且支持scale子资源，requireScale为false时只校验存在
+func CheckScaleTarget(ctx context.Context, client kubernetes.Interface, dynamicClient dynamic.Interface,
+	namespace, apiVersion, kind, name string, requireScale bool) error {
+	apiVersion = DefaultScaleTargetAPIVersion(kind, apiVersion)
+	if apiVersion == "" {
+		return fmt.Errorf("未指定 %s 的apiVersion", kind)
+	}
+	gv, err := schema.ParseGroupVersion(apiVersion)
+	if err != nil {
+		return fmt.Errorf("无效的apiVersion %s: %w", apiVersion, err)
+	}
+
+	resources, err := client.Discovery().ServerResourcesForGroupVersion(apiVersion)
+	if err != nil {
+		return fmt.Errorf("集群不支持 %s: %w", apiVersion, err)
+	}
+	resource := ""
+	for _, r := range resources.APIResources {
+		if r.Kind == kind && !strings.Contains(r.Name, "/") {
+			resource = r.Name
+			break
+		}
+	}
+	if resource == "" {
+		return fmt.Errorf("%s 中不存在资源类型 %s", apiVersion, kind)
+	}
+	if requireScale {
+		hasScale := false
+		for _, r := range resources.APIResources {
+			if r.Name == resource+"/scale" {
+				hasScale = true
+				break
+			}
+		}
+		if !hasScale {
+			return fmt.Errorf("%s 不支持scale子资源，无法自动扩缩容", kind)
+		}
+	}
+
+	gvr := gv.WithResource(resource)
+	if _, err := dynamicClient.Resource(gvr).Namespace(namespace).Get(ctx, name, metav1.GetOptions{}); err != nil {
+		return fmt.Errorf("目标 %s/%s 不存在: %w", kind, name, err)
+	}
+	return nil
+}
@@ -0,0 +1,46 @@
+package workload
+
+import (
+	"fmt"
+	"strings"
+	"time"
+
+	"github.com/robfig/cron/v3"
+)
+
+// cronParser 与kube-controller-manager一致的5字段cron解析器，支持@hourly等描述符和CRON_TZ前缀
+var cronParser = cron.NewParser(cron.Minute | cron.Hour | cron.Dom | cron.Month | cron.Dow | cron.Descriptor)
+
+// NextSchedules 根据cron表达式和时区计算from之后的n次调度时间，timeZone为空时使用控制器所在时区（按UTC计算）
+func NextSchedules(schedule, timeZone string, from time.Time, n int) ([]time.Time, error) {
+	schedule = strings.TrimSpace(schedule)
+	if strings.HasPrefix(schedule, "TZ=") || strings.HasPrefix(schedule, "CRON_TZ=") {
+		if timeZone != "" {
+			return nil, fmt.Errorf("设置timeZone时schedule不能包含TZ前缀")
+		}
+	} else {
+		if timeZone == "" {
+			timeZone = "UTC"
+		}
+		if _, err := time.LoadLocation(timeZone); err != nil {
+			return nil, fmt.Errorf("无效的时区 %s: %w", timeZone, err)
+		}
+		schedule = "CRON_TZ=" + timeZone + " " + schedule
+	}
+
+	parsed, err := cronParser.Parse(schedule)
+	if err != nil {
+		return nil, fmt.Errorf("无效的cron表达式: %w", err)
+	}
+
+	result := make([]time.Time, 0, n)
+	next := from
+	for i := 0; i < n; i++ {
+		next = parsed.Next(next)
+		if next.IsZero() {
+			break
+		}
+		result = append(result, next)
+	}
+	return result, nil
+}
@@ -0,0 +1,215 @@
+package watcher
+
+import (
+	"devops-console-backend/internal/dal"
+	"devops-console-backend/pkg/configs"
+	"devops-console-backend/pkg/utils/logs"
+	"time"
+
+	corev1 "k8s.io/api/core/v1"
+	"k8s.io/client-go/informers"
+	"k8s.io/client-go/kubernetes"
+	"k8s.io/client-go/tools/cache"
+)
+
+const (
+	// eventSyncInterval 检查K8s实例变化的间隔
+	eventSyncInterval = time.Minute
+	// eventCleanupInterval 清理过期归档事件的间隔
+	eventCleanupInterval = time.Hour
+)
+
+// eventInformer 单个集群的事件监听
+type eventInformer struct {
+	client *kubernetes.Clientset
+	stopCh chan struct{}
+}
+
+// EventWatcher 监听所有已连接集群的K8s事件并归档到数据库
+type EventWatcher struct {
+	repo          *configs.K8sEventRepository
+	retentionDays int
+	informers     map[uint]*eventInformer
+}
+
+// NewEventWatcher 创建K8s事件归档监听器，retentionDays小于等于0时不清理历史事件
+func NewEventWatcher(retentionDays int) *EventWatcher {
+	return &EventWatcher{
+		repo:          configs.NewK8sEventRepository(),
+		retentionDays: retentionDays,
+		informers:     make(map[uint]*eventInformer),
+	}
+}
+
+// Run 启动事件归档，直到stopCh关闭
+func (w *EventWatcher) Run(stopCh <-chan struct{}) {
+	logs.Info(map[string]interface{}{
+		"retentionDays": w.retentionDays,
+	}, "K8s事件归档已启动")
+
+	w.syncInstances()
+	w.cleanup()
+
+	syncTicker := time.NewTicker(eventSyncInterval)
+	defer syncTicker.Stop()
+	cleanupTicker := time.NewTicker(eventCleanupInterval)
+	defer cleanupTicker.Stop()
+
+	for {
+		select {
+		case <-stopCh:
+			for id, inf := range w.informers {
+				close(inf.stopCh)
+				delete(w.informers, id)
+			}
+			return
+		case <-syncTicker.C:
+			w.syncInstances()
+		case <-cleanupTicker.C:
+			w.cleanup()
+		}
+	}
+}
+
+// syncInstances 为新增的集群启动监听，停止已移除或客户端已重建的集群的监听
+func (w *EventWatcher) syncInstances() {
+	active := make(map[uint]bool)
+	for _, id := range configs.GetK8sInstanceIDs() {
+		client, ok := configs.GetK8sClient(id)
+		if !ok {
+			continue
+		}
+		active[id] = true
+
+		if inf, exists := w.informers[id]; exists {
+			if inf.client == client {
+				continue
+			}
+			close(inf.stopCh)
+		}
+		w.informers[id] = w.startInformer(id, client)
+	}
+
+	for id, inf := range w.informers {
+		if !active[id] {
+			close(inf.stopCh)
+			delete(w.informers, id)
+		}
+	}
+}
+
+// startInformer 启动单个集群的事件Informer
+func (w *EventWatcher) startInformer(instanceID uint, client *kubernetes.Clientset) *eventInformer {
+	inf := &eventInformer{client: client, stopCh: make(chan struct{})}
+
+	factory := informers.NewSharedInformerFactory(client, 0)
+	informer := factory.Core().V1().Events().Informer()
+	informer.AddEventHandler(cache.ResourceEventHandlerFuncs{
+		AddFunc: func(obj interface{}) {
+			if event, ok := obj.(*corev1.Event); ok {
+				w.archive(instanceID, event)
+			}
+		},
+		UpdateFunc: func(oldObj, newObj interface{}) {
+			oldEvent, ok1 := oldObj.(*corev1.Event)
+			newEvent, ok2 := newObj.(*corev1.Event)
+			if !ok1 || !ok2 {
+				return
+			}
+			// 事件次数和时间未变化时跳过，避免重复写库
+			if oldEvent.Count == newEvent.Count && oldEvent.LastTimestamp.Equal(&newEvent.LastTimestamp) {
+				return
+			}
+			w.archive(instanceID, newEvent)
+		},
+	})
+
+	go factory.Start(inf.stopCh)
+
+	logs.Info(map[string]interface{}{
+		"instanceId": instanceID,
+	}, "开始监听K8s事件")
+	return inf
+}
+
+// archive 写入单条事件
+func (w *EventWatcher) archive(instanceID uint, event *corev1.Event) {
+	record := toK8sEvent(instanceID, event)
+	if err := w.repo.Upsert(record); err != nil {
+		logs.Error(map[string]interface{}{
+			"instanceId": instanceID,
+			"event":      event.Namespace + "/" + event.Name,
+			"error":      err.Error(),
+		}, "K8s事件归档失败")
+	}
+}
+
+// cleanup 删除超出保留天数的归档事件
+func (w *EventWatcher) cleanup() {
+	if w.retentionDays <= 0 {
+		return
+	}
+	before := time.Now().AddDate(0, 0, -w.retentionDays)
+	deleted, err := w.repo.DeleteBefore(before)
+	if err != nil {
+		logs.Error(map[string]interface{}{
+			"error": err.Error(),
+		}, "清理过期K8s事件失败")
+		return
+	}
+	if deleted > 0 {
+		logs.Info(map[string]interface{}{
+			"deleted": deleted,
+		}, "已清理过期K8s事件")
+	}
+}
+
+// toK8sEvent 将K8s事件转换为归档模型
+func toK8sEvent(instanceID uint, event *corev1.Event) *dal.K8sEvent {
+	// 新版事件API产生的事件可能只有EventTime，没有First/LastTimestamp
+	first := event.FirstTimestamp.Time
+	last := event.LastTimestamp.Time
+	if first.IsZero() {
+		first = event.EventTime.Time
+	}
+	if first.IsZero() {
+		first = event.CreationTimestamp.Time
+	}
+	if last.IsZero() {
+		last = first
+	}
+	if event.Series != nil && event.Series.LastObservedTime.After(last) {
+		last = event.Series.LastObservedTime.Time
+	}
+
+	count := event.Count
+	if event.Series != nil && event.Series.Count > count {
+		count = event.Series.Count
+	}
+	if count == 0 {
+		count = 1
+	}
+
+	source := event.Source.Component
+	if source == "" {
+		source = event.ReportingController
+	}
+
+	return &dal.K8sEvent{
+		InstanceID:      instanceID,
+		UID:             string(event.UID),
+		Name:            event.Name,
+		Namespace:       event.Namespace,
+		Type:            event.Type,
+		Reason:          event.Reason,
+		Message:         event.Message,
+		InvolvedKind:    event.InvolvedObject.Kind,
+		InvolvedName:    event.InvolvedObject.Name,
+		InvolvedUID:     string(event.InvolvedObject.UID),
+		Source:          source,
+		Count:           count,
+		FirstTimestamp:  first,
+		LastTimestamp:   last,
+		ResourceVersion: event.ResourceVersion,
+	}
+}
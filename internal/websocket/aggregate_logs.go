@@ -0,0 +1,505 @@
+package websocket
+
+import (
+	"bufio"
+	"context"
+	"devops-console-backend/internal/dal"
+	"devops-console-backend/pkg/configs"
+	"devops-console-backend/pkg/utils"
+	"devops-console-backend/pkg/utils/logs"
+	"fmt"
+	"net/http"
+	"regexp"
+	"sort"
+	"strconv"
+	"strings"
+	"sync"
+	"time"
+
+	"github.com/gin-gonic/gin"
+	"github.com/gorilla/websocket"
+	corev1 "k8s.io/api/core/v1"
+	metav1 "k8s.io/apimachinery/pkg/apis/meta/v1"
+	"k8s.io/apimachinery/pkg/labels"
+	"k8s.io/apimachinery/pkg/watch"
+	"k8s.io/client-go/kubernetes"
+)
+
+const (
+	// aggregateFlushInterval 日志合并排序的时间窗口
+	aggregateFlushInterval = 300 * time.Millisecond
+	// aggregateMaxStreams 单个连接同时跟踪的容器日志流上限
+	aggregateMaxStreams = 50
+	// aggregateMaxBuffer 缓冲的日志行数超过该值时立即推送
+	aggregateMaxBuffer = 5000
+)
+
+// AggregateLogHandler 多Pod多容器聚合日志WebSocket处理器
+type AggregateLogHandler struct{}
+
+// NewAggregateLogHandler 创建聚合日志处理器
+func NewAggregateLogHandler() *AggregateLogHandler {
+	return &AggregateLogHandler{}
+}
+
+// aggregateOptions 聚合日志参数
+type aggregateOptions struct {
+	namespace    string
+	selector     string
+	container    string
+	previous     bool
+	sinceSeconds *int64
+	tailLines    *int64
+	timestamps   bool
+	grep         *regexp.Regexp
+}
+
+// logLine 单行日志
+type logLine struct {
+	pod       string
+	container string
+	ts        time.Time
+	text      string
+}
+
+// aggregateSession 一次聚合日志连接
+type aggregateSession struct {
+	client *kubernetes.Clientset
+	opts   aggregateOptions
+	lines  chan logLine
+	notes  chan string
+
+	mu       sync.Mutex
+	active   map[string]bool      // 正在跟踪的 pod/container
+	lastSeen map[string]time.Time // 每个容器最后一行日志的时间，用于容器重启后续接
+	wg       sync.WaitGroup
+}
+
+// HandleWebSocket 处理聚合日志连接
+// 参数: namespace, labelSelector 或 kind+name（deployment/statefulset/daemonset/replicaset/job）,
+// container 只看指定容器, previous, sinceSeconds, tail, timestamps, grep 正则过滤
+func (h *AggregateLogHandler) HandleWebSocket(c *gin.Context) {
+	claims, err := parseClaims(c)
+	if err != nil {
+		c.JSON(http.StatusUnauthorized, gin.H{"error": "认证失败"})
+		return
+	}
+
+	namespace := c.Query("namespace")
+	if namespace == "" {
+		c.JSON(http.StatusBadRequest, gin.H{"error": "缺少namespace参数"})
+		return
+	}
+
+	// 查看日志需要命名空间的logs授权
+	instanceID := utils.GetInstanceIDFromContext(c)
+	allowed, err := configs.HasNamespacePermission(claims.GetUserId(), claims.GetUserName(), claims.GetRoles(),
+		instanceID, namespace, dal.K8sActionLogs)
+	if err != nil {
+		c.JSON(http.StatusInternalServerError, gin.H{"error": "校验权限失败"})
+		return
+	}
+	if !allowed {
+		c.JSON(http.StatusForbidden, gin.H{"error": "没有该命名空间的日志查看权限"})
+		return
+	}
+
+	client, exists := configs.GetK8sClient(instanceID)
+	if !exists {
+		c.JSON(http.StatusInternalServerError, gin.H{"error": "K8s客户端未初始化"})
+		return
+	}
+
+	opts, err := parseAggregateOptions(c, client)
+	if err != nil {
+		c.JSON(http.StatusBadRequest, gin.H{"error": err.Error()})
+		return
+	}
+
+	conn, err := newUpgrader().Upgrade(c.Writer, c.Request, nil)
+	if err != nil {
+		return
+	}
+	defer func() { _ = conn.Close() }()
+
+	ctx, cancel := context.WithCancel(c.Request.Context())
+	defer cancel()
+	go func() {
+		defer cancel()
+		for {
+			if _, _, err := conn.ReadMessage(); err != nil {
+				return
+			}
+		}
+	}()
+
+	session := &aggregateSession{
+		client:   client,
+		opts:     opts,
+		lines:    make(chan logLine, 1000),
+		notes:    make(chan string, 100),
+		active:   make(map[string]bool),
+		lastSeen: make(map[string]time.Time),
+	}
+
+	done := make(chan struct{})
+	go func() {
+		defer close(done)
+		if opts.previous {
+			// 上一个容器实例的日志无法跟随，输出完即结束
+			session.streamExisting(ctx)
+			session.wg.Wait()
+			return
+		}
+		session.watchPods(ctx)
+		session.wg.Wait()
+	}()
+
+	session.pump(ctx, conn, done)
+}
+
+// parseAggregateOptions 解析请求参数，kind+name优先于labelSelector
+func parseAggregateOptions(c *gin.Context, client *kubernetes.Clientset) (aggregateOptions, error) {
+	opts := aggregateOptions{
+		namespace:  c.Query("namespace"),
+		selector:   c.Query("labelSelector"),
+		container:  c.Query("container"),
+		previous:   c.Query("previous") == "true",
+		timestamps: c.Query("timestamps") == "true",
+	}
+	if opts.namespace == "" {
+		return opts, fmt.Errorf("缺少namespace参数")
+	}
+
+	if kind, name := c.Query("kind"), c.Query("name"); kind != "" && name != "" {
+		selector, err := workloadSelector(c.Request.Context(), client, opts.namespace, kind, name)
+		if err != nil {
+			return opts, err
+		}
+		opts.selector = selector
+	}
+	if opts.selector == "" {
+		return opts, fmt.Errorf("需要指定labelSelector或kind和name")
+	}
+	if _, err := labels.Parse(opts.selector); err != nil {
+		return opts, fmt.Errorf("标签选择器格式错误: %v", err)
+	}
+
+	if s := c.Query("sinceSeconds"); s != "" {
+		seconds, err := strconv.ParseInt(s, 10, 64)
+		if err != nil || seconds <= 0 {
+			return opts, fmt.Errorf("sinceSeconds格式错误")
+		}
+		opts.sinceSeconds = &seconds
+	}
+	tail := int64(100)
+	if s := c.Query("tail"); s != "" {
+		if v, err := strconv.ParseInt(s, 10, 64); err == nil {
+			tail = v
+		}
+	}
+	if tail > 0 {
+		opts.tailLines = &tail
+	}
+
+	if pattern := c.Query("grep"); pattern != "" {
+		re, err := regexp.Compile(pattern)
+		if err != nil {
+			return opts, fmt.Errorf("过滤表达式错误: %v", err)
+		}
+		opts.grep = re
+	}
+	return opts, nil
+}
+
+// workloadSelector 获取工作负载的Pod标签选择器
+func workloadSelector(ctx context.Context, client *kubernetes.Clientset, namespace, kind, name string) (string, error) {
+	var selector *metav1.LabelSelector
+	switch strings.ToLower(kind) {
+	case "deployment":
+		obj, err := client.AppsV1().Deployments(namespace).Get(ctx, name, metav1.GetOptions{})
+		if err != nil {
+			return "", fmt.Errorf("获取Deployment失败: %v", err)
+		}
+		selector = obj.Spec.Selector
+	case "statefulset":
+		obj, err := client.AppsV1().StatefulSets(namespace).Get(ctx, name, metav1.GetOptions{})
+		if err != nil {
+			return "", fmt.Errorf("获取StatefulSet失败: %v", err)
+		}
+		selector = obj.Spec.Selector
+	case "daemonset":
+		obj, err := client.AppsV1().DaemonSets(namespace).Get(ctx, name, metav1.GetOptions{})
+		if err != nil {
+			return "", fmt.Errorf("获取DaemonSet失败: %v", err)
+		}
+		selector = obj.Spec.Selector
+	case "replicaset":
+		obj, err := client.AppsV1().ReplicaSets(namespace).Get(ctx, name, metav1.GetOptions{})
+		if err != nil {
+			return "", fmt.Errorf("获取ReplicaSet失败: %v", err)
+		}
+		selector = obj.Spec.Selector
+	case "job":
+		obj, err := client.BatchV1().Jobs(namespace).Get(ctx, name, metav1.GetOptions{})
+		if err != nil {
+			return "", fmt.Errorf("获取Job失败: %v", err)
+		}
+		selector = obj.Spec.Selector
+	default:
+		return "", fmt.Errorf("不支持的工作负载类型: %s", kind)
+	}
+
+	s, err := metav1.LabelSelectorAsSelector(selector)
+	if err != nil {
+		return "", fmt.Errorf("解析工作负载选择器失败: %v", err)
+	}
+	return s.String(), nil
+}
+
+// streamExisting 对当前匹配的Pod各输出一次日志
+func (s *aggregateSession) streamExisting(ctx context.Context) {
+	pods, err := s.client.CoreV1().Pods(s.opts.namespace).List(ctx, metav1.ListOptions{LabelSelector: s.opts.selector})
+	if err != nil {
+		s.note("获取Pod列表失败: " + err.Error())
+		return
+	}
+	for i := range pods.Items {
+		s.ensureStreams(ctx, &pods.Items[i], false)
+	}
+}
+
+// watchPods 跟随匹配的Pod，会话期间新建的Pod自动加入
+func (s *aggregateSession) watchPods(ctx context.Context) {
+	initial := true
+	for ctx.Err() == nil {
+		pods, err := s.client.CoreV1().Pods(s.opts.namespace).List(ctx, metav1.ListOptions{LabelSelector: s.opts.selector})
+		if err != nil {
+			if ctx.Err() == nil {
+				s.note("获取Pod列表失败: " + err.Error())
+			}
+			return
+		}
+		for i := range pods.Items {
+			// 首次列出的Pod按tail截取，之后出现的Pod输出全部日志
+			s.ensureStreams(ctx, &pods.Items[i], initial)
+		}
+		initial = false
+
+		watcher, err := s.client.CoreV1().Pods(s.opts.namespace).Watch(ctx, metav1.ListOptions{
+			LabelSelector:   s.opts.selector,
+			ResourceVersion: pods.ResourceVersion,
+		})
+		if err != nil {
+			if ctx.Err() == nil {
+				s.note("监听Pod失败: " + err.Error())
+			}
+			return
+		}
+		for event := range watcher.ResultChan() {
+			if event.Type != watch.Added && event.Type != watch.Modified {
+				continue
+			}
+			if pod, ok := event.Object.(*corev1.Pod); ok {
+				s.ensureStreams(ctx, pod, false)
+			}
+		}
+		watcher.Stop()
+	}
+}
+
+// ensureStreams 为Pod中运行中且未在跟踪的容器启动日志流
+func (s *aggregateSession) ensureStreams(ctx context.Context, pod *corev1.Pod, initial bool) {
+	running := make(map[string]bool)
+	for _, status := range pod.Status.ContainerStatuses {
+		running[status.Name] = status.State.Running != nil
+	}
+
+	for _, container := range pod.Spec.Containers {
+		if s.opts.container != "" && container.Name != s.opts.container {
+			continue
+		}
+		if !s.opts.previous && !running[container.Name] {
+			continue
+		}
+
+		key := pod.Name + "/" + container.Name
+		s.mu.Lock()
+		if s.active[key] {
+			s.mu.Unlock()
+			continue
+		}
+		if len(s.active) >= aggregateMaxStreams {
+			s.mu.Unlock()
+			s.note(fmt.Sprintf("日志流数量已达上限%d，忽略 %s", aggregateMaxStreams, key))
+			continue
+		}
+		s.active[key] = true
+		since, resumed := s.lastSeen[key]
+		s.mu.Unlock()
+
+		logOpts := &corev1.PodLogOptions{
+			Container:    container.Name,
+			Follow:       !s.opts.previous,
+			Previous:     s.opts.previous,
+			Timestamps:   true,
+			SinceSeconds: s.opts.sinceSeconds,
+		}
+		if resumed {
+			// 容器重启后从上次读到的位置继续，重复行在读取时丢弃
+			logOpts.SinceSeconds = nil
+			sinceTime := metav1.NewTime(since)
+			logOpts.SinceTime = &sinceTime
+		} else if initial || s.opts.previous {
+			logOpts.TailLines = s.opts.tailLines
+		}
+
+		s.wg.Add(1)
+		go s.stream(ctx, pod.Name, container.Name, logOpts)
+	}
+}
+
+// stream 读取单个容器的日志
+func (s *aggregateSession) stream(ctx context.Context, podName, containerName string, logOpts *corev1.PodLogOptions) {
+	key := podName + "/" + containerName
+	defer s.wg.Done()
+	defer func() {
+		s.mu.Lock()
+		delete(s.active, key)
+		s.mu.Unlock()
+	}()
+
+	rc, err := s.client.CoreV1().Pods(s.opts.namespace).GetLogs(podName, logOpts).Stream(ctx)
+	if err != nil {
+		if ctx.Err() == nil {
+			s.note(fmt.Sprintf("获取 %s 日志失败: %v", key, err))
+		}
+		return
+	}
+	defer func() { _ = rc.Close() }()
+
+	s.mu.Lock()
+	last := s.lastSeen[key]
+	s.mu.Unlock()
+
+	scanner := bufio.NewScanner(rc)
+	scanner.Buffer(make([]byte, 64*1024), 1024*1024)
+	for scanner.Scan() {
+		line := scanner.Text()
+		ts, text := splitTimestamp(line)
+		if !ts.IsZero() {
+			if !ts.After(last) && !last.IsZero() {
+				continue
+			}
+			last = ts
+			s.mu.Lock()
+			s.lastSeen[key] = ts
+			s.mu.Unlock()
+		}
+		if s.opts.grep != nil && !s.opts.grep.MatchString(text) {
+			continue
+		}
+		select {
+		case s.lines <- logLine{pod: podName, container: containerName, ts: ts, text: text}:
+		case <-ctx.Done():
+			return
+		}
+	}
+}
+
+// note 发送提示信息，通道已满时丢弃
+func (s *aggregateSession) note(message string) {
+	select {
+	case s.notes <- message:
+	default:
+	}
+}
+
+// pump 按时间窗口合并各容器日志并按时间戳排序后推送
+func (s *aggregateSession) pump(ctx context.Context, conn *websocket.Conn, done <-chan struct{}) {
+	ticker := time.NewTicker(aggregateFlushInterval)
+	defer ticker.Stop()
+
+	buffer := make([]logLine, 0, 256)
+	flush := func() bool {
+		sort.SliceStable(buffer, func(i, j int) bool {
+			return buffer[i].ts.Before(buffer[j].ts)
+		})
+		for _, line := range buffer {
+			if err := conn.WriteJSON(s.message(line)); err != nil {
+				return false
+			}
+		}
+		buffer = buffer[:0]
+		return true
+	}
+
+	for {
+		select {
+		case <-ctx.Done():
+			return
+		case line := <-s.lines:
+			buffer = append(buffer, line)
+			if len(buffer) >= aggregateMaxBuffer && !flush() {
+				return
+			}
+		case message := <-s.notes:
+			_ = conn.WriteJSON(map[string]interface{}{
+				"type":    "info",
+				"content": message,
+				"time":    time.Now().Unix(),
+			})
+		case <-ticker.C:
+			if !flush() {
+				return
+			}
+		case <-done:
+			// 读取剩余日志后结束
+		drain:
+			for {
+				select {
+				case line := <-s.lines:
+					buffer = append(buffer, line)
+				default:
+					break drain
+				}
+			}
+			flush()
+			_ = conn.WriteJSON(map[string]interface{}{
+				"type": "end",
+				"time": time.Now().Unix(),
+			})
+			logs.Debug(map[string]interface{}{"selector": s.opts.selector}, "聚合日志结束")
+			return
+		}
+	}
+}
+
+// message 构造推送的日志消息
+func (s *aggregateSession) message(line logLine) map[string]interface{} {
+	content := line.text
+	if s.opts.timestamps && !line.ts.IsZero() {
+		content = line.ts.Format(time.RFC3339Nano) + " " + content
+	}
+	return map[string]interface{}{
+		"type":      "log",
+		"pod":       line.pod,
+		"container": line.container,
+		"content":   "[" + line.pod + "/" + line.container + "] " + content,
+		"timestamp": line.ts.UnixMilli(),
+		"time":      time.Now().Unix(),
+	}
+}
+
+// splitTimestamp 拆分K8s日志行首的RFC3339时间戳
+func splitTimestamp(line string) (time.Time, string) {
+	prefix, rest, found := strings.Cut(line, " ")
+	if !found {
+		return time.Time{}, line
+	}
+	ts, err := time.Parse(time.RFC3339Nano, prefix)
+	if err != nil {
+		return time.Time{}, line
+	}
+	return ts, rest
+}
@@ -0,0 +1,79 @@
+package websocket
+
+import (
+	"devops-console-backend/internal/common"
+	"devops-console-backend/internal/middlewares"
+	"devops-console-backend/pkg/configs"
+	"devops-console-backend/pkg/utils/jwt"
+	"errors"
+	"net/http"
+	"net/url"
+	"strings"
+
+	"github.com/gin-gonic/gin"
+	"github.com/gorilla/websocket"
+)
+
+// bearerSubprotocol 通过子协议传递token时使用的协议名，客户端按 ["bearer", token] 的顺序声明
+const bearerSubprotocol = "bearer"
+
+// parseClaims 解析WebSocket连接的用户身份
+// 浏览器建立WebSocket连接时无法设置请求头，因此同时支持通过token查询参数或子协议传递
+func parseClaims(c *gin.Context) (*jwt.Claims, error) {
+	token := strings.TrimPrefix(c.GetHeader(common.TokenKey), "Bearer ")
+	if token == "" {
+		token = c.Query("token")
+	}
+	if token == "" {
+		protocols := websocket.Subprotocols(c.Request)
+		if len(protocols) >= 2 && protocols[0] == bearerSubprotocol {
+			token = protocols[1]
+		}
+	}
+	if token == "" {
+		return nil, errors.New("缺少认证token")
+	}
+	claims, err := jwt.ParseToken(token)
+	if err != nil {
+		return nil, err
+	}
+	// 与HTTP认证中间件一致，已退出登录或被下线的token不能建立连接
+	blocked, err := middlewares.IsTokenBlocked(claims, token)
+	if err != nil {
+		return nil, err
+	}
+	if blocked {
+		return nil, errors.New("账号已在其他地方登录")
+	}
+	return claims, nil
+}
+
+// newUpgrader 创建按来源白名单校验的WebSocket升级器
+func newUpgrader() *websocket.Upgrader {
+	return &websocket.Upgrader{
+		ReadBufferSize:  1024,
+		WriteBufferSize: 4096,
+		Subprotocols:    []string{bearerSubprotocol},
+		CheckOrigin:     checkOrigin,
+	}
+}
+
+// checkOrigin 校验来源，未配置白名单时只允许同源
+func checkOrigin(r *http.Request) bool {
+	origin := r.Header.Get("Origin")
+	if origin == "" {
+		return true
+	}
+
+	allowed := configs.GetWebSocketConfig().AllowedOrigins
+	if len(allowed) == 0 {
+		u, err := url.Parse(origin)
+		return err == nil && strings.EqualFold(u.Host, r.Host)
+	}
+	for _, item := range allowed {
+		if item == "*" || strings.EqualFold(item, origin) {
+			return true
+		}
+	}
+	return false
+}
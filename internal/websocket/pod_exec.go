@@ -1,12 +1,21 @@
 package websocket
 
 import (
+	"context"
+	"devops-console-backend/internal/dal"
+	"devops-console-backend/internal/services/terminal"
 	"devops-console-backend/pkg/configs"
+	"devops-console-backend/pkg/utils"
+	"devops-console-backend/pkg/utils/logs"
+	"errors"
 	"io"
 	"net/http"
 	"strconv"
+	"sync"
+	"time"
 
 	"github.com/gin-gonic/gin"
+	"github.com/google/uuid"
 	"github.com/gorilla/websocket"
 	corev1 "k8s.io/api/core/v1"
 	"k8s.io/client-go/kubernetes/scheme"
@@ -15,13 +24,13 @@ import (
 
 // PodExecHandler WebSocket处理器
 type PodExecHandler struct {
-	clients map[*websocket.Conn]bool
+	registry *terminal.Registry
 }
 
 // NewPodExecHandler 创建新的Pod Exec处理器
 func NewPodExecHandler() *PodExecHandler {
 	return &PodExecHandler{
-		clients: make(map[*websocket.Conn]bool),
+		registry: terminal.DefaultRegistry,
 	}
 }
 
@@ -36,47 +45,61 @@ type TerminalMessage struct {
 // TerminalSession 终端会话
 type TerminalSession struct {
 	conn      *websocket.Conn
+	writeMu   sync.Mutex // 保护conn的并发写
 	sizeChan  chan remotecommand.TerminalSize
 	closeChan chan struct{}
+	closeOnce sync.Once
+	pending   []byte // 上次未读完的stdin数据
+	recorder  *terminal.Recorder
 	rows      uint16
 	cols      uint16
 }
 
-// Read 实现io.Reader接口
+// Read 实现io.Reader接口，单条stdin消息超过缓冲区时分多次返回
 func (t *TerminalSession) Read(p []byte) (int, error) {
-	var msg TerminalMessage
-	err := t.conn.ReadJSON(&msg)
-	if err != nil {
-		if err == io.EOF || websocket.IsCloseError(err, websocket.CloseNormalClosure) {
-			return 0, io.EOF
+	for len(t.pending) == 0 {
+		var msg TerminalMessage
+		err := t.conn.ReadJSON(&msg)
+		if err != nil {
+			if err == io.EOF || websocket.IsCloseError(err, websocket.CloseNormalClosure, websocket.CloseGoingAway) {
+				return 0, io.EOF
+			}
+			return 0, err
 		}
-		return 0, err
-	}
 
-	switch msg.Type {
-	case "stdin":
-		copy(p, msg.Data)
-		return len(msg.Data), nil
-	case "resize":
-		if msg.Rows > 0 && msg.Cols > 0 {
-			t.rows = msg.Rows
-			t.cols = msg.Cols
-			t.sizeChan <- remotecommand.TerminalSize{
-				Width:  msg.Cols,
-				Height: msg.Rows,
+		switch msg.Type {
+		case "stdin":
+			t.pending = []byte(msg.Data)
+		case "resize":
+			if msg.Rows > 0 && msg.Cols > 0 {
+				t.rows = msg.Rows
+				t.cols = msg.Cols
+				if t.recorder != nil {
+					t.recorder.Resize(msg.Cols, msg.Rows)
+				}
+				select {
+				case t.sizeChan <- remotecommand.TerminalSize{Width: msg.Cols, Height: msg.Rows}:
+				case <-t.closeChan:
+					return 0, io.EOF
+				}
 			}
 		}
 	}
-	return 0, nil
+
+	n := copy(p, t.pending)
+	t.pending = t.pending[n:]
+	return n, nil
 }
 
 // Write 实现io.Writer接口
 func (t *TerminalSession) Write(p []byte) (int, error) {
-	msg := map[string]interface{}{
+	if t.recorder != nil {
+		t.recorder.Output(p)
+	}
+	err := t.writeJSON(map[string]interface{}{
 		"type": "stdout",
 		"data": string(p),
-	}
-	err := t.conn.WriteJSON(msg)
+	})
 	if err != nil {
 		return 0, err
 	}
@@ -93,50 +116,92 @@ func (t *TerminalSession) Next() *remotecommand.TerminalSize {
 	}
 }
 
+// Close 关闭会话，可重复调用
+func (t *TerminalSession) Close() {
+	t.closeOnce.Do(func() {
+		close(t.closeChan)
+		_ = t.conn.Close()
+	})
+}
+
+// writeJSON 并发安全地写入消息
+func (t *TerminalSession) writeJSON(v interface{}) error {
+	t.writeMu.Lock()
+	defer t.writeMu.Unlock()
+	return t.conn.WriteJSON(v)
+}
+
+// sendError 发送错误消息
+func (t *TerminalSession) sendError(message string) {
+	_ = t.writeJSON(map[string]interface{}{
+		"type":  "error",
+		"error": message,
+	})
+}
+
 // HandleWebSocket 处理WebSocket连接
 func (h *PodExecHandler) HandleWebSocket(c *gin.Context) {
-	upgrader := websocket.Upgrader{
-		ReadBufferSize:  1024,
-		WriteBufferSize: 1024,
-		CheckOrigin: func(r *http.Request) bool {
-			return true
-		},
-	}
-
-	conn, err := upgrader.Upgrade(c.Writer, c.Request, nil)
+	claims, err := parseClaims(c)
 	if err != nil {
-		c.JSON(http.StatusBadRequest, gin.H{"error": "升级WebSocket失败"})
+		c.JSON(http.StatusUnauthorized, gin.H{"error": "认证失败"})
 		return
 	}
 
-	h.clients[conn] = true
-	defer delete(h.clients, conn)
-
 	// 获取参数
 	namespace := c.Query("namespace")
 	podName := c.Param("podname")
 	container := c.Query("container")
-	instanceIdStr := c.Query("instance_id")
 	shell := c.DefaultQuery("shell", "/bin/sh")
+	instanceID, err := strconv.ParseUint(c.Query("instance_id"), 10, 32)
+	if err != nil || instanceID == 0 {
+		c.JSON(http.StatusBadRequest, gin.H{"error": "缺少或无效的instance_id参数"})
+		return
+	}
+	if namespace == "" {
+		c.JSON(http.StatusBadRequest, gin.H{"error": "缺少namespace参数"})
+		return
+	}
 
-	instanceID := uint(1)
-	if instanceIdStr != "" {
-		if id, err := strconv.ParseInt(instanceIdStr, 10, 32); err == nil {
-			instanceID = uint(id)
-		}
+	// 非管理员需要命名空间的exec授权
+	allowed, err := configs.HasNamespacePermission(claims.GetUserId(), claims.GetUserName(), claims.GetRoles(),
+		uint(instanceID), namespace, dal.K8sActionExec)
+	if err != nil {
+		c.JSON(http.StatusInternalServerError, gin.H{"error": "校验权限失败"})
+		return
+	}
+	if !allowed {
+		c.JSON(http.StatusForbidden, gin.H{"error": "没有该命名空间的终端权限"})
+		return
 	}
 
-	client, exists := configs.GetK8sClient(instanceID)
+	client, exists := configs.GetK8sClient(uint(instanceID))
 	if !exists {
-		sendExecError(conn, "K8s客户端未初始化")
+		c.JSON(http.StatusInternalServerError, gin.H{"error": "K8s客户端未初始化"})
+		return
+	}
+	config, exists := configs.GetK8sConfig(uint(instanceID))
+	if !exists {
+		c.JSON(http.StatusInternalServerError, gin.H{"error": "获取K8s配置失败"})
 		return
 	}
 
-	// 获取 RESTClient
-	restClient := client.CoreV1().RESTClient()
+	conn, err := newUpgrader().Upgrade(c.Writer, c.Request, nil)
+	if err != nil {
+		return
+	}
+
+	// 创建终端会话
+	session := &TerminalSession{
+		conn:      conn,
+		sizeChan:  make(chan remotecommand.TerminalSize, 10),
+		closeChan: make(chan struct{}),
+		rows:      24,
+		cols:      80,
+	}
+	defer session.Close()
 
 	// 创建exec请求
-	req := restClient.Post().
+	req := client.CoreV1().RESTClient().Post().
 		Resource("pods").
 		Name(podName).
 		Namespace(namespace).
@@ -150,32 +215,83 @@ func (h *PodExecHandler) HandleWebSocket(c *gin.Context) {
 			TTY:       true,
 		}, scheme.ParameterCodec)
 
-	// 获取配置
-	config, exists := configs.GetK8sConfig(instanceID)
-	if !exists {
-		sendExecError(conn, "获取K8s配置失败")
-		return
-	}
-
 	// 创建executor
 	executor, err := remotecommand.NewSPDYExecutor(config, "POST", req.URL())
 	if err != nil {
-		sendExecError(conn, "创建executor失败: "+err.Error())
+		session.sendError("创建executor失败: " + err.Error())
 		return
 	}
 
-	// 创建终端会话
-	session := &TerminalSession{
-		conn:      conn,
-		sizeChan:  make(chan remotecommand.TerminalSize, 10),
-		closeChan: make(chan struct{}),
-		rows:      24,
-		cols:      80,
+	sessionID := uuid.NewString()
+	logData := map[string]interface{}{
+		"sessionId":  sessionID,
+		"user":       claims.GetUserName(),
+		"instanceId": instanceID,
+		"namespace":  namespace,
+		"pod":        podName,
+		"container":  container,
+	}
+
+	// 录制会话，录制失败不影响使用
+	recorder, err := terminal.NewRecorder(configs.GetWebSocketConfig().RecordDir, sessionID, session.cols, session.rows,
+		namespace+"/"+podName+"/"+container)
+	if err != nil {
+		logs.Warning(logData, "创建终端录像失败: "+err.Error())
+	} else {
+		session.recorder = recorder
+		defer func() { _ = recorder.Close() }()
+	}
+
+	// 审计记录
+	sessionRepo := configs.NewK8sExecSessionRepository()
+	record := &dal.K8sExecSession{
+		SessionID:  sessionID,
+		UserID:     claims.GetUserId(),
+		Username:   claims.GetUserName(),
+		InstanceID: uint(instanceID),
+		Namespace:  namespace,
+		Pod:        podName,
+		Container:  container,
+		Command:    shell,
+		ClientIP:   utils.GetClientIP(c.Request),
+		Status:     dal.ExecSessionStatusActive,
+		StartedAt:  time.Now(),
+	}
+	if recorder != nil {
+		record.RecordPath = recorder.Path()
+	}
+	if err := sessionRepo.Create(record); err != nil {
+		logs.Error(logData, "保存终端会话记录失败: "+err.Error())
 	}
-	defer close(session.closeChan)
+	logs.Info(logData, "终端会话开始")
+
+	// 登记会话，管理员可强制结束
+	ctx, cancel := context.WithCancel(c.Request.Context())
+	defer cancel()
+	var killedBy string
+	var killMu sync.Mutex
+	h.registry.Add(&terminal.LiveSession{
+		SessionID:  sessionID,
+		UserID:     record.UserID,
+		Username:   record.Username,
+		InstanceID: record.InstanceID,
+		Namespace:  namespace,
+		Pod:        podName,
+		Container:  container,
+		ClientIP:   record.ClientIP,
+		StartedAt:  record.StartedAt,
+	}, func(operator string) {
+		killMu.Lock()
+		killedBy = operator
+		killMu.Unlock()
+		session.sendError("会话已被管理员 " + operator + " 终止")
+		cancel()
+		session.Close()
+	})
+	defer h.registry.Remove(sessionID)
 
 	// 执行命令
-	err = executor.Stream(remotecommand.StreamOptions{
+	err = executor.StreamWithContext(ctx, remotecommand.StreamOptions{
 		Stdin:             session,
 		Stdout:            session,
 		Stderr:            session,
@@ -183,16 +299,18 @@ func (h *PodExecHandler) HandleWebSocket(c *gin.Context) {
 		TerminalSizeQueue: session,
 	})
 
-	if err != nil {
-		sendExecError(conn, "执行命令失败: "+err.Error())
+	killMu.Lock()
+	status := dal.ExecSessionStatusClosed
+	if killedBy != "" {
+		status = dal.ExecSessionStatusKilled
 	}
-}
-
-// sendExecError 发送错误消息
-func sendExecError(conn *websocket.Conn, message string) {
-	errorMsg := map[string]interface{}{
-		"type":  "error",
-		"error": message,
+	killMu.Unlock()
+	if err != nil && status != dal.ExecSessionStatusKilled && !errors.Is(err, context.Canceled) {
+		session.sendError("执行命令失败: " + err.Error())
+	}
+	if err := sessionRepo.Finish(sessionID, status, killedBy); err != nil {
+		logs.Error(logData, "更新终端会话记录失败: "+err.Error())
 	}
-	_ = conn.WriteJSON(errorMsg)
+	logData["status"] = status
+	logs.Info(logData, "终端会话结束")
 }
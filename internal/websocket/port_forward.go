@@ -0,0 +1,374 @@
+package websocket
+
+import (
+	"context"
+	"devops-console-backend/internal/dal"
+	"devops-console-backend/internal/services/terminal"
+	"devops-console-backend/pkg/configs"
+	"devops-console-backend/pkg/utils"
+	"devops-console-backend/pkg/utils/logs"
+	"fmt"
+	"io"
+	"net/http"
+	"strconv"
+	"sync"
+	"sync/atomic"
+	"time"
+
+	"github.com/gin-gonic/gin"
+	"github.com/google/uuid"
+	"github.com/gorilla/websocket"
+	corev1 "k8s.io/api/core/v1"
+	metav1 "k8s.io/apimachinery/pkg/apis/meta/v1"
+	"k8s.io/apimachinery/pkg/labels"
+	"k8s.io/apimachinery/pkg/util/httpstream"
+	"k8s.io/client-go/kubernetes"
+	"k8s.io/client-go/tools/portforward"
+	"k8s.io/client-go/transport/spdy"
+)
+
+// PortForwardHandler 端口转发WebSocket处理器，每个WebSocket连接对应一条到Pod端口的TCP连接
+type PortForwardHandler struct {
+	registry *terminal.Registry
+}
+
+// NewPortForwardHandler 创建端口转发处理器
+func NewPortForwardHandler() *PortForwardHandler {
+	return &PortForwardHandler{
+		registry: terminal.PortForwardRegistry,
+	}
+}
+
+// HandleWebSocket 处理端口转发连接
+// 参数: instance_id, namespace, pod 或 service, port（Pod端口或Service端口）, ttl（分钟）
+// 连接建立后客户端与Pod端口之间以二进制消息透传数据
+func (h *PortForwardHandler) HandleWebSocket(c *gin.Context) {
+	claims, err := parseClaims(c)
+	if err != nil {
+		c.JSON(http.StatusUnauthorized, gin.H{"error": "认证失败"})
+		return
+	}
+
+	namespace := c.Query("namespace")
+	podName := c.Query("pod")
+	serviceName := c.Query("service")
+	instanceID, err := strconv.ParseUint(c.Query("instance_id"), 10, 32)
+	if err != nil || instanceID == 0 {
+		c.JSON(http.StatusBadRequest, gin.H{"error": "缺少或无效的instance_id参数"})
+		return
+	}
+	port, err := strconv.ParseInt(c.Query("port"), 10, 32)
+	if err != nil || port <= 0 || port > 65535 {
+		c.JSON(http.StatusBadRequest, gin.H{"error": "无效的port参数"})
+		return
+	}
+	if namespace == "" || (podName == "" && serviceName == "") {
+		c.JSON(http.StatusBadRequest, gin.H{"error": "需要指定namespace以及pod或service"})
+		return
+	}
+
+	forwardConfig := configs.GetKubernetesConfig().PortForward
+	ttl := forwardConfig.DefaultTTL
+	if s := c.Query("ttl"); s != "" {
+		if v, err := strconv.Atoi(s); err == nil && v > 0 {
+			ttl = v
+		}
+	}
+	if forwardConfig.MaxTTL > 0 && ttl > forwardConfig.MaxTTL {
+		ttl = forwardConfig.MaxTTL
+	}
+
+	allowed, err := configs.HasNamespacePermission(claims.GetUserId(), claims.GetUserName(), claims.GetRoles(),
+		uint(instanceID), namespace, dal.K8sActionPortForward)
+	if err != nil {
+		c.JSON(http.StatusInternalServerError, gin.H{"error": "校验权限失败"})
+		return
+	}
+	if !allowed {
+		c.JSON(http.StatusForbidden, gin.H{"error": "没有该命名空间的端口转发权限"})
+		return
+	}
+
+	client, exists := configs.GetK8sClient(uint(instanceID))
+	if !exists {
+		c.JSON(http.StatusInternalServerError, gin.H{"error": "K8s客户端未初始化"})
+		return
+	}
+	config, exists := configs.GetK8sConfig(uint(instanceID))
+	if !exists {
+		c.JSON(http.StatusInternalServerError, gin.H{"error": "获取K8s配置失败"})
+		return
+	}
+
+	targetKind, targetName := "pod", podName
+	podPort := int32(port)
+	if serviceName != "" {
+		targetKind, targetName = "service", serviceName
+		podName, podPort, err = resolveServiceTarget(c.Request.Context(), client, namespace, serviceName, int32(port))
+		if err != nil {
+			c.JSON(http.StatusBadRequest, gin.H{"error": err.Error()})
+			return
+		}
+	}
+
+	// 先建立到Pod的SPDY连接，失败时仍可返回HTTP错误
+	reqURL := client.CoreV1().RESTClient().Post().
+		Resource("pods").
+		Namespace(namespace).
+		Name(podName).
+		SubResource("portforward").
+		URL()
+	transport, upgrader, err := spdy.RoundTripperFor(config)
+	if err != nil {
+		c.JSON(http.StatusInternalServerError, gin.H{"error": "创建SPDY连接失败: " + err.Error()})
+		return
+	}
+	dialer := spdy.NewDialer(upgrader, &http.Client{Transport: transport}, http.MethodPost, reqURL)
+	streamConn, _, err := dialer.Dial(portforward.PortForwardProtocolV1Name)
+	if err != nil {
+		c.JSON(http.StatusBadGateway, gin.H{"error": "连接Pod失败: " + err.Error()})
+		return
+	}
+	defer func() { _ = streamConn.Close() }()
+
+	errorStream, dataStream, err := createForwardStreams(streamConn, podPort)
+	if err != nil {
+		c.JSON(http.StatusBadGateway, gin.H{"error": err.Error()})
+		return
+	}
+
+	conn, err := newUpgrader().Upgrade(c.Writer, c.Request, nil)
+	if err != nil {
+		return
+	}
+	defer func() { _ = conn.Close() }()
+
+	sessionID := uuid.NewString()
+	startedAt := time.Now()
+	expiresAt := startedAt.Add(time.Duration(ttl) * time.Minute)
+	logData := map[string]interface{}{
+		"sessionId":  sessionID,
+		"user":       claims.GetUserName(),
+		"instanceId": instanceID,
+		"namespace":  namespace,
+		"target":     targetKind + "/" + targetName,
+		"pod":        podName,
+		"port":       podPort,
+	}
+
+	// 审计记录
+	sessionRepo := configs.NewK8sPortForwardSessionRepository()
+	record := &dal.K8sPortForwardSession{
+		SessionID:  sessionID,
+		UserID:     claims.GetUserId(),
+		Username:   claims.GetUserName(),
+		InstanceID: uint(instanceID),
+		Namespace:  namespace,
+		TargetKind: targetKind,
+		TargetName: targetName,
+		Pod:        podName,
+		Port:       podPort,
+		ClientIP:   utils.GetClientIP(c.Request),
+		Status:     dal.ExecSessionStatusActive,
+		StartedAt:  startedAt,
+		ExpiresAt:  expiresAt,
+	}
+	if err := sessionRepo.Create(record); err != nil {
+		logs.Error(logData, "保存端口转发记录失败: "+err.Error())
+	}
+	logs.Info(logData, "端口转发开始")
+
+	ctx, cancel := context.WithDeadline(c.Request.Context(), expiresAt)
+	defer cancel()
+
+	var mu sync.Mutex
+	var killedBy string
+	h.registry.Add(&terminal.LiveSession{
+		SessionID:  sessionID,
+		UserID:     record.UserID,
+		Username:   record.Username,
+		InstanceID: record.InstanceID,
+		Namespace:  namespace,
+		Pod:        podName,
+		Target:     targetKind + "/" + targetName,
+		Port:       podPort,
+		ClientIP:   record.ClientIP,
+		StartedAt:  startedAt,
+		ExpiresAt:  &expiresAt,
+	}, func(operator string) {
+		mu.Lock()
+		killedBy = operator
+		mu.Unlock()
+		cancel()
+	})
+	defer h.registry.Remove(sessionID)
+
+	var bytesIn, bytesOut int64
+	relayPortForward(ctx, cancel, conn, errorStream, dataStream, &bytesIn, &bytesOut)
+
+	mu.Lock()
+	status := dal.ExecSessionStatusClosed
+	switch {
+	case killedBy != "":
+		status = dal.ExecSessionStatusKilled
+	case ctx.Err() == context.DeadlineExceeded:
+		status = dal.ExecSessionStatusExpired
+	}
+	mu.Unlock()
+
+	if err := sessionRepo.Finish(sessionID, status, killedBy, atomic.LoadInt64(&bytesIn), atomic.LoadInt64(&bytesOut)); err != nil {
+		logs.Error(logData, "更新端口转发记录失败: "+err.Error())
+	}
+	logData["status"] = status
+	logData["bytesIn"] = atomic.LoadInt64(&bytesIn)
+	logData["bytesOut"] = atomic.LoadInt64(&bytesOut)
+	logs.Info(logData, "端口转发结束")
+}
+
+// createForwardStreams 按端口转发协议创建错误流和数据流
+func createForwardStreams(streamConn httpstream.Connection, port int32) (httpstream.Stream, httpstream.Stream, error) {
+	headers := http.Header{}
+	headers.Set(corev1.StreamType, corev1.StreamTypeError)
+	headers.Set(corev1.PortHeader, strconv.Itoa(int(port)))
+	headers.Set(corev1.PortForwardRequestIDHeader, "0")
+	errorStream, err := streamConn.CreateStream(headers)
+	if err != nil {
+		return nil, nil, fmt.Errorf("创建错误流失败: %v", err)
+	}
+	// 错误流只读
+	_ = errorStream.Close()
+
+	headers.Set(corev1.StreamType, corev1.StreamTypeData)
+	dataStream, err := streamConn.CreateStream(headers)
+	if err != nil {
+		return nil, nil, fmt.Errorf("创建数据流失败: %v", err)
+	}
+	return errorStream, dataStream, nil
+}
+
+// relayPortForward 在WebSocket与Pod端口之间双向转发数据，任一方向结束或ctx取消时返回
+func relayPortForward(ctx context.Context, cancel context.CancelFunc, conn *websocket.Conn,
+	errorStream, dataStream httpstream.Stream, bytesIn, bytesOut *int64) {
+	var writeMu sync.Mutex
+	writeMessage := func(messageType int, data []byte) error {
+		writeMu.Lock()
+		defer writeMu.Unlock()
+		return conn.WriteMessage(messageType, data)
+	}
+
+	// Pod返回的错误
+	go func() {
+		message, err := io.ReadAll(errorStream)
+		if err == nil && len(message) > 0 {
+			_ = writeMessage(websocket.TextMessage, []byte("error: "+string(message)))
+			cancel()
+		}
+	}()
+
+	// 客户端 -> Pod
+	go func() {
+		defer cancel()
+		for {
+			messageType, data, err := conn.ReadMessage()
+			if err != nil {
+				return
+			}
+			if messageType != websocket.BinaryMessage && messageType != websocket.TextMessage {
+				continue
+			}
+			if _, err := dataStream.Write(data); err != nil {
+				return
+			}
+			atomic.AddInt64(bytesIn, int64(len(data)))
+		}
+	}()
+
+	// Pod -> 客户端
+	go func() {
+		defer cancel()
+		buf := make([]byte, 32*1024)
+		for {
+			n, err := dataStream.Read(buf)
+			if n > 0 {
+				if werr := writeMessage(websocket.BinaryMessage, buf[:n]); werr != nil {
+					return
+				}
+				atomic.AddInt64(bytesOut, int64(n))
+			}
+			if err != nil {
+				return
+			}
+		}
+	}()
+
+	<-ctx.Done()
+	if ctx.Err() == context.DeadlineExceeded {
+		_ = writeMessage(websocket.TextMessage, []byte("error: 端口转发已超过有效期"))
+	}
+	_ = dataStream.Close()
+	writeMu.Lock()
+	_ = conn.WriteControl(websocket.CloseMessage,
+		websocket.FormatCloseMessage(websocket.CloseNormalClosure, ""), time.Now().Add(time.Second))
+	writeMu.Unlock()
+}
+
+// resolveServiceTarget 将Service端口解析为一个就绪Pod及其容器端口
+func resolveServiceTarget(ctx context.Context, client *kubernetes.Clientset, namespace, name string, port int32) (string, int32, error) {
+	svc, err := client.CoreV1().Services(namespace).Get(ctx, name, metav1.GetOptions{})
+	if err != nil {
+		return "", 0, fmt.Errorf("获取Service失败: %v", err)
+	}
+	if len(svc.Spec.Selector) == 0 {
+		return "", 0, fmt.Errorf("Service %s 没有选择器，无法转发", name)
+	}
+
+	var servicePort *corev1.ServicePort
+	for i := range svc.Spec.Ports {
+		if svc.Spec.Ports[i].Port == port {
+			servicePort = &svc.Spec.Ports[i]
+			break
+		}
+	}
+	if servicePort == nil {
+		return "", 0, fmt.Errorf("Service %s 没有端口 %d", name, port)
+	}
+
+	pods, err := client.CoreV1().Pods(namespace).List(ctx, metav1.ListOptions{
+		LabelSelector: labels.SelectorFromSet(svc.Spec.Selector).String(),
+	})
+	if err != nil {
+		return "", 0, fmt.Errorf("获取Service的Pod失败: %v", err)
+	}
+	for i := range pods.Items {
+		pod := &pods.Items[i]
+		if pod.Status.Phase != corev1.PodRunning || pod.DeletionTimestamp != nil || !isPodReady(pod) {
+			continue
+		}
+		targetPort := servicePort.TargetPort
+		if targetPort.IntValue() > 0 {
+			return pod.Name, int32(targetPort.IntValue()), nil
+		}
+		if targetPort.StrVal == "" {
+			return pod.Name, servicePort.Port, nil
+		}
+		// 命名端口需要在容器端口中查找
+		for _, container := range pod.Spec.Containers {
+			for _, containerPort := range container.Ports {
+				if containerPort.Name == targetPort.StrVal {
+					return pod.Name, containerPort.ContainerPort, nil
+				}
+			}
+		}
+	}
+	return "", 0, fmt.Errorf("Service %s 没有可用的就绪Pod", name)
+}
+
+// isPodReady 判断Pod是否就绪
+func isPodReady(pod *corev1.Pod) bool {
+	for _, condition := range pod.Status.Conditions {
+		if condition.Type == corev1.PodReady {
+			return condition.Status == corev1.ConditionTrue
+		}
+	}
+	return false
+}
@@ -0,0 +1,271 @@
+package websocket
+
+import (
+	"context"
+	"devops-console-backend/internal/dal"
+	"devops-console-backend/pkg/configs"
+	"devops-console-backend/pkg/utils"
+	"devops-console-backend/pkg/utils/logs"
+	"net/http"
+	"sync"
+	"time"
+
+	"github.com/gin-gonic/gin"
+	"github.com/gorilla/websocket"
+	apierrors "k8s.io/apimachinery/pkg/api/errors"
+	metav1 "k8s.io/apimachinery/pkg/apis/meta/v1"
+	"k8s.io/apimachinery/pkg/apis/meta/v1/unstructured"
+	"k8s.io/apimachinery/pkg/labels"
+	"k8s.io/apimachinery/pkg/runtime/schema"
+	"k8s.io/apimachinery/pkg/watch"
+)
+
+// watchPingInterval 资源监听连接的心跳间隔
+const watchPingInterval = 30 * time.Second
+
+// watchResource 支持监听的资源类型
+type watchResource struct {
+	gvr        schema.GroupVersionResource
+	namespaced bool
+}
+
+// watchResources 资源类型名称到GVR的映射，Secret不在其中以免推送明文数据
+var watchResources = map[string]watchResource{
+	"pods":                   {schema.GroupVersionResource{Version: "v1", Resource: "pods"}, true},
+	"services":               {schema.GroupVersionResource{Version: "v1", Resource: "services"}, true},
+	"configmaps":             {schema.GroupVersionResource{Version: "v1", Resource: "configmaps"}, true},
+	"events":                 {schema.GroupVersionResource{Version: "v1", Resource: "events"}, true},
+	"persistentvolumeclaims": {schema.GroupVersionResource{Version: "v1", Resource: "persistentvolumeclaims"}, true},
+	"persistentvolumes":      {schema.GroupVersionResource{Version: "v1", Resource: "persistentvolumes"}, false},
+	"nodes":                  {schema.GroupVersionResource{Version: "v1", Resource: "nodes"}, false},
+	"namespaces":             {schema.GroupVersionResource{Version: "v1", Resource: "namespaces"}, false},
+	"deployments":            {schema.GroupVersionResource{Group: "apps", Version: "v1", Resource: "deployments"}, true},
+	"statefulsets":           {schema.GroupVersionResource{Group: "apps", Version: "v1", Resource: "statefulsets"}, true},
+	"daemonsets":             {schema.GroupVersionResource{Group: "apps", Version: "v1", Resource: "daemonsets"}, true},
+	"replicasets":            {schema.GroupVersionResource{Group: "apps", Version: "v1", Resource: "replicasets"}, true},
+	"jobs":                   {schema.GroupVersionResource{Group: "batch", Version: "v1", Resource: "jobs"}, true},
+	"cronjobs":               {schema.GroupVersionResource{Group: "batch", Version: "v1", Resource: "cronjobs"}, true},
+	"ingresses":              {schema.GroupVersionResource{Group: "networking.k8s.io", Version: "v1", Resource: "ingresses"}, true},
+}
+
+// ResourceWatchHandler 资源变更监听WebSocket处理器
+type ResourceWatchHandler struct {
+	mu    sync.Mutex
+	conns map[int64]int // 用户ID -> 当前连接数
+}
+
+// NewResourceWatchHandler 创建资源变更监听处理器
+func NewResourceWatchHandler() *ResourceWatchHandler {
+	return &ResourceWatchHandler{
+		conns: make(map[int64]int),
+	}
+}
+
+// WatchMessage 推送给客户端的资源变更消息
+type WatchMessage struct {
+	Type            string      `json:"type"` // ADDED, MODIFIED, DELETED, BOOKMARK, EXPIRED, error
+	ResourceVersion string      `json:"resourceVersion,omitempty"`
+	Object          interface{} `json:"object,omitempty"`
+	Error           string      `json:"error,omitempty"`
+	Time            int64       `json:"time"`
+}
+
+// acquire 占用一个用户连接名额
+func (h *ResourceWatchHandler) acquire(userID int64) bool {
+	limit := configs.GetWebSocketConfig().MaxWatchPerUser
+	h.mu.Lock()
+	defer h.mu.Unlock()
+	if limit > 0 && h.conns[userID] >= limit {
+		return false
+	}
+	h.conns[userID]++
+	return true
+}
+
+// release 释放用户连接名额
+func (h *ResourceWatchHandler) release(userID int64) {
+	h.mu.Lock()
+	defer h.mu.Unlock()
+	h.conns[userID]--
+	if h.conns[userID] <= 0 {
+		delete(h.conns, userID)
+	}
+}
+
+// HandleWebSocket 处理资源监听连接
+// 参数: resource 资源类型, namespace 命名空间(all表示全部), labelSelector 标签选择器,
+// resourceVersion 续传的资源版本，为空时先推送全部现有对象
+func (h *ResourceWatchHandler) HandleWebSocket(c *gin.Context) {
+	claims, err := parseClaims(c)
+	if err != nil {
+		c.JSON(http.StatusUnauthorized, gin.H{"error": "认证失败"})
+		return
+	}
+
+	resourceType := c.Param("resource")
+	resource, ok := watchResources[resourceType]
+	if !ok {
+		c.JSON(http.StatusBadRequest, gin.H{"error": "不支持监听的资源类型: " + resourceType})
+		return
+	}
+
+	labelSelector := c.Query("labelSelector")
+	if _, err := labels.Parse(labelSelector); err != nil {
+		c.JSON(http.StatusBadRequest, gin.H{"error": "标签选择器格式错误: " + err.Error()})
+		return
+	}
+
+	namespace := c.Query("namespace")
+	if namespace == "all" || !resource.namespaced {
+		namespace = ""
+	}
+
+	instanceID := utils.GetInstanceIDFromContext(c)
+
+	// 监听全部命名空间或集群级资源需要管理员，其余需要命名空间的watch授权
+	if namespace == "" {
+		if !configs.IsAdminUser(claims.GetUserName(), claims.GetRoles()) {
+			c.JSON(http.StatusForbidden, gin.H{"error": "只有管理员可以监听全部命名空间或集群级资源"})
+			return
+		}
+	} else {
+		allowed, err := configs.HasNamespacePermission(claims.GetUserId(), claims.GetUserName(), claims.GetRoles(),
+			instanceID, namespace, dal.K8sActionWatch)
+		if err != nil {
+			c.JSON(http.StatusInternalServerError, gin.H{"error": "校验权限失败"})
+			return
+		}
+		if !allowed {
+			c.JSON(http.StatusForbidden, gin.H{"error": "没有该命名空间的监听权限"})
+			return
+		}
+	}
+
+	dynamicClient, exists := configs.GetDynamicClient(instanceID)
+	if !exists {
+		c.JSON(http.StatusInternalServerError, gin.H{"error": "K8s客户端未初始化"})
+		return
+	}
+
+	userID := claims.GetUserId()
+	if !h.acquire(userID) {
+		c.JSON(http.StatusTooManyRequests, gin.H{"error": "监听连接数已达上限"})
+		return
+	}
+	defer h.release(userID)
+
+	conn, err := newUpgrader().Upgrade(c.Writer, c.Request, nil)
+	if err != nil {
+		return
+	}
+	defer func() { _ = conn.Close() }()
+
+	logData := map[string]interface{}{
+		"user":          claims.GetUserName(),
+		"instanceId":    instanceID,
+		"resource":      resourceType,
+		"namespace":     namespace,
+		"labelSelector": labelSelector,
+	}
+	logs.Debug(logData, "开始监听资源变更")
+
+	// 客户端断开时取消监听
+	ctx, cancel := context.WithCancel(c.Request.Context())
+	defer cancel()
+	go func() {
+		defer cancel()
+		for {
+			if _, _, err := conn.ReadMessage(); err != nil {
+				return
+			}
+		}
+	}()
+
+	ping := time.NewTicker(watchPingInterval)
+	defer ping.Stop()
+
+	resourceVersion := c.Query("resourceVersion")
+	for {
+		watcher, err := dynamicClient.Resource(resource.gvr).Namespace(namespace).Watch(ctx, metav1.ListOptions{
+			LabelSelector:       labelSelector,
+			ResourceVersion:     resourceVersion,
+			AllowWatchBookmarks: true,
+		})
+		if err != nil {
+			if ctx.Err() != nil {
+				return
+			}
+			if apierrors.IsResourceExpired(err) || apierrors.IsGone(err) {
+				sendWatchMessage(conn, WatchMessage{Type: "EXPIRED", ResourceVersion: resourceVersion})
+				return
+			}
+			sendWatchMessage(conn, WatchMessage{Type: "error", Error: "监听资源失败: " + err.Error()})
+			return
+		}
+
+		var done bool
+		resourceVersion, done = h.stream(ctx, conn, watcher, ping, resourceVersion)
+		watcher.Stop()
+		if done {
+			logs.Debug(logData, "资源监听结束")
+			return
+		}
+		// 服务端超时关闭了监听，从最后的资源版本继续
+	}
+}
+
+// stream 转发一次Watch的事件，返回最新的资源版本以及连接是否应当结束
+func (h *ResourceWatchHandler) stream(ctx context.Context, conn *websocket.Conn, watcher watch.Interface, ping *time.Ticker, resourceVersion string) (string, bool) {
+	for {
+		select {
+		case <-ctx.Done():
+			return resourceVersion, true
+		case <-ping.C:
+			if err := conn.WriteControl(websocket.PingMessage, nil, time.Now().Add(10*time.Second)); err != nil {
+				return resourceVersion, true
+			}
+		case event, ok := <-watcher.ResultChan():
+			if !ok {
+				return resourceVersion, false
+			}
+
+			switch event.Type {
+			case watch.Error:
+				status := apierrors.FromObject(event.Object)
+				if apierrors.IsResourceExpired(status) || apierrors.IsGone(status) {
+					sendWatchMessage(conn, WatchMessage{Type: "EXPIRED", ResourceVersion: resourceVersion})
+				} else {
+					sendWatchMessage(conn, WatchMessage{Type: "error", Error: status.Error()})
+				}
+				return resourceVersion, true
+			case watch.Bookmark:
+				if obj, ok := event.Object.(*unstructured.Unstructured); ok {
+					resourceVersion = obj.GetResourceVersion()
+				}
+				if err := sendWatchMessage(conn, WatchMessage{Type: string(event.Type), ResourceVersion: resourceVersion}); err != nil {
+					return resourceVersion, true
+				}
+			default:
+				obj, ok := event.Object.(*unstructured.Unstructured)
+				if !ok {
+					continue
+				}
+				resourceVersion = obj.GetResourceVersion()
+				err := sendWatchMessage(conn, WatchMessage{
+					Type:            string(event.Type),
+					ResourceVersion: resourceVersion,
+					Object:          obj.Object,
+				})
+				if err != nil {
+					return resourceVersion, true
+				}
+			}
+		}
+	}
+}
+
+// sendWatchMessage 发送资源变更消息
+func sendWatchMessage(conn *websocket.Conn, msg WatchMessage) error {
+	msg.Time = time.Now().Unix()
+	return conn.WriteJSON(msg)
+}
@@ -11,6 +11,15 @@ func RegisterWebSocketRoutes(r *gin.Engine) {
 	// Pod日志WebSocket
 	ws.GET("/pod/:podname/logs", NewPodLogHandler().HandleWebSocket)
 
+	// 多Pod聚合日志WebSocket
+	ws.GET("/logs/aggregate", NewAggregateLogHandler().HandleWebSocket)
+
 	// Pod终端WebSocket
 	ws.GET("/pod/:podname/exec", NewPodExecHandler().HandleWebSocket)
+
+	// 端口转发WebSocket
+	ws.GET("/portforward", NewPortForwardHandler().HandleWebSocket)
+
+	// 资源变更监听WebSocket
+	ws.GET("/watch/:resource", NewResourceWatchHandler().HandleWebSocket)
 }
@@ -65,9 +65,63 @@ type ElasticsearchConfig struct {
 
 // Kubernetes配置
 type KubernetesConfig struct {
-	ConfigPath string `mapstructure:"config_path" yaml:"config_path"`
-	Timeout    int    `mapstructure:"timeout" yaml:"timeout"`
-	Retry      int    `mapstructure:"retry" yaml:"retry"`
+	ConfigPath   string             `mapstructure:"config_path" yaml:"config_path"`
+	Timeout      int                `mapstructure:"timeout" yaml:"timeout"`
+	Retry        int                `mapstructure:"retry" yaml:"retry"`
+	EventArchive EventArchiveConfig `mapstructure:"event_archive" yaml:"event_archive"`
+	LogSearch    LogSearchConfig    `mapstructure:"log_search" yaml:"log_search"`
+	FileTransfer FileTransferConfig `mapstructure:"file_transfer" yaml:"file_transfer"`
+	PortForward  PortForwardConfig  `mapstructure:"port_forward" yaml:"port_forward"`
+	Debug        DebugConfig        `mapstructure:"debug" yaml:"debug"`
+	Quota        QuotaConfig        `mapstructure:"quota" yaml:"quota"`
+	Scan         ScanConfig         `mapstructure:"scan" yaml:"scan"`
+}
+
+// 最佳实践与安全扫描配置
+type ScanConfig struct {
+	Enabled           bool     `mapstructure:"enabled" yaml:"enabled"`
+	Interval          int      `mapstructure:"interval" yaml:"interval"`                     // 定时扫描间隔（小时）
+	RetentionDays     int      `mapstructure:"retention_days" yaml:"retention_days"`         // 扫描结果保留天数
+	ExcludeNamespaces []string `mapstructure:"exclude_namespaces" yaml:"exclude_namespaces"` // 不参与扫描的命名空间
+}
+
+// 资源配额配置
+type QuotaConfig struct {
+	WarningThreshold float64 `mapstructure:"warning_threshold" yaml:"warning_threshold"` // 配额使用率告警阈值（百分比）
+}
+
+// 临时调试容器配置
+type DebugConfig struct {
+	DefaultImage  string   `mapstructure:"default_image" yaml:"default_image"`   // 默认调试镜像
+	AllowedImages []string `mapstructure:"allowed_images" yaml:"allowed_images"` // 允许使用的调试镜像，为空时不限制
+}
+
+// 端口转发配置
+type PortForwardConfig struct {
+	DefaultTTL int `mapstructure:"default_ttl" yaml:"default_ttl"` // 默认有效期（分钟）
+	MaxTTL     int `mapstructure:"max_ttl" yaml:"max_ttl"`         // 最长有效期（分钟）
+}
+
+// 容器文件传输配置
+type FileTransferConfig struct {
+	MaxUploadSize   int64 `mapstructure:"max_upload_size" yaml:"max_upload_size"`     // 上传文件大小上限（MB）
+	MaxDownloadSize int64 `mapstructure:"max_download_size" yaml:"max_download_size"` // 下载大小上限（MB）
+}
+
+// 集群日志检索配置，字段名对应日志采集器写入ES的字段
+type LogSearchConfig struct {
+	Index          string `mapstructure:"index" yaml:"index"`
+	NamespaceField string `mapstructure:"namespace_field" yaml:"namespace_field"`
+	PodField       string `mapstructure:"pod_field" yaml:"pod_field"`
+	ContainerField string `mapstructure:"container_field" yaml:"container_field"`
+	MessageField   string `mapstructure:"message_field" yaml:"message_field"`
+	TimestampField string `mapstructure:"timestamp_field" yaml:"timestamp_field"`
+}
+
+// K8s事件归档配置
+type EventArchiveConfig struct {
+	Enabled       bool `mapstructure:"enabled" yaml:"enabled"`
+	RetentionDays int  `mapstructure:"retention_days" yaml:"retention_days"` // 事件保留天数
 }
 
 // Swagger配置
@@ -84,6 +138,26 @@ type HealthConfig struct {
 	Interval int    `mapstructure:"interval" yaml:"interval"`
 }
 
+// 告警配置
+type AlertConfig struct {
+	Enabled          bool `mapstructure:"enabled" yaml:"enabled"`
+	EvaluateInterval int  `mapstructure:"evaluate_interval" yaml:"evaluate_interval"` // 规则评估间隔（秒）
+	NotifyTimeout    int  `mapstructure:"notify_timeout" yaml:"notify_timeout"`       // 通知发送超时（秒）
+}
+
+// WebSocket配置
+type WebSocketConfig struct {
+	MaxWatchPerUser int      `mapstructure:"max_watch_per_user" yaml:"max_watch_per_user"` // 每个用户同时打开的资源监听连接数上限
+	AllowedOrigins  []string `mapstructure:"allowed_origins" yaml:"allowed_origins"`       // 允许的来源，为空时只允许同源，*表示全部
+	RecordDir       string   `mapstructure:"record_dir" yaml:"record_dir"`                 // 终端会话录像存储目录
+}
+
+// 安全配置
+type SecurityConfig struct {
+	AdminUsers    []string `mapstructure:"admin_users" yaml:"admin_users"`       // 管理员用户名
+	EncryptionKey string   `mapstructure:"encryption_key" yaml:"encryption_key"` // 敏感数据加密密钥，如Secret历史版本、告警渠道密码和密钥，未配置时不保存Secret历史版本
+}
+
 // 应用配置
 type AppConfig struct {
 	Server        ServerConfig        `mapstructure:"server" yaml:"server"`
@@ -93,6 +167,9 @@ type AppConfig struct {
 	Kubernetes    KubernetesConfig    `mapstructure:"kubernetes" yaml:"kubernetes"`
 	Swagger       SwaggerConfig       `mapstructure:"swagger" yaml:"swagger"`
 	Health        HealthConfig        `mapstructure:"health" yaml:"health"`
+	Alert         AlertConfig         `mapstructure:"alert" yaml:"alert"`
+	WebSocket     WebSocketConfig     `mapstructure:"websocket" yaml:"websocket"`
+	Security      SecurityConfig      `mapstructure:"security" yaml:"security"`
 }
 
 // initLogConfig 初始化日志配置
@@ -164,6 +241,44 @@ func GetHealthConfig() HealthConfig {
 	return Config.Health
 }
 
+// GetAlertConfig 获取告警配置
+func GetAlertConfig() AlertConfig {
+	return Config.Alert
+}
+
+// GetWebSocketConfig 获取WebSocket配置
+func GetWebSocketConfig() WebSocketConfig {
+	return Config.WebSocket
+}
+
+// IsAdminUser 判断用户是否为管理员，拥有admin角色或在管理员列表中
+func IsAdminUser(username string, roles []string) bool {
+	for _, role := range roles {
+		if role == "admin" {
+			return true
+		}
+	}
+	for _, admin := range Config.Security.AdminUsers {
+		if admin == username {
+			return true
+		}
+	}
+	return false
+}
+
+// HasNamespacePermission 判断用户能否在命名空间执行操作，管理员不受限制
+func HasNamespacePermission(userID int64, username string, roles []string, instanceID uint, namespace, action string) (bool, error) {
+	if IsAdminUser(username, roles) {
+		return true, nil
+	}
+	return NewK8sNamespacePermissionRepository().HasPermission(userID, instanceID, namespace, action)
+}
+
+// GetSecurityConfig 获取安全配置
+func GetSecurityConfig() SecurityConfig {
+	return Config.Security
+}
+
 // IsDebugMode 判断是否为调试模式
 func IsDebugMode() bool {
 	return strings.ToLower(Config.Server.LogLevel) == "debug"
@@ -263,6 +378,32 @@ func setDefaults() {
 	viper.SetDefault("database.mysql.parse_time", true)
 	viper.SetDefault("database.mysql.max_open_conns", 10)
 	viper.SetDefault("database.mysql.max_idle_conns", 5)
+
+	viper.SetDefault("kubernetes.event_archive.enabled", true)
+	viper.SetDefault("kubernetes.event_archive.retention_days", 7)
+	viper.SetDefault("kubernetes.log_search.index", "filebeat-*")
+	viper.SetDefault("kubernetes.log_search.namespace_field", "kubernetes.namespace")
+	viper.SetDefault("kubernetes.log_search.pod_field", "kubernetes.pod.name")
+	viper.SetDefault("kubernetes.log_search.container_field", "kubernetes.container.name")
+	viper.SetDefault("kubernetes.log_search.message_field", "message")
+	viper.SetDefault("kubernetes.log_search.timestamp_field", "@timestamp")
+	viper.SetDefault("kubernetes.file_transfer.max_upload_size", 100)
+	viper.SetDefault("kubernetes.file_transfer.max_download_size", 1024)
+	viper.SetDefault("kubernetes.port_forward.default_ttl", 30)
+	viper.SetDefault("kubernetes.port_forward.max_ttl", 240)
+	viper.SetDefault("kubernetes.debug.default_image", "busybox:1.36")
+	viper.SetDefault("kubernetes.quota.warning_threshold", 80)
+	viper.SetDefault("kubernetes.scan.enabled", true)
+	viper.SetDefault("kubernetes.scan.interval", 24)
+	viper.SetDefault("kubernetes.scan.retention_days", 90)
+	viper.SetDefault("kubernetes.scan.exclude_namespaces", []string{"kube-system", "kube-public", "kube-node-lease"})
+
+	viper.SetDefault("alert.enabled", true)
+	viper.SetDefault("alert.evaluate_interval", 60)
+	viper.SetDefault("alert.notify_timeout", 10)
+
+	viper.SetDefault("websocket.max_watch_per_user", 10)
+	viper.SetDefault("websocket.record_dir", "./data/recordings")
 }
 
 // Initialize 初始化应用配置
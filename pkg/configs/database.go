@@ -1,6 +1,8 @@
 package configs
 
 import (
+	"devops-console-backend/internal/dal"
+	"devops-console-backend/pkg/utils/logs"
 	"fmt"
 	"time"
 
@@ -44,3 +46,33 @@ func CloseDB() {
 		return
 	}
 }
+
+// AutoMigrate 根据配置自动迁移数据库模型
+func AutoMigrate() {
+	if !Config.Database.AutoMigrate || GORMDB == nil {
+		return
+	}
+	err := GORMDB.AutoMigrate(
+		&dal.AlertRule{},
+		&dal.AlertChannel{},
+		&dal.AlertSilence{},
+		&dal.AlertEvent{},
+		&dal.K8sEvent{},
+		&dal.K8sExecSession{},
+		&dal.K8sNamespacePermission{},
+		&dal.K8sPortForwardSession{},
+		&dal.K8sNamespaceTemplate{},
+		&dal.K8sSecretAccessLog{},
+		&dal.K8sConfigRevision{},
+		&dal.K8sScanRun{},
+		&dal.K8sScanNamespaceScore{},
+		&dal.K8sScanFinding{},
+	)
+	if err != nil {
+		logs.Error(map[string]interface{}{
+			"error": err.Error(),
+		}, "数据库模型迁移失败")
+		return
+	}
+	logs.Info(nil, "数据库模型迁移完成")
+}
@@ -303,6 +303,18 @@ func GetEsClient(instanceID uint) (*elasticsearch.Client, bool) {
 	return client, true
 }
 
+// GetEsInstanceIDs 获取所有已初始化Elasticsearch客户端的实例ID
+func GetEsInstanceIDs() []uint {
+	esClientsMutex.RLock()
+	defer esClientsMutex.RUnlock()
+
+	ids := make([]uint, 0, len(EsClients))
+	for id := range EsClients {
+		ids = append(ids, id)
+	}
+	return ids
+}
+
 // CloseEsClient 封装内部
 func CloseEsClient(client *elasticsearch.Client) {
 	closeEsClient(client)
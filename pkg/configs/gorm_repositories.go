@@ -7,6 +7,7 @@ import (
 	"time"
 
 	"gorm.io/gorm"
+	"gorm.io/gorm/clause"
 )
 
 // InstanceTypeRepository 实例类型GORM操作
@@ -569,3 +570,510 @@ func (r *AccountRepository) Update(account *dal.Account) error {
 func (r *AccountRepository) Delete(id uint) error {
 	return GORMDB.Delete(&dal.Account{}, id).Error
 }
+
+// K8sEventRepository K8s事件归档GORM操作
+type K8sEventRepository struct{}
+
+// NewK8sEventRepository 创建K8s事件归档GORM操作实例
+func NewK8sEventRepository() *K8sEventRepository {
+	return &K8sEventRepository{}
+}
+
+// Upsert 按实例ID和事件UID写入事件，已存在时更新次数、时间和消息
+func (r *K8sEventRepository) Upsert(event *dal.K8sEvent) error {
+	return GORMDB.Clauses(clause.OnConflict{
+		Columns: []clause.Column{{Name: "instance_id"}, {Name: "uid"}},
+		DoUpdates: clause.AssignmentColumns([]string{
+			"type", "reason", "message", "count", "last_timestamp", "resource_version", "updated_at",
+		}),
+	}).Create(event).Error
+}
+
+// filterQuery 根据过滤条件构建查询
+func (r *K8sEventRepository) filterQuery(filter *dal.K8sEventFilter) *gorm.DB {
+	query := GORMDB.Model(&dal.K8sEvent{}).Where("instance_id = ?", filter.InstanceID)
+	if filter.Namespace != "" {
+		query = query.Where("namespace = ?", filter.Namespace)
+	}
+	if filter.InvolvedKind != "" {
+		query = query.Where("involved_kind = ?", filter.InvolvedKind)
+	}
+	if filter.InvolvedName != "" {
+		query = query.Where("involved_name = ?", filter.InvolvedName)
+	}
+	if filter.Reason != "" {
+		query = query.Where("reason = ?", filter.Reason)
+	}
+	if filter.Type != "" {
+		query = query.Where("type = ?", filter.Type)
+	}
+	if filter.StartTime != nil {
+		query = query.Where("last_timestamp >= ?", *filter.StartTime)
+	}
+	if filter.EndTime != nil {
+		query = query.Where("last_timestamp <= ?", *filter.EndTime)
+	}
+	return query
+}
+
+// GetWithPagination 分页查询归档事件
+func (r *K8sEventRepository) GetWithPagination(filter *dal.K8sEventFilter, offset, limit int) ([]dal.K8sEvent, int64, error) {
+	var events []dal.K8sEvent
+	var total int64
+
+	query := r.filterQuery(filter)
+	if err := query.Count(&total).Error; err != nil {
+		return nil, 0, err
+	}
+	err := query.Order("last_timestamp DESC").Offset(offset).Limit(limit).Find(&events).Error
+	return events, total, err
+}
+
+// GetWarningSummary 按原因聚合Warning事件，ownedUIDs不为空时同时统计这些对象（如Deployment下属的ReplicaSet和Pod）的事件
+func (r *K8sEventRepository) GetWarningSummary(instanceID uint, namespace, kind, name string, ownedUIDs []string, since time.Time) ([]dal.K8sEventWarningSummary, error) {
+	baseQuery := func() *gorm.DB {
+		query := GORMDB.Model(&dal.K8sEvent{}).
+			Where("instance_id = ? AND namespace = ? AND type = ? AND last_timestamp >= ?", instanceID, namespace, "Warning", since)
+		if len(ownedUIDs) > 0 {
+			return query.Where("((involved_kind = ? AND involved_name = ?) OR involved_uid IN ?)", kind, name, ownedUIDs)
+		}
+		return query.Where("involved_kind = ? AND involved_name = ?", kind, name)
+	}
+
+	var summaries []dal.K8sEventWarningSummary
+	err := baseQuery().Select("reason, SUM(count) AS count, COUNT(DISTINCT involved_name) AS objects, MAX(last_timestamp) AS last_timestamp").
+		Group("reason").Order("last_timestamp DESC").Scan(&summaries).Error
+	if err != nil {
+		return nil, err
+	}
+
+	// 补充每个原因最近一次的事件消息
+	for i := range summaries {
+		var latest dal.K8sEvent
+		err := baseQuery().Where("reason = ?", summaries[i].Reason).
+			Order("last_timestamp DESC").Limit(1).Find(&latest).Error
+		if err == nil {
+			summaries[i].LastMessage = latest.Message
+		}
+	}
+	return summaries, nil
+}
+
+// DeleteBefore 删除指定时间之前的归档事件
+func (r *K8sEventRepository) DeleteBefore(t time.Time) (int64, error) {
+	result := GORMDB.Where("last_timestamp < ?", t).Delete(&dal.K8sEvent{})
+	return result.RowsAffected, result.Error
+}
+
+// K8sExecSessionRepository 容器终端会话GORM操作
+type K8sExecSessionRepository struct{}
+
+// NewK8sExecSessionRepository 创建容器终端会话GORM操作实例
+func NewK8sExecSessionRepository() *K8sExecSessionRepository {
+	return &K8sExecSessionRepository{}
+}
+
+// Create 创建会话记录
+func (r *K8sExecSessionRepository) Create(session *dal.K8sExecSession) error {
+	return GORMDB.Create(session).Error
+}
+
+// Finish 结束会话
+func (r *K8sExecSessionRepository) Finish(sessionID, status, killedBy string) error {
+	return GORMDB.Model(&dal.K8sExecSession{}).
+		Where("session_id = ? AND status = ?", sessionID, dal.ExecSessionStatusActive).
+		Updates(map[string]interface{}{
+			"status":    status,
+			"killed_by": killedBy,
+			"ended_at":  time.Now(),
+		}).Error
+}
+
+// GetBySessionID 根据会话ID获取会话记录
+func (r *K8sExecSessionRepository) GetBySessionID(sessionID string) (*dal.K8sExecSession, error) {
+	var session dal.K8sExecSession
+	err := GORMDB.Where("session_id = ?", sessionID).First(&session).Error
+	if err != nil {
+		return nil, err
+	}
+	return &session, nil
+}
+
+// GetWithPagination 分页获取会话记录，userID为0时返回全部用户的会话
+func (r *K8sExecSessionRepository) GetWithPagination(userID int64, offset, limit int) ([]dal.K8sExecSession, int64, error) {
+	var sessions []dal.K8sExecSession
+	var total int64
+
+	query := GORMDB.Model(&dal.K8sExecSession{})
+	if userID > 0 {
+		query = query.Where("user_id = ?", userID)
+	}
+	if err := query.Count(&total).Error; err != nil {
+		return nil, 0, err
+	}
+	err := query.Order("started_at DESC").Offset(offset).Limit(limit).Find(&sessions).Error
+	return sessions, total, err
+}
+
+// MarkInterrupted 将遗留的进行中会话标记为结束，用于服务重启后修正状态
+func (r *K8sExecSessionRepository) MarkInterrupted() error {
+	return GORMDB.Model(&dal.K8sExecSession{}).
+		Where("status = ?", dal.ExecSessionStatusActive).
+		Updates(map[string]interface{}{
+			"status":   dal.ExecSessionStatusClosed,
+			"ended_at": time.Now(),
+		}).Error
+}
+
+// K8sNamespacePermissionRepository 命名空间操作授权GORM操作
+type K8sNamespacePermissionRepository struct{}
+
+// NewK8sNamespacePermissionRepository 创建命名空间操作授权GORM操作实例
+func NewK8sNamespacePermissionRepository() *K8sNamespacePermissionRepository {
+	return &K8sNamespacePermissionRepository{}
+}
+
+// HasPermission 判断用户在指定命名空间是否有操作权限
+func (r *K8sNamespacePermissionRepository) HasPermission(userID int64, instanceID uint, namespace, action string) (bool, error) {
+	var count int64
+	err := GORMDB.Model(&dal.K8sNamespacePermission{}).
+		Where("user_id = ? AND instance_id = ? AND action = ? AND namespace IN ?", userID, instanceID, action, []string{namespace, "*"}).
+		Count(&count).Error
+	return count > 0, err
+}
+
+// List 获取授权列表，userID为0时返回全部
+func (r *K8sNamespacePermissionRepository) List(userID int64) ([]dal.K8sNamespacePermission, error) {
+	var permissions []dal.K8sNamespacePermission
+	query := GORMDB.Order("id DESC")
+	if userID > 0 {
+		query = query.Where("user_id = ?", userID)
+	}
+	err := query.Find(&permissions).Error
+	return permissions, err
+}
+
+// Create 创建授权
+func (r *K8sNamespacePermissionRepository) Create(permission *dal.K8sNamespacePermission) error {
+	return GORMDB.Create(permission).Error
+}
+
+// Delete 删除授权
+func (r *K8sNamespacePermissionRepository) Delete(id uint) error {
+	return GORMDB.Delete(&dal.K8sNamespacePermission{}, id).Error
+}
+
+// K8sPortForwardSessionRepository 端口转发会话GORM操作
+type K8sPortForwardSessionRepository struct{}
+
+// NewK8sPortForwardSessionRepository 创建端口转发会话GORM操作实例
+func NewK8sPortForwardSessionRepository() *K8sPortForwardSessionRepository {
+	return &K8sPortForwardSessionRepository{}
+}
+
+// Create 创建会话记录
+func (r *K8sPortForwardSessionRepository) Create(session *dal.K8sPortForwardSession) error {
+	return GORMDB.Create(session).Error
+}
+
+// Finish 结束会话并记录传输字节数
+func (r *K8sPortForwardSessionRepository) Finish(sessionID, status, killedBy string, bytesIn, bytesOut int64) error {
+	return GORMDB.Model(&dal.K8sPortForwardSession{}).
+		Where("session_id = ? AND status = ?", sessionID, dal.ExecSessionStatusActive).
+		Updates(map[string]interface{}{
+			"status":    status,
+			"killed_by": killedBy,
+			"bytes_in":  bytesIn,
+			"bytes_out": bytesOut,
+			"ended_at":  time.Now(),
+		}).Error
+}
+
+// GetWithPagination 分页获取会话记录，userID为0时返回全部用户的会话
+func (r *K8sPortForwardSessionRepository) GetWithPagination(userID int64, offset, limit int) ([]dal.K8sPortForwardSession, int64, error) {
+	var sessions []dal.K8sPortForwardSession
+	var total int64
+
+	query := GORMDB.Model(&dal.K8sPortForwardSession{})
+	if userID > 0 {
+		query = query.Where("user_id = ?", userID)
+	}
+	if err := query.Count(&total).Error; err != nil {
+		return nil, 0, err
+	}
+	err := query.Order("started_at DESC").Offset(offset).Limit(limit).Find(&sessions).Error
+	return sessions, total, err
+}
+
+// MarkInterrupted 将遗留的进行中会话标记为结束，用于服务重启后修正状态
+func (r *K8sPortForwardSessionRepository) MarkInterrupted() error {
+	return GORMDB.Model(&dal.K8sPortForwardSession{}).
+		Where("status = ?", dal.ExecSessionStatusActive).
+		Updates(map[string]interface{}{
+			"status":   dal.ExecSessionStatusClosed,
+			"ended_at": time.Now(),
+		}).Error
+}
+
+// K8sNamespaceTemplateRepository 命名空间模板GORM操作
+type K8sNamespaceTemplateRepository struct{}
+
+// NewK8sNamespaceTemplateRepository 创建命名空间模板GORM操作实例
+func NewK8sNamespaceTemplateRepository() *K8sNamespaceTemplateRepository {
+	return &K8sNamespaceTemplateRepository{}
+}
+
+// List 获取全部模板
+func (r *K8sNamespaceTemplateRepository) List() ([]dal.K8sNamespaceTemplate, error) {
+	var templates []dal.K8sNamespaceTemplate
+	err := GORMDB.Order("name ASC").Find(&templates).Error
+	return templates, err
+}
+
+// GetByID 根据ID获取模板
+func (r *K8sNamespaceTemplateRepository) GetByID(id uint) (*dal.K8sNamespaceTemplate, error) {
+	var template dal.K8sNamespaceTemplate
+	if err := GORMDB.First(&template, id).Error; err != nil {
+		return nil, err
+	}
+	return &template, nil
+}
+
+// GetByName 根据名称获取模板
+func (r *K8sNamespaceTemplateRepository) GetByName(name string) (*dal.K8sNamespaceTemplate, error) {
+	var template dal.K8sNamespaceTemplate
+	if err := GORMDB.Where("name = ?", name).First(&template).Error; err != nil {
+		return nil, err
+	}
+	return &template, nil
+}
+
+// Create 创建模板
+func (r *K8sNamespaceTemplateRepository) Create(template *dal.K8sNamespaceTemplate) error {
+	return GORMDB.Create(template).Error
+}
+
+// Update 更新模板
+func (r *K8sNamespaceTemplateRepository) Update(template *dal.K8sNamespaceTemplate) error {
+	return GORMDB.Save(template).Error
+}
+
+// Delete 删除模板
+func (r *K8sNamespaceTemplateRepository) Delete(id uint) error {
+	return GORMDB.Delete(&dal.K8sNamespaceTemplate{}, id).Error
+}
+
+// K8sSecretAccessLogRepository Secret查看审计GORM操作
+type K8sSecretAccessLogRepository struct{}
+
+// NewK8sSecretAccessLogRepository 创建Secret查看审计GORM操作实例
+func NewK8sSecretAccessLogRepository() *K8sSecretAccessLogRepository {
+	return &K8sSecretAccessLogRepository{}
+}
+
+// Create 创建审计记录
+func (r *K8sSecretAccessLogRepository) Create(log *dal.K8sSecretAccessLog) error {
+	return GORMDB.Create(log).Error
+}
+
+// GetWithPagination 分页获取审计记录，userID为0时返回全部用户的记录
+func (r *K8sSecretAccessLogRepository) GetWithPagination(userID int64, offset, limit int) ([]dal.K8sSecretAccessLog, int64, error) {
+	var logs []dal.K8sSecretAccessLog
+	var total int64
+
+	query := GORMDB.Model(&dal.K8sSecretAccessLog{})
+	if userID > 0 {
+		query = query.Where("user_id = ?", userID)
+	}
+	if err := query.Count(&total).Error; err != nil {
+		return nil, 0, err
+	}
+	err := query.Order("created_at DESC").Offset(offset).Limit(limit).Find(&logs).Error
+	return logs, total, err
+}
+
+// K8sConfigRevisionRepository ConfigMap/Secret历史版本GORM操作
+type K8sConfigRevisionRepository struct{}
+
+// NewK8sConfigRevisionRepository 创建历史版本GORM操作实例
+func NewK8sConfigRevisionRepository() *K8sConfigRevisionRepository {
+	return &K8sConfigRevisionRepository{}
+}
+
+// Create 保存历史版本，版本号在同一对象内递增
+func (r *K8sConfigRevisionRepository) Create(revision *dal.K8sConfigRevision) error {
+	return GORMDB.Transaction(func(tx *gorm.DB) error {
+		var maxVersion int
+		err := tx.Model(&dal.K8sConfigRevision{}).
+			Where("instance_id = ? AND kind = ? AND namespace = ? AND name = ?",
+				revision.InstanceID, revision.Kind, revision.Namespace, revision.Name).
+			Select("COALESCE(MAX(version), 0)").Scan(&maxVersion).Error
+		if err != nil {
+			return err
+		}
+		revision.Version = maxVersion + 1
+		return tx.Create(revision).Error
+	})
+}
+
+// List 获取对象的历史版本，按版本倒序
+func (r *K8sConfigRevisionRepository) List(instanceID uint, kind, namespace, name string) ([]dal.K8sConfigRevision, error) {
+	var revisions []dal.K8sConfigRevision
+	err := GORMDB.Where("instance_id = ? AND kind = ? AND namespace = ? AND name = ?", instanceID, kind, namespace, name).
+		Order("version DESC").Find(&revisions).Error
+	return revisions, err
+}
+
+// GetByVersion 获取指定版本
+func (r *K8sConfigRevisionRepository) GetByVersion(instanceID uint, kind, namespace, name string, version int) (*dal.K8sConfigRevision, error) {
+	var revision dal.K8sConfigRevision
+	err := GORMDB.Where("instance_id = ? AND kind = ? AND namespace = ? AND name = ? AND version = ?",
+		instanceID, kind, namespace, name, version).First(&revision).Error
+	if err != nil {
+		return nil, err
+	}
+	return &revision, nil
+}
+
+// Delete 删除历史版本，用于对象更新失败时撤销本次记录
+func (r *K8sConfigRevisionRepository) Delete(id uint) error {
+	return GORMDB.Delete(&dal.K8sConfigRevision{}, id).Error
+}
+
+// K8sScanRepository 最佳实践与安全扫描GORM操作
+type K8sScanRepository struct{}
+
+// NewK8sScanRepository 创建扫描GORM操作实例
+func NewK8sScanRepository() *K8sScanRepository {
+	return &K8sScanRepository{}
+}
+
+// CreateRun 创建扫描记录
+func (r *K8sScanRepository) CreateRun(run *dal.K8sScanRun) error {
+	return GORMDB.Create(run).Error
+}
+
+// SaveResults 保存扫描结果：命名空间得分、问题明细和扫描汇总
+func (r *K8sScanRepository) SaveResults(run *dal.K8sScanRun, scores []dal.K8sScanNamespaceScore, findings []dal.K8sScanFinding) error {
+	return GORMDB.Transaction(func(tx *gorm.DB) error {
+		if len(scores) > 0 {
+			if err := tx.CreateInBatches(scores, 200).Error; err != nil {
+				return err
+			}
+		}
+		if len(findings) > 0 {
+			if err := tx.CreateInBatches(findings, 500).Error; err != nil {
+				return err
+			}
+		}
+		return tx.Save(run).Error
+	})
+}
+
+// FinishRun 更新扫描状态
+func (r *K8sScanRepository) FinishRun(run *dal.K8sScanRun) error {
+	return GORMDB.Save(run).Error
+}
+
+// MarkInterrupted 将服务重启前未完成的扫描标记为失败
+func (r *K8sScanRepository) MarkInterrupted() error {
+	return GORMDB.Model(&dal.K8sScanRun{}).Where("status = ?", dal.ScanStatusRunning).
+		Updates(map[string]interface{}{"status": dal.ScanStatusFailed, "error": "服务重启，扫描中断"}).Error
+}
+
+// GetRunsWithPagination 分页获取扫描记录，instanceID为0时不过滤
+func (r *K8sScanRepository) GetRunsWithPagination(instanceID uint, offset, limit int) ([]dal.K8sScanRun, int64, error) {
+	var runs []dal.K8sScanRun
+	var total int64
+
+	query := GORMDB.Model(&dal.K8sScanRun{})
+	if instanceID > 0 {
+		query = query.Where("instance_id = ?", instanceID)
+	}
+	if err := query.Count(&total).Error; err != nil {
+		return nil, 0, err
+	}
+	err := query.Order("id DESC").Offset(offset).Limit(limit).Find(&runs).Error
+	return runs, total, err
+}
+
+// GetRun 获取扫描记录
+func (r *K8sScanRepository) GetRun(id uint) (*dal.K8sScanRun, error) {
+	var run dal.K8sScanRun
+	if err := GORMDB.First(&run, id).Error; err != nil {
+		return nil, err
+	}
+	return &run, nil
+}
+
+// GetScores 获取扫描的命名空间得分，按得分从低到高
+func (r *K8sScanRepository) GetScores(runID uint) ([]dal.K8sScanNamespaceScore, error) {
+	var scores []dal.K8sScanNamespaceScore
+	err := GORMDB.Where("run_id = ?", runID).Order("score ASC, namespace ASC").Find(&scores).Error
+	return scores, err
+}
+
+// GetFindingsWithPagination 分页获取扫描问题
+func (r *K8sScanRepository) GetFindingsWithPagination(filter *dal.K8sScanFindingFilter, offset, limit int) ([]dal.K8sScanFinding, int64, error) {
+	var findings []dal.K8sScanFinding
+	var total int64
+
+	query := GORMDB.Model(&dal.K8sScanFinding{}).Where("run_id = ?", filter.RunID)
+	if filter.Namespace != "" {
+		query = query.Where("namespace = ?", filter.Namespace)
+	}
+	if filter.Rule != "" {
+		query = query.Where("rule = ?", filter.Rule)
+	}
+	if filter.Severity != "" {
+		query = query.Where("severity = ?", filter.Severity)
+	}
+	if err := query.Count(&total).Error; err != nil {
+		return nil, 0, err
+	}
+	err := query.Order("FIELD(severity, 'critical', 'warning', 'info'), namespace, kind, name").
+		Offset(offset).Limit(limit).Find(&findings).Error
+	return findings, total, err
+}
+
+// GetTrend 获取得分趋势，namespace为空时返回集群整体得分
+func (r *K8sScanRepository) GetTrend(instanceID uint, namespace string, since time.Time) ([]dal.K8sScanTrendPoint, error) {
+	var points []dal.K8sScanTrendPoint
+	if namespace == "" {
+		err := GORMDB.Model(&dal.K8sScanRun{}).
+			Select("id AS run_id, started_at, score, critical, warning").
+			Where("instance_id = ? AND status = ? AND started_at >= ?", instanceID, dal.ScanStatusSuccess, since).
+			Order("started_at ASC").Scan(&points).Error
+		return points, err
+	}
+	err := GORMDB.Table(dal.K8sScanNamespaceScore{}.TableName()+" AS s").
+		Select("s.run_id, r.started_at, s.score, s.critical, s.warning").
+		Joins("JOIN "+dal.K8sScanRun{}.TableName()+" AS r ON r.id = s.run_id").
+		Where("s.instance_id = ? AND s.namespace = ? AND r.status = ? AND r.started_at >= ?", instanceID, namespace, dal.ScanStatusSuccess, since).
+		Order("r.started_at ASC").Scan(&points).Error
+	return points, err
+}
+
+// DeleteBefore 删除指定时间之前的扫描记录及其结果
+func (r *K8sScanRepository) DeleteBefore(t time.Time) (int64, error) {
+	var deleted int64
+	err := GORMDB.Transaction(func(tx *gorm.DB) error {
+		var runIDs []uint
+		if err := tx.Model(&dal.K8sScanRun{}).Where("started_at < ?", t).Pluck("id", &runIDs).Error; err != nil {
+			return err
+		}
+		if len(runIDs) == 0 {
+			return nil
+		}
+		if err := tx.Where("run_id IN ?", runIDs).Delete(&dal.K8sScanFinding{}).Error; err != nil {
+			return err
+		}
+		if err := tx.Where("run_id IN ?", runIDs).Delete(&dal.K8sScanNamespaceScore{}).Error; err != nil {
+			return err
+		}
+		result := tx.Where("id IN ?", runIDs).Delete(&dal.K8sScanRun{})
+		deleted = result.RowsAffected
+		return result.Error
+	})
+	return deleted, err
+}
@@ -147,6 +147,18 @@ func GetK8sClient(instanceID uint) (*kubernetes.Clientset, bool) {
 	return client, exists
 }
 
+// GetK8sInstanceIDs 获取所有已初始化K8s客户端的实例ID
+func GetK8sInstanceIDs() []uint {
+	k8sClientsLock.RLock()
+	defer k8sClientsLock.RUnlock()
+
+	ids := make([]uint, 0, len(k8sClients))
+	for id := range k8sClients {
+		ids = append(ids, id)
+	}
+	return ids
+}
+
 func GetK8sConfig(instanceID uint) (*rest.Config, bool) {
 	k8sConfigLock.RLock()
 	defer k8sConfigLock.RUnlock()
@@ -2,7 +2,9 @@ package utils
 
 import (
 	"devops-console-backend/internal/common"
+	"devops-console-backend/pkg/configs"
 	"devops-console-backend/pkg/utils/jwt"
+	"strconv"
 
 	"github.com/gin-gonic/gin"
 )
@@ -25,3 +27,28 @@ func GetUserIdFromContext(c *gin.Context) int64 {
 func GetUserNameFromContext(c *gin.Context) string {
 	return GetUserInfoFromContext(c).GetUserName()
 }
+
+// IsAdminFromContext 判断当前用户是否为管理员，不写入响应，由调用方决定如何拒绝
+func IsAdminFromContext(c *gin.Context) bool {
+	value, _ := c.Get(common.UserInfoKey)
+	claims, ok := value.(*jwt.Claims)
+	return ok && claims != nil && configs.IsAdminUser(claims.GetUserName(), claims.GetRoles())
+}
+
+// GetInstanceIDFromContext 获取请求的实例ID，优先使用InstanceAuth中间件写入上下文的值，
+// 否则解析instance_id参数或X-Instance-ID请求头，缺省或无效时为1
+func GetInstanceIDFromContext(c *gin.Context) uint {
+	if value, exists := c.Get(common.InstanceIDKey); exists {
+		if instanceID, ok := value.(uint); ok {
+			return instanceID
+		}
+	}
+	instanceIDStr := c.Query("instance_id")
+	if instanceIDStr == "" {
+		instanceIDStr = c.GetHeader("X-Instance-ID")
+	}
+	if id, err := strconv.ParseUint(instanceIDStr, 10, 32); err == nil {
+		return uint(id)
+	}
+	return 1
+}
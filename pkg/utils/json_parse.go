@@ -84,6 +84,9 @@ func GetParam(c *gin.Context, key string, param interface{}, validate func(param
 	case *uint32:
 		u32, _ := strconv.ParseUint(value, 10, 32)
 		*v = uint32(u32)
+	case *uint:
+		u, _ := strconv.ParseUint(value, 10, 32)
+		*v = uint(u)
 	case uint:
 		value, _ := strconv.ParseUint(value, 10, 32)
 		v = uint(value)
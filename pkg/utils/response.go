@@ -62,6 +62,11 @@ func (rh *ResponseHelper) BadRequest(message string) {
 	rh.Error(400, message)
 }
 
+// Forbidden 403错误响应
+func (rh *ResponseHelper) Forbidden(message string) {
+	rh.Error(403, message)
+}
+
 // NotFound 404错误响应
 func (rh *ResponseHelper) NotFound(message string) {
 	rh.Error(404, message)